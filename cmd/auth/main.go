@@ -76,8 +76,9 @@ func main() {
 	}
 
 	// Auth
-	authRepository := AuthRepository.CreateAuthRepository(postgresDB, redisDB)
-	authUsecase := AuthUsecase.CreateAuthUsecase(authRepository)
+	authRepository := AuthRepository.CreateAuthRepository(postgresDB, redisDB,
+		config.CurrentConfig.SessionIdleTimeout, config.CurrentConfig.SessionMaxLifetime)
+	authUsecase := AuthUsecase.CreateAuthUsecase(authRepository, misc.CreateMailer(), config.CurrentConfig.Auth.PasswordResetURL)
 	authDelivery := AuthDelivery.CreateAuthServer(authUsecase)
 
 	if authDelivery == nil {
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"RPO_back/internal/pkg/config"
+	"RPO_back/internal/pkg/utils/misc"
+	"RPO_back/internal/pkg/utils/uploads"
+	"context"
+	"flag"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Досчитывает SHA-256 для файлов, загруженных до дедупликации по хешу, и сливает найденные дубликаты.
+// Запускается с тем же окружением, что и сервисы; повторный запуск безопасен
+func main() {
+	batchSize := flag.Int("batch", 100, "how many files to fetch from the database at once")
+	flag.Parse()
+
+	err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("environment configuration is invalid: %v", err)
+		return
+	}
+
+	postgresDB, err := misc.ConnectToPgx(1)
+	if err != nil {
+		log.Fatal("error connecting to PostgreSQL: ", err)
+		return
+	}
+	defer postgresDB.Close()
+
+	fileStorage, err := misc.CreateFileStorage()
+	if err != nil {
+		log.Fatal("error creating file storage: ", err)
+		return
+	}
+
+	stats, err := uploads.BackfillHashes(context.Background(), postgresDB, fileStorage, *batchSize)
+	log.Infof("Files hashed: %d, duplicates merged: %d, missing in storage: %d", stats.Hashed, stats.Merged, stats.Missing)
+	if err != nil {
+		log.Fatal("backfill stopped: ", err)
+	}
+}
@@ -78,9 +78,15 @@ func main() {
 		log.Fatal("error while pinging GRPC: ", err)
 	}
 
+	// Хранилище загруженных файлов
+	fileStorage, err := misc.CreateFileStorage()
+	if err != nil {
+		log.Fatal("error creating file storage: ", err)
+	}
+
 	//Board
 	boardRepository := BoardRepository.CreateBoardRepository(postgresDB)
-	boardUsecase := BoardUsecase.CreateBoardUsecase(boardRepository)
+	boardUsecase := BoardUsecase.CreateBoardUsecase(boardRepository, fileStorage)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(boardUsecase)
 
 	// Создаём новый маршрутизатор
@@ -90,9 +96,9 @@ func main() {
 	router.Use(no_panic.PanicMiddleware)
 	router.Use(logging_middleware.LoggingMiddleware)
 	router.Use(cors.CorsMiddleware)
-	router.Use(csrf.CSRFMiddleware)
 	sm := session.CreateSessionMiddleware(authGRPC)
 	router.Use(sm.Middleware)
+	router.Use(csrf.CSRFMiddleware)
 
 	// Регистрируем обработчики
 	router.HandleFunc("/boards", boardDelivery.CreateNewBoard).Methods("POST", "OPTIONS")
@@ -100,6 +106,8 @@ func main() {
 	router.HandleFunc("/boards/{boardID}", boardDelivery.UpdateBoard).Methods("PUT", "OPTIONS")
 	router.HandleFunc("/boards/{boardID}/backgroundImage", boardDelivery.SetBoardBackground).Methods("PUT", "OPTIONS")
 	router.HandleFunc("/boards/my", boardDelivery.GetMyBoards).Methods("GET", "OPTIONS")
+	router.HandleFunc("/boards/{boardID}/timesheet", boardDelivery.GetBoardTimesheet).Methods("GET", "OPTIONS")
+	router.HandleFunc("/boards/{boardID}/export", boardDelivery.ExportBoard).Methods("GET", "OPTIONS")
 	router.HandleFunc("/userPermissions/{boardID}", boardDelivery.GetMembersPermissions).Methods("GET", "OPTIONS")
 	router.HandleFunc("/userPermissions/{boardID}", boardDelivery.AddMember).Methods("POST", "OPTIONS")
 	router.HandleFunc("/userPermissions/{boardID}/{userID}", boardDelivery.UpdateMemberRole).Methods("PUT", "OPTIONS")
@@ -120,6 +128,24 @@ func main() {
 	router.HandleFunc("/checkList/{cardID}", boardDelivery.AddCheckListField).Methods("POST", "OPTIONS")
 	router.HandleFunc("/checkList/{fieldID}", boardDelivery.UpdateCheckListField).Methods("PATCH", "OPTIONS")
 	router.HandleFunc("/checkList/{fieldID}", boardDelivery.DeleteCheckListField).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/checkLists/{cardID}", boardDelivery.AddCheckList).Methods("POST", "OPTIONS")
+	router.HandleFunc("/checkLists/{checkListID}", boardDelivery.UpdateCheckList).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/checkLists/{checkListID}", boardDelivery.DeleteCheckList).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/subtask/{fieldID}", boardDelivery.PromoteCheckListField).Methods("POST", "OPTIONS")
+	router.HandleFunc("/customFields/{boardID}", boardDelivery.GetBoardCustomFields).Methods("GET", "OPTIONS")
+	router.HandleFunc("/customFields/{boardID}", boardDelivery.CreateCustomField).Methods("POST", "OPTIONS")
+	router.HandleFunc("/customFields/{customFieldID}", boardDelivery.UpdateCustomField).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/customFields/{customFieldID}", boardDelivery.DeleteCustomField).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/cardCustomFields/{cardID}/{customFieldID}", boardDelivery.SetCardCustomFieldValue).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/automations/{boardID}", boardDelivery.GetAutomationRules).Methods("GET", "OPTIONS")
+	router.HandleFunc("/automations/{boardID}", boardDelivery.CreateAutomationRule).Methods("POST", "OPTIONS")
+	router.HandleFunc("/automations/{ruleID}", boardDelivery.UpdateAutomationRule).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/automations/{ruleID}", boardDelivery.DeleteAutomationRule).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/timer/my", boardDelivery.GetRunningTimer).Methods("GET", "OPTIONS")
+	router.HandleFunc("/timer/{cardID}", boardDelivery.StartTimer).Methods("POST", "OPTIONS")
+	router.HandleFunc("/timer/{cardID}", boardDelivery.StopTimer).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/workLog/{cardID}", boardDelivery.AddWorkLog).Methods("POST", "OPTIONS")
+	router.HandleFunc("/workLog/{entryID}", boardDelivery.DeleteTimeEntry).Methods("DELETE", "OPTIONS")
 	router.HandleFunc("/cardCover/{cardID}", boardDelivery.SetCardCover).Methods("PUT", "OPTIONS")
 	router.HandleFunc("/cardCover/{cardID}", boardDelivery.DeleteCardCover).Methods("DELETE", "OPTIONS")
 	router.HandleFunc("/attachments/{cardID}", boardDelivery.AddAttachment).Methods("PUT", "OPTIONS")
@@ -132,6 +158,17 @@ func main() {
 	router.HandleFunc("/joinBoard/{inviteUUID}", boardDelivery.FetchInvite).Methods("GET", "OPTIONS")
 	router.HandleFunc("/joinBoard/{inviteUUID}", boardDelivery.AcceptInvite).Methods("POST", "OPTIONS")
 
+	// Правила автоматизации по прошедшим дедлайнам проверяются раз в минуту
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := boardUsecase.RunDeadlineAutomations(context.Background()); err != nil {
+				log.Error("deadline automations: ", err)
+			}
+		}
+	}()
+
 	// Запускаем сервер
 	addr := fmt.Sprintf(":%s", os.Getenv("SERVER_PORT"))
 	log.Infof("server started at http://0.0.0.0%s", addr)
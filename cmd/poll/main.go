@@ -8,10 +8,13 @@ import (
 	"RPO_back/internal/pkg/middleware/no_panic"
 	"RPO_back/internal/pkg/middleware/session"
 	PollDelivery "RPO_back/internal/pkg/poll/delivery"
+	PollGRPC "RPO_back/internal/pkg/poll/delivery/grpc/gen"
 	PollRepository "RPO_back/internal/pkg/poll/repository"
+	"RPO_back/internal/pkg/poll/schedule"
 	PollUsecase "RPO_back/internal/pkg/poll/usecase"
 	"RPO_back/internal/pkg/utils/logging"
 	"RPO_back/internal/pkg/utils/misc"
+	"net"
 	"net/http"
 	"time"
 
@@ -74,8 +77,29 @@ func main() {
 
 	// Poll
 	pollRepository := PollRepository.CreatePollRepository(postgresDB)
-	pollUsecase := PollUsecase.CreatePollUsecase(pollRepository, authGRPC)
+	pollUsecase := PollUsecase.CreatePollUsecase(pollRepository, authGRPC, schedule.CreatePolicy(config.CurrentConfig.Poll),
+		config.CurrentConfig.Poll.ExportSalt, config.CurrentConfig.Poll.ExportMinTextLength)
 	pollDelivery := PollDelivery.CreatePollDelivery(pollUsecase)
+	pollServer := PollDelivery.CreatePollServer(pollUsecase)
+
+	// GRPC для других сервисов (выдача вопросов в профиле пользователя)
+	LogMiddleware := logging_middleware.CreateGrpcLogMiddleware(log.StandardLogger())
+	grpcServer := grpc.NewServer(
+		grpc.Creds(insecure.NewCredentials()),
+		grpc.UnaryInterceptor(LogMiddleware.InterceptorLogger),
+	)
+	PollGRPC.RegisterPollServer(grpcServer, pollServer)
+
+	listener, err := net.Listen("tcp4", ":"+config.CurrentConfig.PollGRPCPort)
+	if err != nil {
+		log.Fatalf("failed to listen on port %s: %v", config.CurrentConfig.PollGRPCPort, err)
+	}
+	log.Infof("gRPC server is listening on port %s", config.CurrentConfig.PollGRPCPort)
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("failed to serve gRPC server: %v", err)
+		}
+	}()
 
 	// Создаём новый маршрутизатор
 	router := mux.NewRouter()
@@ -84,13 +108,22 @@ func main() {
 	router.Use(no_panic.PanicMiddleware)
 	router.Use(logging_middleware.LoggingMiddleware)
 	router.Use(cors.CorsMiddleware)
-	router.Use(csrf.CSRFMiddleware)
 	sm := session.CreateSessionMiddleware(authGRPC)
 	router.Use(sm.Middleware)
+	router.Use(csrf.CSRFMiddleware)
 
 	// Регистрируем обработчики
 	router.HandleFunc("/poll/submit", pollDelivery.SubmitPoll).Methods("POST", "OPTIONS")
 	router.HandleFunc("/poll/results", pollDelivery.GetPollResults).Methods("GET", "OPTIONS")
+	router.HandleFunc("/poll/snooze", pollDelivery.SnoozePoll).Methods("POST", "OPTIONS")
+	router.HandleFunc("/poll/dismiss", pollDelivery.DismissPoll).Methods("POST", "OPTIONS")
+	router.HandleFunc("/poll/admin/questions", pollDelivery.GetPollQuestions).Methods("GET", "OPTIONS")
+	router.HandleFunc("/poll/admin/export", pollDelivery.ExportPollResults).Methods("GET", "OPTIONS")
+	router.HandleFunc("/poll/admin/questions", pollDelivery.CreatePollQuestion).Methods("POST", "OPTIONS")
+	router.HandleFunc("/poll/admin/questions/order", pollDelivery.ReorderPollQuestions).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/poll/admin/questions/{questionID}", pollDelivery.UpdatePollQuestion).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/poll/admin/questions/{questionID}/activate", pollDelivery.ActivatePollQuestion).Methods("POST", "OPTIONS")
+	router.HandleFunc("/poll/admin/questions/{questionID}/deactivate", pollDelivery.DeactivatePollQuestion).Methods("POST", "OPTIONS")
 
 	// Запускаем сервер
 	addr := fmt.Sprintf(":%s", os.Getenv("SERVER_PORT"))
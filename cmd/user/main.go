@@ -7,11 +7,13 @@ import (
 	"RPO_back/internal/pkg/middleware/logging_middleware"
 	"RPO_back/internal/pkg/middleware/no_panic"
 	"RPO_back/internal/pkg/middleware/session"
+	"RPO_back/internal/pkg/user"
 	UserDelivery "RPO_back/internal/pkg/user/delivery"
 	UserRepository "RPO_back/internal/pkg/user/repository"
 	UserUsecase "RPO_back/internal/pkg/user/usecase"
 	"RPO_back/internal/pkg/utils/logging"
 	"RPO_back/internal/pkg/utils/misc"
+	"RPO_back/internal/pkg/utils/oidc"
 	"net/http"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 	"os"
 
 	AuthGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	PollGRPC "RPO_back/internal/pkg/poll/delivery/grpc/gen"
 
 	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
@@ -72,10 +75,41 @@ func main() {
 		log.Fatal("error while pinging GRPC: ", err)
 	}
 
+	// Подключение к GRPC сервису опросов. Соединение ленивое: пока сервис опросов
+	// недоступен, профиль отдаётся без вопросов
+	pollConn, err := grpc.NewClient(config.CurrentConfig.PollURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatal("error connecting to poll GRPC: ", err)
+	}
+	pollGRPC := PollGRPC.NewPollClient(pollConn)
+
+	// Хранилище загруженных файлов
+	fileStorage, err := misc.CreateFileStorage()
+	if err != nil {
+		log.Fatal("error creating file storage: ", err)
+	}
+
+	// Вход через OIDC (необязательный)
+	var oidcProvider user.OIDCProvider
+	var oidcAllowSignup bool
+	var oidcPostLoginURL string
+	if oidcConfig := config.CurrentConfig.OIDC; oidcConfig != nil {
+		oidcProvider = oidc.CreateProvider(oidc.Config{
+			Issuer:       oidcConfig.Issuer,
+			ClientID:     oidcConfig.ClientID,
+			ClientSecret: oidcConfig.ClientSecret,
+			RedirectURL:  oidcConfig.RedirectURL,
+		})
+		oidcAllowSignup = oidcConfig.AllowSignup
+		oidcPostLoginURL = oidcConfig.PostLoginURL
+	}
+
 	// User
 	userRepository := UserRepository.CreateUserRepository(postgresDB)
-	userUsecase := UserUsecase.CreateUserUsecase(userRepository, authGRPC)
-	userDelivery := UserDelivery.CreateUserDelivery(userUsecase)
+	userUsecase := UserUsecase.CreateUserUsecase(userRepository, authGRPC, misc.CreateMailer(),
+		config.CurrentConfig.User.EmailVerificationURL, config.CurrentConfig.User.AllowUnverifiedLogin,
+		oidcProvider, oidcAllowSignup, pollGRPC, fileStorage)
+	userDelivery := UserDelivery.CreateUserDelivery(userUsecase, config.CurrentConfig.SessionMaxLifetime, oidcPostLoginURL, fileStorage)
 
 	// Создаём новый маршрутизатор
 	router := mux.NewRouter()
@@ -84,18 +118,38 @@ func main() {
 	router.Use(no_panic.PanicMiddleware)
 	router.Use(logging_middleware.LoggingMiddleware)
 	router.Use(cors.CorsMiddleware)
-	router.Use(csrf.CSRFMiddleware)
 	sm := session.CreateSessionMiddleware(authGRPC)
 	router.Use(sm.Middleware)
+	router.Use(csrf.CSRFMiddleware)
 
 	// Регистрируем обработчики
 	router.HandleFunc("/auth/register", userDelivery.RegisterUser).Methods("POST", "OPTIONS")
 	router.HandleFunc("/auth/login", userDelivery.LoginUser).Methods("POST", "OPTIONS")
+	router.HandleFunc("/auth/login/2fa", userDelivery.VerifyTwoFactorLogin).Methods("POST", "OPTIONS")
 	router.HandleFunc("/auth/logout", userDelivery.LogoutUser).Methods("POST", "OPTIONS")
 	router.HandleFunc("/auth/changePassword", userDelivery.ChangePassword).Methods("POST", "OPTIONS")
+	router.HandleFunc("/auth/resetPassword/request", userDelivery.RequestPasswordReset).Methods("POST", "OPTIONS")
+	router.HandleFunc("/auth/resetPassword/confirm", userDelivery.ConfirmPasswordReset).Methods("POST", "OPTIONS")
+	router.HandleFunc("/auth/verifyEmail", userDelivery.VerifyEmail).Methods("POST", "OPTIONS")
+	router.HandleFunc("/auth/verifyEmail/resend", userDelivery.ResendEmailVerificationByEmail).Methods("POST", "OPTIONS")
+	router.HandleFunc("/auth/oidc/login", userDelivery.OIDCLogin).Methods("GET", "OPTIONS")
+	router.HandleFunc("/auth/oidc/callback", userDelivery.OIDCCallback).Methods("GET", "OPTIONS")
 	router.HandleFunc("/users/me", userDelivery.GetMyProfile).Methods("GET", "OPTIONS")
 	router.HandleFunc("/users/me", userDelivery.UpdateMyProfile).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/users/me", userDelivery.DeleteMyAccount).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/users/me/export", userDelivery.ExportMyData).Methods("GET", "OPTIONS")
 	router.HandleFunc("/users/me/avatar", userDelivery.SetMyAvatar).Methods("PUT", "OPTIONS")
+	router.HandleFunc("/images/{fileName}/{variant}", userDelivery.GetImageVariant).Methods("GET", "OPTIONS")
+	router.HandleFunc("/users/me/email/resendVerification", userDelivery.ResendEmailVerification).Methods("POST", "OPTIONS")
+	router.HandleFunc("/users/me/2fa/enroll", userDelivery.BeginTOTPEnrollment).Methods("POST", "OPTIONS")
+	router.HandleFunc("/users/me/2fa/confirm", userDelivery.ConfirmTOTPEnrollment).Methods("POST", "OPTIONS")
+	router.HandleFunc("/users/me/2fa/disable", userDelivery.DisableTOTP).Methods("POST", "OPTIONS")
+	router.HandleFunc("/users/me/sessions", userDelivery.ListSessions).Methods("GET", "OPTIONS")
+	router.HandleFunc("/users/me/sessions/revokeOthers", userDelivery.RevokeOtherSessions).Methods("POST", "OPTIONS")
+	router.HandleFunc("/users/me/sessions/{sessionID}", userDelivery.RevokeSession).Methods("DELETE", "OPTIONS")
+	router.HandleFunc("/users/me/tokens", userDelivery.ListAccessTokens).Methods("GET", "OPTIONS")
+	router.HandleFunc("/users/me/tokens", userDelivery.CreateAccessToken).Methods("POST", "OPTIONS")
+	router.HandleFunc("/users/me/tokens/{tokenID}", userDelivery.RevokeAccessToken).Methods("DELETE", "OPTIONS")
 
 	// Запускаем сервер
 	addr := fmt.Sprintf(":%s", os.Getenv("SERVER_PORT"))
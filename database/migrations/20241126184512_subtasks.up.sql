@@ -0,0 +1,2 @@
+-- Modify "checklist_field" table
+ALTER TABLE "public"."checklist_field" ADD COLUMN "subtask_card_id" bigint NULL, ADD CONSTRAINT "checklist_field_subtask_card_id_key" UNIQUE ("subtask_card_id"), ADD CONSTRAINT "checklist_field_subtask_card_id_fkey" FOREIGN KEY ("subtask_card_id") REFERENCES "public"."card" ("card_id") ON UPDATE CASCADE ON DELETE SET NULL;
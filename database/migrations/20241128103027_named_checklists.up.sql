@@ -0,0 +1,8 @@
+-- Create "checklist" table
+CREATE TABLE "public"."checklist" ("checklist_id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY, "card_id" bigint NOT NULL, "title" text NOT NULL, "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, "order_index" integer NOT NULL, PRIMARY KEY ("checklist_id"), CONSTRAINT "checklist_card_id_fkey" FOREIGN KEY ("card_id") REFERENCES "public"."card" ("card_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Существующие строки чеклистов переносим в один чеклист на карточку
+INSERT INTO "public"."checklist" ("card_id", "title", "order_index") SELECT DISTINCT "card_id", 'Чеклист', 0 FROM "public"."checklist_field";
+-- Modify "checklist_field" table
+ALTER TABLE "public"."checklist_field" ADD COLUMN "checklist_id" bigint NULL;
+UPDATE "public"."checklist_field" AS cf SET "checklist_id" = cl."checklist_id" FROM "public"."checklist" AS cl WHERE cl."card_id" = cf."card_id";
+ALTER TABLE "public"."checklist_field" ALTER COLUMN "checklist_id" SET NOT NULL, ADD CONSTRAINT "checklist_field_checklist_id_fkey" FOREIGN KEY ("checklist_id") REFERENCES "public"."checklist" ("checklist_id") ON UPDATE CASCADE ON DELETE CASCADE;
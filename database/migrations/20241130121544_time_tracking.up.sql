@@ -0,0 +1,6 @@
+-- Create "card_time_entry" table
+CREATE TABLE "public"."card_time_entry" ("time_entry_id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY, "card_id" bigint NOT NULL, "u_id" bigint NOT NULL, "started_at" timestamptz NOT NULL, "ended_at" timestamptz NULL, "comment" text NOT NULL DEFAULT '', "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("time_entry_id"), CONSTRAINT "card_time_entry_card_id_fkey" FOREIGN KEY ("card_id") REFERENCES "public"."card" ("card_id") ON UPDATE CASCADE ON DELETE CASCADE, CONSTRAINT "card_time_entry_u_id_fkey" FOREIGN KEY ("u_id") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE CASCADE, CONSTRAINT "card_time_entry_check" CHECK ((ended_at IS NULL) OR (ended_at > started_at)));
+-- Create index "card_time_entry_running_timer" to table: "card_time_entry"
+CREATE UNIQUE INDEX "card_time_entry_running_timer" ON "public"."card_time_entry" ("u_id") WHERE (ended_at IS NULL);
+-- Create index "card_time_entry_card_id" to table: "card_time_entry"
+CREATE INDEX "card_time_entry_card_id" ON "public"."card_time_entry" ("card_id");
@@ -0,0 +1,6 @@
+-- Create enum type "custom_field_type"
+CREATE TYPE "public"."custom_field_type" AS ENUM ('text', 'number', 'date', 'dropdown', 'checkbox');
+-- Create "board_custom_field" table
+CREATE TABLE "public"."board_custom_field" ("custom_field_id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY, "board_id" bigint NOT NULL, "title" text NOT NULL, "field_type" "public"."custom_field_type" NOT NULL, "options" text[] NOT NULL DEFAULT '{}', "order_index" integer NOT NULL, "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("custom_field_id"), CONSTRAINT "board_custom_field_board_id_fkey" FOREIGN KEY ("board_id") REFERENCES "public"."board" ("board_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Create "card_custom_field_value" table
+CREATE TABLE "public"."card_custom_field_value" ("card_id" bigint NOT NULL, "custom_field_id" bigint NOT NULL, "value" text NOT NULL, "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("card_id", "custom_field_id"), CONSTRAINT "card_custom_field_value_card_id_fkey" FOREIGN KEY ("card_id") REFERENCES "public"."card" ("card_id") ON UPDATE CASCADE ON DELETE CASCADE, CONSTRAINT "card_custom_field_value_custom_field_id_fkey" FOREIGN KEY ("custom_field_id") REFERENCES "public"."board_custom_field" ("custom_field_id") ON UPDATE CASCADE ON DELETE CASCADE);
@@ -0,0 +1,8 @@
+-- Create "card_label" table
+CREATE TABLE "public"."card_label" ("card_id" bigint NOT NULL, "title" text NOT NULL, "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("card_id", "title"), CONSTRAINT "card_label_card_id_fkey" FOREIGN KEY ("card_id") REFERENCES "public"."card" ("card_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Create enum type "automation_trigger"
+CREATE TYPE "public"."automation_trigger" AS ENUM ('card_moved', 'card_created', 'deadline_passed', 'checklist_completed');
+-- Create "automation_rule" table
+CREATE TABLE "public"."automation_rule" ("rule_id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY, "board_id" bigint NOT NULL, "title" text NOT NULL, "trigger_type" "public"."automation_trigger" NOT NULL, "trigger_column_id" bigint NULL, "actions" jsonb NOT NULL DEFAULT '[]', "is_enabled" boolean NOT NULL DEFAULT true, "created_by" bigint NOT NULL, "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("rule_id"), CONSTRAINT "automation_rule_board_id_fkey" FOREIGN KEY ("board_id") REFERENCES "public"."board" ("board_id") ON UPDATE CASCADE ON DELETE CASCADE, CONSTRAINT "automation_rule_trigger_column_id_fkey" FOREIGN KEY ("trigger_column_id") REFERENCES "public"."kanban_column" ("col_id") ON UPDATE CASCADE ON DELETE CASCADE, CONSTRAINT "automation_rule_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Create "card_deadline_automation" table
+CREATE TABLE "public"."card_deadline_automation" ("card_id" bigint NOT NULL, "deadline" timestamptz NOT NULL, "fired_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("card_id"), CONSTRAINT "card_deadline_automation_card_id_fkey" FOREIGN KEY ("card_id") REFERENCES "public"."card" ("card_id") ON UPDATE CASCADE ON DELETE CASCADE);
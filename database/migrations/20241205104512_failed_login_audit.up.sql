@@ -0,0 +1,4 @@
+-- Create "failed_login_attempt" table
+CREATE TABLE "public"."failed_login_attempt" ("attempt_id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY, "u_id" bigint NULL, "client_ip" text NOT NULL DEFAULT '', "locked_until" timestamptz NULL, "attempted_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("attempt_id"), CONSTRAINT "failed_login_attempt_u_id_fkey" FOREIGN KEY ("u_id") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Create index "failed_login_attempt_u_id" to table: "failed_login_attempt"
+CREATE INDEX "failed_login_attempt_u_id" ON "public"."failed_login_attempt" ("u_id", "attempted_at");
@@ -0,0 +1,4 @@
+-- Create "password_reset_token" table
+CREATE TABLE "public"."password_reset_token" ("token_hash" text NOT NULL, "u_id" bigint NOT NULL, "expires_at" timestamptz NOT NULL, "used_at" timestamptz NULL, "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("token_hash"), CONSTRAINT "password_reset_token_u_id_fkey" FOREIGN KEY ("u_id") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Create index "password_reset_token_u_id" to table: "password_reset_token"
+CREATE INDEX "password_reset_token_u_id" ON "public"."password_reset_token" ("u_id");
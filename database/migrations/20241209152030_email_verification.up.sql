@@ -0,0 +1,8 @@
+-- Modify "user" table
+ALTER TABLE "public"."user" ADD COLUMN "email_verified_at" timestamptz NULL;
+-- Existing users keep access: their emails are considered verified
+UPDATE "public"."user" SET "email_verified_at" = "joined_at";
+-- Create "email_verification_token" table
+CREATE TABLE "public"."email_verification_token" ("token_hash" text NOT NULL, "u_id" bigint NOT NULL, "email" text NOT NULL, "expires_at" timestamptz NOT NULL, "used_at" timestamptz NULL, "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("token_hash"), CONSTRAINT "email_verification_token_u_id_fkey" FOREIGN KEY ("u_id") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Create index "email_verification_token_u_id" to table: "email_verification_token"
+CREATE INDEX "email_verification_token_u_id" ON "public"."email_verification_token" ("u_id", "created_at");
@@ -0,0 +1,6 @@
+-- Create "user_totp" table
+CREATE TABLE "public"."user_totp" ("u_id" bigint NOT NULL, "secret" text NOT NULL, "enabled_at" timestamptz NULL, "last_used_step" bigint NOT NULL DEFAULT 0, "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("u_id"), CONSTRAINT "user_totp_u_id_fkey" FOREIGN KEY ("u_id") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Create "totp_recovery_code" table
+CREATE TABLE "public"."totp_recovery_code" ("code_id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY, "u_id" bigint NOT NULL, "code_hash" text NOT NULL, "used_at" timestamptz NULL, PRIMARY KEY ("code_id"), CONSTRAINT "totp_recovery_code_u_id_fkey" FOREIGN KEY ("u_id") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Create index "totp_recovery_code_u_id" to table: "totp_recovery_code"
+CREATE INDEX "totp_recovery_code_u_id" ON "public"."totp_recovery_code" ("u_id", "code_hash");
@@ -0,0 +1,4 @@
+-- Create "personal_access_token" table
+CREATE TABLE "public"."personal_access_token" ("token_id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY, "u_id" bigint NOT NULL, "name" text NOT NULL, "token_hash" text NOT NULL, "scopes" text[] NOT NULL, "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, "expires_at" timestamptz NULL, "last_used_at" timestamptz NULL, PRIMARY KEY ("token_id"), CONSTRAINT "personal_access_token_token_hash_key" UNIQUE ("token_hash"), CONSTRAINT "personal_access_token_u_id_fkey" FOREIGN KEY ("u_id") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Create index "personal_access_token_u_id" to table: "personal_access_token"
+CREATE INDEX "personal_access_token_u_id" ON "public"."personal_access_token" ("u_id");
@@ -0,0 +1,6 @@
+-- Create "user_identity" table
+CREATE TABLE "public"."user_identity" ("issuer" text NOT NULL, "subject" text NOT NULL, "u_id" bigint NOT NULL, "email" text NOT NULL, "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY ("issuer", "subject"), CONSTRAINT "user_identity_u_id_fkey" FOREIGN KEY ("u_id") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE CASCADE);
+-- Create index "user_identity_u_id" to table: "user_identity"
+CREATE INDEX "user_identity_u_id" ON "public"."user_identity" ("u_id");
+-- Create "oidc_login_state" table
+CREATE TABLE "public"."oidc_login_state" ("state_hash" text NOT NULL, "nonce" text NOT NULL, "code_verifier" text NOT NULL, "expires_at" timestamptz NOT NULL, PRIMARY KEY ("state_hash"));
@@ -0,0 +1,4 @@
+-- Modify "card_attachment" table
+ALTER TABLE "public"."card_attachment" ALTER COLUMN "attached_by" DROP NOT NULL, DROP CONSTRAINT "card_attachment_attached_by_fkey", ADD CONSTRAINT "card_attachment_attached_by_fkey" FOREIGN KEY ("attached_by") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE SET NULL;
+-- Modify "card_comment" table
+ALTER TABLE "public"."card_comment" ALTER COLUMN "created_by" DROP NOT NULL, DROP CONSTRAINT "card_comment_created_by_fkey", ADD CONSTRAINT "card_comment_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE SET NULL;
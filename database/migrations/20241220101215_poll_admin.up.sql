@@ -0,0 +1,8 @@
+-- Create enum type "poll_audience"
+CREATE TYPE "public"."poll_audience" AS ENUM ('new_users', 'experienced_users', 'board_admins');
+-- Modify "user" table
+ALTER TABLE "public"."user" ADD COLUMN "is_system_admin" boolean NOT NULL DEFAULT false;
+-- Modify "csat_question" table
+ALTER TABLE "public"."csat_question" ADD COLUMN "order_index" integer NOT NULL DEFAULT 0, ADD COLUMN "rating_min" integer NOT NULL DEFAULT 1, ADD COLUMN "rating_max" integer NOT NULL DEFAULT 5, ADD COLUMN "audience" "public"."poll_audience" NULL, ADD COLUMN "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, ADD CONSTRAINT "csat_question_rating_scale" CHECK (rating_min < rating_max);
+-- Keep the existing order of questions
+UPDATE "public"."csat_question" SET "order_index" = "question_id";
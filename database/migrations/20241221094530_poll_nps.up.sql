@@ -0,0 +1,4 @@
+-- Add value to enum type: "question_type"
+ALTER TYPE "public"."question_type" ADD VALUE 'answer_nps';
+-- Modify "csat_question" table
+ALTER TABLE "public"."csat_question" ADD COLUMN "follow_up_question_id" bigint NULL, ADD CONSTRAINT "csat_question_follow_up_question_id_fkey" FOREIGN KEY ("follow_up_question_id") REFERENCES "public"."csat_question" ("question_id") ON UPDATE CASCADE ON DELETE SET NULL, ADD CONSTRAINT "csat_question_follow_up_not_self" CHECK (follow_up_question_id <> question_id);
@@ -0,0 +1,8 @@
+-- Modify "user" table
+ALTER TABLE "public"."user" ALTER COLUMN "csat_poll_dt" SET DEFAULT CURRENT_TIMESTAMP;
+-- Modify "card" table
+ALTER TABLE "public"."card" ADD COLUMN "created_by" bigint NULL, ADD CONSTRAINT "card_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE SET NULL;
+-- Create index "card_created_by" to table: "card"
+CREATE INDEX "card_created_by" ON "public"."card" ("created_by");
+-- Create "csat_poll_state" table
+CREATE TABLE "public"."csat_poll_state" ("u_id" bigint NOT NULL, "prompt_count" integer NOT NULL DEFAULT 0, "last_prompted_at" timestamptz NULL, "dismiss_count" integer NOT NULL DEFAULT 0, "last_dismissed_at" timestamptz NULL, "snoozed_until" timestamptz NULL, PRIMARY KEY ("u_id"), CONSTRAINT "csat_poll_state_u_id_fkey" FOREIGN KEY ("u_id") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE CASCADE);
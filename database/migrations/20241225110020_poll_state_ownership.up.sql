@@ -0,0 +1,6 @@
+-- Modify "csat_poll_state" table
+ALTER TABLE "public"."csat_poll_state" ADD COLUMN "next_poll_at" timestamptz NULL;
+-- Move pending cool-downs out of "user"
+INSERT INTO "public"."csat_poll_state" ("u_id", "next_poll_at") SELECT "u_id", "csat_poll_dt" FROM "public"."user" WHERE "csat_poll_dt" > CURRENT_TIMESTAMP ON CONFLICT ("u_id") DO UPDATE SET "next_poll_at" = EXCLUDED."next_poll_at";
+-- Modify "user" table
+ALTER TABLE "public"."user" DROP COLUMN "csat_poll_dt";
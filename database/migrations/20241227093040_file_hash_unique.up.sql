@@ -0,0 +1,4 @@
+-- Reset hashes not produced by SHA-256 (cmd/backfill_file_hashes recomputes them)
+UPDATE "public"."user_uploaded_file" SET "file_hash" = NULL WHERE "file_hash" !~ '^[0-9a-f]{64}$';
+-- Create index "user_uploaded_file_file_hash" to table: "user_uploaded_file"
+CREATE UNIQUE INDEX "user_uploaded_file_file_hash" ON "public"."user_uploaded_file" ("file_hash");
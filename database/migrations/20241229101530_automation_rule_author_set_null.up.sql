@@ -0,0 +1,2 @@
+-- Modify "automation_rule" table
+ALTER TABLE "public"."automation_rule" ALTER COLUMN "created_by" DROP NOT NULL, DROP CONSTRAINT "automation_rule_created_by_fkey", ADD CONSTRAINT "automation_rule_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE SET NULL;
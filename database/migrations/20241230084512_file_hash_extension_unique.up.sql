@@ -0,0 +1,4 @@
+-- Drop index "user_uploaded_file_file_hash" from table: "user_uploaded_file"
+DROP INDEX "public"."user_uploaded_file_file_hash";
+-- Create index "user_uploaded_file_file_hash_file_extension" to table: "user_uploaded_file"
+CREATE UNIQUE INDEX "user_uploaded_file_file_hash_file_extension" ON "public"."user_uploaded_file" ("file_hash", "file_extension");
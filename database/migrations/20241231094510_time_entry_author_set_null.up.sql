@@ -0,0 +1,2 @@
+-- Modify "card_time_entry" table
+ALTER TABLE "public"."card_time_entry" ALTER COLUMN "u_id" DROP NOT NULL, DROP CONSTRAINT "card_time_entry_u_id_fkey", ADD CONSTRAINT "card_time_entry_u_id_fkey" FOREIGN KEY ("u_id") REFERENCES "public"."user" ("u_id") ON UPDATE CASCADE ON DELETE SET NULL;
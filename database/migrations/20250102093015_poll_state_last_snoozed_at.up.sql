@@ -0,0 +1,2 @@
+-- Modify "csat_poll_state" table
+ALTER TABLE "public"."csat_poll_state" ADD COLUMN "last_snoozed_at" timestamptz NULL;
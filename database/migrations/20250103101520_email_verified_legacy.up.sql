@@ -0,0 +1,4 @@
+-- Modify "user" table
+ALTER TABLE "public"."user" ADD COLUMN "email_verified_legacy" boolean NOT NULL DEFAULT false;
+-- Emails verified by the 20241209152030 backfill were never confirmed by a letter
+UPDATE "public"."user" SET "email_verified_legacy" = true WHERE "email_verified_at" = "joined_at" AND "password_hash" IS NOT NULL;
@@ -9,14 +9,18 @@ CREATE TABLE user_uploaded_file(
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE UNIQUE INDEX user_uploaded_file_file_hash_file_extension ON user_uploaded_file (file_hash, file_extension);
+
 CREATE TABLE "user" (
     u_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
     nickname TEXT NOT NULL UNIQUE,
     joined_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     password_hash TEXT,
-    csat_poll_dt TIMESTAMPTZ NOT NULL,
     email TEXT UNIQUE NOT NULL,
+    email_verified_at TIMESTAMPTZ,
+    email_verified_legacy BOOLEAN NOT NULL DEFAULT FALSE, -- Email подтверждён миграцией, а не письмом
+    is_system_admin BOOLEAN NOT NULL DEFAULT FALSE, -- Администратор всего сервиса (например, опросов CSAT)
     avatar_file_id BIGINT,
     FOREIGN KEY (avatar_file_id) REFERENCES user_uploaded_file(file_id) ON UPDATE CASCADE ON DELETE SET NULL
 );
@@ -80,47 +84,65 @@ CREATE TABLE "card" (
     cover_file_id BIGINT,
     deadline TIMESTAMPTZ,
     is_done BOOLEAN NOT NULL DEFAULT FALSE, -- Видна, когда задан deadline или чеклист
+    created_by BIGINT, -- NULL для старых карточек и если автор удалил аккаунт
 
     FOREIGN KEY (col_id) REFERENCES kanban_column(col_id) ON UPDATE CASCADE ON DELETE CASCADE,
-    FOREIGN KEY (cover_file_id) REFERENCES user_uploaded_file(file_id) ON UPDATE CASCADE ON DELETE SET NULL
+    FOREIGN KEY (cover_file_id) REFERENCES user_uploaded_file(file_id) ON UPDATE CASCADE ON DELETE SET NULL,
+    FOREIGN KEY (created_by) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE SET NULL
 );
 
+CREATE INDEX card_created_by ON "card" (created_by);
+
 CREATE TABLE card_attachment (
     attachment_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
     card_id BIGINT NOT NULL,
     file_id BIGINT NOT NULL,
     original_name TEXT NOT NULL,
     attached_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    attached_by BIGINT NOT NULL,
+    attached_by BIGINT, -- NULL, если пользователь удалил аккаунт
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
 
     FOREIGN KEY (card_id) REFERENCES card(card_id) ON UPDATE CASCADE ON DELETE CASCADE,
     FOREIGN KEY (file_id) REFERENCES user_uploaded_file(file_id) ON UPDATE CASCADE ON DELETE CASCADE,
-    FOREIGN KEY (attached_by) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
+    FOREIGN KEY (attached_by) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE TABLE checklist (
+    checklist_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    card_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    order_index INTEGER NOT NULL, -- Порядковый номер чеклиста на карточке
+
+    FOREIGN KEY (card_id) REFERENCES card(card_id) ON UPDATE CASCADE ON DELETE CASCADE
 );
 
 CREATE TABLE checklist_field (
     checklist_field_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
     card_id BIGINT NOT NULL,
+    checklist_id BIGINT NOT NULL,
     title TEXT NOT NULL,
     is_done BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    order_index INTEGER,
+    order_index INTEGER, -- Порядковый номер строки в чеклисте
+    subtask_card_id BIGINT UNIQUE, -- Карточка, в которую превратили строку чеклиста
 
-    FOREIGN KEY (card_id) REFERENCES card(card_id) ON UPDATE CASCADE ON DELETE CASCADE
+    FOREIGN KEY (card_id) REFERENCES card(card_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (checklist_id) REFERENCES checklist(checklist_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (subtask_card_id) REFERENCES card(card_id) ON UPDATE CASCADE ON DELETE SET NULL
 );
 
 CREATE TABLE card_comment (
     comment_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
     card_id BIGINT NOT NULL,
     title TEXT NOT NULL,
-    created_by BIGINT NOT NULL,
+    created_by BIGINT, -- NULL, если автор удалил аккаунт
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     is_edited BOOLEAN NOT NULL DEFAULT FALSE,
 
     FOREIGN KEY (card_id) REFERENCES card(card_id) ON UPDATE CASCADE ON DELETE CASCADE,
-    FOREIGN KEY (created_by) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
+    FOREIGN KEY (created_by) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE SET NULL
 );
 
 CREATE TABLE card_user_assignment (
@@ -132,9 +154,201 @@ CREATE TABLE card_user_assignment (
     FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
 );
 
+CREATE TABLE card_time_entry (
+    time_entry_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    card_id BIGINT NOT NULL,
+    u_id BIGINT, -- NULL, если пользователь удалил аккаунт: время остаётся в отчётах доски
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ, -- NULL, пока таймер запущен
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (card_id) REFERENCES card(card_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE SET NULL,
+    CHECK (ended_at IS NULL OR ended_at > started_at)
+);
+
+-- У пользователя может быть запущен только один таймер
+CREATE UNIQUE INDEX card_time_entry_running_timer ON card_time_entry (u_id) WHERE ended_at IS NULL;
+CREATE INDEX card_time_entry_card_id ON card_time_entry (card_id);
+
+CREATE TYPE custom_field_type AS ENUM (
+    'text',
+    'number',
+    'date',
+    'dropdown',
+    'checkbox'
+);
+
+CREATE TABLE board_custom_field (
+    custom_field_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    board_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    field_type custom_field_type NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}', -- Варианты для полей типа dropdown
+    order_index INTEGER NOT NULL, -- Порядковый номер поля на доске
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (board_id) REFERENCES board(board_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE card_custom_field_value (
+    card_id BIGINT NOT NULL,
+    custom_field_id BIGINT NOT NULL,
+    value TEXT NOT NULL, -- Значение в нормализованном виде (см. тип поля)
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (card_id, custom_field_id),
+    FOREIGN KEY (card_id) REFERENCES card(card_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (custom_field_id) REFERENCES board_custom_field(custom_field_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE card_label (
+    card_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (card_id, title),
+    FOREIGN KEY (card_id) REFERENCES card(card_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TYPE automation_trigger AS ENUM (
+    'card_moved',
+    'card_created',
+    'deadline_passed',
+    'checklist_completed'
+);
+
+CREATE TABLE automation_rule (
+    rule_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    board_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    trigger_type automation_trigger NOT NULL,
+    trigger_column_id BIGINT, -- Колонка, к которой привязан триггер (NULL - любая)
+    actions JSONB NOT NULL DEFAULT '[]', -- Список действий в порядке выполнения
+    is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by BIGINT, -- От его имени пишутся комментарии; при удалении автора правило переходит к участнику доски
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (board_id) REFERENCES board(board_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (trigger_column_id) REFERENCES kanban_column(col_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+-- Дедлайн карточки, для которого уже отработали правила deadline_passed
+CREATE TABLE card_deadline_automation (
+    card_id BIGINT PRIMARY KEY,
+    deadline TIMESTAMPTZ NOT NULL,
+    fired_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (card_id) REFERENCES card(card_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE failed_login_attempt (
+    attempt_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    u_id BIGINT,
+    client_ip TEXT NOT NULL DEFAULT '',
+    locked_until TIMESTAMPTZ,
+    attempted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX failed_login_attempt_u_id ON failed_login_attempt (u_id, attempted_at);
+
+CREATE TABLE password_reset_token (
+    token_hash TEXT PRIMARY KEY,
+    u_id BIGINT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX password_reset_token_u_id ON password_reset_token (u_id);
+
+CREATE TABLE email_verification_token (
+    token_hash TEXT PRIMARY KEY,
+    u_id BIGINT NOT NULL,
+    email TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX email_verification_token_u_id ON email_verification_token (u_id, created_at);
+
+CREATE TABLE user_totp (
+    u_id BIGINT PRIMARY KEY,
+    secret TEXT NOT NULL,
+    enabled_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE totp_recovery_code (
+    code_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    u_id BIGINT NOT NULL,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMPTZ,
+
+    FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX totp_recovery_code_u_id ON totp_recovery_code (u_id, code_hash);
+
+CREATE TABLE personal_access_token (
+    token_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    u_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+
+    FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX personal_access_token_u_id ON personal_access_token (u_id);
+
+CREATE TABLE user_identity (
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    u_id BIGINT NOT NULL,
+    email TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (issuer, subject),
+    FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX user_identity_u_id ON user_identity (u_id);
+
+CREATE TABLE oidc_login_state (
+    state_hash TEXT PRIMARY KEY,
+    nonce TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
 CREATE TYPE question_type AS ENUM (
     'answer_text',
-    'answer_rating'
+    'answer_rating',
+    'answer_nps' -- Net Promoter Score, оценка от 0 до 10
+);
+
+-- Кому показывать вопрос (NULL - всем)
+CREATE TYPE poll_audience AS ENUM (
+    'new_users',         -- Зарегистрировались меньше 30 дней назад
+    'experienced_users', -- Зарегистрировались 30 и больше дней назад
+    'board_admins'       -- Админы хотя бы одной доски
 );
 
 CREATE TABLE csat_question (
@@ -142,7 +356,17 @@ CREATE TABLE csat_question (
     is_active BOOLEAN NOT NULL DEFAULT TRUE,
     question_text TEXT NOT NULL,
     "type" question_type NOT NULL,
-    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    order_index INTEGER NOT NULL DEFAULT 0, -- Порядковый номер вопроса в опросе
+    rating_min INTEGER NOT NULL DEFAULT 1, -- Шкала оценки (для answer_rating)
+    rating_max INTEGER NOT NULL DEFAULT 5,
+    audience poll_audience,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    follow_up_question_id BIGINT, -- Текстовый вопрос для критиков (только для answer_nps)
+
+    CONSTRAINT csat_question_rating_scale CHECK (rating_min < rating_max),
+    CONSTRAINT csat_question_follow_up_not_self CHECK (follow_up_question_id <> question_id),
+    FOREIGN KEY (follow_up_question_id) REFERENCES csat_question(question_id) ON UPDATE CASCADE ON DELETE SET NULL
 );
 
 CREATE TABLE csat_results (
@@ -156,3 +380,17 @@ CREATE TABLE csat_results (
     FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE,
     FOREIGN KEY (question_id) REFERENCES csat_question(question_id) ON UPDATE CASCADE ON DELETE CASCADE
 );
+
+-- Сколько раз пользователю показывали опрос и как он на это реагировал
+CREATE TABLE csat_poll_state (
+    u_id BIGINT PRIMARY KEY,
+    prompt_count INTEGER NOT NULL DEFAULT 0,
+    last_prompted_at TIMESTAMPTZ,
+    dismiss_count INTEGER NOT NULL DEFAULT 0,
+    last_dismissed_at TIMESTAMPTZ,
+    snoozed_until TIMESTAMPTZ,
+    last_snoozed_at TIMESTAMPTZ, -- Отложенным считается только показ после этого момента
+    next_poll_at TIMESTAMPTZ, -- Раньше этого времени опрос не показывается (cool-down)
+
+    FOREIGN KEY (u_id) REFERENCES "user"(u_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
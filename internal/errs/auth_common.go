@@ -1,9 +1,28 @@
 package errs
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 var ErrWrongCredentials = fmt.Errorf("wrong credentials")
 var ErrBusyEmail = fmt.Errorf("this email is used in another account")
 var ErrBusyNickname = fmt.Errorf("this nickname is used in another account")
+var ErrTooManyAttempts = fmt.Errorf("too many login attempts")
+var ErrEmailNotVerified = fmt.Errorf("email is not verified")
+
+// LockoutError - вход временно заблокирован после неудачных попыток.
+// errors.Is(err, ErrTooManyAttempts) для неё выполняется
+type LockoutError struct {
+	Until time.Time
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("%s, locked until %s", ErrTooManyAttempts, e.Until.Format(time.RFC3339))
+}
+
+func (e *LockoutError) Unwrap() error {
+	return ErrTooManyAttempts
+}
 
 const SessionCookieName string = "session_id"
@@ -5,3 +5,7 @@ import "fmt"
 var ErrNotFound = fmt.Errorf("not found")
 var ErrNotPermitted = fmt.Errorf("not permitted")
 var ErrAlreadyExists = fmt.Errorf("already exists")
+var ErrBadRequest = fmt.Errorf("bad request")
+var ErrTooManyRequests = fmt.Errorf("too many requests")
+var ErrTooLarge = fmt.Errorf("too large")
+var ErrUnsupportedMediaType = fmt.Errorf("unsupported media type")
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Триггеры правил автоматизации
+const (
+	TriggerCardMoved          = "card_moved"
+	TriggerCardCreated        = "card_created"
+	TriggerDeadlinePassed     = "deadline_passed"
+	TriggerCheckListCompleted = "checklist_completed"
+)
+
+// Действия правил автоматизации
+const (
+	ActionSetDone       = "set_done"
+	ActionClearDeadline = "clear_deadline"
+	ActionAssignUser    = "assign_user"
+	ActionMoveCard      = "move_card"
+	ActionAddLabel      = "add_label"
+	ActionPostComment   = "post_comment"
+)
+
+// AutomationTrigger - условие срабатывания правила.
+// Для card_moved и card_created можно указать колонку (иначе подходит любая)
+type AutomationTrigger struct {
+	Type     string `json:"type" validate:"required,oneof=card_moved card_created deadline_passed checklist_completed"`
+	ColumnID *int64 `json:"columnId,omitempty"`
+}
+
+// AutomationAction - действие правила; используются только параметры, нужные его типу
+type AutomationAction struct {
+	Type     string  `json:"type" validate:"required,oneof=set_done clear_deadline assign_user move_card add_label post_comment"`
+	ColumnID *int64  `json:"columnId,omitempty"`
+	UserID   *int64  `json:"userId,omitempty"`
+	Label    *string `json:"label,omitempty" validate:"omitempty,min=1,max=50"`
+	Text     *string `json:"text,omitempty" validate:"omitempty,min=3,max=1024"`
+}
+
+// AutomationRule - правило автоматизации доски «когда X, то Y»
+type AutomationRule struct {
+	ID        int64              `json:"id"`
+	BoardID   int64              `json:"boardId"`
+	Title     string             `json:"title"`
+	Trigger   AutomationTrigger  `json:"trigger"`
+	Actions   []AutomationAction `json:"actions"`
+	IsEnabled bool               `json:"isEnabled"`
+	CreatedBy int64              `json:"createdBy"` // 0 - автор удалил аккаунт, а на доске не осталось участников
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// OverdueCard - карточка с прошедшим дедлайном, для которой ещё не отработали правила
+type OverdueCard struct {
+	CardID   int64
+	BoardID  int64
+	ColumnID int64
+	Deadline time.Time
+}
@@ -3,12 +3,13 @@ package models
 import "time"
 
 type Board struct {
-	ID                 int64     `json:"id"`
-	Name               string    `json:"name"`
-	BackgroundImageURL string    `json:"backgroundImageUrl"`
-	CreatedAt          time.Time `json:"createdAt"`
-	UpdatedAt          time.Time `json:"updatedAt"`
-	LastVisitAt        time.Time `json:"lastVisitAt"`
+	ID                      int64         `json:"id"`
+	Name                    string        `json:"name"`
+	BackgroundImageURL      string        `json:"backgroundImageUrl"`
+	BackgroundImageVariants ImageVariants `json:"backgroundImageVariants"`
+	CreatedAt               time.Time     `json:"createdAt"`
+	UpdatedAt               time.Time     `json:"updatedAt"`
+	LastVisitAt             time.Time     `json:"lastVisitAt"`
 }
 
 // MemberWithPermissions - пользователь с правами (в контексте доски)
@@ -22,27 +23,78 @@ type MemberWithPermissions struct {
 }
 
 type BoardContent struct {
-	MyRole    string   `json:"myRole"`
-	Cards     []Card   `json:"allCards"`
-	Columns   []Column `json:"allColumns"`
-	BoardInfo *Board   `json:"boardInfo"`
+	MyRole            string             `json:"myRole"`
+	Cards             []Card             `json:"allCards"`
+	Columns           []Column           `json:"allColumns"`
+	CustomFields      []CustomField      `json:"customFields"`
+	CustomFieldValues []CustomFieldValue `json:"customFieldValues"`
+	BoardInfo         *Board             `json:"boardInfo"`
+}
+
+// BoardExport - выгрузка доски со значениями пользовательских полей карточек
+type BoardExport struct {
+	Board        *Board        `json:"board"`
+	ExportedAt   time.Time     `json:"exportedAt"`
+	Columns      []Column      `json:"columns"`
+	CustomFields []CustomField `json:"customFields"`
+	Cards        []CardExport  `json:"cards"`
+}
+
+// CardExport - карточка в выгрузке доски
+type CardExport struct {
+	ID                int64              `json:"id"`
+	Title             string             `json:"title"`
+	ColumnID          int64              `json:"columnId"`
+	ColumnTitle       string             `json:"columnTitle"`
+	Deadline          *time.Time         `json:"deadline,omitempty"`
+	IsDone            bool               `json:"isDone"`
+	CreatedAt         time.Time          `json:"createdAt"`
+	UpdatedAt         time.Time          `json:"updatedAt"`
+	CustomFieldValues []CustomFieldValue `json:"customFieldValues"`
+}
+
+// Типы пользовательских полей доски
+const (
+	CustomFieldText     = "text"
+	CustomFieldNumber   = "number"
+	CustomFieldDate     = "date"
+	CustomFieldDropdown = "dropdown"
+	CustomFieldCheckbox = "checkbox"
+)
+
+// CustomField - пользовательское поле, заданное на доске
+type CustomField struct {
+	ID         int64    `json:"id"`
+	Title      string   `json:"title"`
+	Type       string   `json:"type"`
+	Options    []string `json:"options"`
+	OrderIndex int64    `json:"-"`
+}
+
+// CustomFieldValue - значение пользовательского поля на карточке.
+// Хранится строкой: число в десятичной записи, дата как YYYY-MM-DD, флажок как true/false
+type CustomFieldValue struct {
+	CardID  int64  `json:"cardId"`
+	FieldID int64  `json:"fieldId"`
+	Value   string `json:"value"`
 }
 
 type Card struct {
-	ID               int64      `json:"id"`
-	UUID             string     `json:"cardUuid"`
-	Title            string     `json:"title"`
-	CoverImageURL    string     `json:"coverImageUrl"`
-	ColumnID         int64      `json:"columnId"`
-	CreatedAt        time.Time  `json:"createdAt"`
-	UpdatedAt        time.Time  `json:"updatedAt"`
-	Deadine          *time.Time `json:"deadline,omitempty"`
-	IsDone           bool       `json:"isDone"`
-	HasCheckList     bool       `json:"hasCheckList"`
-	HasAttachments   bool       `json:"hasAttachments"`
-	HasAssignedUsers bool       `json:"hasAssignedUsers"`
-	HasComments      bool       `json:"hasComments"`
-	OrderIndex       int64      `json:"-"`
+	ID                 int64         `json:"id"`
+	UUID               string        `json:"cardUuid"`
+	Title              string        `json:"title"`
+	CoverImageURL      string        `json:"coverImageUrl"`
+	CoverImageVariants ImageVariants `json:"coverImageVariants"`
+	ColumnID           int64         `json:"columnId"`
+	CreatedAt          time.Time     `json:"createdAt"`
+	UpdatedAt          time.Time     `json:"updatedAt"`
+	Deadine            *time.Time    `json:"deadline,omitempty"`
+	IsDone             bool          `json:"isDone"`
+	HasCheckList       bool          `json:"hasCheckList"`
+	HasAttachments     bool          `json:"hasAttachments"`
+	HasAssignedUsers   bool          `json:"hasAssignedUsers"`
+	HasComments        bool          `json:"hasComments"`
+	OrderIndex         int64         `json:"-"`
 }
 
 type Column struct {
@@ -55,16 +107,69 @@ type Comment struct {
 	ID        int64        `json:"id"`
 	Text      string       `json:"text"`
 	IsEdited  bool         `json:"isEdited"`
-	CreatedBy *UserProfile `json:"createdBy"`
+	CreatedBy *UserProfile `json:"createdBy"` // ID 0 и имя deleted - автор удалил аккаунт
 	CreatedAt time.Time    `json:"createdAt"`
 }
 
+// CheckList - именованный чеклист на карточке
+type CheckList struct {
+	ID                int64            `json:"id"`
+	Title             string           `json:"title"`
+	CreatedAt         time.Time        `json:"createdAt"`
+	CompletionPercent int              `json:"completionPercent"`
+	Fields            []CheckListField `json:"fields"`
+	OrderIndex        int64            `json:"-"`
+}
+
 type CheckListField struct {
-	ID         int64     `json:"id"`
-	Title      string    `json:"title"`
-	CreatedAt  time.Time `json:"createdAt"`
-	IsDone     bool      `json:"isDone"`
-	OrderIndex int64     `json:"-"`
+	ID            int64     `json:"id"`
+	CheckListID   int64     `json:"checkListId"`
+	Title         string    `json:"title"`
+	CreatedAt     time.Time `json:"createdAt"`
+	IsDone        bool      `json:"isDone"`
+	SubtaskCardID *int64    `json:"subtaskCardId,omitempty"`
+	OrderIndex    int64     `json:"-"`
+}
+
+// Subtask - дочерняя карточка, в которую превратили строку чеклиста
+type Subtask struct {
+	CardID           int64  `json:"cardId"`
+	CheckListFieldID int64  `json:"checkListFieldId"`
+	Title            string `json:"title"`
+	ColumnID         int64  `json:"columnId"`
+	IsDone           bool   `json:"isDone"`
+}
+
+// TimeEntry - отрезок времени, потраченный пользователем на карточку
+// (запущенный таймер или запись, добавленная вручную)
+type TimeEntry struct {
+	ID              int64      `json:"id"`
+	CardID          int64      `json:"cardId"`
+	UserID          int64      `json:"userId"`
+	Nickname        string     `json:"nickname"`
+	StartedAt       time.Time  `json:"startedAt"`
+	EndedAt         *time.Time `json:"endedAt,omitempty"`
+	DurationSeconds int64      `json:"durationSeconds"`
+	Comment         string     `json:"comment"`
+	IsRunning       bool       `json:"isRunning"`
+}
+
+// TimesheetRow - суммарное время одного пользователя на одной карточке
+type TimesheetRow struct {
+	UserID       int64  `json:"userId"`
+	Nickname     string `json:"nickname"`
+	CardID       int64  `json:"cardId"`
+	CardTitle    string `json:"cardTitle"`
+	TotalSeconds int64  `json:"totalSeconds"`
+}
+
+// Timesheet - отчёт о потраченном на доске времени за период
+type Timesheet struct {
+	BoardID      int64          `json:"boardId"`
+	From         time.Time      `json:"from"`
+	To           time.Time      `json:"to"`
+	TotalSeconds int64          `json:"totalSeconds"`
+	Rows         []TimesheetRow `json:"rows"`
 }
 
 type Attachment struct {
@@ -75,11 +180,17 @@ type Attachment struct {
 }
 
 type CardDetails struct {
-	Card          *Card            `json:"card"`
-	CheckList     []CheckListField `json:"checkList"`
-	Attachments   []Attachment     `json:"attachments"`
-	Comments      []Comment        `json:"comments"`
-	AssignedUsers []UserProfile    `json:"assignedUsers"`
+	Card              *Card              `json:"card"`
+	CheckList         []CheckListField   `json:"checkList"` // Строки всех чеклистов подряд, как до появления именованных чеклистов
+	CheckLists        []CheckList        `json:"checkLists"`
+	Attachments       []Attachment       `json:"attachments"`
+	Comments          []Comment          `json:"comments"`
+	AssignedUsers     []UserProfile      `json:"assignedUsers"`
+	Subtasks          []Subtask          `json:"subtasks"`
+	TimeEntries       []TimeEntry        `json:"timeEntries"`
+	CustomFields      []CustomField      `json:"customFields"`
+	CustomFieldValues []CustomFieldValue `json:"customFieldValues"`
+	Labels            []string           `json:"labels"`
 }
 
 type InviteLink struct {
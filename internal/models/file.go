@@ -1,9 +1,21 @@
 package models
 
+import "io"
+
 type UploadedFile struct {
-	FileID        *int64 // Суррогатный первичный ключ в таблице user_uploaded_file
-	Content       []byte // Полное содержимое файла
+	FileID        *int64    // Суррогатный первичный ключ в таблице user_uploaded_file
+	Content       io.Reader // Поток содержимого из запроса; читается один раз при сохранении в хранилище
+	ContentType   string    // MIME-тип, определённый по содержимому
+	Size          int64     // Размер в байтах, известен после сохранения
+	Hash          string    // SHA-256 содержимого (hex), известен после сохранения
 	OriginalName  string
 	UUID          *string // UUID файла (в таблице user_uploaded_file)
 	FileExtension string  // nil, если у файла нет расширения
 }
+
+// ImageVariants - адреса уменьшенных копий картинки (если копии не делаются, все ведут на оригинал)
+type ImageVariants struct {
+	Thumbnail string `json:"thumbnail"`
+	Medium    string `json:"medium"`
+	Full      string `json:"full"`
+}
@@ -1,8 +1,66 @@
 package models
 
+import "time"
+
+// Сводка оценок по одному вопросу типа answer_rating
 type RatingResults struct {
-	Question string `json:"question"`
-	Rating   string `json:"rating"`
+	QuestionID int64               `json:"questionId"`
+	Question   string              `json:"question"`
+	RatingMin  int                 `json:"ratingMin"`
+	RatingMax  int                 `json:"ratingMax"`
+	Average    float64             `json:"average"` // 0, если оценок за период нет
+	Count      int64               `json:"count"`
+	Histogram  []RatingBucket      `json:"histogram"` // Все значения шкалы, включая нулевые
+	Trend      []RatingTrendBucket `json:"trend"`
+}
+
+// Сводка по одному вопросу типа answer_nps.
+// Score = % промоутеров - % критиков, от -100 до 100 (0, если ответов нет)
+type NPSResults struct {
+	QuestionID int64            `json:"questionId"`
+	Question   string           `json:"question"`
+	Score      float64          `json:"score"`
+	Count      int64            `json:"count"`
+	Promoters  int64            `json:"promoters"`
+	Passives   int64            `json:"passives"`
+	Detractors int64            `json:"detractors"`
+	Histogram  []RatingBucket   `json:"histogram"`
+	Trend      []NPSTrendBucket `json:"trend"`
+}
+
+// NPS за одну неделю или месяц
+type NPSTrendBucket struct {
+	PeriodStart time.Time `json:"periodStart"`
+	Score       float64   `json:"score"`
+	Count       int64     `json:"count"`
+	Promoters   int64     `json:"promoters"`
+	Detractors  int64     `json:"detractors"`
+}
+
+// Количество ответов с конкретной оценкой
+type RatingBucket struct {
+	Rating int   `json:"rating"`
+	Count  int64 `json:"count"`
+}
+
+// Оценки за одну неделю или месяц. PeriodStart - начало периода в UTC
+type RatingTrendBucket struct {
+	PeriodStart time.Time `json:"periodStart"`
+	Average     float64   `json:"average"`
+	Count       int64     `json:"count"`
+}
+
+// Период трендов в результатах опроса
+const (
+	PollTrendWeek  = "week"
+	PollTrendMonth = "month"
+)
+
+// Фильтр результатов опроса. From включительно, To не включительно, nil - без ограничения
+type PollResultsFilter struct {
+	From   *time.Time
+	To     *time.Time
+	Period string
 }
 
 type AnswerResults struct {
@@ -14,6 +72,56 @@ type PollQuestion struct {
 	QuestionID   int64  `json:"questionId" `
 	QuestionText string `json:"questionText" `
 	QuestionType string `json:"questionType" `
+	RatingMin    int    `json:"ratingMin"` // Шкала оценки для answer_rating
+	RatingMax    int    `json:"ratingMax"`
+}
+
+// Вопрос опроса со всеми настройками - для администраторов опросов
+type PollQuestionAdmin struct {
+	ID         int64     `json:"id"`
+	Text       string    `json:"text"`
+	Type       string    `json:"type"`
+	IsActive   bool      `json:"isActive"`
+	OrderIndex int       `json:"orderIndex"`
+	RatingMin  int       `json:"ratingMin"`
+	RatingMax  int       `json:"ratingMax"`
+	Audience   *string   `json:"audience"` // nil - вопрос показывается всем
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	// Текстовый вопрос, который показывается только критикам (для answer_nps)
+	FollowUpQuestionID *int64 `json:"followUpQuestionId"`
+}
+
+// Типы вопросов опроса
+const (
+	PollQuestionText   = "answer_text"
+	PollQuestionRating = "answer_rating"
+	PollQuestionNPS    = "answer_nps"
+)
+
+// Шкала NPS фиксирована: от 0 до 10. Оценки до NPSDetractorMax включительно - критики,
+// от NPSPromoterMin - промоутеры, остальные - нейтралы
+const (
+	NPSRatingMin    = 0
+	NPSRatingMax    = 10
+	NPSDetractorMax = 6
+	NPSPromoterMin  = 9
+)
+
+// Группы отвечающих в NPS
+const (
+	NPSPromoter  = "promoter"  // 9-10
+	NPSPassive   = "passive"   // 7-8
+	NPSDetractor = "detractor" // 0-6
+)
+
+// Всё, что нужно планировщику, чтобы решить, показывать ли пользователю опрос
+type PollState struct {
+	JoinedAt     time.Time
+	NextPollAt   *time.Time // Раньше этого времени опрос не показывается (cool-down)
+	SnoozedUntil *time.Time // Пользователь попросил напомнить позже
+	PromptCount  int
+	CardsCreated int
 }
 
 type PollSubmit struct {
@@ -23,7 +131,30 @@ type PollSubmit struct {
 	Text         *string `json:"text" `
 }
 
+// Ответ на отправку ответа. FollowUpQuestion заполнен, если ответ на NPS
+// попал в критики и у вопроса есть уточняющий вопрос
+type PollSubmitResponse struct {
+	FollowUpQuestion *PollQuestion `json:"followUpQuestion,omitempty"`
+}
+
+// Одна строка выгрузки ответов. В обезличенной выгрузке вместо UserID заполнен UserHash
+type PollExportRow struct {
+	ResultID     int64     `json:"resultId"`
+	QuestionID   int64     `json:"questionId"`
+	Question     string    `json:"question"`
+	QuestionType string    `json:"questionType"`
+	UserID       *int64    `json:"userId,omitempty"`
+	UserHash     string    `json:"userHash,omitempty"`
+	Rating       *int      `json:"rating"`
+	Text         *string   `json:"text"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
 type PollResults struct {
+	From          *time.Time      `json:"from,omitempty"`
+	To            *time.Time      `json:"to,omitempty"`
+	Period        string          `json:"period"`
 	RatingResults []RatingResults `json:"ratingResults"`
+	NPSResults    []NPSResults    `json:"npsResults"`
 	TextResults   []AnswerResults `json:"textResults"`
 }
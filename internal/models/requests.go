@@ -39,6 +39,45 @@ type ChangePasswordRequest struct {
 	OldPassword string `json:"oldPassword" validate:"required"`
 }
 
+// Удаление аккаунта подтверждается паролем. Пользователь, входящий только через SSO,
+// пароля не передаёт: ему достаточно недавнего входа
+type AccountDeleteRequest struct {
+	Password string `json:"password"`
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type PasswordResetConfirmRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required,min=8,max=50"`
+}
+
+// Второй шаг входа: токен из ответа на логин и код из приложения (или код восстановления)
+type TwoFactorLoginRequest struct {
+	Token string `json:"token" validate:"required"`
+	Code  string `json:"code" validate:"required,max=20"`
+}
+
+type TOTPCodeRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+type AccessTokenCreateRequest struct {
+	Name          string   `json:"name" validate:"required,max=50"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=boards:read cards:write admin"`
+	ExpiresInDays *int     `json:"expiresInDays" validate:"omitempty,min=1,max=365"` // null - бессрочный
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendEmailVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
 type UserRegisterRequest struct {
 	Name     string `json:"name" validate:"required,min=3,max=30"`
 	Email    string `json:"email" validate:"required,email"`
@@ -55,14 +94,80 @@ type CommentRequest struct {
 }
 
 type CheckListFieldPatchRequest struct {
-	Title           *string `json:"title" validate:"min=3,max=50"`
+	Title           *string `json:"title" validate:"omitempty,min=3,max=50"`
 	IsDone          *bool   `json:"isDone"`
+	CheckListID     *int64  `json:"checkListId"`
 	PreviousFieldID *int64  `json:"previousFieldId"`
 	NextFieldID     *int64  `json:"nextFieldId"`
 }
 
 type CheckListFieldPostRequest struct {
-	Title string `json:"title" validate:"required"`
+	Title       string `json:"title" validate:"required"`
+	CheckListID *int64 `json:"checkListId"`
+}
+
+type CheckListRequest struct {
+	Title string `json:"title" validate:"required,max=50"`
+}
+
+type CheckListFieldPromoteRequest struct {
+	ColumnID *int64 `json:"columnId" validate:"required"`
+}
+
+type WorkLogRequest struct {
+	StartedAt time.Time `json:"startedAt" validate:"required"`
+	EndedAt   time.Time `json:"endedAt" validate:"required,gtfield=StartedAt"`
+	Comment   string    `json:"comment" validate:"max=1024"`
+}
+
+type CustomFieldPostRequest struct {
+	Title   string   `json:"title" validate:"required,max=50"`
+	Type    string   `json:"type" validate:"required,oneof=text number date dropdown checkbox"`
+	Options []string `json:"options" validate:"dive,required,max=50"`
+}
+
+type CustomFieldPutRequest struct {
+	Title   string   `json:"title" validate:"required,max=50"`
+	Options []string `json:"options" validate:"dive,required,max=50"`
+}
+
+// CustomFieldValueRequest задаёт значение поля на карточке; null очищает значение
+type CustomFieldValueRequest struct {
+	Value *string `json:"value" validate:"omitempty,max=1024"`
+}
+
+type AutomationRuleRequest struct {
+	Title     string             `json:"title" validate:"required,max=100"`
+	Trigger   AutomationTrigger  `json:"trigger"`
+	Actions   []AutomationAction `json:"actions" validate:"required,min=1,max=10,dive"`
+	IsEnabled *bool              `json:"isEnabled"`
+}
+
+// Новый вопрос опроса. Шкала по умолчанию - от 1 до 5 (у NPS всегда от 0 до 10), вопрос сразу активен
+type PollQuestionPostRequest struct {
+	Text      string  `json:"text" validate:"required,max=500"`
+	Type      string  `json:"type" validate:"required,oneof=answer_text answer_rating answer_nps"`
+	RatingMin *int    `json:"ratingMin" validate:"omitempty,min=0,max=10"`
+	RatingMax *int    `json:"ratingMax" validate:"omitempty,min=1,max=10"`
+	Audience  *string `json:"audience" validate:"omitempty,oneof=new_users experienced_users board_admins"`
+	IsActive  *bool   `json:"isActive"`
+	// Уточняющий текстовый вопрос для критиков, только для answer_nps
+	FollowUpQuestionID *int64 `json:"followUpQuestionId"`
+}
+
+// Изменение вопроса опроса (тип вопроса не меняется, чтобы не смешивать ответы)
+type PollQuestionPutRequest struct {
+	Text      string  `json:"text" validate:"required,max=500"`
+	RatingMin int     `json:"ratingMin" validate:"min=0,max=10"`
+	RatingMax int     `json:"ratingMax" validate:"min=1,max=10"`
+	Audience  *string `json:"audience" validate:"omitempty,oneof=new_users experienced_users board_admins"`
+	// nil - убрать уточняющий вопрос
+	FollowUpQuestionID *int64 `json:"followUpQuestionId"`
+}
+
+// Новый порядок вопросов: в списке должны быть все вопросы, по одному разу
+type PollQuestionOrderRequest struct {
+	QuestionIDs []int64 `json:"questionIds" validate:"required,min=1,unique"`
 }
 
 type CardMoveRequest struct {
@@ -1,10 +1,36 @@
 package models
 
+import "time"
+
 type BadResponse struct {
 	Status int    `json:"status"`
 	Text   string `json:"text"`
 }
 
+// Если вход временно заблокирован после неудачных попыток
+type LoginLockoutResponse struct {
+	Status      int       `json:"status"`
+	Text        string    `json:"text"`
+	LockedUntil time.Time `json:"lockedUntil"`
+}
+
+// Если пароль верный, но для входа нужен код второго фактора
+type TwoFactorRequiredResponse struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	TwoFactorToken    string `json:"twoFactorToken"`
+}
+
+// Секрет для приложения-аутентификатора; ProvisioningURI кодируется в QR-код
+type TOTPEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+// Одноразовые коды восстановления, показываются пользователю один раз
+type RecoveryCodesResponse struct {
+	Codes []string `json:"codes"`
+}
+
 // Если карточка находится на той доске, на которой пользователь есть
 type SharedCardFoundResponse struct {
 	BoardID int `json:"boardId"`
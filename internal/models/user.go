@@ -3,12 +3,108 @@ package models
 import "time"
 
 type UserProfile struct {
-	ID             int64          `json:"id"`
-	Name           string         `json:"name"`
-	Email          string         `json:"email"`
-	JoinedAt       time.Time      `json:"joinedAt"`
-	UpdatedAt      time.Time      `json:"updatedAt"`
-	AvatarImageURL string         `json:"avatarImageUrl"`
-	CsatPollDT     time.Time      `json:"-"`
-	PollQuestions  []PollQuestion `json:"pollQuestions,omitempty"`
+	ID                  int64          `json:"id"`
+	Name                string         `json:"name"`
+	Email               string         `json:"email"`
+	EmailVerified       bool           `json:"emailVerified"`
+	EmailVerifiedLegacy bool           `json:"-"`                      // Email подтверждён миграцией для старых пользователей, а не письмом
+	PendingEmail        *string        `json:"pendingEmail,omitempty"` // Новый email, который ещё не подтверждён
+	JoinedAt            time.Time      `json:"joinedAt"`
+	UpdatedAt           time.Time      `json:"updatedAt"`
+	AvatarImageURL      string         `json:"avatarImageUrl"`
+	AvatarImageVariants ImageVariants  `json:"avatarImageVariants"`
+	PollQuestions       []PollQuestion `json:"pollQuestions,omitempty"`
+}
+
+// Настройки двухфакторной аутентификации пользователя (TOTP)
+type UserTOTP struct {
+	UserID       int64
+	Secret       string
+	EnabledAt    *time.Time // nil - подключение начато, но ещё не подтверждено кодом
+	LastUsedStep int64      // Последний принятый 30-секундный интервал, защищает от повторного использования кода
+}
+
+// Активная сессия пользователя (устройство, с которого выполнен вход)
+type SessionInfo struct {
+	SessionID  string    `json:"-"`
+	ID         string    `json:"id"` // Публичный ID, по нему сессию можно завершить
+	UserAgent  string    `json:"userAgent"`
+	ClientIP   string    `json:"ip"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	Current    bool      `json:"current"`
+}
+
+// Персональный токен доступа для скриптов и API. Сам токен отдаётся только при создании
+type AccessToken struct {
+	ID         int64      `json:"id"`
+	Token      string     `json:"token,omitempty"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"` // nil - бессрочный
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
+
+// Выгрузка персональных данных пользователя (отдаётся zip-архивом)
+type UserDataExport struct {
+	ExportedAt  time.Time          `json:"exportedAt"`
+	Profile     *UserProfile       `json:"profile"`
+	Boards      []BoardMembership  `json:"boards"`
+	Comments    []CommentExport    `json:"comments"`
+	Attachments []AttachmentExport `json:"attachments"`
+	TimeEntries []TimeEntryExport  `json:"timeEntries"`
+}
+
+// Участие пользователя в доске
+type BoardMembership struct {
+	BoardID     int64     `json:"boardId"`
+	BoardName   string    `json:"boardName"`
+	Role        string    `json:"role"`
+	AddedAt     time.Time `json:"addedAt"`
+	LastVisitAt time.Time `json:"lastVisitAt"`
+}
+
+// Комментарий пользователя вместе с тем, где он оставлен
+type CommentExport struct {
+	ID        int64     `json:"id"`
+	BoardID   int64     `json:"boardId"`
+	BoardName string    `json:"boardName"`
+	CardID    int64     `json:"cardId"`
+	CardTitle string    `json:"cardTitle"`
+	Text      string    `json:"text"`
+	IsEdited  bool      `json:"isEdited"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Вложение, прикреплённое пользователем. Сам файл лежит в архиве по пути ArchivePath
+type AttachmentExport struct {
+	ID           int64     `json:"id"`
+	BoardID      int64     `json:"boardId"`
+	BoardName    string    `json:"boardName"`
+	CardID       int64     `json:"cardId"`
+	CardTitle    string    `json:"cardTitle"`
+	OriginalName string    `json:"originalName"`
+	AttachedAt   time.Time `json:"attachedAt"`
+	ArchivePath  string    `json:"archivePath,omitempty"` // Пусто, если файл не удалось прочитать
+	StoredName   string    `json:"-"`                     // Имя файла в каталоге загрузок
+}
+
+// Запись учёта времени пользователя вместе с тем, где она сделана
+type TimeEntryExport struct {
+	ID        int64      `json:"id"`
+	BoardID   int64      `json:"boardId"`
+	BoardName string     `json:"boardName"`
+	CardID    int64      `json:"cardId"`
+	CardTitle string     `json:"cardTitle"`
+	StartedAt time.Time  `json:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"` // Пусто, если таймер запущен
+	Comment   string     `json:"comment"`
+}
+
+// Что стало с досками удалённого пользователя
+type AccountDeletionResult struct {
+	TransferredBoards []int64 `json:"transferredBoards"` // Доски, где админом стал другой участник
+	DeletedBoards     []int64 `json:"deletedBoards"`     // Доски, где пользователь был единственным участником
 }
@@ -2,11 +2,14 @@ package delivery
 
 import (
 	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
 	"RPO_back/internal/pkg/auth"
 	"RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	"RPO_back/internal/pkg/utils/logging"
 	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
 type AuthDelivery struct {
@@ -21,9 +24,33 @@ func CreateAuthServer(uc auth.AuthUsecase) *AuthDelivery {
 }
 
 func (d *AuthDelivery) CreateSession(ctx context.Context, request *gen.UserDataRequest) (*gen.Session, error) {
-	sessionID, err := d.authUsecase.CreateSession(ctx, request.UserID, request.Password)
+	sessionID, twoFactorRequired, err := d.authUsecase.CreateSession(ctx, request.UserID, request.Password, request.ClientIP, request.UserAgent)
 	if err != nil {
-		return &gen.Session{Error: gen.Error_INVALID_CREDENTIALS}, err
+		return sessionErrorToGRPC(ctx, err), nil
+	}
+
+	if twoFactorRequired {
+		return &gen.Session{TwoFactorRequired: true, PartialSessionID: sessionID, Error: gen.Error_NONE}, nil
+	}
+	return &gen.Session{SessionID: sessionID, Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) CreateTrustedSession(ctx context.Context, request *gen.TrustedSessionRequest) (*gen.Session, error) {
+	sessionID, twoFactorRequired, err := d.authUsecase.CreateTrustedSession(ctx, request.UserID, request.ClientIP, request.UserAgent)
+	if err != nil {
+		return sessionErrorToGRPC(ctx, err), nil
+	}
+
+	if twoFactorRequired {
+		return &gen.Session{TwoFactorRequired: true, PartialSessionID: sessionID, Error: gen.Error_NONE}, nil
+	}
+	return &gen.Session{SessionID: sessionID, Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) VerifyTwoFactor(ctx context.Context, request *gen.TwoFactorRequest) (*gen.Session, error) {
+	sessionID, err := d.authUsecase.VerifyTwoFactor(ctx, request.PartialSessionID, request.Code, request.ClientIP, request.UserAgent)
+	if err != nil {
+		return sessionErrorToGRPC(ctx, err), nil
 	}
 
 	return &gen.Session{SessionID: sessionID, Error: gen.Error_NONE}, nil
@@ -44,17 +71,227 @@ func (d *AuthDelivery) CheckSession(ctx context.Context, request *gen.CheckSessi
 func (d *AuthDelivery) DeleteSession(ctx context.Context, request *gen.Session) (*gen.StatusResponse, error) {
 	err := d.authUsecase.KillSession(ctx, request.SessionID)
 	if err != nil {
-		return &gen.StatusResponse{Error: gen.Error_INTERNAL_SERVER_ERROR}, nil
+		return &gen.StatusResponse{Error: errorToGRPC(ctx, err)}, nil
 	}
 
 	return &gen.StatusResponse{Error: gen.Error_NONE}, nil
 }
 
+func (d *AuthDelivery) CheckPassword(ctx context.Context, request *gen.CheckPasswordRequest) (*gen.UserDataResponse, error) {
+	userID, err := d.authUsecase.CheckPassword(ctx, request.SessionID, request.Password)
+	if err != nil {
+		return &gen.UserDataResponse{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return &gen.UserDataResponse{UserID: int64(userID), Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) CheckLoginLockout(ctx context.Context, request *gen.LoginLockoutRequest) (*gen.Session, error) {
+	err := d.authUsecase.CheckLoginLockout(ctx, request.ClientIP)
+	if err != nil {
+		return sessionErrorToGRPC(ctx, err), nil
+	}
+
+	return &gen.Session{Error: gen.Error_NONE}, nil
+}
+
 func (d *AuthDelivery) ChangePassword(ctx context.Context, request *gen.ChangePasswordRequest) (*gen.StatusResponse, error) {
 	err := d.authUsecase.ChangePassword(ctx, request.PasswordOld, request.PasswordNew, request.SessionID)
 	if err != nil {
-		return &gen.StatusResponse{Error: gen.Error_INTERNAL_SERVER_ERROR}, nil
+		return &gen.StatusResponse{Error: errorToGRPC(ctx, err)}, nil
 	}
 
 	return &gen.StatusResponse{Error: gen.Error_NONE}, nil
 }
+
+func (d *AuthDelivery) RequestPasswordReset(ctx context.Context, request *gen.PasswordResetRequest) (*gen.StatusResponse, error) {
+	err := d.authUsecase.RequestPasswordReset(ctx, request.Email, request.ClientIP)
+	if err != nil {
+		return &gen.StatusResponse{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return &gen.StatusResponse{Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) ConfirmPasswordReset(ctx context.Context, request *gen.ConfirmPasswordResetRequest) (*gen.StatusResponse, error) {
+	err := d.authUsecase.ConfirmPasswordReset(ctx, request.Token, request.PasswordNew)
+	if err != nil {
+		return &gen.StatusResponse{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return &gen.StatusResponse{Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) BeginTOTPEnrollment(ctx context.Context, request *gen.CheckSessionRequest) (*gen.TOTPEnrollment, error) {
+	secret, provisioningURI, err := d.authUsecase.BeginTOTPEnrollment(ctx, request.SessionID)
+	if err != nil {
+		return &gen.TOTPEnrollment{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return &gen.TOTPEnrollment{Secret: secret, ProvisioningURI: provisioningURI, Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) ConfirmTOTPEnrollment(ctx context.Context, request *gen.TOTPCodeRequest) (*gen.RecoveryCodes, error) {
+	codes, err := d.authUsecase.ConfirmTOTPEnrollment(ctx, request.SessionID, request.Code)
+	if err != nil {
+		return &gen.RecoveryCodes{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return &gen.RecoveryCodes{Codes: codes, Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) DisableTOTP(ctx context.Context, request *gen.TOTPCodeRequest) (*gen.StatusResponse, error) {
+	err := d.authUsecase.DisableTOTP(ctx, request.SessionID, request.Code)
+	if err != nil {
+		return &gen.StatusResponse{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return &gen.StatusResponse{Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) ListSessions(ctx context.Context, request *gen.CheckSessionRequest) (*gen.SessionList, error) {
+	sessions, err := d.authUsecase.ListSessions(ctx, request.SessionID)
+	if err != nil {
+		return &gen.SessionList{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	list := make([]*gen.SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		info := &gen.SessionInfo{
+			Id:        session.ID,
+			UserAgent: session.UserAgent,
+			ClientIP:  session.ClientIP,
+			Current:   session.Current,
+		}
+		if !session.CreatedAt.IsZero() {
+			info.CreatedAt = session.CreatedAt.Unix()
+		}
+		if !session.LastSeenAt.IsZero() {
+			info.LastSeenAt = session.LastSeenAt.Unix()
+		}
+		list = append(list, info)
+	}
+
+	return &gen.SessionList{Sessions: list, Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) RevokeSession(ctx context.Context, request *gen.RevokeSessionRequest) (*gen.StatusResponse, error) {
+	err := d.authUsecase.RevokeSession(ctx, request.SessionID, request.TargetID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			logging.Warn(ctx, err)
+			return &gen.StatusResponse{Error: gen.Error_NOT_FOUND}, nil
+		}
+		return &gen.StatusResponse{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return &gen.StatusResponse{Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) RevokeOtherSessions(ctx context.Context, request *gen.CheckSessionRequest) (*gen.StatusResponse, error) {
+	err := d.authUsecase.RevokeOtherSessions(ctx, request.SessionID)
+	if err != nil {
+		return &gen.StatusResponse{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return &gen.StatusResponse{Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) CheckAccessToken(ctx context.Context, request *gen.AccessTokenRequest) (*gen.AccessTokenCheckResponse, error) {
+	userID, scopes, err := d.authUsecase.CheckAccessToken(ctx, request.Token)
+	if err != nil {
+		return &gen.AccessTokenCheckResponse{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return &gen.AccessTokenCheckResponse{UserID: userID, Scopes: scopes, Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) CreateAccessToken(ctx context.Context, request *gen.CreateAccessTokenRequest) (*gen.AccessToken, error) {
+	var expiresAt *time.Time
+	if request.ExpiresAt != 0 {
+		expires := time.Unix(request.ExpiresAt, 0)
+		expiresAt = &expires
+	}
+
+	token, err := d.authUsecase.CreateAccessToken(ctx, request.SessionID, request.Name, request.Scopes, expiresAt)
+	if err != nil {
+		return &gen.AccessToken{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return accessTokenToGRPC(token), nil
+}
+
+func (d *AuthDelivery) ListAccessTokens(ctx context.Context, request *gen.CheckSessionRequest) (*gen.AccessTokenList, error) {
+	tokens, err := d.authUsecase.ListAccessTokens(ctx, request.SessionID)
+	if err != nil {
+		return &gen.AccessTokenList{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	list := make([]*gen.AccessToken, 0, len(tokens))
+	for i := range tokens {
+		list = append(list, accessTokenToGRPC(&tokens[i]))
+	}
+
+	return &gen.AccessTokenList{Tokens: list, Error: gen.Error_NONE}, nil
+}
+
+func (d *AuthDelivery) RevokeAccessToken(ctx context.Context, request *gen.RevokeAccessTokenRequest) (*gen.StatusResponse, error) {
+	err := d.authUsecase.RevokeAccessToken(ctx, request.SessionID, request.TokenID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			logging.Warn(ctx, err)
+			return &gen.StatusResponse{Error: gen.Error_NOT_FOUND}, nil
+		}
+		return &gen.StatusResponse{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return &gen.StatusResponse{Error: gen.Error_NONE}, nil
+}
+
+// accessTokenToGRPC переводит персональный токен в gRPC-сообщение; отсутствующие даты - 0
+func accessTokenToGRPC(token *models.AccessToken) *gen.AccessToken {
+	message := &gen.AccessToken{
+		Id:        token.ID,
+		Token:     token.Token,
+		Name:      token.Name,
+		Scopes:    token.Scopes,
+		CreatedAt: token.CreatedAt.Unix(),
+		Error:     gen.Error_NONE,
+	}
+	if token.ExpiresAt != nil {
+		message.ExpiresAt = token.ExpiresAt.Unix()
+	}
+	if token.LastUsedAt != nil {
+		message.LastUsedAt = token.LastUsedAt.Unix()
+	}
+	return message
+}
+
+// sessionErrorToGRPC собирает ответ с ошибкой входа; при блокировке в нём есть время её снятия
+func sessionErrorToGRPC(ctx context.Context, err error) *gen.Session {
+	var lockoutErr *errs.LockoutError
+	if errors.As(err, &lockoutErr) {
+		logging.Warn(ctx, err)
+		return &gen.Session{Error: gen.Error_TOO_MANY_ATTEMPTS, LockedUntil: lockoutErr.Until.Unix()}
+	}
+	return &gen.Session{Error: errorToGRPC(ctx, err)}
+}
+
+// errorToGRPC переводит ошибку usecase в код ошибки gRPC-ответа.
+// Неверный пароль и неизвестные пользователь или сессия дают одинаковый INVALID_CREDENTIALS
+func errorToGRPC(ctx context.Context, err error) gen.Error {
+	if errors.Is(err, errs.ErrWrongCredentials) || errors.Is(err, errs.ErrNotFound) {
+		logging.Warn(ctx, err)
+		return gen.Error_INVALID_CREDENTIALS
+	}
+	if errors.Is(err, errs.ErrTooManyAttempts) || errors.Is(err, errs.ErrTooManyRequests) {
+		logging.Warn(ctx, err)
+		return gen.Error_TOO_MANY_ATTEMPTS
+	}
+	if errors.Is(err, errs.ErrBadRequest) || errors.Is(err, errs.ErrAlreadyExists) {
+		logging.Warn(ctx, err)
+		return gen.Error_BAD_REQUEST
+	}
+	logging.Error(ctx, err)
+	return gen.Error_INTERNAL_SERVER_ERROR
+}
@@ -1,27 +1,79 @@
 package auth
 
 import (
+	"RPO_back/internal/models"
 	"context"
+	"time"
 )
 
 const (
 	SessionCookieName = "session_id"
+	AccessTokenPrefix = "pat_" // Префикс персональных токенов доступа, чтобы их было видно в логах и секретах
+)
+
+// Права персональных токенов доступа
+const (
+	ScopeBoardsRead = "boards:read" // Чтение досок и карточек (GET-запросы к ним)
+	ScopeCardsWrite = "cards:write" // Изменение карточек и их содержимого
+	ScopeAdmin      = "admin"       // Любые запросы от имени пользователя
 )
 
 //go:generate mockgen -source=interfaces.go -destination=mocks/mock.go
 
 type AuthUsecase interface {
-	CreateSession(ctx context.Context, userID int64, password string) (sessionID string, err error)
+	CreateSession(ctx context.Context, userID int64, password string, clientIP string, userAgent string) (sessionID string, twoFactorRequired bool, err error)
+	CreateTrustedSession(ctx context.Context, userID int64, clientIP string, userAgent string) (sessionID string, twoFactorRequired bool, err error)
 	CheckSession(ctx context.Context, sessionID string) (userID int, err error)
 	KillSession(ctx context.Context, sessionID string) (err error)
 	ChangePassword(ctx context.Context, oldPassword string, newPassword string, sessionID string) (err error)
+	CheckPassword(ctx context.Context, sessionID string, password string) (userID int, err error)
+	CheckLoginLockout(ctx context.Context, clientIP string) (err error)
+	RequestPasswordReset(ctx context.Context, email string, clientIP string) (err error)
+	ConfirmPasswordReset(ctx context.Context, token string, newPassword string) (err error)
+	VerifyTwoFactor(ctx context.Context, partialSessionID string, code string, clientIP string, userAgent string) (sessionID string, err error)
+	BeginTOTPEnrollment(ctx context.Context, sessionID string) (secret string, provisioningURI string, err error)
+	ConfirmTOTPEnrollment(ctx context.Context, sessionID string, code string) (recoveryCodes []string, err error)
+	DisableTOTP(ctx context.Context, sessionID string, code string) (err error)
+	ListSessions(ctx context.Context, sessionID string) (sessions []models.SessionInfo, err error)
+	RevokeSession(ctx context.Context, sessionID string, targetID string) (err error)
+	RevokeOtherSessions(ctx context.Context, sessionID string) (err error)
+	CheckAccessToken(ctx context.Context, token string) (userID int64, scopes []string, err error)
+	CreateAccessToken(ctx context.Context, sessionID string, name string, scopes []string, expiresAt *time.Time) (token *models.AccessToken, err error)
+	ListAccessTokens(ctx context.Context, sessionID string) (tokens []models.AccessToken, err error)
+	RevokeAccessToken(ctx context.Context, sessionID string, tokenID int64) (err error)
 }
 
 type AuthRepo interface {
-	RegisterSessionRedis(ctx context.Context, cookie string, userID int) error
+	RegisterSessionRedis(ctx context.Context, cookie string, userID int, userAgent string, clientIP string) error
+	TouchSession(ctx context.Context, sessionID string) error
+	GetUserSessions(ctx context.Context, userID int64) (sessions []models.SessionInfo, err error)
+	GetSessionCreatedAt(ctx context.Context, sessionID string) (createdAt time.Time, err error)
 	KillSessionRedis(ctx context.Context, sessionID string) error
 	CheckSession(ctx context.Context, sessionID string) (userID int, err error)
 	SetNewPasswordHash(ctx context.Context, userID int, newPasswordHash string) error
 	GetUserPasswordHash(ctx context.Context, userID int) (passwordHash *string, err error)
 	DisplaceUserSessions(ctx context.Context, sessionID string, userID int64) error
+	GetLoginLockout(ctx context.Context, userID int64, clientIP string) (lockedUntil time.Time, err error)
+	RegisterFailedLogin(ctx context.Context, userID int64, clientIP string) (userFailures int64, ipFailures int64, err error)
+	SetLoginLockout(ctx context.Context, userID int64, clientIP string, userUntil time.Time, ipUntil time.Time) error
+	ResetFailedLogins(ctx context.Context, userID int64) error
+	SaveFailedLoginAttempt(ctx context.Context, userID int64, clientIP string, lockedUntil *time.Time) error
+	GetUserIDByEmail(ctx context.Context, email string) (userID int64, err error)
+	CountPasswordResetRequest(ctx context.Context, email string, clientIP string) (emailRequests int64, ipRequests int64, err error)
+	CreatePasswordResetToken(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error
+	UsePasswordResetToken(ctx context.Context, tokenHash string) (userID int64, err error)
+	GetUserEmail(ctx context.Context, userID int64) (email string, err error)
+	GetUserTOTP(ctx context.Context, userID int64) (settings *models.UserTOTP, err error)
+	SaveTOTPSecret(ctx context.Context, userID int64, secret string) error
+	EnableTOTP(ctx context.Context, userID int64, step int64, recoveryCodeHashes []string) error
+	UseTOTPStep(ctx context.Context, userID int64, step int64) error
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) error
+	DeleteTOTP(ctx context.Context, userID int64) error
+	RegisterPartialSession(ctx context.Context, partialSessionID string, userID int64) error
+	CheckPartialSession(ctx context.Context, partialSessionID string) (userID int64, attempt int64, err error)
+	KillPartialSession(ctx context.Context, partialSessionID string) error
+	CreateAccessToken(ctx context.Context, userID int64, name string, tokenHash string, scopes []string, expiresAt *time.Time) (token *models.AccessToken, err error)
+	GetUserAccessTokens(ctx context.Context, userID int64) (tokens []models.AccessToken, err error)
+	DeleteAccessToken(ctx context.Context, userID int64, tokenID int64) error
+	UseAccessToken(ctx context.Context, tokenHash string) (userID int64, scopes []string, err error)
 }
@@ -0,0 +1,807 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auth_grpc.pb.go
+
+// Package mock_auth is a generated GoMock package.
+package mock_auth
+
+import (
+	gen "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockAuthClient is a mock of AuthClient interface.
+type MockAuthClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthClientMockRecorder
+}
+
+// MockAuthClientMockRecorder is the mock recorder for MockAuthClient.
+type MockAuthClientMockRecorder struct {
+	mock *MockAuthClient
+}
+
+// NewMockAuthClient creates a new mock instance.
+func NewMockAuthClient(ctrl *gomock.Controller) *MockAuthClient {
+	mock := &MockAuthClient{ctrl: ctrl}
+	mock.recorder = &MockAuthClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthClient) EXPECT() *MockAuthClientMockRecorder {
+	return m.recorder
+}
+
+// BeginTOTPEnrollment mocks base method.
+func (m *MockAuthClient) BeginTOTPEnrollment(ctx context.Context, in *gen.CheckSessionRequest, opts ...grpc.CallOption) (*gen.TOTPEnrollment, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BeginTOTPEnrollment", varargs...)
+	ret0, _ := ret[0].(*gen.TOTPEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTOTPEnrollment indicates an expected call of BeginTOTPEnrollment.
+func (mr *MockAuthClientMockRecorder) BeginTOTPEnrollment(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTOTPEnrollment", reflect.TypeOf((*MockAuthClient)(nil).BeginTOTPEnrollment), varargs...)
+}
+
+// ChangePassword mocks base method.
+func (m *MockAuthClient) ChangePassword(ctx context.Context, in *gen.ChangePasswordRequest, opts ...grpc.CallOption) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ChangePassword", varargs...)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAuthClientMockRecorder) ChangePassword(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthClient)(nil).ChangePassword), varargs...)
+}
+
+// CheckAccessToken mocks base method.
+func (m *MockAuthClient) CheckAccessToken(ctx context.Context, in *gen.AccessTokenRequest, opts ...grpc.CallOption) (*gen.AccessTokenCheckResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckAccessToken", varargs...)
+	ret0, _ := ret[0].(*gen.AccessTokenCheckResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAccessToken indicates an expected call of CheckAccessToken.
+func (mr *MockAuthClientMockRecorder) CheckAccessToken(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAccessToken", reflect.TypeOf((*MockAuthClient)(nil).CheckAccessToken), varargs...)
+}
+
+// CheckLoginLockout mocks base method.
+func (m *MockAuthClient) CheckLoginLockout(ctx context.Context, in *gen.LoginLockoutRequest, opts ...grpc.CallOption) (*gen.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckLoginLockout", varargs...)
+	ret0, _ := ret[0].(*gen.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLoginLockout indicates an expected call of CheckLoginLockout.
+func (mr *MockAuthClientMockRecorder) CheckLoginLockout(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLoginLockout", reflect.TypeOf((*MockAuthClient)(nil).CheckLoginLockout), varargs...)
+}
+
+// CheckPassword mocks base method.
+func (m *MockAuthClient) CheckPassword(ctx context.Context, in *gen.CheckPasswordRequest, opts ...grpc.CallOption) (*gen.UserDataResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckPassword", varargs...)
+	ret0, _ := ret[0].(*gen.UserDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPassword indicates an expected call of CheckPassword.
+func (mr *MockAuthClientMockRecorder) CheckPassword(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPassword", reflect.TypeOf((*MockAuthClient)(nil).CheckPassword), varargs...)
+}
+
+// CheckSession mocks base method.
+func (m *MockAuthClient) CheckSession(ctx context.Context, in *gen.CheckSessionRequest, opts ...grpc.CallOption) (*gen.UserDataResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckSession", varargs...)
+	ret0, _ := ret[0].(*gen.UserDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSession indicates an expected call of CheckSession.
+func (mr *MockAuthClientMockRecorder) CheckSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSession", reflect.TypeOf((*MockAuthClient)(nil).CheckSession), varargs...)
+}
+
+// ConfirmPasswordReset mocks base method.
+func (m *MockAuthClient) ConfirmPasswordReset(ctx context.Context, in *gen.ConfirmPasswordResetRequest, opts ...grpc.CallOption) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ConfirmPasswordReset", varargs...)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPasswordReset indicates an expected call of ConfirmPasswordReset.
+func (mr *MockAuthClientMockRecorder) ConfirmPasswordReset(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPasswordReset", reflect.TypeOf((*MockAuthClient)(nil).ConfirmPasswordReset), varargs...)
+}
+
+// ConfirmTOTPEnrollment mocks base method.
+func (m *MockAuthClient) ConfirmTOTPEnrollment(ctx context.Context, in *gen.TOTPCodeRequest, opts ...grpc.CallOption) (*gen.RecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ConfirmTOTPEnrollment", varargs...)
+	ret0, _ := ret[0].(*gen.RecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTPEnrollment indicates an expected call of ConfirmTOTPEnrollment.
+func (mr *MockAuthClientMockRecorder) ConfirmTOTPEnrollment(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPEnrollment", reflect.TypeOf((*MockAuthClient)(nil).ConfirmTOTPEnrollment), varargs...)
+}
+
+// CreateAccessToken mocks base method.
+func (m *MockAuthClient) CreateAccessToken(ctx context.Context, in *gen.CreateAccessTokenRequest, opts ...grpc.CallOption) (*gen.AccessToken, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateAccessToken", varargs...)
+	ret0, _ := ret[0].(*gen.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccessToken indicates an expected call of CreateAccessToken.
+func (mr *MockAuthClientMockRecorder) CreateAccessToken(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessToken", reflect.TypeOf((*MockAuthClient)(nil).CreateAccessToken), varargs...)
+}
+
+// CreateSession mocks base method.
+func (m *MockAuthClient) CreateSession(ctx context.Context, in *gen.UserDataRequest, opts ...grpc.CallOption) (*gen.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateSession", varargs...)
+	ret0, _ := ret[0].(*gen.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockAuthClientMockRecorder) CreateSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockAuthClient)(nil).CreateSession), varargs...)
+}
+
+// CreateTrustedSession mocks base method.
+func (m *MockAuthClient) CreateTrustedSession(ctx context.Context, in *gen.TrustedSessionRequest, opts ...grpc.CallOption) (*gen.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTrustedSession", varargs...)
+	ret0, _ := ret[0].(*gen.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrustedSession indicates an expected call of CreateTrustedSession.
+func (mr *MockAuthClientMockRecorder) CreateTrustedSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrustedSession", reflect.TypeOf((*MockAuthClient)(nil).CreateTrustedSession), varargs...)
+}
+
+// DeleteSession mocks base method.
+func (m *MockAuthClient) DeleteSession(ctx context.Context, in *gen.Session, opts ...grpc.CallOption) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteSession", varargs...)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockAuthClientMockRecorder) DeleteSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockAuthClient)(nil).DeleteSession), varargs...)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockAuthClient) DisableTOTP(ctx context.Context, in *gen.TOTPCodeRequest, opts ...grpc.CallOption) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisableTOTP", varargs...)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockAuthClientMockRecorder) DisableTOTP(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockAuthClient)(nil).DisableTOTP), varargs...)
+}
+
+// ListAccessTokens mocks base method.
+func (m *MockAuthClient) ListAccessTokens(ctx context.Context, in *gen.CheckSessionRequest, opts ...grpc.CallOption) (*gen.AccessTokenList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAccessTokens", varargs...)
+	ret0, _ := ret[0].(*gen.AccessTokenList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccessTokens indicates an expected call of ListAccessTokens.
+func (mr *MockAuthClientMockRecorder) ListAccessTokens(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccessTokens", reflect.TypeOf((*MockAuthClient)(nil).ListAccessTokens), varargs...)
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthClient) ListSessions(ctx context.Context, in *gen.CheckSessionRequest, opts ...grpc.CallOption) (*gen.SessionList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSessions", varargs...)
+	ret0, _ := ret[0].(*gen.SessionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthClientMockRecorder) ListSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthClient)(nil).ListSessions), varargs...)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockAuthClient) RequestPasswordReset(ctx context.Context, in *gen.PasswordResetRequest, opts ...grpc.CallOption) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequestPasswordReset", varargs...)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAuthClientMockRecorder) RequestPasswordReset(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthClient)(nil).RequestPasswordReset), varargs...)
+}
+
+// RevokeAccessToken mocks base method.
+func (m *MockAuthClient) RevokeAccessToken(ctx context.Context, in *gen.RevokeAccessTokenRequest, opts ...grpc.CallOption) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeAccessToken", varargs...)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAccessToken indicates an expected call of RevokeAccessToken.
+func (mr *MockAuthClientMockRecorder) RevokeAccessToken(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessToken", reflect.TypeOf((*MockAuthClient)(nil).RevokeAccessToken), varargs...)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockAuthClient) RevokeOtherSessions(ctx context.Context, in *gen.CheckSessionRequest, opts ...grpc.CallOption) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", varargs...)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockAuthClientMockRecorder) RevokeOtherSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockAuthClient)(nil).RevokeOtherSessions), varargs...)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthClient) RevokeSession(ctx context.Context, in *gen.RevokeSessionRequest, opts ...grpc.CallOption) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeSession", varargs...)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthClientMockRecorder) RevokeSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthClient)(nil).RevokeSession), varargs...)
+}
+
+// VerifyTwoFactor mocks base method.
+func (m *MockAuthClient) VerifyTwoFactor(ctx context.Context, in *gen.TwoFactorRequest, opts ...grpc.CallOption) (*gen.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyTwoFactor", varargs...)
+	ret0, _ := ret[0].(*gen.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyTwoFactor indicates an expected call of VerifyTwoFactor.
+func (mr *MockAuthClientMockRecorder) VerifyTwoFactor(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTwoFactor", reflect.TypeOf((*MockAuthClient)(nil).VerifyTwoFactor), varargs...)
+}
+
+// MockAuthServer is a mock of AuthServer interface.
+type MockAuthServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthServerMockRecorder
+}
+
+// MockAuthServerMockRecorder is the mock recorder for MockAuthServer.
+type MockAuthServerMockRecorder struct {
+	mock *MockAuthServer
+}
+
+// NewMockAuthServer creates a new mock instance.
+func NewMockAuthServer(ctrl *gomock.Controller) *MockAuthServer {
+	mock := &MockAuthServer{ctrl: ctrl}
+	mock.recorder = &MockAuthServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthServer) EXPECT() *MockAuthServerMockRecorder {
+	return m.recorder
+}
+
+// BeginTOTPEnrollment mocks base method.
+func (m *MockAuthServer) BeginTOTPEnrollment(arg0 context.Context, arg1 *gen.CheckSessionRequest) (*gen.TOTPEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTOTPEnrollment", arg0, arg1)
+	ret0, _ := ret[0].(*gen.TOTPEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTOTPEnrollment indicates an expected call of BeginTOTPEnrollment.
+func (mr *MockAuthServerMockRecorder) BeginTOTPEnrollment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTOTPEnrollment", reflect.TypeOf((*MockAuthServer)(nil).BeginTOTPEnrollment), arg0, arg1)
+}
+
+// ChangePassword mocks base method.
+func (m *MockAuthServer) ChangePassword(arg0 context.Context, arg1 *gen.ChangePasswordRequest) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", arg0, arg1)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAuthServerMockRecorder) ChangePassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthServer)(nil).ChangePassword), arg0, arg1)
+}
+
+// CheckAccessToken mocks base method.
+func (m *MockAuthServer) CheckAccessToken(arg0 context.Context, arg1 *gen.AccessTokenRequest) (*gen.AccessTokenCheckResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAccessToken", arg0, arg1)
+	ret0, _ := ret[0].(*gen.AccessTokenCheckResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAccessToken indicates an expected call of CheckAccessToken.
+func (mr *MockAuthServerMockRecorder) CheckAccessToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAccessToken", reflect.TypeOf((*MockAuthServer)(nil).CheckAccessToken), arg0, arg1)
+}
+
+// CheckLoginLockout mocks base method.
+func (m *MockAuthServer) CheckLoginLockout(arg0 context.Context, arg1 *gen.LoginLockoutRequest) (*gen.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLoginLockout", arg0, arg1)
+	ret0, _ := ret[0].(*gen.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLoginLockout indicates an expected call of CheckLoginLockout.
+func (mr *MockAuthServerMockRecorder) CheckLoginLockout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLoginLockout", reflect.TypeOf((*MockAuthServer)(nil).CheckLoginLockout), arg0, arg1)
+}
+
+// CheckPassword mocks base method.
+func (m *MockAuthServer) CheckPassword(arg0 context.Context, arg1 *gen.CheckPasswordRequest) (*gen.UserDataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPassword", arg0, arg1)
+	ret0, _ := ret[0].(*gen.UserDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPassword indicates an expected call of CheckPassword.
+func (mr *MockAuthServerMockRecorder) CheckPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPassword", reflect.TypeOf((*MockAuthServer)(nil).CheckPassword), arg0, arg1)
+}
+
+// CheckSession mocks base method.
+func (m *MockAuthServer) CheckSession(arg0 context.Context, arg1 *gen.CheckSessionRequest) (*gen.UserDataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSession", arg0, arg1)
+	ret0, _ := ret[0].(*gen.UserDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSession indicates an expected call of CheckSession.
+func (mr *MockAuthServerMockRecorder) CheckSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSession", reflect.TypeOf((*MockAuthServer)(nil).CheckSession), arg0, arg1)
+}
+
+// ConfirmPasswordReset mocks base method.
+func (m *MockAuthServer) ConfirmPasswordReset(arg0 context.Context, arg1 *gen.ConfirmPasswordResetRequest) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPasswordReset", arg0, arg1)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPasswordReset indicates an expected call of ConfirmPasswordReset.
+func (mr *MockAuthServerMockRecorder) ConfirmPasswordReset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPasswordReset", reflect.TypeOf((*MockAuthServer)(nil).ConfirmPasswordReset), arg0, arg1)
+}
+
+// ConfirmTOTPEnrollment mocks base method.
+func (m *MockAuthServer) ConfirmTOTPEnrollment(arg0 context.Context, arg1 *gen.TOTPCodeRequest) (*gen.RecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPEnrollment", arg0, arg1)
+	ret0, _ := ret[0].(*gen.RecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTPEnrollment indicates an expected call of ConfirmTOTPEnrollment.
+func (mr *MockAuthServerMockRecorder) ConfirmTOTPEnrollment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPEnrollment", reflect.TypeOf((*MockAuthServer)(nil).ConfirmTOTPEnrollment), arg0, arg1)
+}
+
+// CreateAccessToken mocks base method.
+func (m *MockAuthServer) CreateAccessToken(arg0 context.Context, arg1 *gen.CreateAccessTokenRequest) (*gen.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessToken", arg0, arg1)
+	ret0, _ := ret[0].(*gen.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccessToken indicates an expected call of CreateAccessToken.
+func (mr *MockAuthServerMockRecorder) CreateAccessToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessToken", reflect.TypeOf((*MockAuthServer)(nil).CreateAccessToken), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockAuthServer) CreateSession(arg0 context.Context, arg1 *gen.UserDataRequest) (*gen.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(*gen.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockAuthServerMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockAuthServer)(nil).CreateSession), arg0, arg1)
+}
+
+// CreateTrustedSession mocks base method.
+func (m *MockAuthServer) CreateTrustedSession(arg0 context.Context, arg1 *gen.TrustedSessionRequest) (*gen.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrustedSession", arg0, arg1)
+	ret0, _ := ret[0].(*gen.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrustedSession indicates an expected call of CreateTrustedSession.
+func (mr *MockAuthServerMockRecorder) CreateTrustedSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrustedSession", reflect.TypeOf((*MockAuthServer)(nil).CreateTrustedSession), arg0, arg1)
+}
+
+// DeleteSession mocks base method.
+func (m *MockAuthServer) DeleteSession(arg0 context.Context, arg1 *gen.Session) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockAuthServerMockRecorder) DeleteSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockAuthServer)(nil).DeleteSession), arg0, arg1)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockAuthServer) DisableTOTP(arg0 context.Context, arg1 *gen.TOTPCodeRequest) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", arg0, arg1)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockAuthServerMockRecorder) DisableTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockAuthServer)(nil).DisableTOTP), arg0, arg1)
+}
+
+// ListAccessTokens mocks base method.
+func (m *MockAuthServer) ListAccessTokens(arg0 context.Context, arg1 *gen.CheckSessionRequest) (*gen.AccessTokenList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccessTokens", arg0, arg1)
+	ret0, _ := ret[0].(*gen.AccessTokenList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccessTokens indicates an expected call of ListAccessTokens.
+func (mr *MockAuthServerMockRecorder) ListAccessTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccessTokens", reflect.TypeOf((*MockAuthServer)(nil).ListAccessTokens), arg0, arg1)
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthServer) ListSessions(arg0 context.Context, arg1 *gen.CheckSessionRequest) (*gen.SessionList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", arg0, arg1)
+	ret0, _ := ret[0].(*gen.SessionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthServerMockRecorder) ListSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthServer)(nil).ListSessions), arg0, arg1)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockAuthServer) RequestPasswordReset(arg0 context.Context, arg1 *gen.PasswordResetRequest) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", arg0, arg1)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAuthServerMockRecorder) RequestPasswordReset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthServer)(nil).RequestPasswordReset), arg0, arg1)
+}
+
+// RevokeAccessToken mocks base method.
+func (m *MockAuthServer) RevokeAccessToken(arg0 context.Context, arg1 *gen.RevokeAccessTokenRequest) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccessToken", arg0, arg1)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAccessToken indicates an expected call of RevokeAccessToken.
+func (mr *MockAuthServerMockRecorder) RevokeAccessToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessToken", reflect.TypeOf((*MockAuthServer)(nil).RevokeAccessToken), arg0, arg1)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockAuthServer) RevokeOtherSessions(arg0 context.Context, arg1 *gen.CheckSessionRequest) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", arg0, arg1)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockAuthServerMockRecorder) RevokeOtherSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockAuthServer)(nil).RevokeOtherSessions), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthServer) RevokeSession(arg0 context.Context, arg1 *gen.RevokeSessionRequest) (*gen.StatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1)
+	ret0, _ := ret[0].(*gen.StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthServerMockRecorder) RevokeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthServer)(nil).RevokeSession), arg0, arg1)
+}
+
+// VerifyTwoFactor mocks base method.
+func (m *MockAuthServer) VerifyTwoFactor(arg0 context.Context, arg1 *gen.TwoFactorRequest) (*gen.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(*gen.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyTwoFactor indicates an expected call of VerifyTwoFactor.
+func (mr *MockAuthServerMockRecorder) VerifyTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTwoFactor", reflect.TypeOf((*MockAuthServer)(nil).VerifyTwoFactor), arg0, arg1)
+}
+
+// mustEmbedUnimplementedAuthServer mocks base method.
+func (m *MockAuthServer) mustEmbedUnimplementedAuthServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedAuthServer")
+}
+
+// mustEmbedUnimplementedAuthServer indicates an expected call of mustEmbedUnimplementedAuthServer.
+func (mr *MockAuthServerMockRecorder) mustEmbedUnimplementedAuthServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAuthServer", reflect.TypeOf((*MockAuthServer)(nil).mustEmbedUnimplementedAuthServer))
+}
+
+// MockUnsafeAuthServer is a mock of UnsafeAuthServer interface.
+type MockUnsafeAuthServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeAuthServerMockRecorder
+}
+
+// MockUnsafeAuthServerMockRecorder is the mock recorder for MockUnsafeAuthServer.
+type MockUnsafeAuthServerMockRecorder struct {
+	mock *MockUnsafeAuthServer
+}
+
+// NewMockUnsafeAuthServer creates a new mock instance.
+func NewMockUnsafeAuthServer(ctrl *gomock.Controller) *MockUnsafeAuthServer {
+	mock := &MockUnsafeAuthServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeAuthServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeAuthServer) EXPECT() *MockUnsafeAuthServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedAuthServer mocks base method.
+func (m *MockUnsafeAuthServer) mustEmbedUnimplementedAuthServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedAuthServer")
+}
+
+// mustEmbedUnimplementedAuthServer indicates an expected call of mustEmbedUnimplementedAuthServer.
+func (mr *MockUnsafeAuthServerMockRecorder) mustEmbedUnimplementedAuthServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAuthServer", reflect.TypeOf((*MockUnsafeAuthServer)(nil).mustEmbedUnimplementedAuthServer))
+}
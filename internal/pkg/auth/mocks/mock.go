@@ -5,8 +5,10 @@
 package mock_auth
 
 import (
+	models "RPO_back/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,6 +36,22 @@ func (m *MockAuthUsecase) EXPECT() *MockAuthUsecaseMockRecorder {
 	return m.recorder
 }
 
+// BeginTOTPEnrollment mocks base method.
+func (m *MockAuthUsecase) BeginTOTPEnrollment(ctx context.Context, sessionID string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTOTPEnrollment", ctx, sessionID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BeginTOTPEnrollment indicates an expected call of BeginTOTPEnrollment.
+func (mr *MockAuthUsecaseMockRecorder) BeginTOTPEnrollment(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTOTPEnrollment", reflect.TypeOf((*MockAuthUsecase)(nil).BeginTOTPEnrollment), ctx, sessionID)
+}
+
 // ChangePassword mocks base method.
 func (m *MockAuthUsecase) ChangePassword(ctx context.Context, oldPassword, newPassword, sessionID string) error {
 	m.ctrl.T.Helper()
@@ -48,6 +66,51 @@ func (mr *MockAuthUsecaseMockRecorder) ChangePassword(ctx, oldPassword, newPassw
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthUsecase)(nil).ChangePassword), ctx, oldPassword, newPassword, sessionID)
 }
 
+// CheckAccessToken mocks base method.
+func (m *MockAuthUsecase) CheckAccessToken(ctx context.Context, token string) (int64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAccessToken", ctx, token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CheckAccessToken indicates an expected call of CheckAccessToken.
+func (mr *MockAuthUsecaseMockRecorder) CheckAccessToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAccessToken", reflect.TypeOf((*MockAuthUsecase)(nil).CheckAccessToken), ctx, token)
+}
+
+// CheckLoginLockout mocks base method.
+func (m *MockAuthUsecase) CheckLoginLockout(ctx context.Context, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLoginLockout", ctx, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckLoginLockout indicates an expected call of CheckLoginLockout.
+func (mr *MockAuthUsecaseMockRecorder) CheckLoginLockout(ctx, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLoginLockout", reflect.TypeOf((*MockAuthUsecase)(nil).CheckLoginLockout), ctx, clientIP)
+}
+
+// CheckPassword mocks base method.
+func (m *MockAuthUsecase) CheckPassword(ctx context.Context, sessionID, password string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPassword", ctx, sessionID, password)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPassword indicates an expected call of CheckPassword.
+func (mr *MockAuthUsecaseMockRecorder) CheckPassword(ctx, sessionID, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPassword", reflect.TypeOf((*MockAuthUsecase)(nil).CheckPassword), ctx, sessionID, password)
+}
+
 // CheckSession mocks base method.
 func (m *MockAuthUsecase) CheckSession(ctx context.Context, sessionID string) (int, error) {
 	m.ctrl.T.Helper()
@@ -63,19 +126,94 @@ func (mr *MockAuthUsecaseMockRecorder) CheckSession(ctx, sessionID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSession", reflect.TypeOf((*MockAuthUsecase)(nil).CheckSession), ctx, sessionID)
 }
 
-// CreateSession mocks base method.
-func (m *MockAuthUsecase) CreateSession(ctx context.Context, userID int64, password string) (string, error) {
+// ConfirmPasswordReset mocks base method.
+func (m *MockAuthUsecase) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", ctx, userID, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ConfirmPasswordReset", ctx, token, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmPasswordReset indicates an expected call of ConfirmPasswordReset.
+func (mr *MockAuthUsecaseMockRecorder) ConfirmPasswordReset(ctx, token, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPasswordReset", reflect.TypeOf((*MockAuthUsecase)(nil).ConfirmPasswordReset), ctx, token, newPassword)
+}
+
+// ConfirmTOTPEnrollment mocks base method.
+func (m *MockAuthUsecase) ConfirmTOTPEnrollment(ctx context.Context, sessionID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPEnrollment", ctx, sessionID, code)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ConfirmTOTPEnrollment indicates an expected call of ConfirmTOTPEnrollment.
+func (mr *MockAuthUsecaseMockRecorder) ConfirmTOTPEnrollment(ctx, sessionID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPEnrollment", reflect.TypeOf((*MockAuthUsecase)(nil).ConfirmTOTPEnrollment), ctx, sessionID, code)
+}
+
+// CreateAccessToken mocks base method.
+func (m *MockAuthUsecase) CreateAccessToken(ctx context.Context, sessionID, name string, scopes []string, expiresAt *time.Time) (*models.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessToken", ctx, sessionID, name, scopes, expiresAt)
+	ret0, _ := ret[0].(*models.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccessToken indicates an expected call of CreateAccessToken.
+func (mr *MockAuthUsecaseMockRecorder) CreateAccessToken(ctx, sessionID, name, scopes, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessToken", reflect.TypeOf((*MockAuthUsecase)(nil).CreateAccessToken), ctx, sessionID, name, scopes, expiresAt)
+}
+
+// CreateSession mocks base method.
+func (m *MockAuthUsecase) CreateSession(ctx context.Context, userID int64, password, clientIP, userAgent string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, userID, password, clientIP, userAgent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // CreateSession indicates an expected call of CreateSession.
-func (mr *MockAuthUsecaseMockRecorder) CreateSession(ctx, userID, password interface{}) *gomock.Call {
+func (mr *MockAuthUsecaseMockRecorder) CreateSession(ctx, userID, password, clientIP, userAgent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockAuthUsecase)(nil).CreateSession), ctx, userID, password, clientIP, userAgent)
+}
+
+// CreateTrustedSession mocks base method.
+func (m *MockAuthUsecase) CreateTrustedSession(ctx context.Context, userID int64, clientIP, userAgent string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrustedSession", ctx, userID, clientIP, userAgent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateTrustedSession indicates an expected call of CreateTrustedSession.
+func (mr *MockAuthUsecaseMockRecorder) CreateTrustedSession(ctx, userID, clientIP, userAgent interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockAuthUsecase)(nil).CreateSession), ctx, userID, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrustedSession", reflect.TypeOf((*MockAuthUsecase)(nil).CreateTrustedSession), ctx, userID, clientIP, userAgent)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockAuthUsecase) DisableTOTP(ctx context.Context, sessionID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, sessionID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockAuthUsecaseMockRecorder) DisableTOTP(ctx, sessionID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockAuthUsecase)(nil).DisableTOTP), ctx, sessionID, code)
 }
 
 // KillSession mocks base method.
@@ -92,6 +230,107 @@ func (mr *MockAuthUsecaseMockRecorder) KillSession(ctx, sessionID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillSession", reflect.TypeOf((*MockAuthUsecase)(nil).KillSession), ctx, sessionID)
 }
 
+// ListAccessTokens mocks base method.
+func (m *MockAuthUsecase) ListAccessTokens(ctx context.Context, sessionID string) ([]models.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccessTokens", ctx, sessionID)
+	ret0, _ := ret[0].([]models.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccessTokens indicates an expected call of ListAccessTokens.
+func (mr *MockAuthUsecaseMockRecorder) ListAccessTokens(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccessTokens", reflect.TypeOf((*MockAuthUsecase)(nil).ListAccessTokens), ctx, sessionID)
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthUsecase) ListSessions(ctx context.Context, sessionID string) ([]models.SessionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, sessionID)
+	ret0, _ := ret[0].([]models.SessionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthUsecaseMockRecorder) ListSessions(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthUsecase)(nil).ListSessions), ctx, sessionID)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockAuthUsecase) RequestPasswordReset(ctx context.Context, email, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, email, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAuthUsecaseMockRecorder) RequestPasswordReset(ctx, email, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthUsecase)(nil).RequestPasswordReset), ctx, email, clientIP)
+}
+
+// RevokeAccessToken mocks base method.
+func (m *MockAuthUsecase) RevokeAccessToken(ctx context.Context, sessionID string, tokenID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccessToken", ctx, sessionID, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAccessToken indicates an expected call of RevokeAccessToken.
+func (mr *MockAuthUsecaseMockRecorder) RevokeAccessToken(ctx, sessionID, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessToken", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeAccessToken), ctx, sessionID, tokenID)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockAuthUsecase) RevokeOtherSessions(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockAuthUsecaseMockRecorder) RevokeOtherSessions(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeOtherSessions), ctx, sessionID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthUsecase) RevokeSession(ctx context.Context, sessionID, targetID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthUsecaseMockRecorder) RevokeSession(ctx, sessionID, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeSession), ctx, sessionID, targetID)
+}
+
+// VerifyTwoFactor mocks base method.
+func (m *MockAuthUsecase) VerifyTwoFactor(ctx context.Context, partialSessionID, code, clientIP, userAgent string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTwoFactor", ctx, partialSessionID, code, clientIP, userAgent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyTwoFactor indicates an expected call of VerifyTwoFactor.
+func (mr *MockAuthUsecaseMockRecorder) VerifyTwoFactor(ctx, partialSessionID, code, clientIP, userAgent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTwoFactor", reflect.TypeOf((*MockAuthUsecase)(nil).VerifyTwoFactor), ctx, partialSessionID, code, clientIP, userAgent)
+}
+
 // MockAuthRepo is a mock of AuthRepo interface.
 type MockAuthRepo struct {
 	ctrl     *gomock.Controller
@@ -115,6 +354,22 @@ func (m *MockAuthRepo) EXPECT() *MockAuthRepoMockRecorder {
 	return m.recorder
 }
 
+// CheckPartialSession mocks base method.
+func (m *MockAuthRepo) CheckPartialSession(ctx context.Context, partialSessionID string) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPartialSession", ctx, partialSessionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CheckPartialSession indicates an expected call of CheckPartialSession.
+func (mr *MockAuthRepoMockRecorder) CheckPartialSession(ctx, partialSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPartialSession", reflect.TypeOf((*MockAuthRepo)(nil).CheckPartialSession), ctx, partialSessionID)
+}
+
 // CheckSession mocks base method.
 func (m *MockAuthRepo) CheckSession(ctx context.Context, sessionID string) (int, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +385,79 @@ func (mr *MockAuthRepoMockRecorder) CheckSession(ctx, sessionID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSession", reflect.TypeOf((*MockAuthRepo)(nil).CheckSession), ctx, sessionID)
 }
 
+// CountPasswordResetRequest mocks base method.
+func (m *MockAuthRepo) CountPasswordResetRequest(ctx context.Context, email, clientIP string) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPasswordResetRequest", ctx, email, clientIP)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountPasswordResetRequest indicates an expected call of CountPasswordResetRequest.
+func (mr *MockAuthRepoMockRecorder) CountPasswordResetRequest(ctx, email, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPasswordResetRequest", reflect.TypeOf((*MockAuthRepo)(nil).CountPasswordResetRequest), ctx, email, clientIP)
+}
+
+// CreateAccessToken mocks base method.
+func (m *MockAuthRepo) CreateAccessToken(ctx context.Context, userID int64, name, tokenHash string, scopes []string, expiresAt *time.Time) (*models.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessToken", ctx, userID, name, tokenHash, scopes, expiresAt)
+	ret0, _ := ret[0].(*models.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccessToken indicates an expected call of CreateAccessToken.
+func (mr *MockAuthRepoMockRecorder) CreateAccessToken(ctx, userID, name, tokenHash, scopes, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessToken", reflect.TypeOf((*MockAuthRepo)(nil).CreateAccessToken), ctx, userID, name, tokenHash, scopes, expiresAt)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockAuthRepo) CreatePasswordResetToken(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", ctx, userID, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockAuthRepoMockRecorder) CreatePasswordResetToken(ctx, userID, tokenHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockAuthRepo)(nil).CreatePasswordResetToken), ctx, userID, tokenHash, expiresAt)
+}
+
+// DeleteAccessToken mocks base method.
+func (m *MockAuthRepo) DeleteAccessToken(ctx context.Context, userID, tokenID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccessToken", ctx, userID, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccessToken indicates an expected call of DeleteAccessToken.
+func (mr *MockAuthRepoMockRecorder) DeleteAccessToken(ctx, userID, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccessToken", reflect.TypeOf((*MockAuthRepo)(nil).DeleteAccessToken), ctx, userID, tokenID)
+}
+
+// DeleteTOTP mocks base method.
+func (m *MockAuthRepo) DeleteTOTP(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTOTP", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTOTP indicates an expected call of DeleteTOTP.
+func (mr *MockAuthRepoMockRecorder) DeleteTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTP", reflect.TypeOf((*MockAuthRepo)(nil).DeleteTOTP), ctx, userID)
+}
+
 // DisplaceUserSessions mocks base method.
 func (m *MockAuthRepo) DisplaceUserSessions(ctx context.Context, sessionID string, userID int64) error {
 	m.ctrl.T.Helper()
@@ -144,11 +472,100 @@ func (mr *MockAuthRepoMockRecorder) DisplaceUserSessions(ctx, sessionID, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisplaceUserSessions", reflect.TypeOf((*MockAuthRepo)(nil).DisplaceUserSessions), ctx, sessionID, userID)
 }
 
+// EnableTOTP mocks base method.
+func (m *MockAuthRepo) EnableTOTP(ctx context.Context, userID, step int64, recoveryCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTP", ctx, userID, step, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTOTP indicates an expected call of EnableTOTP.
+func (mr *MockAuthRepoMockRecorder) EnableTOTP(ctx, userID, step, recoveryCodeHashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTP", reflect.TypeOf((*MockAuthRepo)(nil).EnableTOTP), ctx, userID, step, recoveryCodeHashes)
+}
+
+// GetLoginLockout mocks base method.
+func (m *MockAuthRepo) GetLoginLockout(ctx context.Context, userID int64, clientIP string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginLockout", ctx, userID, clientIP)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginLockout indicates an expected call of GetLoginLockout.
+func (mr *MockAuthRepoMockRecorder) GetLoginLockout(ctx, userID, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginLockout", reflect.TypeOf((*MockAuthRepo)(nil).GetLoginLockout), ctx, userID, clientIP)
+}
+
+// GetSessionCreatedAt mocks base method.
+func (m *MockAuthRepo) GetSessionCreatedAt(ctx context.Context, sessionID string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionCreatedAt", ctx, sessionID)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionCreatedAt indicates an expected call of GetSessionCreatedAt.
+func (mr *MockAuthRepoMockRecorder) GetSessionCreatedAt(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionCreatedAt", reflect.TypeOf((*MockAuthRepo)(nil).GetSessionCreatedAt), ctx, sessionID)
+}
+
+// GetUserAccessTokens mocks base method.
+func (m *MockAuthRepo) GetUserAccessTokens(ctx context.Context, userID int64) ([]models.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAccessTokens", ctx, userID)
+	ret0, _ := ret[0].([]models.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAccessTokens indicates an expected call of GetUserAccessTokens.
+func (mr *MockAuthRepoMockRecorder) GetUserAccessTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAccessTokens", reflect.TypeOf((*MockAuthRepo)(nil).GetUserAccessTokens), ctx, userID)
+}
+
+// GetUserEmail mocks base method.
+func (m *MockAuthRepo) GetUserEmail(ctx context.Context, userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEmail", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserEmail indicates an expected call of GetUserEmail.
+func (mr *MockAuthRepoMockRecorder) GetUserEmail(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEmail", reflect.TypeOf((*MockAuthRepo)(nil).GetUserEmail), ctx, userID)
+}
+
+// GetUserIDByEmail mocks base method.
+func (m *MockAuthRepo) GetUserIDByEmail(ctx context.Context, email string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDByEmail", ctx, email)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDByEmail indicates an expected call of GetUserIDByEmail.
+func (mr *MockAuthRepoMockRecorder) GetUserIDByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDByEmail", reflect.TypeOf((*MockAuthRepo)(nil).GetUserIDByEmail), ctx, email)
+}
+
 // GetUserPasswordHash mocks base method.
-func (m *MockAuthRepo) GetUserPasswordHash(ctx context.Context, userID int) (string, error) {
+func (m *MockAuthRepo) GetUserPasswordHash(ctx context.Context, userID int) (*string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserPasswordHash", ctx, userID)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -159,6 +576,50 @@ func (mr *MockAuthRepoMockRecorder) GetUserPasswordHash(ctx, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPasswordHash", reflect.TypeOf((*MockAuthRepo)(nil).GetUserPasswordHash), ctx, userID)
 }
 
+// GetUserSessions mocks base method.
+func (m *MockAuthRepo) GetUserSessions(ctx context.Context, userID int64) ([]models.SessionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessions", ctx, userID)
+	ret0, _ := ret[0].([]models.SessionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessions indicates an expected call of GetUserSessions.
+func (mr *MockAuthRepoMockRecorder) GetUserSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessions", reflect.TypeOf((*MockAuthRepo)(nil).GetUserSessions), ctx, userID)
+}
+
+// GetUserTOTP mocks base method.
+func (m *MockAuthRepo) GetUserTOTP(ctx context.Context, userID int64) (*models.UserTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTOTP", ctx, userID)
+	ret0, _ := ret[0].(*models.UserTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTOTP indicates an expected call of GetUserTOTP.
+func (mr *MockAuthRepoMockRecorder) GetUserTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTOTP", reflect.TypeOf((*MockAuthRepo)(nil).GetUserTOTP), ctx, userID)
+}
+
+// KillPartialSession mocks base method.
+func (m *MockAuthRepo) KillPartialSession(ctx context.Context, partialSessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KillPartialSession", ctx, partialSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// KillPartialSession indicates an expected call of KillPartialSession.
+func (mr *MockAuthRepoMockRecorder) KillPartialSession(ctx, partialSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillPartialSession", reflect.TypeOf((*MockAuthRepo)(nil).KillPartialSession), ctx, partialSessionID)
+}
+
 // KillSessionRedis mocks base method.
 func (m *MockAuthRepo) KillSessionRedis(ctx context.Context, sessionID string) error {
 	m.ctrl.T.Helper()
@@ -173,18 +634,104 @@ func (mr *MockAuthRepoMockRecorder) KillSessionRedis(ctx, sessionID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillSessionRedis", reflect.TypeOf((*MockAuthRepo)(nil).KillSessionRedis), ctx, sessionID)
 }
 
+// RegisterFailedLogin mocks base method.
+func (m *MockAuthRepo) RegisterFailedLogin(ctx context.Context, userID int64, clientIP string) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFailedLogin", ctx, userID, clientIP)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RegisterFailedLogin indicates an expected call of RegisterFailedLogin.
+func (mr *MockAuthRepoMockRecorder) RegisterFailedLogin(ctx, userID, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailedLogin", reflect.TypeOf((*MockAuthRepo)(nil).RegisterFailedLogin), ctx, userID, clientIP)
+}
+
+// RegisterPartialSession mocks base method.
+func (m *MockAuthRepo) RegisterPartialSession(ctx context.Context, partialSessionID string, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterPartialSession", ctx, partialSessionID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterPartialSession indicates an expected call of RegisterPartialSession.
+func (mr *MockAuthRepoMockRecorder) RegisterPartialSession(ctx, partialSessionID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPartialSession", reflect.TypeOf((*MockAuthRepo)(nil).RegisterPartialSession), ctx, partialSessionID, userID)
+}
+
 // RegisterSessionRedis mocks base method.
-func (m *MockAuthRepo) RegisterSessionRedis(ctx context.Context, cookie string, userID int) error {
+func (m *MockAuthRepo) RegisterSessionRedis(ctx context.Context, cookie string, userID int, userAgent, clientIP string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterSessionRedis", ctx, cookie, userID)
+	ret := m.ctrl.Call(m, "RegisterSessionRedis", ctx, cookie, userID, userAgent, clientIP)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RegisterSessionRedis indicates an expected call of RegisterSessionRedis.
-func (mr *MockAuthRepoMockRecorder) RegisterSessionRedis(ctx, cookie, userID interface{}) *gomock.Call {
+func (mr *MockAuthRepoMockRecorder) RegisterSessionRedis(ctx, cookie, userID, userAgent, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSessionRedis", reflect.TypeOf((*MockAuthRepo)(nil).RegisterSessionRedis), ctx, cookie, userID, userAgent, clientIP)
+}
+
+// ResetFailedLogins mocks base method.
+func (m *MockAuthRepo) ResetFailedLogins(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailedLogins", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailedLogins indicates an expected call of ResetFailedLogins.
+func (mr *MockAuthRepoMockRecorder) ResetFailedLogins(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailedLogins", reflect.TypeOf((*MockAuthRepo)(nil).ResetFailedLogins), ctx, userID)
+}
+
+// SaveFailedLoginAttempt mocks base method.
+func (m *MockAuthRepo) SaveFailedLoginAttempt(ctx context.Context, userID int64, clientIP string, lockedUntil *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFailedLoginAttempt", ctx, userID, clientIP, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFailedLoginAttempt indicates an expected call of SaveFailedLoginAttempt.
+func (mr *MockAuthRepoMockRecorder) SaveFailedLoginAttempt(ctx, userID, clientIP, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFailedLoginAttempt", reflect.TypeOf((*MockAuthRepo)(nil).SaveFailedLoginAttempt), ctx, userID, clientIP, lockedUntil)
+}
+
+// SaveTOTPSecret mocks base method.
+func (m *MockAuthRepo) SaveTOTPSecret(ctx context.Context, userID int64, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTOTPSecret", ctx, userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTOTPSecret indicates an expected call of SaveTOTPSecret.
+func (mr *MockAuthRepoMockRecorder) SaveTOTPSecret(ctx, userID, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTPSecret", reflect.TypeOf((*MockAuthRepo)(nil).SaveTOTPSecret), ctx, userID, secret)
+}
+
+// SetLoginLockout mocks base method.
+func (m *MockAuthRepo) SetLoginLockout(ctx context.Context, userID int64, clientIP string, userUntil, ipUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLoginLockout", ctx, userID, clientIP, userUntil, ipUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLoginLockout indicates an expected call of SetLoginLockout.
+func (mr *MockAuthRepoMockRecorder) SetLoginLockout(ctx, userID, clientIP, userUntil, ipUntil interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSessionRedis", reflect.TypeOf((*MockAuthRepo)(nil).RegisterSessionRedis), ctx, cookie, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoginLockout", reflect.TypeOf((*MockAuthRepo)(nil).SetLoginLockout), ctx, userID, clientIP, userUntil, ipUntil)
 }
 
 // SetNewPasswordHash mocks base method.
@@ -200,3 +747,76 @@ func (mr *MockAuthRepoMockRecorder) SetNewPasswordHash(ctx, userID, newPasswordH
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNewPasswordHash", reflect.TypeOf((*MockAuthRepo)(nil).SetNewPasswordHash), ctx, userID, newPasswordHash)
 }
+
+// TouchSession mocks base method.
+func (m *MockAuthRepo) TouchSession(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockAuthRepoMockRecorder) TouchSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockAuthRepo)(nil).TouchSession), ctx, sessionID)
+}
+
+// UseAccessToken mocks base method.
+func (m *MockAuthRepo) UseAccessToken(ctx context.Context, tokenHash string) (int64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseAccessToken", ctx, tokenHash)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UseAccessToken indicates an expected call of UseAccessToken.
+func (mr *MockAuthRepoMockRecorder) UseAccessToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseAccessToken", reflect.TypeOf((*MockAuthRepo)(nil).UseAccessToken), ctx, tokenHash)
+}
+
+// UsePasswordResetToken mocks base method.
+func (m *MockAuthRepo) UsePasswordResetToken(ctx context.Context, tokenHash string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordResetToken", ctx, tokenHash)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePasswordResetToken indicates an expected call of UsePasswordResetToken.
+func (mr *MockAuthRepoMockRecorder) UsePasswordResetToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordResetToken", reflect.TypeOf((*MockAuthRepo)(nil).UsePasswordResetToken), ctx, tokenHash)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockAuthRepo) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockAuthRepoMockRecorder) UseRecoveryCode(ctx, userID, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockAuthRepo)(nil).UseRecoveryCode), ctx, userID, codeHash)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockAuthRepo) UseTOTPStep(ctx context.Context, userID, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockAuthRepoMockRecorder) UseTOTPStep(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockAuthRepo)(nil).UseTOTPStep), ctx, userID, step)
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// scanAccessToken читает токен в порядке полей token_id, name, scopes, created_at, expires_at, last_used_at
+func scanAccessToken(row pgx.Row) (token *models.AccessToken, err error) {
+	token = &models.AccessToken{}
+	err = row.Scan(
+		&token.ID,
+		&token.Name,
+		&token.Scopes,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// CreateAccessToken сохраняет хеш нового персонального токена доступа
+func (r *AuthRepository) CreateAccessToken(ctx context.Context, userID int64, name string, tokenHash string, scopes []string, expiresAt *time.Time) (token *models.AccessToken, err error) {
+	query := `
+	INSERT INTO personal_access_token (u_id, name, token_hash, scopes, expires_at)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING token_id, name, scopes, created_at, expires_at, last_used_at;
+	`
+
+	token, err = scanAccessToken(r.db.QueryRow(ctx, query, userID, name, tokenHash, scopes, expiresAt))
+	logging.Debug(ctx, "CreateAccessToken query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("CreateAccessToken: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetUserAccessTokens получает персональные токены пользователя, включая истёкшие
+func (r *AuthRepository) GetUserAccessTokens(ctx context.Context, userID int64) (tokens []models.AccessToken, err error) {
+	query := `
+	SELECT token_id, name, scopes, created_at, expires_at, last_used_at
+	FROM personal_access_token
+	WHERE u_id=$1
+	ORDER BY token_id;
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	logging.Debug(ctx, "GetUserAccessTokens query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("GetUserAccessTokens (query): %w", err)
+	}
+	defer rows.Close()
+
+	tokens = make([]models.AccessToken, 0)
+	for rows.Next() {
+		token, err := scanAccessToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("GetUserAccessTokens (scan): %w", err)
+		}
+		tokens = append(tokens, *token)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetUserAccessTokens (rows): %w", err)
+	}
+
+	return tokens, nil
+}
+
+// DeleteAccessToken отзывает персональный токен пользователя. Если у пользователя нет такого токена - errs.ErrNotFound
+func (r *AuthRepository) DeleteAccessToken(ctx context.Context, userID int64, tokenID int64) error {
+	query := `
+	DELETE FROM personal_access_token
+	WHERE u_id=$1 AND token_id=$2;
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, tokenID)
+	logging.Debug(ctx, "DeleteAccessToken query has err: ", err, " tag: ", tag)
+	if err != nil {
+		return fmt.Errorf("DeleteAccessToken: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("DeleteAccessToken: %w", errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Время использования токена обновляем не чаще раза в accessTokenTouchInterval, чтобы не писать в базу на каждый запрос
+const accessTokenTouchInterval = 5 * time.Minute
+
+// UseAccessToken проверяет персональный токен по хешу и отмечает время его использования.
+// Если токена нет или он истёк - errs.ErrNotFound
+func (r *AuthRepository) UseAccessToken(ctx context.Context, tokenHash string) (userID int64, scopes []string, err error) {
+	query := `
+	WITH token AS (
+		SELECT token_id, u_id, scopes, last_used_at
+		FROM personal_access_token
+		WHERE token_hash=$1 AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+	),
+	touch_token AS (
+		UPDATE personal_access_token AS pat
+		SET last_used_at=CURRENT_TIMESTAMP
+		FROM token
+		WHERE pat.token_id=token.token_id AND (token.last_used_at IS NULL OR token.last_used_at < $2)
+	)
+	SELECT u_id, scopes FROM token;
+	`
+
+	err = r.db.QueryRow(ctx, query, tokenHash, time.Now().Add(-accessTokenTouchInterval)).Scan(&userID, &scopes)
+	logging.Debug(ctx, "UseAccessToken query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil, fmt.Errorf("UseAccessToken: %w", errs.ErrNotFound)
+		}
+		return 0, nil, fmt.Errorf("UseAccessToken: %w", err)
+	}
+
+	return userID, scopes, nil
+}
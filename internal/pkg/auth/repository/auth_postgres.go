@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
@@ -47,3 +48,18 @@ func (r *AuthRepository) GetUserPasswordHash(ctx context.Context, userID int) (p
 
 	return passwordHash, nil
 }
+
+// SaveFailedLoginAttempt записывает неудачную попытку входа в журнал.
+// Если пользователя с таким ID нет, попытка пишется без него
+func (r *AuthRepository) SaveFailedLoginAttempt(ctx context.Context, userID int64, clientIP string, lockedUntil *time.Time) error {
+	query := `
+	INSERT INTO failed_login_attempt (u_id, client_ip, locked_until)
+	VALUES ((SELECT u_id FROM "user" WHERE u_id=$1), $2, $3);
+	`
+	_, err := r.db.Exec(ctx, query, userID, clientIP, lockedUntil)
+	logging.Debug(ctx, "SaveFailedLoginAttempt query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("SaveFailedLoginAttempt: %w", err)
+	}
+	return nil
+}
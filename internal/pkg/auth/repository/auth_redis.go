@@ -6,7 +6,6 @@ import (
 	"RPO_back/internal/pkg/utils/pgxiface"
 	"context"
 	"fmt"
-	"log"
 	"strconv"
 	"time"
 
@@ -14,30 +13,46 @@ import (
 )
 
 const (
-	sessionPrefix   = "s_" // Префикс для сессии
-	userPrefix      = "u_" // Префикс для сета, в котором находятся все сессии данного пользователя
-	sessionLifeTime = 7 * 24 * time.Hour
+	sessionPrefix     = "s_"  // Префикс для сессии
+	userPrefix        = "u_"  // Префикс для сета, в котором находятся все сессии данного пользователя
+	sessionMetaPrefix = "sm_" // Префикс для хеша с данными об устройстве сессии
 )
 
 type AuthRepository struct {
-	db      pgxiface.PgxIface
-	redisDb *redis.Client
+	db                 pgxiface.PgxIface
+	redisDb            *redis.Client
+	sessionIdleTimeout time.Duration // На столько продлевается сессия при каждом запросе
+	sessionMaxLifetime time.Duration // Дольше этого с момента входа сессия не живёт
 }
 
-func CreateAuthRepository(postgresDb pgxiface.PgxIface, redisDb *redis.Client) *AuthRepository {
+func CreateAuthRepository(postgresDb pgxiface.PgxIface, redisDb *redis.Client, sessionIdleTimeout time.Duration, sessionMaxLifetime time.Duration) *AuthRepository {
 	return &AuthRepository{
 		db: postgresDb, redisDb: redisDb,
+		sessionIdleTimeout: sessionIdleTimeout,
+		sessionMaxLifetime: sessionMaxLifetime,
 	}
 }
 
-// RegisterSessionRedis регистрирует сессию в Redis
-func (r *AuthRepository) RegisterSessionRedis(ctx context.Context, sessionID string, userID int) error {
+// sessionTTL - сколько ещё может прожить сессия, созданная в createdAt: её продлевают
+// на sessionIdleTimeout, но не дальше createdAt + sessionMaxLifetime
+func (r *AuthRepository) sessionTTL(createdAt time.Time, now time.Time) time.Duration {
+	ttl := createdAt.Add(r.sessionMaxLifetime).Sub(now)
+	if ttl > r.sessionIdleTimeout {
+		ttl = r.sessionIdleTimeout
+	}
+	return ttl
+}
+
+// RegisterSessionRedis регистрирует сессию в Redis вместе с данными об устройстве, с которого выполнен вход
+func (r *AuthRepository) RegisterSessionRedis(ctx context.Context, sessionID string, userID int, userAgent string, clientIP string) error {
 	redisConn := r.redisDb.Conn(r.redisDb.Context())
 	defer redisConn.Close()
 
 	fmt.Print("REGISTER SESSION user ", userID, "   session ", sessionID)
 
-	err := redisConn.Set(r.redisDb.Context(), fmt.Sprintf("%s%s", sessionPrefix, sessionID), userID, sessionLifeTime).Err()
+	now := time.Now()
+	ttl := r.sessionTTL(now, now)
+	err := redisConn.Set(r.redisDb.Context(), fmt.Sprintf("%s%s", sessionPrefix, sessionID), userID, ttl).Err()
 	logging.Debug(ctx, "RegisterSessionRedis query to redis has err: ", err)
 	if err != nil {
 		return fmt.Errorf("RegisterSessionRedis (session): %w", err)
@@ -48,6 +63,16 @@ func (r *AuthRepository) RegisterSessionRedis(ctx context.Context, sessionID str
 		return fmt.Errorf("RegisterSessionRedis (user): %w", err)
 	}
 
+	metaKey := sessionMetaPrefix + sessionID
+	_, err = redisConn.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, metaKey, sessionMetaUserAgent, userAgent, sessionMetaClientIP, clientIP, sessionMetaCreatedAt, now.Unix(), sessionMetaLastSeenAt, now.Unix())
+		pipe.Expire(ctx, metaKey, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("RegisterSessionRedis (meta): %w", err)
+	}
+
 	return nil
 }
 
@@ -56,25 +81,23 @@ func (r *AuthRepository) KillSessionRedis(ctx context.Context, sessionID string)
 	redisConn := r.redisDb.Conn(r.redisDb.Context())
 	defer redisConn.Close()
 
-	res := redisConn.Get(r.redisDb.Context(), sessionID)
-	if res.Err() != nil {
-		return fmt.Errorf("KillSessionRedis (get): %w", res.Err())
-	}
-
-	userID, err := strconv.ParseInt(res.String(), 10, 64)
-	if err != nil {
-		return fmt.Errorf("KillSessionRedis (atoi): %w", res.Err())
+	sessionKey := sessionPrefix + sessionID
+	userID, err := redisConn.Get(ctx, sessionKey).Int64()
+	if err == redis.Nil {
+		return fmt.Errorf("KillSessionRedis (get): %w", errs.ErrNotFound)
+	} else if err != nil {
+		return fmt.Errorf("KillSessionRedis (get): %w", err)
 	}
 
-	err = redisConn.Del(r.redisDb.Context(), sessionID).Err()
+	err = redisConn.Del(ctx, sessionKey, sessionMetaPrefix+sessionID).Err()
 	if err != nil {
 		return fmt.Errorf("KillSessionRedis (del): %w", err)
 	}
 
 	userKey := fmt.Sprintf("%s%d", userPrefix, userID)
-	res2 := redisConn.SRem(ctx, userKey, sessionID)
-	if res2.Err() != nil {
-		return fmt.Errorf("KillSessionRedis (srem): %w", res2.Err())
+	err = redisConn.SRem(ctx, userKey, sessionID).Err()
+	if err != nil {
+		return fmt.Errorf("KillSessionRedis (srem): %w", err)
 	}
 
 	return nil
@@ -82,9 +105,6 @@ func (r *AuthRepository) KillSessionRedis(ctx context.Context, sessionID string)
 
 // DisplaceUserSessions удаляет все сессии пользователя из Redis, кроме одной сессии - sessionID
 func (r *AuthRepository) DisplaceUserSessions(ctx context.Context, sessionID string, userID int64) error {
-	if 1 == 1 {
-		return nil
-	}
 	setKey := fmt.Sprintf("%s%d", userPrefix, userID)
 
 	redisConn := r.redisDb.Conn(r.redisDb.Context())
@@ -92,23 +112,29 @@ func (r *AuthRepository) DisplaceUserSessions(ctx context.Context, sessionID str
 
 	sessions, err := redisConn.SMembers(ctx, setKey).Result()
 	if err != nil {
-		log.Fatalf("DisplaceUserSessions (get user): %w", err)
+		return fmt.Errorf("DisplaceUserSessions (get user): %w", err)
 	}
 
-	sessionsToDelete := make([]interface{}, 0)
+	sessionKeys := make([]string, 0, 2*len(sessions))
+	sessionsToDelete := make([]interface{}, 0, len(sessions))
 	for _, session := range sessions {
 		if session != sessionID {
+			sessionKeys = append(sessionKeys, sessionPrefix+session, sessionMetaPrefix+session)
 			sessionsToDelete = append(sessionsToDelete, session)
-			res := redisConn.Del(ctx, sessionPrefix+sessionID)
-			if res.Err() != nil {
-				log.Fatalf("DisplaceUserSessions (del): %v", res.Err())
-			}
 		}
 	}
+	if len(sessionKeys) == 0 {
+		return nil
+	}
 
-	res := redisConn.SRem(ctx, setKey, sessionsToDelete...)
-	if res.Err() != nil {
-		log.Fatalf("DisplaceUserSessions (srem): %v", res.Err())
+	err = redisConn.Del(ctx, sessionKeys...).Err()
+	if err != nil {
+		return fmt.Errorf("DisplaceUserSessions (del): %w", err)
+	}
+
+	err = redisConn.SRem(ctx, setKey, sessionsToDelete...).Err()
+	if err != nil {
+		return fmt.Errorf("DisplaceUserSessions (srem): %w", err)
 	}
 
 	return nil
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	failedLoginUserPrefix = "fl_u_"  // Счётчик неудачных входов в аккаунт
+	failedLoginIPPrefix   = "fl_ip_" // Счётчик неудачных входов с IP-адреса
+	loginLockUserPrefix   = "ll_u_"  // Блокировка входа в аккаунт, значение - Unix-время снятия
+	loginLockIPPrefix     = "ll_ip_" // Блокировка входа с IP-адреса, значение - Unix-время снятия
+	failedLoginWindow     = 24 * time.Hour
+)
+
+// GetLoginLockout возвращает время снятия блокировки входа для аккаунта или IP-адреса
+// (более позднее из двух). Нулевое время - блокировки нет
+func (r *AuthRepository) GetLoginLockout(ctx context.Context, userID int64, clientIP string) (lockedUntil time.Time, err error) {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	keys := make([]string, 0, 2)
+	if userID != 0 {
+		keys = append(keys, fmt.Sprintf("%s%d", loginLockUserPrefix, userID))
+	}
+	if clientIP != "" {
+		keys = append(keys, loginLockIPPrefix+clientIP)
+	}
+	if len(keys) == 0 {
+		return time.Time{}, nil
+	}
+
+	values, err := redisConn.MGet(ctx, keys...).Result()
+	logging.Debug(ctx, "GetLoginLockout query to redis has err: ", err)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("GetLoginLockout (mget): %w", err)
+	}
+
+	for _, value := range values {
+		rawUntil, ok := value.(string)
+		if !ok {
+			continue
+		}
+		unixUntil, err := strconv.ParseInt(rawUntil, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("GetLoginLockout (atoi): %w", err)
+		}
+		if until := time.Unix(unixUntil, 0); until.After(lockedUntil) {
+			lockedUntil = until
+		}
+	}
+
+	return lockedUntil, nil
+}
+
+// RegisterFailedLogin увеличивает счётчики неудачных входов аккаунта и IP-адреса.
+// Если userID = 0 (пользователь не найден), считается только IP-адрес
+func (r *AuthRepository) RegisterFailedLogin(ctx context.Context, userID int64, clientIP string) (userFailures int64, ipFailures int64, err error) {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	var userCounter, ipCounter *redis.IntCmd
+	_, err = redisConn.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if userID != 0 {
+			userKey := fmt.Sprintf("%s%d", failedLoginUserPrefix, userID)
+			userCounter = pipe.Incr(ctx, userKey)
+			pipe.Expire(ctx, userKey, failedLoginWindow)
+		}
+		if clientIP != "" {
+			ipKey := failedLoginIPPrefix + clientIP
+			ipCounter = pipe.Incr(ctx, ipKey)
+			pipe.Expire(ctx, ipKey, failedLoginWindow)
+		}
+		return nil
+	})
+	logging.Debug(ctx, "RegisterFailedLogin query to redis has err: ", err)
+	if err != nil {
+		return 0, 0, fmt.Errorf("RegisterFailedLogin (incr): %w", err)
+	}
+
+	if userCounter != nil {
+		userFailures = userCounter.Val()
+	}
+	if ipCounter != nil {
+		ipFailures = ipCounter.Val()
+	}
+	return userFailures, ipFailures, nil
+}
+
+// SetLoginLockout блокирует вход в аккаунт и с IP-адреса до указанного времени.
+// Нулевое время означает, что соответствующая блокировка не ставится
+func (r *AuthRepository) SetLoginLockout(ctx context.Context, userID int64, clientIP string, userUntil time.Time, ipUntil time.Time) error {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	_, err := redisConn.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if userID != 0 && !userUntil.IsZero() {
+			pipe.Set(ctx, fmt.Sprintf("%s%d", loginLockUserPrefix, userID), userUntil.Unix(), time.Until(userUntil))
+		}
+		if clientIP != "" && !ipUntil.IsZero() {
+			pipe.Set(ctx, loginLockIPPrefix+clientIP, ipUntil.Unix(), time.Until(ipUntil))
+		}
+		return nil
+	})
+	logging.Debug(ctx, "SetLoginLockout query to redis has err: ", err)
+	if err != nil {
+		return fmt.Errorf("SetLoginLockout (set): %w", err)
+	}
+
+	return nil
+}
+
+// ResetFailedLogins сбрасывает счётчик неудачных входов аккаунта после успешного входа
+func (r *AuthRepository) ResetFailedLogins(ctx context.Context, userID int64) error {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	err := redisConn.Del(ctx, fmt.Sprintf("%s%d", failedLoginUserPrefix, userID)).Err()
+	logging.Debug(ctx, "ResetFailedLogins query to redis has err: ", err)
+	if err != nil {
+		return fmt.Errorf("ResetFailedLogins (del): %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	passwordResetEmailPrefix = "pr_e_"  // Счётчик запросов сброса пароля для email
+	passwordResetIPPrefix    = "pr_ip_" // Счётчик запросов сброса пароля с IP-адреса
+	passwordResetWindow      = time.Hour
+)
+
+// CountPasswordResetRequest учитывает запрос сброса пароля и возвращает, сколько таких запросов
+// было за последний час для email и с IP-адреса (включая этот)
+func (r *AuthRepository) CountPasswordResetRequest(ctx context.Context, email string, clientIP string) (emailRequests int64, ipRequests int64, err error) {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	var emailCounter, ipCounter *redis.IntCmd
+	_, err = redisConn.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		emailKey := passwordResetEmailPrefix + email
+		emailCounter = pipe.Incr(ctx, emailKey)
+		pipe.Expire(ctx, emailKey, passwordResetWindow)
+		if clientIP != "" {
+			ipKey := passwordResetIPPrefix + clientIP
+			ipCounter = pipe.Incr(ctx, ipKey)
+			pipe.Expire(ctx, ipKey, passwordResetWindow)
+		}
+		return nil
+	})
+	logging.Debug(ctx, "CountPasswordResetRequest query to redis has err: ", err)
+	if err != nil {
+		return 0, 0, fmt.Errorf("CountPasswordResetRequest (incr): %w", err)
+	}
+
+	emailRequests = emailCounter.Val()
+	if ipCounter != nil {
+		ipRequests = ipCounter.Val()
+	}
+	return emailRequests, ipRequests, nil
+}
+
+// GetUserIDByEmail получает ID пользователя по email
+func (r *AuthRepository) GetUserIDByEmail(ctx context.Context, email string) (userID int64, err error) {
+	query := `
+	SELECT u_id
+	FROM "user"
+	WHERE email=$1;
+	`
+
+	err = r.db.QueryRow(ctx, query, email).Scan(&userID)
+	logging.Debug(ctx, "GetUserIDByEmail query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("GetUserIDByEmail: %w", errs.ErrNotFound)
+		}
+		return 0, fmt.Errorf("GetUserIDByEmail: %w", err)
+	}
+
+	return userID, nil
+}
+
+// CreatePasswordResetToken сохраняет хеш токена сброса пароля
+func (r *AuthRepository) CreatePasswordResetToken(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error {
+	query := `
+	INSERT INTO password_reset_token (token_hash, u_id, expires_at)
+	VALUES ($1, $2, $3);
+	`
+
+	_, err := r.db.Exec(ctx, query, tokenHash, userID, expiresAt)
+	logging.Debug(ctx, "CreatePasswordResetToken query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("CreatePasswordResetToken: %w", err)
+	}
+
+	return nil
+}
+
+// UsePasswordResetToken погашает действующий токен сброса пароля и возвращает владельца.
+// Остальные неиспользованные токены пользователя погашаются вместе с ним.
+// Если токен не найден, уже использован или истёк - errs.ErrNotFound
+func (r *AuthRepository) UsePasswordResetToken(ctx context.Context, tokenHash string) (userID int64, err error) {
+	query := `
+	WITH used_token AS (
+		UPDATE password_reset_token
+		SET used_at=CURRENT_TIMESTAMP
+		WHERE token_hash=$1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING u_id
+	),
+	revoke_other_tokens AS (
+		UPDATE password_reset_token
+		SET used_at=CURRENT_TIMESTAMP
+		WHERE u_id=(SELECT u_id FROM used_token) AND used_at IS NULL AND token_hash<>$1
+	)
+	SELECT u_id FROM used_token;
+	`
+
+	err = r.db.QueryRow(ctx, query, tokenHash).Scan(&userID)
+	logging.Debug(ctx, "UsePasswordResetToken query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("UsePasswordResetToken: %w", errs.ErrNotFound)
+		}
+		return 0, fmt.Errorf("UsePasswordResetToken: %w", err)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Поля хеша с данными об устройстве сессии
+const (
+	sessionMetaUserAgent  = "ua"
+	sessionMetaClientIP   = "ip"
+	sessionMetaCreatedAt  = "created"
+	sessionMetaLastSeenAt = "seen"
+)
+
+// Сессию продлеваем не чаще раза в sessionRefreshInterval, чтобы не писать в Redis на каждый запрос
+const sessionRefreshInterval = 5 * time.Minute
+
+// TouchSession запоминает время последнего запроса с сессией и продлевает её (скользящее истечение).
+// Продление ограничено максимальным временем жизни сессии с момента входа
+func (r *AuthRepository) TouchSession(ctx context.Context, sessionID string) error {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	metaKey := sessionMetaPrefix + sessionID
+	meta, err := redisConn.HMGet(ctx, metaKey, sessionMetaCreatedAt, sessionMetaLastSeenAt).Result()
+	logging.Debug(ctx, "TouchSession query to redis has err: ", err)
+	if err != nil {
+		return fmt.Errorf("TouchSession (hmget): %w", err)
+	}
+
+	// Сессии без времени входа (созданные до появления данных об устройстве) не продлеваются
+	now := time.Now()
+	createdAt, lastSeenAt := parseUnixValue(meta[0]), parseUnixValue(meta[1])
+	if createdAt.IsZero() || now.Sub(lastSeenAt) < sessionRefreshInterval {
+		return nil
+	}
+	ttl := r.sessionTTL(createdAt, now)
+	if ttl <= 0 {
+		return nil
+	}
+
+	_, err = redisConn.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, metaKey, sessionMetaLastSeenAt, now.Unix())
+		pipe.Expire(ctx, sessionPrefix+sessionID, ttl)
+		pipe.Expire(ctx, metaKey, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("TouchSession (hset): %w", err)
+	}
+
+	return nil
+}
+
+// GetUserSessions получает действующие сессии пользователя с данными об устройствах.
+// Истёкшие сессии заодно убираются из сета сессий пользователя
+func (r *AuthRepository) GetUserSessions(ctx context.Context, userID int64) (sessions []models.SessionInfo, err error) {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	setKey := fmt.Sprintf("%s%d", userPrefix, userID)
+	sessionIDs, err := redisConn.SMembers(ctx, setKey).Result()
+	logging.Debug(ctx, "GetUserSessions query to redis has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("GetUserSessions (smembers): %w", err)
+	}
+
+	existsCmds := make([]*redis.IntCmd, len(sessionIDs))
+	metaCmds := make([]*redis.StringStringMapCmd, len(sessionIDs))
+	_, err = redisConn.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, sessionID := range sessionIDs {
+			existsCmds[i] = pipe.Exists(ctx, sessionPrefix+sessionID)
+			metaCmds[i] = pipe.HGetAll(ctx, sessionMetaPrefix+sessionID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetUserSessions (hgetall): %w", err)
+	}
+
+	sessions = make([]models.SessionInfo, 0, len(sessionIDs))
+	expired := make([]interface{}, 0)
+	for i, sessionID := range sessionIDs {
+		if existsCmds[i].Val() == 0 {
+			expired = append(expired, sessionID)
+			continue
+		}
+
+		meta := metaCmds[i].Val()
+		sessions = append(sessions, models.SessionInfo{
+			SessionID:  sessionID,
+			UserAgent:  meta[sessionMetaUserAgent],
+			ClientIP:   meta[sessionMetaClientIP],
+			CreatedAt:  parseUnixField(meta[sessionMetaCreatedAt]),
+			LastSeenAt: parseUnixField(meta[sessionMetaLastSeenAt]),
+		})
+	}
+
+	if len(expired) > 0 {
+		err = redisConn.SRem(ctx, setKey, expired...).Err()
+		if err != nil {
+			logging.Warn(ctx, "GetUserSessions (srem): ", err)
+		}
+	}
+
+	return sessions, nil
+}
+
+// parseUnixValue - parseUnixField для результата HMGET (nil, если поля нет)
+func parseUnixValue(value interface{}) time.Time {
+	str, _ := value.(string)
+	return parseUnixField(str)
+}
+
+// parseUnixField разбирает Unix-время из поля хеша; для сессий, созданных до появления данных об устройстве, - нулевое время
+func parseUnixField(value string) time.Time {
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
+}
+
+// GetSessionCreatedAt получает время входа, с которым создана сессия.
+// Для сессий, созданных до появления данных об устройстве, - нулевое время
+func (r *AuthRepository) GetSessionCreatedAt(ctx context.Context, sessionID string) (createdAt time.Time, err error) {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	created, err := redisConn.HGet(ctx, sessionMetaPrefix+sessionID, sessionMetaCreatedAt).Result()
+	logging.Debug(ctx, "GetSessionCreatedAt query to redis has err: ", err)
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("GetSessionCreatedAt (hget): %w", err)
+	}
+
+	return parseUnixField(created), nil
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	partialSessionPrefix         = "ps_" // Сессия, ожидающая второй фактор, значение - ID пользователя
+	partialSessionAttemptsPrefix = "pa_" // Счётчик попыток ввести код для такой сессии
+	partialSessionLifeTime       = 5 * time.Minute
+)
+
+// GetUserEmail получает email пользователя (нужен для подписи в приложении-аутентификаторе)
+func (r *AuthRepository) GetUserEmail(ctx context.Context, userID int64) (email string, err error) {
+	query := `
+	SELECT email
+	FROM "user"
+	WHERE u_id=$1;
+	`
+
+	err = r.db.QueryRow(ctx, query, userID).Scan(&email)
+	logging.Debug(ctx, "GetUserEmail query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("GetUserEmail: %w", errs.ErrNotFound)
+		}
+		return "", fmt.Errorf("GetUserEmail: %w", err)
+	}
+
+	return email, nil
+}
+
+// GetUserTOTP получает настройки TOTP пользователя. Если TOTP не подключался - errs.ErrNotFound
+func (r *AuthRepository) GetUserTOTP(ctx context.Context, userID int64) (settings *models.UserTOTP, err error) {
+	query := `
+	SELECT u_id, secret, enabled_at, last_used_step
+	FROM user_totp
+	WHERE u_id=$1;
+	`
+
+	settings = &models.UserTOTP{}
+	err = r.db.QueryRow(ctx, query, userID).Scan(
+		&settings.UserID,
+		&settings.Secret,
+		&settings.EnabledAt,
+		&settings.LastUsedStep,
+	)
+	logging.Debug(ctx, "GetUserTOTP query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("GetUserTOTP: %w", errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("GetUserTOTP: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SaveTOTPSecret сохраняет секрет для неподтверждённого подключения TOTP, заменяя прежний неподтверждённый.
+// Если TOTP уже включён - errs.ErrAlreadyExists
+func (r *AuthRepository) SaveTOTPSecret(ctx context.Context, userID int64, secret string) error {
+	query := `
+	INSERT INTO user_totp (u_id, secret)
+	VALUES ($1, $2)
+	ON CONFLICT (u_id) DO UPDATE
+	SET secret=EXCLUDED.secret, last_used_step=0, created_at=CURRENT_TIMESTAMP
+	WHERE user_totp.enabled_at IS NULL;
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, secret)
+	logging.Debug(ctx, "SaveTOTPSecret query has err: ", err, " tag: ", tag)
+	if err != nil {
+		return fmt.Errorf("SaveTOTPSecret: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("SaveTOTPSecret: %w", errs.ErrAlreadyExists)
+	}
+
+	return nil
+}
+
+// EnableTOTP включает TOTP, запоминает использованный при подтверждении интервал
+// и заменяет коды восстановления новыми. Если подключение не начато или уже подтверждено - errs.ErrNotFound
+func (r *AuthRepository) EnableTOTP(ctx context.Context, userID int64, step int64, recoveryCodeHashes []string) error {
+	query := `
+	WITH enabled AS (
+		UPDATE user_totp
+		SET enabled_at=CURRENT_TIMESTAMP, last_used_step=$2
+		WHERE u_id=$1 AND enabled_at IS NULL AND last_used_step < $2
+		RETURNING u_id
+	),
+	deleted_codes AS (
+		DELETE FROM totp_recovery_code
+		WHERE u_id=(SELECT u_id FROM enabled)
+	)
+	INSERT INTO totp_recovery_code (u_id, code_hash)
+	SELECT enabled.u_id, code_hash
+	FROM enabled, unnest($3::text[]) AS code_hash;
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, step, recoveryCodeHashes)
+	logging.Debug(ctx, "EnableTOTP query has err: ", err, " tag: ", tag)
+	if err != nil {
+		return fmt.Errorf("EnableTOTP: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("EnableTOTP: %w", errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+// UseTOTPStep отмечает интервал step как использованный. Код того же или более раннего интервала
+// повторно не принимается: в этом случае (и если TOTP выключен) - errs.ErrNotFound
+func (r *AuthRepository) UseTOTPStep(ctx context.Context, userID int64, step int64) error {
+	query := `
+	UPDATE user_totp
+	SET last_used_step=$2
+	WHERE u_id=$1 AND enabled_at IS NOT NULL AND last_used_step < $2;
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, step)
+	logging.Debug(ctx, "UseTOTPStep query has err: ", err, " tag: ", tag)
+	if err != nil {
+		return fmt.Errorf("UseTOTPStep: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("UseTOTPStep: %w", errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode погашает неиспользованный код восстановления. Если такого нет - errs.ErrNotFound
+func (r *AuthRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) error {
+	query := `
+	UPDATE totp_recovery_code
+	SET used_at=CURRENT_TIMESTAMP
+	WHERE code_id=(
+		SELECT code_id
+		FROM totp_recovery_code
+		WHERE u_id=$1 AND code_hash=$2 AND used_at IS NULL
+		LIMIT 1
+	) AND used_at IS NULL;
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, codeHash)
+	logging.Debug(ctx, "UseRecoveryCode query has err: ", err, " tag: ", tag)
+	if err != nil {
+		return fmt.Errorf("UseRecoveryCode: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("UseRecoveryCode: %w", errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+// DeleteTOTP отключает TOTP и удаляет коды восстановления
+func (r *AuthRepository) DeleteTOTP(ctx context.Context, userID int64) error {
+	query := `
+	WITH deleted_codes AS (
+		DELETE FROM totp_recovery_code
+		WHERE u_id=$1
+	)
+	DELETE FROM user_totp
+	WHERE u_id=$1;
+	`
+
+	_, err := r.db.Exec(ctx, query, userID)
+	logging.Debug(ctx, "DeleteTOTP query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("DeleteTOTP: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterPartialSession запоминает, что пользователь ввёл верный пароль и ждёт проверки второго фактора
+func (r *AuthRepository) RegisterPartialSession(ctx context.Context, partialSessionID string, userID int64) error {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	err := redisConn.Set(ctx, partialSessionPrefix+partialSessionID, userID, partialSessionLifeTime).Err()
+	logging.Debug(ctx, "RegisterPartialSession query to redis has err: ", err)
+	if err != nil {
+		return fmt.Errorf("RegisterPartialSession (set): %w", err)
+	}
+
+	return nil
+}
+
+// CheckPartialSession получает пользователя сессии, ожидающей второй фактор, и засчитывает попытку ввода кода.
+// Возвращает номер попытки. Если сессии нет или она истекла - errs.ErrNotFound
+func (r *AuthRepository) CheckPartialSession(ctx context.Context, partialSessionID string) (userID int64, attempt int64, err error) {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	userID, err = redisConn.Get(ctx, partialSessionPrefix+partialSessionID).Int64()
+	logging.Debug(ctx, "CheckPartialSession query to redis has err: ", err)
+	if err == redis.Nil {
+		return 0, 0, fmt.Errorf("CheckPartialSession (get): %w", errs.ErrNotFound)
+	} else if err != nil {
+		return 0, 0, fmt.Errorf("CheckPartialSession (get): %w", err)
+	}
+
+	attemptsKey := partialSessionAttemptsPrefix + partialSessionID
+	var counter *redis.IntCmd
+	_, err = redisConn.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		counter = pipe.Incr(ctx, attemptsKey)
+		pipe.Expire(ctx, attemptsKey, partialSessionLifeTime)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("CheckPartialSession (incr): %w", err)
+	}
+
+	return userID, counter.Val(), nil
+}
+
+// KillPartialSession удаляет сессию, ожидающую второй фактор, вместе со счётчиком попыток
+func (r *AuthRepository) KillPartialSession(ctx context.Context, partialSessionID string) error {
+	redisConn := r.redisDb.Conn(r.redisDb.Context())
+	defer redisConn.Close()
+
+	err := redisConn.Del(ctx, partialSessionPrefix+partialSessionID, partialSessionAttemptsPrefix+partialSessionID).Err()
+	logging.Debug(ctx, "KillPartialSession query to redis has err: ", err)
+	if err != nil {
+		return fmt.Errorf("KillPartialSession (del): %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/auth"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxAccessTokens - сколько персональных токенов может быть у одного пользователя
+const maxAccessTokens = 50
+
+var knownScopes = []string{auth.ScopeBoardsRead, auth.ScopeCardsWrite, auth.ScopeAdmin}
+
+// CheckAccessToken проверяет персональный токен доступа и возвращает его владельца и права.
+// Неизвестный, отозванный и истёкший токены дают errs.ErrWrongCredentials
+func (uc *AuthUsecase) CheckAccessToken(ctx context.Context, token string) (userID int64, scopes []string, err error) {
+	if !strings.HasPrefix(token, auth.AccessTokenPrefix) {
+		return 0, nil, fmt.Errorf("CheckAccessToken: bad token format: %w", errs.ErrWrongCredentials)
+	}
+
+	userID, scopes, err = uc.authRepo.UseAccessToken(ctx, encrypt.HashToken(token))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return 0, nil, fmt.Errorf("CheckAccessToken (UseAccessToken): %w", errs.ErrWrongCredentials)
+		}
+		return 0, nil, fmt.Errorf("CheckAccessToken (UseAccessToken): %w", err)
+	}
+
+	return userID, scopes, nil
+}
+
+// CreateAccessToken создаёт персональный токен доступа для пользователя текущей сессии.
+// Сам токен возвращается только здесь, в базе хранится его хеш
+func (uc *AuthUsecase) CreateAccessToken(ctx context.Context, sessionID string, name string, scopes []string, expiresAt *time.Time) (token *models.AccessToken, err error) {
+	userID, err := uc.authRepo.CheckSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("CreateAccessToken (CheckSession): %w", err)
+	}
+
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("CreateAccessToken: no scopes: %w", errs.ErrBadRequest)
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(knownScopes, scope) {
+			return nil, fmt.Errorf("CreateAccessToken: unknown scope %q: %w", scope, errs.ErrBadRequest)
+		}
+	}
+	scopes = slices.Compact(slices.Sorted(slices.Values(scopes)))
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("CreateAccessToken: expiry in the past: %w", errs.ErrBadRequest)
+	}
+
+	tokens, err := uc.authRepo.GetUserAccessTokens(ctx, int64(userID))
+	if err != nil {
+		return nil, fmt.Errorf("CreateAccessToken (GetUserAccessTokens): %w", err)
+	}
+	if len(tokens) >= maxAccessTokens {
+		return nil, fmt.Errorf("CreateAccessToken: too many tokens: %w", errs.ErrBadRequest)
+	}
+
+	rawToken := auth.AccessTokenPrefix + encrypt.GenerateSessionID()
+	token, err = uc.authRepo.CreateAccessToken(ctx, int64(userID), name, encrypt.HashToken(rawToken), scopes, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("CreateAccessToken (CreateAccessToken): %w", err)
+	}
+	token.Token = rawToken
+
+	return token, nil
+}
+
+// ListAccessTokens возвращает персональные токены пользователя текущей сессии (без самих токенов)
+func (uc *AuthUsecase) ListAccessTokens(ctx context.Context, sessionID string) (tokens []models.AccessToken, err error) {
+	userID, err := uc.authRepo.CheckSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("ListAccessTokens (CheckSession): %w", err)
+	}
+
+	tokens, err = uc.authRepo.GetUserAccessTokens(ctx, int64(userID))
+	if err != nil {
+		return nil, fmt.Errorf("ListAccessTokens (GetUserAccessTokens): %w", err)
+	}
+
+	return tokens, nil
+}
+
+// RevokeAccessToken отзывает персональный токен пользователя текущей сессии
+func (uc *AuthUsecase) RevokeAccessToken(ctx context.Context, sessionID string, tokenID int64) (err error) {
+	userID, err := uc.authRepo.CheckSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("RevokeAccessToken (CheckSession): %w", errs.ErrWrongCredentials)
+		}
+		return fmt.Errorf("RevokeAccessToken (CheckSession): %w", err)
+	}
+
+	err = uc.authRepo.DeleteAccessToken(ctx, int64(userID), tokenID)
+	if err != nil {
+		return fmt.Errorf("RevokeAccessToken (DeleteAccessToken): %w", err)
+	}
+
+	return nil
+}
@@ -4,39 +4,120 @@ import (
 	"RPO_back/internal/errs"
 	"RPO_back/internal/pkg/auth"
 	"RPO_back/internal/pkg/utils/encrypt"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/mailer"
 	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
 type AuthUsecase struct {
-	authRepo auth.AuthRepo
+	authRepo         auth.AuthRepo
+	mailer           mailer.Mailer
+	passwordResetURL string
 }
 
-func CreateAuthUsecase(repo auth.AuthRepo) *AuthUsecase {
+func CreateAuthUsecase(repo auth.AuthRepo, mailer mailer.Mailer, passwordResetURL string) *AuthUsecase {
 	return &AuthUsecase{
-		authRepo: repo,
+		authRepo:         repo,
+		mailer:           mailer,
+		passwordResetURL: passwordResetURL,
 	}
 }
 
-func (uc *AuthUsecase) CreateSession(ctx context.Context, userID int64, password string) (sessionID string, err error) {
-	_, err = uc.authRepo.GetUserPasswordHash(ctx, int(userID))
+// CreateSession проверяет пароль пользователя и создаёт ему сессию.
+// Для несуществующего пользователя проверка идёт столько же времени, а ошибка та же - errs.ErrWrongCredentials.
+// После серии неудачных попыток вход в аккаунт или с IP-адреса блокируется (ошибка *errs.LockoutError).
+// Если у пользователя включён TOTP, вместо сессии возвращается ID частичной сессии и twoFactorRequired,
+// а сама сессия создаётся в VerifyTwoFactor после проверки кода
+func (uc *AuthUsecase) CreateSession(ctx context.Context, userID int64, password string, clientIP string, userAgent string) (sessionID string, twoFactorRequired bool, err error) {
+	lockedUntil, err := uc.authRepo.GetLoginLockout(ctx, userID, clientIP)
 	if err != nil {
-		return "", err
+		return "", false, fmt.Errorf("CreateSession (GetLoginLockout): %w", err)
+	}
+	if time.Now().Before(lockedUntil) {
+		return "", false, fmt.Errorf("CreateSession (GetLoginLockout): %w", &errs.LockoutError{Until: lockedUntil})
+	}
+
+	passwordHash, err := uc.authRepo.GetUserPasswordHash(ctx, int(userID))
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return "", false, fmt.Errorf("CreateSession (GetUserPasswordHash): %w", err)
+	}
+	userExists := err == nil
+
+	if !encrypt.CheckPasswordOrDummy(password, passwordHash) {
+		if !userExists {
+			userID = 0
+		}
+		return "", false, fmt.Errorf("CreateSession (CheckPassword): passwords do not match: %w", uc.registerFailedLogin(ctx, userID, clientIP))
+	}
+
+	sessionID, twoFactorRequired, err = uc.completeLogin(ctx, userID, clientIP, userAgent)
+	if err != nil {
+		return "", false, fmt.Errorf("CreateSession (completeLogin): %w", err)
+	}
+
+	return sessionID, twoFactorRequired, nil
+}
+
+// CreateTrustedSession создаёт сессию для пользователя, которого уже аутентифицировал
+// внешний провайдер (OIDC). Пароль не проверяется, но блокировка входа и TOTP действуют
+func (uc *AuthUsecase) CreateTrustedSession(ctx context.Context, userID int64, clientIP string, userAgent string) (sessionID string, twoFactorRequired bool, err error) {
+	lockedUntil, err := uc.authRepo.GetLoginLockout(ctx, userID, clientIP)
+	if err != nil {
+		return "", false, fmt.Errorf("CreateTrustedSession (GetLoginLockout): %w", err)
+	}
+	if time.Now().Before(lockedUntil) {
+		return "", false, fmt.Errorf("CreateTrustedSession (GetLoginLockout): %w", &errs.LockoutError{Until: lockedUntil})
+	}
+
+	sessionID, twoFactorRequired, err = uc.completeLogin(ctx, userID, clientIP, userAgent)
+	if err != nil {
+		return "", false, fmt.Errorf("CreateTrustedSession (completeLogin): %w", err)
+	}
+
+	return sessionID, twoFactorRequired, nil
+}
+
+// completeLogin - общий хвост входа после успешной первичной аутентификации: либо просит
+// второй фактор (и возвращает токен частичной сессии), либо создаёт полноценную сессию
+func (uc *AuthUsecase) completeLogin(ctx context.Context, userID int64, clientIP string, userAgent string) (sessionID string, twoFactorRequired bool, err error) {
+	twoFactorRequired, err = uc.twoFactorEnabled(ctx, userID)
+	if err != nil {
+		return "", false, fmt.Errorf("completeLogin (twoFactorEnabled): %w", err)
+	}
+	if twoFactorRequired {
+		// Счётчик неудачных входов не сбрасывается до проверки кода, иначе подбор кода
+		// можно было бы растянуть, каждый раз заново вводя верный пароль
+		partialSessionID := encrypt.GenerateSessionID()
+		err = uc.authRepo.RegisterPartialSession(ctx, partialSessionID, userID)
+		if err != nil {
+			return "", false, fmt.Errorf("completeLogin (RegisterPartialSession): %w", err)
+		}
+		return partialSessionID, true, nil
+	}
+
+	err = uc.authRepo.ResetFailedLogins(ctx, userID)
+	if err != nil {
+		logging.Warn(ctx, "completeLogin (ResetFailedLogins): ", err)
+	}
+
+	sessionID, err = uc.registerSession(ctx, userID, clientIP, userAgent)
+	if err != nil {
+		return "", false, fmt.Errorf("completeLogin (registerSession): %w", err)
 	}
 
-	// if passwordHash != nil {
-	// 	ok := encrypt.CheckPassword(password, *passwordHash)
-	// 	if !ok {
-	// 		return "", fmt.Errorf("LoginUser: passwords not match: %w", errs.ErrWrongCredentials)
-	// 	}
-	// }
+	return sessionID, false, nil
+}
 
+// registerSession создаёт пользователю новую полноценную сессию
+func (uc *AuthUsecase) registerSession(ctx context.Context, userID int64, clientIP string, userAgent string) (sessionID string, err error) {
 	sessionID = encrypt.GenerateSessionID()
 
-	err = uc.authRepo.RegisterSessionRedis(ctx, sessionID, int(userID))
+	err = uc.authRepo.RegisterSessionRedis(ctx, sessionID, int(userID), truncateUserAgent(userAgent), clientIP)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("registerSession (RegisterSessionRedis): %w", err)
 	}
 
 	return sessionID, nil
@@ -53,6 +134,11 @@ func (uc *AuthUsecase) CheckSession(ctx context.Context, sessionID string) (user
 		return 0, fmt.Errorf("CheckSession: %w", err)
 	}
 
+	err = uc.authRepo.TouchSession(ctx, sessionID)
+	if err != nil {
+		logging.Warn(ctx, "CheckSession (TouchSession): ", err)
+	}
+
 	return userID, nil
 }
 
@@ -65,27 +151,61 @@ func (uc *AuthUsecase) KillSession(ctx context.Context, sessionID string) (err e
 	return nil
 }
 
+// Без пароля (вход только через SSO) необратимые действия разрешены лишь вскоре после входа
+const recentLoginWindow = 10 * time.Minute
+
+// CheckPassword проверяет пароль владельца сессии и возвращает его ID.
+// У пользователя без пароля вместо него проверяется, что сессия создана не раньше recentLoginWindow назад:
+// иначе нужно заново войти через провайдера
+func (uc *AuthUsecase) CheckPassword(ctx context.Context, sessionID string, password string) (userID int, err error) {
+	userID, err = uc.authRepo.CheckSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return 0, fmt.Errorf("CheckPassword (CheckSession): %w", errs.ErrWrongCredentials)
+		}
+		return 0, fmt.Errorf("CheckPassword (CheckSession): %w", err)
+	}
+
+	passwordHash, err := uc.authRepo.GetUserPasswordHash(ctx, userID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return 0, fmt.Errorf("CheckPassword (GetUserPasswordHash): %w", err)
+	}
+
+	if passwordHash == nil {
+		createdAt, err := uc.authRepo.GetSessionCreatedAt(ctx, sessionID)
+		if err != nil {
+			return 0, fmt.Errorf("CheckPassword (GetSessionCreatedAt): %w", err)
+		}
+		if createdAt.IsZero() || time.Since(createdAt) > recentLoginWindow {
+			return 0, fmt.Errorf("CheckPassword: no password and login is not recent: %w", errs.ErrWrongCredentials)
+		}
+		return userID, nil
+	}
+
+	if !encrypt.CheckPassword(password, *passwordHash) {
+		return 0, fmt.Errorf("CheckPassword (CheckPassword): passwords do not match: %w", errs.ErrWrongCredentials)
+	}
+
+	return userID, nil
+}
+
+// ChangePassword меняет пароль пользователя и завершает все его сессии, кроме текущей
 func (uc *AuthUsecase) ChangePassword(ctx context.Context, oldPassword string, newPassword string, sessionID string) (err error) {
 	userID, err := uc.authRepo.CheckSession(ctx, sessionID)
 	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("ChangePassword (CheckSession): %w", errs.ErrWrongCredentials)
+		}
 		return fmt.Errorf("ChangePassword (CheckSession): %w", err)
 	}
 
-	_, err = uc.authRepo.GetUserPasswordHash(ctx, int(userID))
-	if err != nil {
+	oldPasswordHash, err := uc.authRepo.GetUserPasswordHash(ctx, userID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
 		return fmt.Errorf("ChangePassword (GetUserPasswordHash): %w", err)
 	}
 
-	// if oldPasswordHash != nil {
-	// 	ok := encrypt.CheckPassword(oldPassword, *oldPasswordHash)
-	// 	if !ok {
-	// 		return fmt.Errorf("ChangePassword (CheckPassword): passwords do not match: %w", errs.ErrWrongCredentials)
-	// 	}
-	// }
-
-	err = uc.authRepo.DisplaceUserSessions(ctx, sessionID, int64(userID))
-	if err != nil {
-		return fmt.Errorf("ChangePassword (DisplaceUserSessions): %w", err)
+	if !encrypt.CheckPasswordOrDummy(oldPassword, oldPasswordHash) {
+		return fmt.Errorf("ChangePassword (CheckPassword): passwords do not match: %w", errs.ErrWrongCredentials)
 	}
 
 	newPasswordHash, err := encrypt.SaltAndHashPassword(newPassword)
@@ -93,14 +213,14 @@ func (uc *AuthUsecase) ChangePassword(ctx context.Context, oldPassword string, n
 		return fmt.Errorf("ChangePassword (SaltAndHashPassword): %w", err)
 	}
 
-	err = uc.authRepo.SetNewPasswordHash(ctx, int(userID), newPasswordHash)
+	err = uc.authRepo.SetNewPasswordHash(ctx, userID, newPasswordHash)
 	if err != nil {
 		return fmt.Errorf("ChangePassword (SetNewPasswordHash): %w", err)
 	}
 
-	err = uc.authRepo.RegisterSessionRedis(ctx, sessionID, int(userID))
+	err = uc.authRepo.DisplaceUserSessions(ctx, sessionID, int64(userID))
 	if err != nil {
-		return fmt.Errorf("ChangePassword (RegisterSessionRedis): %w", err)
+		return fmt.Errorf("ChangePassword (DisplaceUserSessions): %w", err)
 	}
 
 	return nil
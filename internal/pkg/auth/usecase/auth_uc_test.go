@@ -2,13 +2,13 @@ package usecase_test
 
 import (
 	"RPO_back/internal/errs"
-	"RPO_back/internal/models"
 	mocks "RPO_back/internal/pkg/auth/mocks"
 	"RPO_back/internal/pkg/utils/encrypt"
 	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	AuthUsecase "RPO_back/internal/pkg/auth/usecase"
 
@@ -20,72 +20,76 @@ func isStringLengthMoreThan10(s string) bool {
 	return len(s) > 10
 }
 
-func TestAuthUsecase_LoginUser(t *testing.T) {
+func TestAuthUsecase_CreateSession(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
-	authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo)
+	authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo, nil, "")
 
 	tests := []struct {
 		name                  string
-		email                 string
+		userID                int64
 		password              string
 		setupMock             func()
 		expectedError         bool
+		expectedErrorIs       error
 		expectedResultChecker func(string) bool
 	}{
 		{
 			name:     "successful login",
-			email:    "test@example.com",
+			userID:   123,
 			password: "11111111",
 			setupMock: func() {
 				pHash, _ := encrypt.SaltAndHashPassword("11111111")
-				mockAuthRepo.EXPECT().GetUserByEmail(gomock.Any(), "test@example.com").Return(&models.UserProfile{
-					ID:           123,
-					PasswordHash: pHash,
-				}, nil)
-
-				mockAuthRepo.EXPECT().RegisterSessionRedis(gomock.Any(), gomock.Any(), 123).Return(nil)
+				mockAuthRepo.EXPECT().GetLoginLockout(gomock.Any(), int64(123), "203.0.113.7").Return(time.Time{}, nil)
+				mockAuthRepo.EXPECT().GetUserPasswordHash(gomock.Any(), 123).Return(&pHash, nil)
+				mockAuthRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(123)).Return(nil, errs.ErrNotFound)
+				mockAuthRepo.EXPECT().ResetFailedLogins(gomock.Any(), int64(123)).Return(nil)
+				mockAuthRepo.EXPECT().RegisterSessionRedis(gomock.Any(), gomock.Any(), 123, "test-agent", "203.0.113.7").Return(nil)
 			},
-			expectedError:         false,
 			expectedResultChecker: isStringLengthMoreThan10,
 		},
 		{
 			name:     "user not found",
-			email:    "notfound@example.com",
+			userID:   404,
 			password: "any-password",
 			setupMock: func() {
-				mockAuthRepo.EXPECT().GetUserByEmail(gomock.Any(), "notfound@example.com").Return(nil, errs.ErrWrongCredentials)
+				mockAuthRepo.EXPECT().GetLoginLockout(gomock.Any(), int64(404), "203.0.113.7").Return(time.Time{}, nil)
+				mockAuthRepo.EXPECT().GetUserPasswordHash(gomock.Any(), 404).Return(nil, errs.ErrNotFound)
+				mockAuthRepo.EXPECT().RegisterFailedLogin(gomock.Any(), int64(0), "203.0.113.7").Return(int64(1), int64(1), nil)
+				mockAuthRepo.EXPECT().SaveFailedLoginAttempt(gomock.Any(), int64(0), "203.0.113.7", nil).Return(nil)
 			},
 			expectedError:         true,
+			expectedErrorIs:       errs.ErrWrongCredentials,
 			expectedResultChecker: func(value string) bool { return value == "" },
 		},
 		{
 			name:     "wrong password",
-			email:    "test@example.com",
+			userID:   123,
 			password: "wrongpassword",
 			setupMock: func() {
 				hash, _ := encrypt.SaltAndHashPassword("11111111")
-				mockAuthRepo.EXPECT().GetUserByEmail(gomock.Any(), "test@example.com").Return(&models.UserProfile{
-					ID:           123,
-					PasswordHash: hash,
-				}, nil)
+				mockAuthRepo.EXPECT().GetLoginLockout(gomock.Any(), int64(123), "203.0.113.7").Return(time.Time{}, nil)
+				mockAuthRepo.EXPECT().GetUserPasswordHash(gomock.Any(), 123).Return(&hash, nil)
+				mockAuthRepo.EXPECT().RegisterFailedLogin(gomock.Any(), int64(123), "203.0.113.7").Return(int64(1), int64(1), nil)
+				mockAuthRepo.EXPECT().SaveFailedLoginAttempt(gomock.Any(), int64(123), "203.0.113.7", nil).Return(nil)
 			},
 			expectedError:         true,
+			expectedErrorIs:       errs.ErrWrongCredentials,
 			expectedResultChecker: func(value string) bool { return value == "" },
 		},
 		{
 			name:     "redis session registration fails",
-			email:    "test@example.com",
+			userID:   123,
 			password: "11111111",
 			setupMock: func() {
 				hash, _ := encrypt.SaltAndHashPassword("11111111")
-				mockAuthRepo.EXPECT().GetUserByEmail(gomock.Any(), "test@example.com").Return(&models.UserProfile{
-					ID:           123,
-					PasswordHash: hash,
-				}, nil)
-				mockAuthRepo.EXPECT().RegisterSessionRedis(gomock.Any(), gomock.Any(), 123).Return(errors.New("redis error"))
+				mockAuthRepo.EXPECT().GetLoginLockout(gomock.Any(), int64(123), "203.0.113.7").Return(time.Time{}, nil)
+				mockAuthRepo.EXPECT().GetUserPasswordHash(gomock.Any(), 123).Return(&hash, nil)
+				mockAuthRepo.EXPECT().GetUserTOTP(gomock.Any(), int64(123)).Return(nil, errs.ErrNotFound)
+				mockAuthRepo.EXPECT().ResetFailedLogins(gomock.Any(), int64(123)).Return(nil)
+				mockAuthRepo.EXPECT().RegisterSessionRedis(gomock.Any(), gomock.Any(), 123, "test-agent", "203.0.113.7").Return(errors.New("redis error"))
 			},
 			expectedError:         true,
 			expectedResultChecker: func(value string) bool { return value == "" },
@@ -94,109 +98,25 @@ func TestAuthUsecase_LoginUser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			encrypt.GenerateSessionID() // Mock session ID generation
-
-			tt.setupMock()
-
-			result, err := authUsecase.LoginUser(context.Background(), tt.email, tt.password)
-			assert.Equal(t, err != nil, tt.expectedError)
-			assert.Equal(t, tt.expectedResultChecker(result), true)
-		})
-	}
-}
-
-func TestAuthUsecase_RegisterUser(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
-	authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo)
-
-	tests := []struct {
-		name                  string
-		user                  *models.UserRegisterRequest
-		setupMock             func()
-		expectedError         bool
-		expectedResultChecker func(string) bool
-	}{
-		{
-			name: "successful registration",
-			user: &models.UserRegisterRequest{
-				Name:     "Test User",
-				Email:    "test@example.com",
-				Password: "11111111",
-			},
-			setupMock: func() {
-				mockAuthRepo.EXPECT().CheckUniqueCredentials(gomock.Any(), "Test User", "test@example.com").Return(nil)
-				mockAuthRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.UserProfile{ID: 1}, nil)
-				mockAuthRepo.EXPECT().RegisterSessionRedis(gomock.Any(), gomock.Any(), 1).Return(nil)
-			},
-			expectedError:         false,
-			expectedResultChecker: isStringLengthMoreThan10,
-		},
-		{
-			name: "non-unique credentials",
-			user: &models.UserRegisterRequest{
-				Name:     "Test User",
-				Email:    "test@example.com",
-				Password: "11111111",
-			},
-			setupMock: func() {
-				mockAuthRepo.EXPECT().CheckUniqueCredentials(gomock.Any(), "Test User", "test@example.com").Return(errors.New("credentials not unique"))
-			},
-			expectedError:         true,
-			expectedResultChecker: func(value string) bool { return value == "" },
-		},
-		{
-			name: "failed to create user",
-			user: &models.UserRegisterRequest{
-				Name:     "Test User",
-				Email:    "test@example.com",
-				Password: "11111111",
-			},
-			setupMock: func() {
-				mockAuthRepo.EXPECT().CheckUniqueCredentials(gomock.Any(), "Test User", "test@example.com").Return(nil)
-				mockAuthRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("create user error"))
-			},
-			expectedError:         true,
-			expectedResultChecker: func(value string) bool { return value == "" },
-		},
-		{
-			name: "failed to register session",
-			user: &models.UserRegisterRequest{
-				Name:     "Test User",
-				Email:    "test@example.com",
-				Password: "11111111",
-			},
-			setupMock: func() {
-				mockAuthRepo.EXPECT().CheckUniqueCredentials(gomock.Any(), "Test User", "test@example.com").Return(nil)
-				mockAuthRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.UserProfile{ID: 1}, nil)
-				mockAuthRepo.EXPECT().RegisterSessionRedis(gomock.Any(), gomock.Any(), 1).Return(errors.New("session registration error"))
-			},
-			expectedError:         true,
-			expectedResultChecker: func(value string) bool { return value == "" },
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			encrypt.GenerateSessionID()
-
 			tt.setupMock()
 
-			result, err := authUsecase.RegisterUser(context.Background(), tt.user)
+			result, twoFactorRequired, err := authUsecase.CreateSession(context.Background(), tt.userID, tt.password, "203.0.113.7", "test-agent")
 			assert.Equal(t, err != nil, tt.expectedError)
+			if tt.expectedErrorIs != nil {
+				assert.ErrorIs(t, err, tt.expectedErrorIs)
+			}
+			assert.False(t, twoFactorRequired)
 			assert.Equal(t, tt.expectedResultChecker(result), true)
 		})
 	}
 }
 
-func TestAuthUsecase_LogoutUser(t *testing.T) {
+func TestAuthUsecase_KillSession(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
-	authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo)
+	authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo, nil, "")
 
 	tests := []struct {
 		name          string
@@ -226,7 +146,7 @@ func TestAuthUsecase_LogoutUser(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			err := authUsecase.LogoutUser(context.Background(), tt.sessionID)
+			err := authUsecase.KillSession(context.Background(), tt.sessionID)
 			assert.Equal(t, err != nil, tt.expectedError)
 		})
 	}
@@ -0,0 +1,63 @@
+package usecase_test
+
+import (
+	"RPO_back/internal/errs"
+	mocks "RPO_back/internal/pkg/auth/mocks"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"context"
+	"testing"
+	"time"
+
+	AuthUsecase "RPO_back/internal/pkg/auth/usecase"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthUsecase_CheckPassword(t *testing.T) {
+	passwordHash, err := encrypt.SaltAndHashPassword("correct horse")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		password     string
+		passwordHash *string
+		loggedInAgo  time.Duration // < 0 - время входа сессии неизвестно
+		expectedErr  error
+	}{
+		{name: "correct password", password: "correct horse", passwordHash: &passwordHash},
+		{name: "wrong password", password: "battery staple", passwordHash: &passwordHash, expectedErr: errs.ErrWrongCredentials},
+		{name: "sso user logged in recently", loggedInAgo: time.Minute},
+		{name: "sso user logged in long ago", loggedInAgo: time.Hour, expectedErr: errs.ErrWrongCredentials},
+		{name: "sso user with unknown login time", loggedInAgo: -1, expectedErr: errs.ErrWrongCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
+			authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo, nil, "")
+
+			mockAuthRepo.EXPECT().CheckSession(gomock.Any(), "session").Return(42, nil)
+			mockAuthRepo.EXPECT().GetUserPasswordHash(gomock.Any(), 42).Return(tt.passwordHash, nil)
+			if tt.passwordHash == nil {
+				var createdAt time.Time
+				if tt.loggedInAgo >= 0 {
+					createdAt = time.Now().Add(-tt.loggedInAgo)
+				}
+				mockAuthRepo.EXPECT().GetSessionCreatedAt(gomock.Any(), "session").Return(createdAt, nil)
+			}
+
+			userID, err := authUsecase.CheckPassword(context.Background(), "session", tt.password)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 42, userID)
+		})
+	}
+}
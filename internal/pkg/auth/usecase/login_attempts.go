@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	userFreeLoginAttempts = 5  // Столько неудачных входов в аккаунт подряд проходят без блокировки
+	ipFreeLoginAttempts   = 20 // Столько неудачных входов с одного IP-адреса проходят без блокировки
+	baseLoginLockout      = 30 * time.Second
+	maxLoginLockout       = time.Hour
+)
+
+// loginLockoutDuration возвращает длительность блокировки после failures неудачных попыток.
+// Каждая следующая попытка сверх бесплатных удваивает блокировку, но не больше maxLoginLockout
+func loginLockoutDuration(failures int64, freeAttempts int64) time.Duration {
+	if failures < freeAttempts {
+		return 0
+	}
+	lockout := baseLoginLockout
+	for i := freeAttempts; i < failures && lockout < maxLoginLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, maxLoginLockout)
+}
+
+// registerFailedLogin учитывает неудачную попытку входа, при необходимости блокирует вход
+// и пишет попытку в журнал. Возвращает ошибку, которую нужно отдать клиенту
+func (uc *AuthUsecase) registerFailedLogin(ctx context.Context, userID int64, clientIP string) error {
+	userFailures, ipFailures, err := uc.authRepo.RegisterFailedLogin(ctx, userID, clientIP)
+	if err != nil {
+		return fmt.Errorf("registerFailedLogin (RegisterFailedLogin): %w", err)
+	}
+
+	now := time.Now()
+	var userUntil, ipUntil, lockedUntil time.Time
+	if lockout := loginLockoutDuration(userFailures, userFreeLoginAttempts); lockout > 0 {
+		userUntil = now.Add(lockout)
+		lockedUntil = userUntil
+	}
+	if lockout := loginLockoutDuration(ipFailures, ipFreeLoginAttempts); lockout > 0 {
+		ipUntil = now.Add(lockout)
+		if ipUntil.After(lockedUntil) {
+			lockedUntil = ipUntil
+		}
+	}
+
+	var auditLockedUntil *time.Time
+	if !lockedUntil.IsZero() {
+		err = uc.authRepo.SetLoginLockout(ctx, userID, clientIP, userUntil, ipUntil)
+		if err != nil {
+			return fmt.Errorf("registerFailedLogin (SetLoginLockout): %w", err)
+		}
+		auditLockedUntil = &lockedUntil
+	}
+
+	err = uc.authRepo.SaveFailedLoginAttempt(ctx, userID, clientIP, auditLockedUntil)
+	if err != nil {
+		logging.Warn(ctx, "registerFailedLogin (SaveFailedLoginAttempt): ", err)
+	}
+
+	if auditLockedUntil != nil {
+		logging.Warn(ctx, "login locked for user ", userID, " from ip ", clientIP, " until ", lockedUntil)
+		return &errs.LockoutError{Until: lockedUntil}
+	}
+	return errs.ErrWrongCredentials
+}
+
+// CheckLoginLockout проверяет, не заблокирован ли вход с IP-адреса. Нужна до создания аккаунта:
+// регистрация сразу открывает сессию, и без проверки заблокированный клиент оставлял бы пользователей без сессии
+func (uc *AuthUsecase) CheckLoginLockout(ctx context.Context, clientIP string) (err error) {
+	lockedUntil, err := uc.authRepo.GetLoginLockout(ctx, 0, clientIP)
+	if err != nil {
+		return fmt.Errorf("CheckLoginLockout (GetLoginLockout): %w", err)
+	}
+	if time.Now().Before(lockedUntil) {
+		return fmt.Errorf("CheckLoginLockout: %w", &errs.LockoutError{Until: lockedUntil})
+	}
+	return nil
+}
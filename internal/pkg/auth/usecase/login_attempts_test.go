@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"RPO_back/internal/errs"
+	mocks "RPO_back/internal/pkg/auth/mocks"
+	AuthUsecase "RPO_back/internal/pkg/auth/usecase"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthUsecase_LoginLockoutBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
+	authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo, nil, "")
+	clientIP := "203.0.113.7"
+	hash, err := encrypt.SaltAndHashPassword("11111111")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		userFailures int64
+		ipFailures   int64
+		lockout      time.Duration // 0 - блокировки нет
+		userLocked   bool
+		ipLocked     bool
+	}{
+		{name: "free attempts", userFailures: 4, ipFailures: 4},
+		{name: "first user lockout", userFailures: 5, ipFailures: 5, lockout: 30 * time.Second, userLocked: true},
+		{name: "lockout doubles", userFailures: 7, ipFailures: 7, lockout: 2 * time.Minute, userLocked: true},
+		{name: "lockout is capped", userFailures: 40, ipFailures: 40, lockout: time.Hour, userLocked: true, ipLocked: true},
+		{name: "ip lockout", userFailures: 1, ipFailures: 21, lockout: time.Minute, ipLocked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthRepo.EXPECT().GetLoginLockout(gomock.Any(), int64(123), clientIP).Return(time.Time{}, nil)
+			mockAuthRepo.EXPECT().GetUserPasswordHash(gomock.Any(), 123).Return(&hash, nil)
+			mockAuthRepo.EXPECT().RegisterFailedLogin(gomock.Any(), int64(123), clientIP).Return(tt.userFailures, tt.ipFailures, nil)
+			if tt.lockout > 0 {
+				mockAuthRepo.EXPECT().SetLoginLockout(gomock.Any(), int64(123), clientIP, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userID int64, clientIP string, userUntil time.Time, ipUntil time.Time) error {
+						assert.Equal(t, tt.userLocked, !userUntil.IsZero())
+						assert.Equal(t, tt.ipLocked, !ipUntil.IsZero())
+						return nil
+					})
+				mockAuthRepo.EXPECT().SaveFailedLoginAttempt(gomock.Any(), int64(123), clientIP, gomock.Not(gomock.Nil())).Return(nil)
+			} else {
+				mockAuthRepo.EXPECT().SaveFailedLoginAttempt(gomock.Any(), int64(123), clientIP, nil).Return(nil)
+			}
+
+			before := time.Now()
+			_, _, err := authUsecase.CreateSession(context.Background(), 123, "wrongpassword", clientIP, "test-agent")
+
+			var lockoutErr *errs.LockoutError
+			if tt.lockout == 0 {
+				assert.ErrorIs(t, err, errs.ErrWrongCredentials)
+				assert.False(t, errors.As(err, &lockoutErr))
+				return
+			}
+			require.True(t, errors.As(err, &lockoutErr))
+			assert.ErrorIs(t, err, errs.ErrTooManyAttempts)
+			assert.WithinDuration(t, before.Add(tt.lockout), lockoutErr.Until, time.Second)
+		})
+	}
+}
+
+func TestAuthUsecase_LoginWhileLockedOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
+	authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo, nil, "")
+	lockedUntil := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	// Пока вход заблокирован, даже верный пароль не проверяется и попытка не считается
+	mockAuthRepo.EXPECT().GetLoginLockout(gomock.Any(), int64(123), "203.0.113.7").Return(lockedUntil, nil)
+
+	_, _, err := authUsecase.CreateSession(context.Background(), 123, "11111111", "203.0.113.7", "test-agent")
+	var lockoutErr *errs.LockoutError
+	require.True(t, errors.As(err, &lockoutErr))
+	assert.Equal(t, lockedUntil, lockoutErr.Until)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/mailer"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	passwordResetTokenLifeTime = time.Hour // Сколько действует ссылка на сброс пароля
+	passwordResetsPerEmail     = 3         // Столько писем о сбросе можно запросить на один email за час
+	passwordResetsPerIP        = 10        // Столько запросов сброса можно отправить с одного IP-адреса за час
+)
+
+// RequestPasswordReset создаёт одноразовый токен сброса пароля и отправляет его на почту.
+// Для неизвестного email ничего не происходит, но ответ тот же, чтобы нельзя было проверить,
+// зарегистрирован ли email. Запросы ограничены по email и по IP-адресу, лимит считается
+// и для неизвестных email
+func (uc *AuthUsecase) RequestPasswordReset(ctx context.Context, email string, clientIP string) (err error) {
+	emailRequests, ipRequests, err := uc.authRepo.CountPasswordResetRequest(ctx, strings.ToLower(email), clientIP)
+	if err != nil {
+		return fmt.Errorf("RequestPasswordReset (CountPasswordResetRequest): %w", err)
+	}
+	if emailRequests > passwordResetsPerEmail || ipRequests > passwordResetsPerIP {
+		logging.Warn(ctx, "RequestPasswordReset: rate limit exceeded for ip ", clientIP)
+		return fmt.Errorf("RequestPasswordReset: %w", errs.ErrTooManyRequests)
+	}
+
+	userID, err := uc.authRepo.GetUserIDByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			logging.Info(ctx, "RequestPasswordReset: no user with requested email")
+			return nil
+		}
+		return fmt.Errorf("RequestPasswordReset (GetUserIDByEmail): %w", err)
+	}
+
+	token := encrypt.GenerateSessionID()
+	err = uc.authRepo.CreatePasswordResetToken(ctx, userID, encrypt.HashToken(token), time.Now().Add(passwordResetTokenLifeTime))
+	if err != nil {
+		return fmt.Errorf("RequestPasswordReset (CreatePasswordResetToken): %w", err)
+	}
+
+	// Письмо уходит в фоне: время ответа не должно зависеть от того, существует ли пользователь
+	msg := uc.passwordResetMessage(email, token)
+	go func() {
+		if err := uc.mailer.Send(context.Background(), msg); err != nil {
+			logging.Error(ctx, "RequestPasswordReset (Send): ", err)
+		}
+	}()
+
+	return nil
+}
+
+// ConfirmPasswordReset задаёт новый пароль по токену из письма и завершает все сессии пользователя
+func (uc *AuthUsecase) ConfirmPasswordReset(ctx context.Context, token string, newPassword string) (err error) {
+	userID, err := uc.authRepo.UsePasswordResetToken(ctx, encrypt.HashToken(token))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("ConfirmPasswordReset (UsePasswordResetToken): %w", errs.ErrWrongCredentials)
+		}
+		return fmt.Errorf("ConfirmPasswordReset (UsePasswordResetToken): %w", err)
+	}
+
+	newPasswordHash, err := encrypt.SaltAndHashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("ConfirmPasswordReset (SaltAndHashPassword): %w", err)
+	}
+
+	err = uc.authRepo.SetNewPasswordHash(ctx, int(userID), newPasswordHash)
+	if err != nil {
+		return fmt.Errorf("ConfirmPasswordReset (SetNewPasswordHash): %w", err)
+	}
+
+	// Пустой sessionID не совпадает ни с одной сессией, поэтому удаляются все
+	err = uc.authRepo.DisplaceUserSessions(ctx, "", userID)
+	if err != nil {
+		return fmt.Errorf("ConfirmPasswordReset (DisplaceUserSessions): %w", err)
+	}
+
+	err = uc.authRepo.ResetFailedLogins(ctx, userID)
+	if err != nil {
+		logging.Warn(ctx, "ConfirmPasswordReset (ResetFailedLogins): ", err)
+	}
+
+	return nil
+}
+
+// passwordResetMessage собирает письмо со ссылкой на сброс пароля
+func (uc *AuthUsecase) passwordResetMessage(email string, token string) mailer.Message {
+	link := token
+	if uc.passwordResetURL != "" {
+		link = uc.passwordResetURL + "?token=" + url.QueryEscape(token)
+	}
+
+	return mailer.Message{
+		To:      email,
+		Subject: "Восстановление пароля",
+		Body: fmt.Sprintf("Здравствуйте!\n\n"+
+			"Кто-то запросил сброс пароля для вашего аккаунта. Чтобы задать новый пароль, перейдите по ссылке:\n%s\n\n"+
+			"Ссылка действует %d минут и сработает только один раз. "+
+			"Если вы не запрашивали сброс пароля, просто проигнорируйте это письмо.\n",
+			link, int(passwordResetTokenLifeTime.Minutes())),
+	}
+}
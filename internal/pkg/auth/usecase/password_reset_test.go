@@ -0,0 +1,49 @@
+package usecase_test
+
+import (
+	"RPO_back/internal/errs"
+	mocks "RPO_back/internal/pkg/auth/mocks"
+	"context"
+	"testing"
+
+	AuthUsecase "RPO_back/internal/pkg/auth/usecase"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthUsecase_RequestPasswordResetRateLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		emailRequests int64
+		ipRequests    int64
+		expectLookup  bool
+	}{
+		{name: "within limits", emailRequests: 3, ipRequests: 10, expectLookup: true},
+		{name: "too many for email", emailRequests: 4, ipRequests: 1},
+		{name: "too many from ip", emailRequests: 1, ipRequests: 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
+			authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo, nil, "")
+
+			mockAuthRepo.EXPECT().CountPasswordResetRequest(gomock.Any(), "user@example.com", "203.0.113.7").
+				Return(tt.emailRequests, tt.ipRequests, nil)
+			if tt.expectLookup {
+				mockAuthRepo.EXPECT().GetUserIDByEmail(gomock.Any(), "User@Example.com").Return(int64(0), errs.ErrNotFound)
+			}
+
+			err := authUsecase.RequestPasswordReset(context.Background(), "User@Example.com", "203.0.113.7")
+			if tt.expectLookup {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, errs.ErrTooManyRequests)
+			}
+		})
+	}
+}
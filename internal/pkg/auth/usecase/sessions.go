@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	publicSessionIDLength = 32  // Длина публичного ID сессии (префикс хеша от ID сессии)
+	maxUserAgentLength    = 512 // User-Agent длиннее этого обрезается
+)
+
+// ListSessions возвращает действующие сессии пользователя текущей сессии, начиная с последней активной.
+// Вместо самих ID сессий (они же значения cookie) отдаются публичные ID
+func (uc *AuthUsecase) ListSessions(ctx context.Context, sessionID string) (sessions []models.SessionInfo, err error) {
+	userID, err := uc.authRepo.CheckSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("ListSessions (CheckSession): %w", err)
+	}
+
+	sessions, err = uc.authRepo.GetUserSessions(ctx, int64(userID))
+	if err != nil {
+		return nil, fmt.Errorf("ListSessions (GetUserSessions): %w", err)
+	}
+
+	for i := range sessions {
+		sessions[i].ID = publicSessionID(sessions[i].SessionID)
+		sessions[i].Current = sessions[i].SessionID == sessionID
+		sessions[i].SessionID = ""
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession завершает сессию пользователя по её публичному ID.
+// Если у пользователя текущей сессии нет такой сессии - errs.ErrNotFound
+func (uc *AuthUsecase) RevokeSession(ctx context.Context, sessionID string, targetID string) (err error) {
+	userID, err := uc.authRepo.CheckSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("RevokeSession (CheckSession): %w", errs.ErrWrongCredentials)
+		}
+		return fmt.Errorf("RevokeSession (CheckSession): %w", err)
+	}
+
+	sessions, err := uc.authRepo.GetUserSessions(ctx, int64(userID))
+	if err != nil {
+		return fmt.Errorf("RevokeSession (GetUserSessions): %w", err)
+	}
+
+	for _, session := range sessions {
+		if publicSessionID(session.SessionID) == targetID {
+			err = uc.authRepo.KillSessionRedis(ctx, session.SessionID)
+			if err != nil {
+				return fmt.Errorf("RevokeSession (KillSessionRedis): %w", err)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("RevokeSession: %w", errs.ErrNotFound)
+}
+
+// RevokeOtherSessions завершает все сессии пользователя, кроме текущей
+func (uc *AuthUsecase) RevokeOtherSessions(ctx context.Context, sessionID string) (err error) {
+	userID, err := uc.authRepo.CheckSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("RevokeOtherSessions (CheckSession): %w", err)
+	}
+
+	err = uc.authRepo.DisplaceUserSessions(ctx, sessionID, int64(userID))
+	if err != nil {
+		return fmt.Errorf("RevokeOtherSessions (DisplaceUserSessions): %w", err)
+	}
+
+	return nil
+}
+
+// publicSessionID получает из ID сессии публичный ID, по которому нельзя восстановить саму сессию
+func publicSessionID(sessionID string) string {
+	return encrypt.HashToken(sessionID)[:publicSessionIDLength]
+}
+
+// truncateUserAgent обрезает User-Agent, чтобы клиент не мог раздуть данные сессии
+func truncateUserAgent(userAgent string) string {
+	if len(userAgent) > maxUserAgentLength {
+		return userAgent[:maxUserAgentLength]
+	}
+	return userAgent
+}
@@ -0,0 +1,86 @@
+package usecase_test
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	mocks "RPO_back/internal/pkg/auth/mocks"
+	AuthUsecase "RPO_back/internal/pkg/auth/usecase"
+	"context"
+	"testing"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthUsecase_RevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
+	authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo, nil, "")
+	ctx := context.Background()
+	now := time.Now()
+	userSessions := func() []models.SessionInfo {
+		return []models.SessionInfo{
+			{SessionID: "current-session", LastSeenAt: now},
+			{SessionID: "phone-session", LastSeenAt: now.Add(-time.Hour)},
+		}
+	}
+
+	mockAuthRepo.EXPECT().CheckSession(gomock.Any(), "current-session").Return(7, nil).AnyTimes()
+	mockAuthRepo.EXPECT().GetUserSessions(gomock.Any(), int64(7)).DoAndReturn(
+		func(ctx context.Context, userID int64) ([]models.SessionInfo, error) {
+			return userSessions(), nil
+		}).AnyTimes()
+
+	// Наружу отдаются только публичные ID, сами ID сессий не раскрываются
+	sessions, err := authUsecase.ListSessions(ctx, "current-session")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.True(t, sessions[0].Current)
+	for _, session := range sessions {
+		assert.Empty(t, session.SessionID)
+		assert.NotContains(t, session.ID, "session")
+	}
+
+	t.Run("revoke by public id", func(t *testing.T) {
+		mockAuthRepo.EXPECT().KillSessionRedis(gomock.Any(), "phone-session").Return(nil)
+		assert.NoError(t, authUsecase.RevokeSession(ctx, "current-session", sessions[1].ID))
+	})
+
+	t.Run("raw session id is not accepted", func(t *testing.T) {
+		err := authUsecase.RevokeSession(ctx, "current-session", "phone-session")
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("foreign session", func(t *testing.T) {
+		mockAuthRepo.EXPECT().CheckSession(gomock.Any(), "other-user-session").Return(8, nil)
+		mockAuthRepo.EXPECT().GetUserSessions(gomock.Any(), int64(8)).Return([]models.SessionInfo{{SessionID: "other-phone"}}, nil)
+
+		err := authUsecase.RevokeSession(ctx, "other-user-session", sessions[1].ID)
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("expired current session", func(t *testing.T) {
+		mockAuthRepo.EXPECT().CheckSession(gomock.Any(), "expired-session").Return(0, errs.ErrNotFound)
+
+		err := authUsecase.RevokeSession(ctx, "expired-session", sessions[1].ID)
+		assert.ErrorIs(t, err, errs.ErrWrongCredentials)
+	})
+}
+
+func TestAuthUsecase_RevokeOtherSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
+	authUsecase := AuthUsecase.CreateAuthUsecase(mockAuthRepo, nil, "")
+
+	// Текущая сессия передаётся в репозиторий, чтобы она одна и осталась
+	mockAuthRepo.EXPECT().CheckSession(gomock.Any(), "current-session").Return(7, nil)
+	mockAuthRepo.EXPECT().DisplaceUserSessions(gomock.Any(), "current-session", int64(7)).Return(nil)
+
+	assert.NoError(t, authUsecase.RevokeOtherSessions(context.Background(), "current-session"))
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/totp"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	totpIssuer           = "Pumpkin"
+	totpSkew             = 1  // Допуск в интервалах на расхождение часов телефона и сервера
+	recoveryCodesCount   = 10 // Столько кодов восстановления выдаётся при подключении TOTP
+	maxTwoFactorAttempts = 5  // Столько попыток ввести код даётся на один вход по паролю
+	totpCodeLength       = totp.Digits
+)
+
+// VerifyTwoFactor завершает вход: проверяет код второго фактора для сессии, созданной CreateSession
+// после верного пароля, и создаёт полноценную сессию. Принимается код из приложения или код восстановления.
+// Неверные коды считаются неудачными попытками входа и приводят к блокировке так же, как неверный пароль
+func (uc *AuthUsecase) VerifyTwoFactor(ctx context.Context, partialSessionID string, code string, clientIP string, userAgent string) (sessionID string, err error) {
+	userID, attempt, err := uc.authRepo.CheckPartialSession(ctx, partialSessionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return "", fmt.Errorf("VerifyTwoFactor (CheckPartialSession): %w", errs.ErrWrongCredentials)
+		}
+		return "", fmt.Errorf("VerifyTwoFactor (CheckPartialSession): %w", err)
+	}
+
+	if attempt > maxTwoFactorAttempts {
+		err = uc.authRepo.KillPartialSession(ctx, partialSessionID)
+		if err != nil {
+			logging.Warn(ctx, "VerifyTwoFactor (KillPartialSession): ", err)
+		}
+		return "", fmt.Errorf("VerifyTwoFactor: attempts exceeded: %w", errs.ErrWrongCredentials)
+	}
+
+	lockedUntil, err := uc.authRepo.GetLoginLockout(ctx, userID, clientIP)
+	if err != nil {
+		return "", fmt.Errorf("VerifyTwoFactor (GetLoginLockout): %w", err)
+	}
+	if time.Now().Before(lockedUntil) {
+		return "", fmt.Errorf("VerifyTwoFactor (GetLoginLockout): %w", &errs.LockoutError{Until: lockedUntil})
+	}
+
+	ok, err := uc.checkSecondFactor(ctx, userID, code)
+	if err != nil {
+		return "", fmt.Errorf("VerifyTwoFactor (checkSecondFactor): %w", err)
+	}
+	if !ok {
+		return "", fmt.Errorf("VerifyTwoFactor: wrong code: %w", uc.registerFailedLogin(ctx, userID, clientIP))
+	}
+
+	err = uc.authRepo.KillPartialSession(ctx, partialSessionID)
+	if err != nil {
+		return "", fmt.Errorf("VerifyTwoFactor (KillPartialSession): %w", err)
+	}
+
+	err = uc.authRepo.ResetFailedLogins(ctx, userID)
+	if err != nil {
+		logging.Warn(ctx, "VerifyTwoFactor (ResetFailedLogins): ", err)
+	}
+
+	sessionID, err = uc.registerSession(ctx, userID, clientIP, userAgent)
+	if err != nil {
+		return "", fmt.Errorf("VerifyTwoFactor (registerSession): %w", err)
+	}
+
+	return sessionID, nil
+}
+
+// BeginTOTPEnrollment создаёт новый секрет TOTP для пользователя сессии. TOTP включится только
+// после подтверждения кодом (ConfirmTOTPEnrollment). Если TOTP уже включён - errs.ErrAlreadyExists
+func (uc *AuthUsecase) BeginTOTPEnrollment(ctx context.Context, sessionID string) (secret string, provisioningURI string, err error) {
+	userID, err := uc.authRepo.CheckSession(ctx, sessionID)
+	if err != nil {
+		return "", "", fmt.Errorf("BeginTOTPEnrollment (CheckSession): %w", err)
+	}
+
+	email, err := uc.authRepo.GetUserEmail(ctx, int64(userID))
+	if err != nil {
+		return "", "", fmt.Errorf("BeginTOTPEnrollment (GetUserEmail): %w", err)
+	}
+
+	secret, err = totp.GenerateSecret()
+	if err != nil {
+		return "", "", fmt.Errorf("BeginTOTPEnrollment (GenerateSecret): %w", err)
+	}
+
+	err = uc.authRepo.SaveTOTPSecret(ctx, int64(userID), secret)
+	if err != nil {
+		return "", "", fmt.Errorf("BeginTOTPEnrollment (SaveTOTPSecret): %w", err)
+	}
+
+	return secret, totp.ProvisioningURI(totpIssuer, email, secret), nil
+}
+
+// ConfirmTOTPEnrollment включает TOTP после проверки первого кода из приложения
+// и возвращает одноразовые коды восстановления (в открытом виде они больше нигде не хранятся)
+func (uc *AuthUsecase) ConfirmTOTPEnrollment(ctx context.Context, sessionID string, code string) (recoveryCodes []string, err error) {
+	userID, err := uc.authRepo.CheckSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("ConfirmTOTPEnrollment (CheckSession): %w", err)
+	}
+
+	settings, err := uc.authRepo.GetUserTOTP(ctx, int64(userID))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, fmt.Errorf("ConfirmTOTPEnrollment (GetUserTOTP): enrollment not started: %w", errs.ErrBadRequest)
+		}
+		return nil, fmt.Errorf("ConfirmTOTPEnrollment (GetUserTOTP): %w", err)
+	}
+	if settings.EnabledAt != nil {
+		return nil, fmt.Errorf("ConfirmTOTPEnrollment: %w", errs.ErrAlreadyExists)
+	}
+
+	step, ok := totp.Validate(settings.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, fmt.Errorf("ConfirmTOTPEnrollment (Validate): wrong code: %w", errs.ErrBadRequest)
+	}
+
+	recoveryCodes, err = totp.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return nil, fmt.Errorf("ConfirmTOTPEnrollment (GenerateRecoveryCodes): %w", err)
+	}
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, recoveryCode := range recoveryCodes {
+		hashes = append(hashes, encrypt.HashToken(recoveryCode))
+	}
+
+	err = uc.authRepo.EnableTOTP(ctx, int64(userID), step, hashes)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, fmt.Errorf("ConfirmTOTPEnrollment (EnableTOTP): %w", errs.ErrBadRequest)
+		}
+		return nil, fmt.Errorf("ConfirmTOTPEnrollment (EnableTOTP): %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+// DisableTOTP отключает TOTP после проверки кода из приложения или кода восстановления.
+// Неподтверждённое подключение отменяется без кода
+func (uc *AuthUsecase) DisableTOTP(ctx context.Context, sessionID string, code string) (err error) {
+	userID, err := uc.authRepo.CheckSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("DisableTOTP (CheckSession): %w", err)
+	}
+
+	settings, err := uc.authRepo.GetUserTOTP(ctx, int64(userID))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("DisableTOTP (GetUserTOTP): totp is not enabled: %w", errs.ErrBadRequest)
+		}
+		return fmt.Errorf("DisableTOTP (GetUserTOTP): %w", err)
+	}
+
+	if settings.EnabledAt != nil {
+		lockedUntil, err := uc.authRepo.GetLoginLockout(ctx, int64(userID), "")
+		if err != nil {
+			return fmt.Errorf("DisableTOTP (GetLoginLockout): %w", err)
+		}
+		if time.Now().Before(lockedUntil) {
+			return fmt.Errorf("DisableTOTP (GetLoginLockout): %w", &errs.LockoutError{Until: lockedUntil})
+		}
+
+		ok, err := uc.checkSecondFactor(ctx, int64(userID), code)
+		if err != nil {
+			return fmt.Errorf("DisableTOTP (checkSecondFactor): %w", err)
+		}
+		if !ok {
+			// Подбор кода по украденной сессии ограничивается так же, как подбор при входе
+			err = uc.registerFailedLogin(ctx, int64(userID), "")
+			if errors.Is(err, errs.ErrWrongCredentials) {
+				err = errs.ErrBadRequest
+			}
+			return fmt.Errorf("DisableTOTP: wrong code: %w", err)
+		}
+	}
+
+	err = uc.authRepo.DeleteTOTP(ctx, int64(userID))
+	if err != nil {
+		return fmt.Errorf("DisableTOTP (DeleteTOTP): %w", err)
+	}
+
+	return nil
+}
+
+// twoFactorEnabled проверяет, нужен ли пользователю второй фактор при входе
+func (uc *AuthUsecase) twoFactorEnabled(ctx context.Context, userID int64) (bool, error) {
+	settings, err := uc.authRepo.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("twoFactorEnabled (GetUserTOTP): %w", err)
+	}
+	return settings.EnabledAt != nil, nil
+}
+
+// checkSecondFactor проверяет код из приложения или код восстановления и сразу погашает его,
+// чтобы один и тот же код нельзя было использовать дважды
+func (uc *AuthUsecase) checkSecondFactor(ctx context.Context, userID int64, code string) (bool, error) {
+	if len(code) == totpCodeLength {
+		settings, err := uc.authRepo.GetUserTOTP(ctx, userID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("checkSecondFactor (GetUserTOTP): %w", err)
+		}
+		if settings.EnabledAt == nil {
+			return false, nil
+		}
+
+		step, ok := totp.Validate(settings.Secret, code, time.Now(), totpSkew)
+		if !ok {
+			return false, nil
+		}
+
+		err = uc.authRepo.UseTOTPStep(ctx, userID, step)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				logging.Warn(ctx, "checkSecondFactor: totp code reuse for user ", userID)
+				return false, nil
+			}
+			return false, fmt.Errorf("checkSecondFactor (UseTOTPStep): %w", err)
+		}
+		return true, nil
+	}
+
+	err := uc.authRepo.UseRecoveryCode(ctx, userID, encrypt.HashToken(totp.NormalizeRecoveryCode(code)))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("checkSecondFactor (UseRecoveryCode): %w", err)
+	}
+	logging.Info(ctx, "checkSecondFactor: recovery code used by user ", userID)
+	return true, nil
+}
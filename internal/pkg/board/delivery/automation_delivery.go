@@ -0,0 +1,118 @@
+package delivery
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetAutomationRules возвращает правила автоматизации доски
+func (d *BoardDelivery) GetAutomationRules(w http.ResponseWriter, r *http.Request) {
+	funcName := "GetAutomationRules"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	boardID, err := requests.GetIDFromRequest(r, "boardID", "board_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	rules, err := d.boardUsecase.GetAutomationRules(r.Context(), userID, boardID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, rules, http.StatusOK)
+}
+
+// CreateAutomationRule создаёт правило автоматизации на доске
+func (d *BoardDelivery) CreateAutomationRule(w http.ResponseWriter, r *http.Request) {
+	funcName := "CreateAutomationRule"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	boardID, err := requests.GetIDFromRequest(r, "boardID", "board_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	data := &models.AutomationRuleRequest{}
+	err = requests.GetRequestData(r, data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
+		return
+	}
+
+	newRule, err := d.boardUsecase.CreateAutomationRule(r.Context(), userID, boardID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, newRule, http.StatusCreated)
+}
+
+// UpdateAutomationRule заменяет правило автоматизации
+func (d *BoardDelivery) UpdateAutomationRule(w http.ResponseWriter, r *http.Request) {
+	funcName := "UpdateAutomationRule"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	ruleID, err := requests.GetIDFromRequest(r, "ruleID", "rule_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	data := &models.AutomationRuleRequest{}
+	err = requests.GetRequestData(r, data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
+		return
+	}
+
+	updatedRule, err := d.boardUsecase.UpdateAutomationRule(r.Context(), userID, ruleID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, updatedRule, http.StatusOK)
+}
+
+// DeleteAutomationRule удаляет правило автоматизации
+func (d *BoardDelivery) DeleteAutomationRule(w http.ResponseWriter, r *http.Request) {
+	funcName := "DeleteAutomationRule"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	ruleID, err := requests.GetIDFromRequest(r, "ruleID", "rule_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	err = d.boardUsecase.DeleteAutomationRule(r.Context(), userID, ruleID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
@@ -424,9 +424,9 @@ func (d *BoardDelivery) SetBoardBackground(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	file, err := uploads.FormFile(r)
+	file, err := uploads.ReceiveFile(w, r, uploads.LimitsFor(uploads.PurposeBackground))
 	if err != nil {
-		responses.DoBadResponse(w, http.StatusBadRequest, "no file found")
+		responses.ResponseErrorAndLog(w, err, funcName)
 		return
 	}
 
@@ -597,7 +597,8 @@ func (d *BoardDelivery) AddCheckListField(w http.ResponseWriter, r *http.Request
 	data := &models.CheckListFieldPostRequest{}
 	err = requests.GetRequestData(r, data)
 	if err != nil {
-		responses.DoBadResponse(w, 404, "bad request")
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
 	}
 
 	cd, err := d.boardUsecase.AddCheckListField(r.Context(), userID, cardID, data)
@@ -626,7 +627,8 @@ func (d *BoardDelivery) UpdateCheckListField(w http.ResponseWriter, r *http.Requ
 	data := &models.CheckListFieldPatchRequest{}
 	err = requests.GetRequestData(r, data)
 	if err != nil {
-		responses.DoBadResponse(w, 404, "bad request")
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
 	}
 
 	cd, err := d.boardUsecase.UpdateCheckListField(r.Context(), userID, fieldID, data)
@@ -661,6 +663,119 @@ func (d *BoardDelivery) DeleteCheckListField(w http.ResponseWriter, r *http.Requ
 	responses.DoEmptyOkResponse(w)
 }
 
+// AddCheckList создаёт на карточке новый именованный чеклист
+func (d *BoardDelivery) AddCheckList(w http.ResponseWriter, r *http.Request) {
+	funcName := "AddCheckList"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	cardID, err := requests.GetIDFromRequest(r, "cardID", "card_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	data := &models.CheckListRequest{}
+	err = requests.GetRequestData(r, data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	newCheckList, err := d.boardUsecase.AddCheckList(r.Context(), userID, cardID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, newCheckList, http.StatusCreated)
+}
+
+// UpdateCheckList переименовывает чеклист
+func (d *BoardDelivery) UpdateCheckList(w http.ResponseWriter, r *http.Request) {
+	funcName := "UpdateCheckList"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	checkListID, err := requests.GetIDFromRequest(r, "checkListID", "checklist_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	data := &models.CheckListRequest{}
+	err = requests.GetRequestData(r, data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	updatedCheckList, err := d.boardUsecase.UpdateCheckList(r.Context(), userID, checkListID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, updatedCheckList, http.StatusOK)
+}
+
+// DeleteCheckList удаляет чеклист со всеми его строками
+func (d *BoardDelivery) DeleteCheckList(w http.ResponseWriter, r *http.Request) {
+	funcName := "DeleteCheckList"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	checkListID, err := requests.GetIDFromRequest(r, "checkListID", "checklist_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	err = d.boardUsecase.DeleteCheckList(r.Context(), userID, checkListID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
+
+// PromoteCheckListField превращает строку чеклиста в дочернюю карточку
+func (d *BoardDelivery) PromoteCheckListField(w http.ResponseWriter, r *http.Request) {
+	funcName := "PromoteCheckListField"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	fieldID, err := requests.GetIDFromRequest(r, "fieldID", "field_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	data := &models.CheckListFieldPromoteRequest{}
+	err = requests.GetRequestData(r, data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	newCard, err := d.boardUsecase.PromoteCheckListField(r.Context(), userID, fieldID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, newCard, http.StatusCreated)
+}
+
 // SetCardCover устанавливает обложку для карточки
 func (d *BoardDelivery) SetCardCover(w http.ResponseWriter, r *http.Request) {
 	funcName := "SetCardCover"
@@ -675,9 +790,9 @@ func (d *BoardDelivery) SetCardCover(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	file, err := uploads.FormFile(r)
+	file, err := uploads.ReceiveFile(w, r, uploads.LimitsFor(uploads.PurposeCover))
 	if err != nil {
-		responses.DoBadResponse(w, http.StatusBadRequest, "no file found")
+		responses.ResponseErrorAndLog(w, err, funcName)
 		return
 	}
 
@@ -727,13 +842,19 @@ func (d *BoardDelivery) AddAttachment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	file, err := uploads.FormFile(r)
+	file, err := uploads.ReceiveFile(w, r, uploads.LimitsFor(uploads.PurposeAttachment))
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	newAttachment, err := d.boardUsecase.AddAttachment(r.Context(), userID, cardID, file)
 	if err != nil {
-		responses.DoBadResponse(w, http.StatusBadRequest, "no file found")
+		responses.ResponseErrorAndLog(w, err, funcName)
 		return
 	}
 
-	d.boardUsecase.AddAttachment(r.Context(), userID, cardID, file)
+	responses.DoJSONResponse(w, newAttachment, http.StatusCreated)
 }
 
 // DeleteAttachment удаляет вложение с карточки
@@ -773,7 +894,7 @@ func (d *BoardDelivery) MoveCard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	moveReq := &models.CardMoveRequest{}
-	err = json.NewDecoder(r.Body).Decode(moveReq)
+	err = requests.GetRequestData(r, moveReq)
 	if err != nil {
 		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
 		return
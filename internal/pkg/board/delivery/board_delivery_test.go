@@ -1,8 +1,8 @@
 package delivery_test
 
 import (
+	"RPO_back/internal/errs"
 	"RPO_back/internal/models"
-	"RPO_back/internal/pkg/board/delivery"
 	BoardDelivery "RPO_back/internal/pkg/board/delivery"
 	mocks "RPO_back/internal/pkg/board/mocks"
 	"RPO_back/internal/pkg/middleware/session"
@@ -10,18 +10,41 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
-	"time"
-
-	"github.com/gorilla/mux"
 
 	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// doBoardRequest вызывает обработчик от имени userID (0 - без сессии)
+func doBoardRequest(handler http.HandlerFunc, method string, target string, vars map[string]string, body interface{}, userID int64) *httptest.ResponseRecorder {
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = bytes.NewBufferString(b)
+	default:
+		data, _ := json.Marshal(b)
+		reader = bytes.NewBuffer(data)
+	}
+	req := httptest.NewRequest(method, target, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if vars != nil {
+		req = mux.SetURLVars(req, vars)
+	}
+	if userID != 0 {
+		req = req.WithContext(context.WithValue(req.Context(), session.UserIDContextKey, userID))
+	}
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w
+}
+
 func TestCreateNewBoard(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -29,88 +52,35 @@ func TestCreateNewBoard(t *testing.T) {
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
+	reqData := models.BoardRequest{NewName: "New Board"}
+
 	t.Run("successful board creation", func(t *testing.T) {
-		userID := 1
-		reqData := models.CreateBoardRequest{Name: "New Board"}
 		expectedBoard := models.Board{ID: 1, Name: "New Board"}
+		mockBoardUsecase.EXPECT().CreateNewBoard(gomock.Any(), int64(1), reqData).Return(&expectedBoard, nil)
 
-		mockBoardUsecase.EXPECT().CreateNewBoard(gomock.Any(), userID, reqData).Return(&expectedBoard, nil)
-
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), session.UserIDContextKey, userID)
-			r = r.WithContext(ctx)
-			boardDelivery.CreateNewBoard(w, r)
-		})
-
-		reqBody, err := json.Marshal(reqData)
-		assert.NoError(t, err)
-
-		req := httptest.NewRequest("POST", "/boards", bytes.NewBuffer(reqBody))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
+		w := doBoardRequest(boardDelivery.CreateNewBoard, http.MethodPost, "/boards", nil, reqData, 1)
 
 		assert.Equal(t, http.StatusCreated, w.Code)
-
 		var gotBoard models.Board
-		err = json.NewDecoder(w.Body).Decode(&gotBoard)
-		assert.NoError(t, err)
-		assert.Equal(t, expectedBoard, gotBoard)
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&gotBoard))
+		assert.Equal(t, expectedBoard.ID, gotBoard.ID)
+		assert.Equal(t, expectedBoard.Name, gotBoard.Name)
 	})
 
 	t.Run("invalid request data", func(t *testing.T) {
-		userID := 1
-
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), session.UserIDContextKey, userID)
-			r = r.WithContext(ctx)
-			boardDelivery.CreateNewBoard(w, r)
-		})
-
-		req := httptest.NewRequest("POST", "/boards", bytes.NewBuffer([]byte("invalid json")))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
-
+		w := doBoardRequest(boardDelivery.CreateNewBoard, http.MethodPost, "/boards", nil, "invalid json", 1)
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		reqData := models.CreateBoardRequest{Name: "New Board"}
-
-		mockBoardUsecase.EXPECT().CreateNewBoard(gomock.Any(), userID, reqData).Return(nil, errors.New("usecase error"))
-
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), session.UserIDContextKey, userID)
-			r = r.WithContext(ctx)
-			boardDelivery.CreateNewBoard(w, r)
-		})
-
-		reqBody, err := json.Marshal(reqData)
-		assert.NoError(t, err)
-
-		req := httptest.NewRequest("POST", "/boards", bytes.NewBuffer(reqBody))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
+		mockBoardUsecase.EXPECT().CreateNewBoard(gomock.Any(), int64(1), reqData).Return(nil, errors.New("usecase error"))
 
+		w := doBoardRequest(boardDelivery.CreateNewBoard, http.MethodPost, "/boards", nil, reqData, 1)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			boardDelivery.CreateNewBoard(w, r)
-		})
-
-		req := httptest.NewRequest("POST", "/boards", nil)
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
-
+	t.Run("no session", func(t *testing.T) {
+		w := doBoardRequest(boardDelivery.CreateNewBoard, http.MethodPost, "/boards", nil, reqData, 0)
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
@@ -122,106 +92,26 @@ func TestUpdateBoard(t *testing.T) {
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-	t.Run("successful board update", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		reqData := models.BoardRequest{NewName: "Updated Board"}
-		expectedBoard := models.Board{ID: boardID, Name: reqData.NewName, Description: "", BackgroundImageURL: "", CreatedAt: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)}
-
-		mockBoardUsecase.EXPECT().UpdateBoard(gomock.Any(), userID, boardID, reqData).Return(&expectedBoard, nil)
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
+	reqData := models.BoardRequest{NewName: "Updated Board"}
+	vars := map[string]string{"boardID": "board_2"}
 
-		reqBody, err := json.Marshal(reqData)
-		assert.NoError(t, err)
-
-		req := httptest.NewRequestWithContext(ctx, "PUT", "/boards/board_1", bytes.NewBuffer(reqBody))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/boards/{boardId}", boardDelivery.UpdateBoard).Methods("PUT")
-
-		r.ServeHTTP(w, req)
+	t.Run("successful update", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().UpdateBoard(gomock.Any(), int64(1), int64(2), reqData).Return(&models.Board{ID: 2, Name: "Updated Board"}, nil)
 
+		w := doBoardRequest(boardDelivery.UpdateBoard, http.MethodPut, "/boards/board_2", vars, reqData, 1)
 		assert.Equal(t, http.StatusOK, w.Code)
-
-		var gotBoard models.Board
-		err = json.NewDecoder(w.Body).Decode(&gotBoard)
-		assert.NoError(t, err)
-		assert.Equal(t, expectedBoard, gotBoard)
 	})
 
-	t.Run("invalid request data", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequestWithContext(ctx, "PUT", "/boards/1", bytes.NewBuffer([]byte("invalid json")))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/boards/{boardId}", boardDelivery.UpdateBoard).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
+	t.Run("invalid board id", func(t *testing.T) {
+		w := doBoardRequest(boardDelivery.UpdateBoard, http.MethodPut, "/boards/abc", map[string]string{"boardID": "abc"}, reqData, 1)
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		reqData := models.BoardRequest{NewName: "Updated Board"}
-
-		mockBoardUsecase.EXPECT().UpdateBoard(gomock.Any(), userID, boardID, reqData).Return(nil, errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		reqBody, err := json.Marshal(reqData)
-		assert.NoError(t, err)
-
-		req := httptest.NewRequestWithContext(ctx, "PUT", "/boards/board_1", bytes.NewBuffer(reqBody))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/boards/{boardId}", boardDelivery.UpdateBoard).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
-	})
-
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			boardDelivery.UpdateBoard(w, r)
-		})
-
-		req := httptest.NewRequest("PUT", "/boards/board_1", nil)
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails", func(t *testing.T) {
-		userID := 1
-
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), session.UserIDContextKey, userID)
-			r = r.WithContext(ctx)
-			boardDelivery.UpdateBoard(w, r)
-		})
+	t.Run("not permitted", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().UpdateBoard(gomock.Any(), int64(1), int64(2), reqData).Return(nil, errs.ErrNotPermitted)
 
-		req := httptest.NewRequest("PUT", "/boards/invalid", nil)
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w := doBoardRequest(boardDelivery.UpdateBoard, http.MethodPut, "/boards/board_2", vars, reqData, 1)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
 
@@ -230,76 +120,27 @@ func TestDeleteBoard(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
-	boardDelivery := delivery.CreateBoardDelivery(mockBoardUsecase)
-
-	t.Run("successful board deletion", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-
-		mockBoardUsecase.EXPECT().DeleteBoard(gomock.Any(), userID, boardID).Return(nil)
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequestWithContext(ctx, "DELETE", "/boards/board_1", nil)
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
+	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-		r := mux.NewRouter()
-		r.HandleFunc("/boards/{boardId}", boardDelivery.DeleteBoard).Methods("DELETE")
+	vars := map[string]string{"boardID": "board_2"}
 
-		r.ServeHTTP(w, req)
+	t.Run("successful deletion", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().DeleteBoard(gomock.Any(), int64(1), int64(2)).Return(nil)
 
+		w := doBoardRequest(boardDelivery.DeleteBoard, http.MethodDelete, "/boards/board_2", vars, nil, 1)
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		ctx := context.Background()
-		req := httptest.NewRequestWithContext(ctx, "DELETE", "/boards/board_1", nil)
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/boards/{boardId}", boardDelivery.DeleteBoard).Methods("DELETE")
+	t.Run("board not found", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().DeleteBoard(gomock.Any(), int64(1), int64(2)).Return(errs.ErrNotFound)
 
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails", func(t *testing.T) {
-		userID := 1
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequestWithContext(ctx, "DELETE", "/boards/invalid", nil)
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/boards/{boardId}", boardDelivery.DeleteBoard).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w := doBoardRequest(boardDelivery.DeleteBoard, http.MethodDelete, "/boards/board_2", vars, nil, 1)
+		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-
-		mockBoardUsecase.EXPECT().DeleteBoard(gomock.Any(), userID, boardID).Return(errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequestWithContext(ctx, "DELETE", "/boards/board_1", nil)
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/boards/{boardId}", boardDelivery.DeleteBoard).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	t.Run("no session", func(t *testing.T) {
+		w := doBoardRequest(boardDelivery.DeleteBoard, http.MethodDelete, "/boards/board_2", vars, nil, 0)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
 
@@ -310,63 +151,21 @@ func TestGetMyBoards(t *testing.T) {
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-	t.Run("successful retrieval of boards", func(t *testing.T) {
-		userID := 1
-		expectedBoards := []models.Board{
-			{ID: 1, Name: "Board 1"},
-			{ID: 2, Name: "Board 2"},
-		}
-
-		mockBoardUsecase.EXPECT().GetMyBoards(gomock.Any(), userID).Return(expectedBoards, nil)
+	t.Run("successful retrieval", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().GetMyBoards(gomock.Any(), int64(1)).Return([]models.Board{{ID: 1}, {ID: 2}}, nil)
 
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), session.UserIDContextKey, userID)
-			r = r.WithContext(ctx)
-			boardDelivery.GetMyBoards(w, r)
-		})
-
-		req := httptest.NewRequest("GET", "/boards/my", nil)
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
+		w := doBoardRequest(boardDelivery.GetMyBoards, http.MethodGet, "/boards/my", nil, nil, 1)
 
 		assert.Equal(t, http.StatusOK, w.Code)
-
-		var gotBoards []models.Board
-		err := json.NewDecoder(w.Body).Decode(&gotBoards)
-		assert.NoError(t, err)
-		assert.Equal(t, expectedBoards, gotBoards)
-	})
-
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			boardDelivery.GetMyBoards(w, r)
-		})
-
-		req := httptest.NewRequest("GET", "/boards", nil)
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		var boards []models.Board
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&boards))
+		assert.Len(t, boards, 2)
 	})
 
 	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-
-		mockBoardUsecase.EXPECT().GetMyBoards(gomock.Any(), userID).Return(nil, errors.New("usecase error"))
-
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), session.UserIDContextKey, userID)
-			r = r.WithContext(ctx)
-			boardDelivery.GetMyBoards(w, r)
-		})
-
-		req := httptest.NewRequest("GET", "/boards", nil)
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
+		mockBoardUsecase.EXPECT().GetMyBoards(gomock.Any(), int64(1)).Return(nil, errors.New("usecase error"))
 
+		w := doBoardRequest(boardDelivery.GetMyBoards, http.MethodGet, "/boards/my", nil, nil, 1)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
@@ -378,84 +177,20 @@ func TestGetMembersPermissions(t *testing.T) {
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-	t.Run("successful retrieval of members' permissions", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-
-		expectedPermissions := []models.MemberWithPermissions{
-			{User: &models.UserProfile{ID: 1}, Role: "admin", AddedAt: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)},
-			{User: &models.UserProfile{ID: 2}, Role: "editor", AddedAt: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)},
-		}
-
-		mockBoardUsecase.EXPECT().GetMembersPermissions(gomock.Any(), gomock.Eq(userID), gomock.Eq(boardID)).Return(expectedPermissions, nil)
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("GET", "/userPermissions/board_1", nil).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1"})
-
-		w := httptest.NewRecorder()
+	vars := map[string]string{"boardID": "board_2"}
 
-		r := mux.NewRouter()
-		r.HandleFunc("/userPermissions/{boardId}", boardDelivery.GetMembersPermissions).Methods("GET")
-
-		r.ServeHTTP(w, req)
+	t.Run("successful retrieval", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().GetMembersPermissions(gomock.Any(), int64(1), int64(2)).Return([]models.MemberWithPermissions{{Role: "admin"}}, nil)
 
+		w := doBoardRequest(boardDelivery.GetMembersPermissions, http.MethodGet, "/userPermissions/board_2", vars, nil, 1)
 		assert.Equal(t, http.StatusOK, w.Code)
-
-		var gotPermissions []models.MemberWithPermissions
-		err := json.NewDecoder(w.Body).Decode(&gotPermissions)
-		assert.NoError(t, err)
-		assert.Equal(t, expectedPermissions, gotPermissions)
 	})
 
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			boardDelivery.GetMembersPermissions(w, r)
-		})
-
-		req := httptest.NewRequest("GET", "/userPermissions/board_1", nil)
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
+	t.Run("not a member", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().GetMembersPermissions(gomock.Any(), int64(1), int64(2)).Return(nil, errs.ErrNotPermitted)
 
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails", func(t *testing.T) {
-		userID := 1
-
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), session.UserIDContextKey, userID)
-			r = r.WithContext(ctx)
-			boardDelivery.GetMembersPermissions(w, r)
-		})
-
-		req := httptest.NewRequest("GET", "/userPermissions/board_1", nil)
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
-
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-
-		mockBoardUsecase.EXPECT().GetMembersPermissions(gomock.Any(), userID, boardID).Return(nil, errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("GET", "/userPermissions/board_1", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/userPermissions/{boardId}", boardDelivery.GetMembersPermissions).Methods("GET")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		w := doBoardRequest(boardDelivery.GetMembersPermissions, http.MethodGet, "/userPermissions/board_2", vars, nil, 1)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
 
@@ -466,188 +201,101 @@ func TestAddMember(t *testing.T) {
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-	t.Run("successful addition of a member", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		reqData := models.AddMemberRequest{MemberNickname: "user123"}
-		expectedMember := models.MemberWithPermissions{User: &models.UserProfile{ID: 2}, Role: "viewer"}
-
-		mockBoardUsecase.EXPECT().AddMember(gomock.Any(), userID, boardID, &reqData).Return(&expectedMember, nil)
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		reqBody, err := json.Marshal(reqData)
-		assert.NoError(t, err)
+	reqData := models.AddMemberRequest{MemberNickname: "newbie"}
+	vars := map[string]string{"boardID": "board_2"}
 
-		req := httptest.NewRequest("POST", "/userPermissions/board_1", bytes.NewBuffer(reqBody))
-		req = req.WithContext(ctx)
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/userPermissions/{boardId}", boardDelivery.AddMember).Methods("POST")
-
-		r.ServeHTTP(w, req)
+	t.Run("successful addition", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().AddMember(gomock.Any(), int64(1), int64(2), &reqData).Return(&models.MemberWithPermissions{Role: "viewer"}, nil)
 
+		w := doBoardRequest(boardDelivery.AddMember, http.MethodPost, "/userPermissions/board_2", vars, reqData, 1)
 		assert.Equal(t, http.StatusOK, w.Code)
-
-		var gotMember models.MemberWithPermissions
-		err = json.NewDecoder(w.Body).Decode(&gotMember)
-		assert.NoError(t, err)
-		assert.Equal(t, expectedMember, gotMember)
 	})
 
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			boardDelivery.AddMember(w, r)
-		})
-
-		req := httptest.NewRequest("POST", "/userPermissions/board_1", nil)
-		w := httptest.NewRecorder()
+	t.Run("already a member", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().AddMember(gomock.Any(), int64(1), int64(2), &reqData).Return(nil, errs.ErrAlreadyExists)
 
-		handler.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails", func(t *testing.T) {
-		userID := 1
-
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), session.UserIDContextKey, userID)
-			r = r.WithContext(ctx)
-			boardDelivery.AddMember(w, r)
-		})
-
-		req := httptest.NewRequest("POST", "/userPermissions/invalid", nil)
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w := doBoardRequest(boardDelivery.AddMember, http.MethodPost, "/userPermissions/board_2", vars, reqData, 1)
+		assert.Equal(t, http.StatusConflict, w.Code)
 	})
 
 	t.Run("invalid request data", func(t *testing.T) {
-		userID := 1
-
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), session.UserIDContextKey, userID)
-			r = r.WithContext(ctx)
-			boardDelivery.AddMember(w, r)
-		})
-
-		req := httptest.NewRequest("POST", "/userPermissions/board_1", bytes.NewBuffer([]byte("invalid json")))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		handler.ServeHTTP(w, req)
-
+		w := doBoardRequest(boardDelivery.AddMember, http.MethodPost, "/userPermissions/board_2", vars, "invalid json", 1)
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
+}
 
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		reqData := models.AddMemberRequest{MemberNickname: "user123"}
-
-		mockBoardUsecase.EXPECT().AddMember(gomock.Any(), userID, boardID, &reqData).Return(nil, errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
+func TestUpdateMemberRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-		marshal, _ := json.Marshal(reqData)
+	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
+	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-		req := httptest.NewRequest("POST", fmt.Sprintf("/userPermissions/board_%d", boardID), bytes.NewBuffer(marshal))
-		req = req.WithContext(ctx)
-		w := httptest.NewRecorder()
+	vars := map[string]string{"boardID": "board_2", "userID": "user_3"}
 
-		r := mux.NewRouter()
-		r.HandleFunc("/userPermissions/{boardId}", boardDelivery.AddMember).Methods("POST")
+	t.Run("successful update", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().UpdateMemberRole(gomock.Any(), int64(1), int64(2), int64(3), "editor").Return(&models.MemberWithPermissions{Role: "editor"}, nil)
 
-		r.ServeHTTP(w, req)
+		w := doBoardRequest(boardDelivery.UpdateMemberRole, http.MethodPut, "/userPermissions/board_2/user_3", vars, models.UpdateMemberRequest{NewRole: "editor"}, 1)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
 
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	t.Run("unknown role", func(t *testing.T) {
+		w := doBoardRequest(boardDelivery.UpdateMemberRole, http.MethodPut, "/userPermissions/board_2/user_3", vars, models.UpdateMemberRequest{NewRole: "owner"}, 1)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
 
-func TestGetBoardContent(t *testing.T) {
+func TestRemoveMember(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-	t.Run("successful retrieval of board content", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-
-		mockBoardUsecase.EXPECT().GetBoardContent(gomock.Any(), gomock.Eq(userID), gomock.Eq(boardID)).Return(nil, nil)
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
+	vars := map[string]string{"boardID": "board_2", "userID": "user_3"}
 
-		req := httptest.NewRequest("GET", "/cards/board_1/allContent", nil).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1"})
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/allContent", boardDelivery.GetBoardContent).Methods("GET")
-
-		r.ServeHTTP(w, req)
+	t.Run("successful removal", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().RemoveMember(gomock.Any(), int64(1), int64(2), int64(3)).Return(nil)
 
+		w := doBoardRequest(boardDelivery.RemoveMember, http.MethodDelete, "/userPermissions/board_2/user_3", vars, nil, 1)
 		assert.Equal(t, http.StatusOK, w.Code)
-
-		var gotContent models.BoardContent
-		err := json.NewDecoder(w.Body).Decode(&gotContent)
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusOK, w.Code, gotContent)
 	})
 
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		req := httptest.NewRequest("GET", "/cards/board_1/allContent", nil)
-		w := httptest.NewRecorder()
+	t.Run("not permitted", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().RemoveMember(gomock.Any(), int64(1), int64(2), int64(3)).Return(errs.ErrNotPermitted)
 
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/allContent", boardDelivery.GetBoardContent).Methods("GET")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w := doBoardRequest(boardDelivery.RemoveMember, http.MethodDelete, "/userPermissions/board_2/user_3", vars, nil, 1)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
+}
 
-	t.Run("GetIDFromRequest fails", func(t *testing.T) {
-		userID := 1
+func TestGetBoardContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
+	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
+	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-		req := httptest.NewRequest("GET", "/cards/invalid_board_id/allContent", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
+	vars := map[string]string{"boardID": "board_2"}
 
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/allContent", boardDelivery.GetBoardContent).Methods("GET")
+	t.Run("successful retrieval", func(t *testing.T) {
+		content := &models.BoardContent{MyRole: "editor", BoardInfo: &models.Board{ID: 2}}
+		mockBoardUsecase.EXPECT().GetBoardContent(gomock.Any(), int64(1), int64(2)).Return(content, nil)
 
-		r.ServeHTTP(w, req)
+		w := doBoardRequest(boardDelivery.GetBoardContent, http.MethodGet, "/cards/board_2/allContent", vars, nil, 1)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var got models.BoardContent
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.Equal(t, "editor", got.MyRole)
 	})
 
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-
-		mockBoardUsecase.EXPECT().GetBoardContent(gomock.Any(), userID, boardID).Return(&models.BoardContent{}, errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("GET", "/cards/board_1/allContent", nil).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1"})
-		w := httptest.NewRecorder()
+	t.Run("board not found", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().GetBoardContent(gomock.Any(), int64(1), int64(2)).Return(nil, errs.ErrNotFound)
 
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/allContent", boardDelivery.GetBoardContent).Methods("GET")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		w := doBoardRequest(boardDelivery.GetBoardContent, http.MethodGet, "/cards/board_2/allContent", vars, nil, 1)
+		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 }
 
@@ -658,98 +306,23 @@ func TestCreateNewCard(t *testing.T) {
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-	t.Run("successful creation of new card", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		requestData := models.CardPatchRequest{NewTitle: "New Task", NewColumnID: 1}
-
-		mockBoardUsecase.EXPECT().CreateNewCard(gomock.Any(), gomock.Eq(userID), gomock.Eq(boardID), gomock.Eq(&requestData)).Return(&models.Card{ID: 1, Title: requestData.NewTitle, ColumnID: requestData.NewColumnID}, nil)
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		body, _ := json.Marshal(requestData)
-		req := httptest.NewRequest("POST", "/cards/board_1", bytes.NewReader(body)).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1"})
-		w := httptest.NewRecorder()
+	title := "New Task"
+	columnID := int64(5)
+	reqData := models.CardPostRequest{Title: &title, ColumnID: &columnID}
+	vars := map[string]string{"boardID": "board_2"}
 
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}", boardDelivery.CreateNewCard).Methods("POST")
-
-		r.ServeHTTP(w, req)
+	t.Run("successful creation", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().CreateNewCard(gomock.Any(), int64(1), int64(2), &reqData).Return(&models.Card{ID: 7, Title: title, ColumnID: columnID}, nil)
 
+		w := doBoardRequest(boardDelivery.CreateNewCard, http.MethodPost, "/cards/board_2", vars, reqData, 1)
 		assert.Equal(t, http.StatusCreated, w.Code)
-
-		var gotCard models.Card
-		err := json.NewDecoder(w.Body).Decode(&gotCard)
-		assert.NoError(t, err)
-		expectedCard := models.Card{ID: 1, Title: requestData.NewTitle, ColumnID: requestData.NewColumnID}
-		assert.Equal(t, expectedCard, gotCard)
-	})
-
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		req := httptest.NewRequest("POST", "/cards/board_1", nil)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}", boardDelivery.CreateNewCard).Methods("POST")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("POST", "/cards/invalid", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}", boardDelivery.CreateNewCard).Methods("POST")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
-
-	t.Run("invalid request data", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("POST", "/cards/board_1", bytes.NewReader([]byte("invalid json"))).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1"})
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}", boardDelivery.CreateNewCard).Methods("POST")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		requestData := models.CardPatchRequest{NewTitle: "New Task", NewColumnID: 1}
-
-		mockBoardUsecase.EXPECT().CreateNewCard(gomock.Any(), userID, boardID, &requestData).Return(nil, errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		body, _ := json.Marshal(requestData)
-		req := httptest.NewRequest("POST", "/cards/board_1", bytes.NewReader(body)).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}", boardDelivery.CreateNewCard).Methods("POST")
-
-		r.ServeHTTP(w, req)
+	t.Run("viewer is not permitted", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().CreateNewCard(gomock.Any(), int64(1), int64(2), &reqData).Return(nil, errs.ErrNotPermitted)
 
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		w := doBoardRequest(boardDelivery.CreateNewCard, http.MethodPost, "/cards/board_2", vars, reqData, 1)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
 
@@ -759,117 +332,22 @@ func TestUpdateCard(t *testing.T) {
 
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
-	t.Run("successful update of card", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		cardID := 1
-		requestData := models.CardPatchRequest{NewTitle: "Updated Task", NewColumnID: 1}
-
-		mockBoardUsecase.EXPECT().UpdateCard(gomock.Any(), userID, boardID, cardID, &requestData).Return(&models.Card{ID: cardID, Title: requestData.NewTitle, ColumnID: requestData.NewColumnID}, nil)
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
 
-		body, _ := json.Marshal(requestData)
-		req := httptest.NewRequest("PUT", "/cards/board_1/card_1", bytes.NewReader(body)).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1", "cardId": "1"})
-		w := httptest.NewRecorder()
+	title := "Updated Task"
+	reqData := models.CardPatchRequest{NewTitle: &title}
+	vars := map[string]string{"cardID": "card_7"}
 
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.UpdateCard).Methods("PUT")
-
-		r.ServeHTTP(w, req)
+	t.Run("successful update", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().UpdateCard(gomock.Any(), int64(1), int64(7), &reqData).Return(&models.Card{ID: 7, Title: title}, nil)
 
+		w := doBoardRequest(boardDelivery.UpdateCard, http.MethodPut, "/cards/card_7", vars, reqData, 1)
 		assert.Equal(t, http.StatusOK, w.Code)
-
-		var gotCard models.Card
-		err := json.NewDecoder(w.Body).Decode(&gotCard)
-		assert.NoError(t, err)
-		expectedCard := models.Card{ID: cardID, Title: requestData.NewTitle, ColumnID: requestData.NewColumnID}
-		assert.Equal(t, expectedCard, gotCard)
-	})
-
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		req := httptest.NewRequest("PUT", "/cards/board_1/card_1", nil)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.UpdateCard).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails for boardId", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("PUT", "/cards/invalid/card_1", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.UpdateCard).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
-	t.Run("GetIDFromRequest fails for cardId", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("PUT", "/cards/board_1/invalid", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.UpdateCard).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
+	t.Run("invalid card id", func(t *testing.T) {
+		w := doBoardRequest(boardDelivery.UpdateCard, http.MethodPut, "/cards/xyz", map[string]string{"cardID": "xyz"}, reqData, 1)
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
-
-	t.Run("invalid request data", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("PUT", "/cards/board_1/card_1", bytes.NewReader([]byte("invalid json"))).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1", "cardId": "1"})
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.UpdateCard).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
-
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		cardID := 1
-		requestData := models.CardPatchRequest{NewTitle: "Updated Task", NewColumnID: 1}
-
-		mockBoardUsecase.EXPECT().UpdateCard(gomock.Any(), userID, boardID, cardID, &requestData).Return(nil, errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		body, _ := json.Marshal(requestData)
-		req := httptest.NewRequest("PUT", "/cards/board_1/card_1", bytes.NewReader(body)).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.UpdateCard).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
-	})
 }
 
 func TestDeleteCard(t *testing.T) {
@@ -879,89 +357,20 @@ func TestDeleteCard(t *testing.T) {
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-	t.Run("successful deletion of card", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		cardID := 1
-
-		mockBoardUsecase.EXPECT().DeleteCard(gomock.Any(), userID, boardID, cardID).Return(nil)
+	vars := map[string]string{"cardID": "card_7"}
 
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("DELETE", "/cards/board_1/card_1", nil).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1", "cardId": "1"})
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.DeleteCard).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
+	t.Run("successful deletion", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().DeleteCard(gomock.Any(), int64(1), int64(7)).Return(nil)
 
+		w := doBoardRequest(boardDelivery.DeleteCard, http.MethodDelete, "/cards/card_7", vars, nil, 1)
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		req := httptest.NewRequest("DELETE", "/cards/board_1/card_1", nil)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.DeleteCard).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails for boardId", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("DELETE", "/cards/invalid/card_1", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.DeleteCard).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails for cardId", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("DELETE", "/cards/board_1/invalid", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.DeleteCard).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
-
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		cardID := 1
-
-		mockBoardUsecase.EXPECT().DeleteCard(gomock.Any(), userID, boardID, cardID).Return(errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("DELETE", "/cards/board_1/card_1", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/cards/{boardId}/{cardId}", boardDelivery.DeleteCard).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
+	t.Run("card not found", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().DeleteCard(gomock.Any(), int64(1), int64(7)).Return(errs.ErrNotFound)
 
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		w := doBoardRequest(boardDelivery.DeleteCard, http.MethodDelete, "/cards/card_7", vars, nil, 1)
+		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 }
 
@@ -972,99 +381,20 @@ func TestCreateColumn(t *testing.T) {
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-	t.Run("successful creation of column", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		requestData := models.ColumnRequest{NewTitle: "New Column"}
-
-		mockBoardUsecase.EXPECT().CreateColumn(gomock.Any(), userID, boardID, &requestData).Return(&models.Column{ID: 1, Title: requestData.NewTitle}, nil)
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
+	reqData := models.ColumnRequest{NewTitle: "To Do"}
+	vars := map[string]string{"boardID": "board_2"}
 
-		body, _ := json.Marshal(requestData)
-		req := httptest.NewRequest("POST", "/columns/board_1", bytes.NewReader(body)).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1"})
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}", boardDelivery.CreateColumn).Methods("POST")
-
-		r.ServeHTTP(w, req)
+	t.Run("successful creation", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().CreateColumn(gomock.Any(), int64(1), int64(2), &reqData).Return(&models.Column{ID: 4, Title: "To Do"}, nil)
 
+		w := doBoardRequest(boardDelivery.CreateColumn, http.MethodPost, "/columns/board_2", vars, reqData, 1)
 		assert.Equal(t, http.StatusCreated, w.Code)
-
-		var gotColumn models.Column
-		err := json.NewDecoder(w.Body).Decode(&gotColumn)
-		assert.NoError(t, err)
-		expectedColumn := models.Column{ID: 1, Title: requestData.NewTitle}
-		assert.Equal(t, expectedColumn, gotColumn)
-	})
-
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		req := httptest.NewRequest("POST", "/columns/board_1", nil)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}", boardDelivery.CreateColumn).Methods("POST")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("POST", "/columns/invalid", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}", boardDelivery.CreateColumn).Methods("POST")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("invalid request data", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("POST", "/columns/board_1", bytes.NewReader([]byte("invalid json"))).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1"})
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}", boardDelivery.CreateColumn).Methods("POST")
-
-		r.ServeHTTP(w, req)
-
+		w := doBoardRequest(boardDelivery.CreateColumn, http.MethodPost, "/columns/board_2", vars, "invalid json", 1)
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
-
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		requestData := models.ColumnRequest{NewTitle: "New Column"}
-
-		mockBoardUsecase.EXPECT().CreateColumn(gomock.Any(), userID, boardID, &requestData).Return(nil, errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		body, _ := json.Marshal(requestData)
-		req := httptest.NewRequest("POST", "/columns/board_1", bytes.NewReader(body)).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}", boardDelivery.CreateColumn).Methods("POST")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
-	})
 }
 
 func TestUpdateColumn(t *testing.T) {
@@ -1074,116 +404,21 @@ func TestUpdateColumn(t *testing.T) {
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-	t.Run("successful update of column", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		columnID := 1
-		requestData := models.ColumnRequest{NewTitle: "Updated Column"}
-
-		mockBoardUsecase.EXPECT().UpdateColumn(gomock.Any(), userID, boardID, columnID, &requestData).Return(&models.Column{ID: columnID, Title: requestData.NewTitle}, nil)
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
+	reqData := models.ColumnRequest{NewTitle: "Done"}
+	vars := map[string]string{"columnId": "column_4"}
 
-		body, _ := json.Marshal(requestData)
-		req := httptest.NewRequest("PUT", "/columns/board_1/column_1", bytes.NewReader(body)).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1", "columnId": "1"})
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.UpdateColumn).Methods("PUT")
-
-		r.ServeHTTP(w, req)
+	t.Run("successful update", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().UpdateColumn(gomock.Any(), int64(1), int64(4), &reqData).Return(&models.Column{ID: 4, Title: "Done"}, nil)
 
+		w := doBoardRequest(boardDelivery.UpdateColumn, http.MethodPut, "/columns/column_4", vars, reqData, 1)
 		assert.Equal(t, http.StatusOK, w.Code)
-
-		var gotColumn models.Column
-		err := json.NewDecoder(w.Body).Decode(&gotColumn)
-		assert.NoError(t, err)
-		expectedColumn := models.Column{ID: columnID, Title: requestData.NewTitle}
-		assert.Equal(t, expectedColumn, gotColumn)
 	})
 
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		req := httptest.NewRequest("PUT", "/columns/board_1/column_1", nil)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.UpdateColumn).Methods("PUT")
+	t.Run("not permitted", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().UpdateColumn(gomock.Any(), int64(1), int64(4), &reqData).Return(nil, errs.ErrNotPermitted)
 
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails for boardId", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("PUT", "/columns/invalid/column_1", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.UpdateColumn).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails for columnId", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("PUT", "/columns/board_1/invalid", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.UpdateColumn).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
-
-	t.Run("invalid request data", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("PUT", "/columns/board_1/column_1", bytes.NewReader([]byte("invalid json"))).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1", "columnId": "1"})
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.UpdateColumn).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
-
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		columnID := 1
-		requestData := models.ColumnRequest{NewTitle: "Updated Column"}
-
-		mockBoardUsecase.EXPECT().UpdateColumn(gomock.Any(), userID, boardID, columnID, &requestData).Return(nil, errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		body, _ := json.Marshal(requestData)
-		req := httptest.NewRequest("PUT", "/columns/board_1/column_1", bytes.NewReader(body)).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.UpdateColumn).Methods("PUT")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		w := doBoardRequest(boardDelivery.UpdateColumn, http.MethodPut, "/columns/column_4", vars, reqData, 1)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
 
@@ -1194,88 +429,17 @@ func TestDeleteColumn(t *testing.T) {
 	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
 	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
 
-	t.Run("successful deletion of column", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		columnID := 1
-
-		mockBoardUsecase.EXPECT().DeleteColumn(gomock.Any(), userID, boardID, columnID).Return(nil)
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("DELETE", "/columns/board_1/column_1", nil).WithContext(ctx)
-		req = mux.SetURLVars(req, map[string]string{"boardId": "1", "columnId": "1"})
-		w := httptest.NewRecorder()
+	vars := map[string]string{"columnID": "column_4"}
 
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.DeleteColumn).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
+	t.Run("successful deletion", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().DeleteColumn(gomock.Any(), int64(1), int64(4)).Return(nil)
 
+		w := doBoardRequest(boardDelivery.DeleteColumn, http.MethodDelete, "/columns/column_4", vars, nil, 1)
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
-	t.Run("GetUserIDOrFail fails", func(t *testing.T) {
-		req := httptest.NewRequest("DELETE", "/columns/board_1/column_1", nil)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.DeleteColumn).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
-
+	t.Run("no session", func(t *testing.T) {
+		w := doBoardRequest(boardDelivery.DeleteColumn, http.MethodDelete, "/columns/column_4", vars, nil, 0)
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
-
-	t.Run("GetIDFromRequest fails for boardId", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("DELETE", "/columns/invalid/column_1", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.DeleteColumn).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
-
-	t.Run("GetIDFromRequest fails for columnId", func(t *testing.T) {
-		userID := 1
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("DELETE", "/columns/board_1/invalid", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.DeleteColumn).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
-
-	t.Run("usecase returns error", func(t *testing.T) {
-		userID := 1
-		boardID := 1
-		columnID := 1
-
-		mockBoardUsecase.EXPECT().DeleteColumn(gomock.Any(), userID, boardID, columnID).Return(errors.New("usecase error"))
-
-		ctx := context.WithValue(context.Background(), session.UserIDContextKey, userID)
-
-		req := httptest.NewRequest("DELETE", "/columns/board_1/column_1", nil).WithContext(ctx)
-		w := httptest.NewRecorder()
-
-		r := mux.NewRouter()
-		r.HandleFunc("/columns/{boardId}/{columnId}", boardDelivery.DeleteColumn).Methods("DELETE")
-
-		r.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
-	})
 }
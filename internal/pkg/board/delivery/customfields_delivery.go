@@ -0,0 +1,155 @@
+package delivery
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetBoardCustomFields возвращает пользовательские поля доски
+func (d *BoardDelivery) GetBoardCustomFields(w http.ResponseWriter, r *http.Request) {
+	funcName := "GetBoardCustomFields"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	boardID, err := requests.GetIDFromRequest(r, "boardID", "board_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	fields, err := d.boardUsecase.GetBoardCustomFields(r.Context(), userID, boardID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, fields, http.StatusOK)
+}
+
+// CreateCustomField добавляет на доску пользовательское поле
+func (d *BoardDelivery) CreateCustomField(w http.ResponseWriter, r *http.Request) {
+	funcName := "CreateCustomField"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	boardID, err := requests.GetIDFromRequest(r, "boardID", "board_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	data := &models.CustomFieldPostRequest{}
+	err = requests.GetRequestData(r, data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
+		return
+	}
+
+	newField, err := d.boardUsecase.CreateCustomField(r.Context(), userID, boardID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, newField, http.StatusCreated)
+}
+
+// UpdateCustomField переименовывает пользовательское поле и меняет варианты выбора
+func (d *BoardDelivery) UpdateCustomField(w http.ResponseWriter, r *http.Request) {
+	funcName := "UpdateCustomField"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	fieldID, err := requests.GetIDFromRequest(r, "customFieldID", "customField_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	data := &models.CustomFieldPutRequest{}
+	err = requests.GetRequestData(r, data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
+		return
+	}
+
+	updatedField, err := d.boardUsecase.UpdateCustomField(r.Context(), userID, fieldID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, updatedField, http.StatusOK)
+}
+
+// DeleteCustomField удаляет пользовательское поле доски
+func (d *BoardDelivery) DeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	funcName := "DeleteCustomField"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	fieldID, err := requests.GetIDFromRequest(r, "customFieldID", "customField_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	err = d.boardUsecase.DeleteCustomField(r.Context(), userID, fieldID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
+
+// SetCardCustomFieldValue задаёт значение пользовательского поля на карточке
+func (d *BoardDelivery) SetCardCustomFieldValue(w http.ResponseWriter, r *http.Request) {
+	funcName := "SetCardCustomFieldValue"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	cardID, err := requests.GetIDFromRequest(r, "cardID", "card_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	fieldID, err := requests.GetIDFromRequest(r, "customFieldID", "customField_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	data := &models.CustomFieldValueRequest{}
+	err = requests.GetRequestData(r, data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
+		return
+	}
+
+	fieldValue, err := d.boardUsecase.SetCardCustomFieldValue(r.Context(), userID, cardID, fieldID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, fieldValue, http.StatusOK)
+}
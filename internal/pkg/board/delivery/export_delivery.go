@@ -0,0 +1,94 @@
+package delivery
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/csvexport"
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ExportBoard отдаёт выгрузку доски со значениями пользовательских полей карточек.
+// По умолчанию в JSON, при format=csv - файлом CSV, где каждому полю отведён свой столбец
+func (d *BoardDelivery) ExportBoard(w http.ResponseWriter, r *http.Request) {
+	funcName := "ExportBoard"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	boardID, err := requests.GetIDFromRequest(r, "boardID", "board_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	export, err := d.boardUsecase.ExportBoard(r.Context(), userID, boardID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	if format == "csv" {
+		writeBoardExportCSV(w, boardID, export)
+		return
+	}
+	responses.DoJSONResponse(w, export, http.StatusOK)
+}
+
+// boardExportCSVRows раскладывает выгрузку доски в строки CSV: общие столбцы карточки,
+// затем по столбцу на каждое пользовательское поле в порядке полей доски
+func boardExportCSVRows(export *models.BoardExport) [][]string {
+	header := []string{"card_id", "title", "column", "deadline", "is_done", "created_at", "updated_at"}
+	fieldColumns := make(map[int64]int, len(export.CustomFields))
+	for _, field := range export.CustomFields {
+		header = append(header, field.Title)
+		fieldColumns[field.ID] = len(header) - 1
+	}
+
+	rows := [][]string{header}
+	for _, card := range export.Cards {
+		row := make([]string, len(header))
+		row[0] = strconv.FormatInt(card.ID, 10)
+		row[1] = card.Title
+		row[2] = card.ColumnTitle
+		if card.Deadline != nil {
+			row[3] = card.Deadline.Format(time.RFC3339)
+		}
+		row[4] = strconv.FormatBool(card.IsDone)
+		row[5] = card.CreatedAt.Format(time.RFC3339)
+		row[6] = card.UpdatedAt.Format(time.RFC3339)
+		for _, value := range card.CustomFieldValues {
+			if idx, ok := fieldColumns[value.FieldID]; ok {
+				row[idx] = value.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// writeBoardExportCSV отдаёт выгрузку доски в формате CSV
+func writeBoardExportCSV(w http.ResponseWriter, boardID int64, export *models.BoardExport) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=\"board_%d.csv\"", boardID))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csvexport.NewWriter(w)
+	_ = writer.WriteAll(boardExportCSVRows(export))
+	if err := writer.Error(); err != nil {
+		log.Error("writeBoardExportCSV: ", err)
+	}
+}
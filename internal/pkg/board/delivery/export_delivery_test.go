@@ -0,0 +1,104 @@
+package delivery_test
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	BoardDelivery "RPO_back/internal/pkg/board/delivery"
+	mocks "RPO_back/internal/pkg/board/mocks"
+	"RPO_back/internal/pkg/middleware/session"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportBoard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoardUsecase := mocks.NewMockBoardUsecase(ctrl)
+	boardDelivery := BoardDelivery.CreateBoardDelivery(mockBoardUsecase)
+
+	userID := int64(1)
+	createdAt := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	deadline := time.Date(2024, 12, 20, 18, 0, 0, 0, time.UTC)
+	export := &models.BoardExport{
+		Board:   &models.Board{ID: 7, Name: "Clients"},
+		Columns: []models.Column{{ID: 3, Title: "Doing"}},
+		CustomFields: []models.CustomField{
+			{ID: 11, Title: "Priority", Type: models.CustomFieldDropdown, Options: []string{"low", "high"}},
+			{ID: 12, Title: "Points", Type: models.CustomFieldNumber},
+		},
+		Cards: []models.CardExport{
+			{
+				ID: 100, Title: "Landing", ColumnID: 3, ColumnTitle: "Doing", Deadline: &deadline,
+				CreatedAt: createdAt, UpdatedAt: createdAt,
+				CustomFieldValues: []models.CustomFieldValue{{CardID: 100, FieldID: 12, Value: "5"}},
+			},
+			{
+				ID: 101, Title: "=Invoice", ColumnID: 3, ColumnTitle: "Doing", IsDone: true,
+				CreatedAt: createdAt, UpdatedAt: createdAt,
+				CustomFieldValues: []models.CustomFieldValue{{CardID: 101, FieldID: 11, Value: "high"}},
+			},
+		},
+	}
+
+	doRequest := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/boards/board_7/export"+query, nil)
+		req = mux.SetURLVars(req, map[string]string{"boardID": "board_7"})
+		req = req.WithContext(context.WithValue(req.Context(), session.UserIDContextKey, userID))
+		w := httptest.NewRecorder()
+		boardDelivery.ExportBoard(w, req)
+		return w
+	}
+
+	t.Run("json by default", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().ExportBoard(gomock.Any(), userID, int64(7)).Return(export, nil)
+
+		w := doRequest("")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var got models.BoardExport
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		require.Len(t, got.Cards, 2)
+		assert.Equal(t, export.Cards[1].CustomFieldValues, got.Cards[1].CustomFieldValues)
+	})
+
+	t.Run("csv has a column per custom field and escaped formulas", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().ExportBoard(gomock.Any(), userID, int64(7)).Return(export, nil)
+
+		w := doRequest("?format=csv")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		rows, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"card_id", "title", "column", "deadline", "is_done", "created_at", "updated_at", "Priority", "Points"},
+			{"100", "Landing", "Doing", "2024-12-20T18:00:00Z", "false", "2024-12-01T10:00:00Z", "2024-12-01T10:00:00Z", "", "5"},
+			{"101", "'=Invoice", "Doing", "", "true", "2024-12-01T10:00:00Z", "2024-12-01T10:00:00Z", "high", ""},
+		}, rows)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		w := doRequest("?format=xml")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		mockBoardUsecase.EXPECT().ExportBoard(gomock.Any(), userID, int64(7)).Return(nil, errs.ErrNotPermitted)
+
+		w := doRequest("")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,239 @@
+package delivery
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/csvexport"
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultTimesheetPeriod - период отчёта, если from не указан
+const defaultTimesheetPeriod = 30 * 24 * time.Hour
+
+// StartTimer запускает таймер пользователя на карточке
+func (d *BoardDelivery) StartTimer(w http.ResponseWriter, r *http.Request) {
+	funcName := "StartTimer"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	cardID, err := requests.GetIDFromRequest(r, "cardID", "card_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	entry, err := d.boardUsecase.StartTimer(r.Context(), userID, cardID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, entry, http.StatusCreated)
+}
+
+// StopTimer останавливает таймер пользователя на карточке
+func (d *BoardDelivery) StopTimer(w http.ResponseWriter, r *http.Request) {
+	funcName := "StopTimer"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	cardID, err := requests.GetIDFromRequest(r, "cardID", "card_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	entry, err := d.boardUsecase.StopTimer(r.Context(), userID, cardID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, entry, http.StatusOK)
+}
+
+// GetRunningTimer возвращает запущенный таймер пользователя
+func (d *BoardDelivery) GetRunningTimer(w http.ResponseWriter, r *http.Request) {
+	funcName := "GetRunningTimer"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	entry, err := d.boardUsecase.GetRunningTimer(r.Context(), userID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, entry, http.StatusOK)
+}
+
+// AddWorkLog добавляет запись о потраченном времени вручную
+func (d *BoardDelivery) AddWorkLog(w http.ResponseWriter, r *http.Request) {
+	funcName := "AddWorkLog"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	cardID, err := requests.GetIDFromRequest(r, "cardID", "card_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	data := &models.WorkLogRequest{}
+	err = requests.GetRequestData(r, data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
+		return
+	}
+
+	entry, err := d.boardUsecase.AddWorkLog(r.Context(), userID, cardID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, entry, http.StatusCreated)
+}
+
+// DeleteTimeEntry удаляет запись учёта времени
+func (d *BoardDelivery) DeleteTimeEntry(w http.ResponseWriter, r *http.Request) {
+	funcName := "DeleteTimeEntry"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	entryID, err := requests.GetIDFromRequest(r, "entryID", "entry_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	err = d.boardUsecase.DeleteTimeEntry(r.Context(), userID, entryID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
+
+// GetBoardTimesheet отдаёт отчёт о потраченном на доске времени.
+// Период задаётся параметрами from и to (RFC3339 или YYYY-MM-DD),
+// при format=csv отчёт отдаётся файлом CSV
+func (d *BoardDelivery) GetBoardTimesheet(w http.ResponseWriter, r *http.Request) {
+	funcName := "GetBoardTimesheet"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	boardID, err := requests.GetIDFromRequest(r, "boardID", "board_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	from, to, err := parseTimesheetPeriod(r)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
+		return
+	}
+
+	timesheet, err := d.boardUsecase.GetBoardTimesheet(r.Context(), userID, boardID, from, to)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "csv" {
+		writeTimesheetCSV(w, timesheet)
+		return
+	}
+	responses.DoJSONResponse(w, timesheet, http.StatusOK)
+}
+
+// parseTimesheetPeriod достаёт период отчёта из query-параметров.
+// Если to задан датой, день to включается в отчёт целиком
+func parseTimesheetPeriod(r *http.Request) (from time.Time, to time.Time, err error) {
+	query := r.URL.Query()
+
+	to = time.Now()
+	if rawTo := query.Get("to"); rawTo != "" {
+		var isDate bool
+		to, isDate, err = parseTimesheetTime(rawTo)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parseTimesheetPeriod (to): %w", err)
+		}
+		if isDate {
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+
+	from = to.Add(-defaultTimesheetPeriod)
+	if rawFrom := query.Get("from"); rawFrom != "" {
+		from, _, err = parseTimesheetTime(rawFrom)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parseTimesheetPeriod (from): %w", err)
+		}
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("parseTimesheetPeriod: from must be before to")
+	}
+	return from, to, nil
+}
+
+// parseTimesheetTime разбирает время в формате RFC3339 или дату YYYY-MM-DD
+func parseTimesheetTime(raw string) (t time.Time, isDate bool, err error) {
+	t, err = time.Parse(time.RFC3339, raw)
+	if err == nil {
+		return t, false, nil
+	}
+	t, err = time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, true, nil
+}
+
+// writeTimesheetCSV отдаёт отчёт о времени в формате CSV
+func writeTimesheetCSV(w http.ResponseWriter, timesheet *models.Timesheet) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=\"timesheet_board_%d.csv\"", timesheet.BoardID))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csvexport.NewWriter(w)
+	_ = writer.Write([]string{"user_id", "nickname", "card_id", "card_title", "hours", "seconds"})
+	for _, row := range timesheet.Rows {
+		_ = writer.Write([]string{
+			strconv.FormatInt(row.UserID, 10),
+			row.Nickname,
+			strconv.FormatInt(row.CardID, 10),
+			row.CardTitle,
+			strconv.FormatFloat(float64(row.TotalSeconds)/3600, 'f', 2, 64),
+			strconv.FormatInt(row.TotalSeconds, 10),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Error("writeTimesheetCSV: ", err)
+	}
+}
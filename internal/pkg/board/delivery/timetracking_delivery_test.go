@@ -0,0 +1,27 @@
+package delivery
+
+import (
+	"RPO_back/internal/models"
+	"encoding/csv"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTimesheetCSV(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeTimesheetCSV(w, &models.Timesheet{
+		BoardID: 3,
+		Rows: []models.TimesheetRow{
+			{UserID: 1, Nickname: "@admin", CardID: 10, CardTitle: "=HYPERLINK(\"http://evil\")", TotalSeconds: 5400},
+		},
+	})
+
+	assert.Equal(t, "attachment; filename=\"timesheet_board_3.csv\"", w.Header().Get("Content-Disposition"))
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"1", "'@admin", "10", "'=HYPERLINK(\"http://evil\")", "1.50", "5400"}, records[1])
+}
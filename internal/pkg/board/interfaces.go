@@ -3,6 +3,7 @@ package board
 import (
 	"RPO_back/internal/models"
 	"context"
+	"time"
 )
 
 //go:generate mockgen -source=interfaces.go -destination=mocks/mock.go
@@ -17,6 +18,7 @@ type BoardUsecase interface {
 	UpdateMemberRole(ctx context.Context, userID int64, boardID int64, memberID int64, newRole string) (updatedMember *models.MemberWithPermissions, err error)
 	RemoveMember(ctx context.Context, userID int64, boardID int64, memberID int64) error
 	GetBoardContent(ctx context.Context, userID int64, boardID int64) (content *models.BoardContent, err error)
+	ExportBoard(ctx context.Context, userID int64, boardID int64) (export *models.BoardExport, err error)
 	CreateNewCard(ctx context.Context, userID int64, boardID int64, data *models.CardPostRequest) (newCard *models.Card, err error)
 	UpdateCard(ctx context.Context, userID int64, cardID int64, data *models.CardPatchRequest) (updatedCard *models.Card, err error)
 	DeleteCard(ctx context.Context, userID int64, cardID int64) (err error)
@@ -32,6 +34,26 @@ type BoardUsecase interface {
 	AddCheckListField(ctx context.Context, userID int64, cardID int64, fieldReq *models.CheckListFieldPostRequest) (newField *models.CheckListField, err error)
 	UpdateCheckListField(ctx context.Context, userID int64, fieldID int64, fieldReq *models.CheckListFieldPatchRequest) (updatedField *models.CheckListField, err error)
 	DeleteCheckListField(ctx context.Context, userID int64, fieldID int64) (err error)
+	PromoteCheckListField(ctx context.Context, userID int64, fieldID int64, promoteReq *models.CheckListFieldPromoteRequest) (newCard *models.Card, err error)
+	AddCheckList(ctx context.Context, userID int64, cardID int64, checkListReq *models.CheckListRequest) (newCheckList *models.CheckList, err error)
+	UpdateCheckList(ctx context.Context, userID int64, checkListID int64, checkListReq *models.CheckListRequest) (updatedCheckList *models.CheckList, err error)
+	DeleteCheckList(ctx context.Context, userID int64, checkListID int64) (err error)
+	StartTimer(ctx context.Context, userID int64, cardID int64) (entry *models.TimeEntry, err error)
+	StopTimer(ctx context.Context, userID int64, cardID int64) (entry *models.TimeEntry, err error)
+	GetRunningTimer(ctx context.Context, userID int64) (entry *models.TimeEntry, err error)
+	AddWorkLog(ctx context.Context, userID int64, cardID int64, workLog *models.WorkLogRequest) (entry *models.TimeEntry, err error)
+	DeleteTimeEntry(ctx context.Context, userID int64, entryID int64) (err error)
+	GetBoardTimesheet(ctx context.Context, userID int64, boardID int64, from time.Time, to time.Time) (timesheet *models.Timesheet, err error)
+	GetBoardCustomFields(ctx context.Context, userID int64, boardID int64) (fields []models.CustomField, err error)
+	CreateCustomField(ctx context.Context, userID int64, boardID int64, data *models.CustomFieldPostRequest) (newField *models.CustomField, err error)
+	UpdateCustomField(ctx context.Context, userID int64, fieldID int64, data *models.CustomFieldPutRequest) (updatedField *models.CustomField, err error)
+	DeleteCustomField(ctx context.Context, userID int64, fieldID int64) (err error)
+	SetCardCustomFieldValue(ctx context.Context, userID int64, cardID int64, fieldID int64, data *models.CustomFieldValueRequest) (fieldValue *models.CustomFieldValue, err error)
+	GetAutomationRules(ctx context.Context, userID int64, boardID int64) (rules []models.AutomationRule, err error)
+	CreateAutomationRule(ctx context.Context, userID int64, boardID int64, data *models.AutomationRuleRequest) (newRule *models.AutomationRule, err error)
+	UpdateAutomationRule(ctx context.Context, userID int64, ruleID int64, data *models.AutomationRuleRequest) (updatedRule *models.AutomationRule, err error)
+	DeleteAutomationRule(ctx context.Context, userID int64, ruleID int64) (err error)
+	RunDeadlineAutomations(ctx context.Context) (err error)
 	SetCardCover(ctx context.Context, userID int64, cardID int64, file *models.UploadedFile) (updatedCard *models.Card, err error)
 	DeleteCardCover(ctx context.Context, userID int64, cardID int64) (err error)
 	AddAttachment(ctx context.Context, userID int64, cardID int64, file *models.UploadedFile) (newAttachment *models.Attachment, err error)
@@ -54,7 +76,7 @@ type BoardRepo interface {
 	GetBoardsForUser(ctx context.Context, userID int64) (boardArray []models.Board, err error)
 	GetCardsForBoard(ctx context.Context, boardID int64) (cards []models.Card, err error)
 	GetColumnsForBoard(ctx context.Context, boardID int64) (columns []models.Column, err error)
-	CreateNewCard(ctx context.Context, columnID int64, title string) (newCard *models.Card, err error)
+	CreateNewCard(ctx context.Context, userID int64, columnID int64, title string) (newCard *models.Card, err error)
 	UpdateCard(ctx context.Context, cardID int64, data models.CardPatchRequest) (updateCard *models.Card, err error)
 	DeleteCard(ctx context.Context, cardID int64) (err error)
 	CreateColumn(ctx context.Context, boardId int64, title string) (newColumn *models.Column, err error)
@@ -70,26 +92,63 @@ type BoardRepo interface {
 	SetBoardBackground(ctx context.Context, userID int64, boardID int64, file *models.UploadedFile) (newBoard *models.Board, err error)
 	GetMemberFromCard(ctx context.Context, userID int64, cardID int64) (role string, boardID int64, err error)
 	GetMemberFromCheckListField(ctx context.Context, userID int64, fieldID int64) (role string, boardID int64, cardID int64, err error)
+	GetMemberFromCheckList(ctx context.Context, userID int64, checkListID int64) (role string, boardID int64, cardID int64, err error)
 	GetMemberFromAttachment(ctx context.Context, userID int64, attachmentID int64) (role string, boardID int64, cardID int64, err error)
 	GetMemberFromColumn(ctx context.Context, userID int64, columnID int64) (role string, boardID int64, err error)
 	GetMemberFromComment(ctx context.Context, userID int64, commentID int64) (role string, boardID int64, cardID int64, err error)
-	GetCardCheckList(ctx context.Context, cardID int64) (checkList []models.CheckListField, err error)
+	GetCardCheckLists(ctx context.Context, cardID int64) (checkLists []models.CheckList, err error)
 	GetCardAssignedUsers(ctx context.Context, cardID int64) (assignedUsers []models.UserProfile, err error)
 	GetCardComments(ctx context.Context, cardID int64) (comments []models.Comment, err error)
 	GetCardAttachments(ctx context.Context, cardID int64) (attachments []models.Attachment, err error)
 	GetCardsForMove(ctx context.Context, col1ID int64, col2ID *int64) (column1 []models.Card, column2 []models.Card, err error)
 	GetColumnsForMove(ctx context.Context, boardID int64) (columns []models.Column, err error)
-	RearrangeCards(ctx context.Context, columnID int64, cards []models.Card) (err error)
+	MoveCard(ctx context.Context, targetColumnID int64, targetCards []models.Card, sourceColumnID int64, sourceCards []models.Card) (err error)
 	RearrangeColumns(ctx context.Context, columns []models.Column) (err error)
-	RearrangeCheckList(ctx context.Context, fields []models.CheckListField) (err error)
+	MoveCheckListField(ctx context.Context, targetListID int64, targetFields []models.CheckListField, sourceListID int64, sourceFields []models.CheckListField) (err error)
 	AssignUserToCard(ctx context.Context, cardID int64, assignedUserID int64) (assignedUser *models.UserProfile, err error)
 	DeassignUserFromCard(ctx context.Context, cardID int64, assignedUserID int64) (err error)
 	CreateComment(ctx context.Context, userID int64, cardID int64, comment *models.CommentRequest) (newComment *models.Comment, err error)
 	UpdateComment(ctx context.Context, commentID int64, update *models.CommentRequest) (updatedComment *models.Comment, err error)
 	DeleteComment(ctx context.Context, commentID int64) (err error)
-	CreateCheckListField(ctx context.Context, cardID int64, field *models.CheckListFieldPostRequest) (newField *models.CheckListField, err error)
+	CreateCheckListField(ctx context.Context, checkListID int64, field *models.CheckListFieldPostRequest) (newField *models.CheckListField, err error)
 	UpdateCheckListField(ctx context.Context, fieldID int64, update *models.CheckListFieldPatchRequest) (updatedField *models.CheckListField, err error)
 	DeleteCheckListField(ctx context.Context, fieldID int64) error
+	CreateCheckList(ctx context.Context, cardID int64, title string) (newCheckList *models.CheckList, err error)
+	GetCheckListFields(ctx context.Context, checkListID int64) (fields []models.CheckListField, err error)
+	UpdateCheckList(ctx context.Context, checkListID int64, title string) (updatedCheckList *models.CheckList, err error)
+	DeleteCheckList(ctx context.Context, checkListID int64) (err error)
+	StartTimer(ctx context.Context, userID int64, cardID int64) (entry *models.TimeEntry, err error)
+	StopTimer(ctx context.Context, userID int64, cardID int64) (entry *models.TimeEntry, err error)
+	GetRunningTimer(ctx context.Context, userID int64) (entry *models.TimeEntry, err error)
+	CreateWorkLog(ctx context.Context, userID int64, cardID int64, workLog *models.WorkLogRequest) (entry *models.TimeEntry, err error)
+	DeleteTimeEntry(ctx context.Context, userID int64, entryID int64) (err error)
+	GetCardTimeEntries(ctx context.Context, cardID int64) (entries []models.TimeEntry, err error)
+	GetMemberFromTimeEntry(ctx context.Context, userID int64, entryID int64) (role string, boardID int64, cardID int64, err error)
+	GetBoardTimesheet(ctx context.Context, boardID int64, from time.Time, to time.Time) (timesheet []models.TimesheetRow, err error)
+	GetBoardCustomFields(ctx context.Context, boardID int64) (fields []models.CustomField, err error)
+	GetCustomField(ctx context.Context, fieldID int64) (field *models.CustomField, err error)
+	CreateCustomField(ctx context.Context, boardID int64, data *models.CustomFieldPostRequest) (newField *models.CustomField, err error)
+	UpdateCustomField(ctx context.Context, fieldID int64, data *models.CustomFieldPutRequest) (updatedField *models.CustomField, err error)
+	DeleteCustomField(ctx context.Context, fieldID int64) (err error)
+	GetMemberFromCustomField(ctx context.Context, userID int64, fieldID int64) (role string, boardID int64, err error)
+	SetCardCustomFieldValue(ctx context.Context, cardID int64, fieldID int64, value string) (fieldValue *models.CustomFieldValue, err error)
+	ClearCardCustomFieldValue(ctx context.Context, cardID int64, fieldID int64) (err error)
+	GetCardCustomFieldValues(ctx context.Context, cardID int64) (values []models.CustomFieldValue, err error)
+	GetBoardCustomFieldValues(ctx context.Context, boardID int64) (values []models.CustomFieldValue, err error)
+	GetCard(ctx context.Context, cardID int64) (card *models.Card, err error)
+	ClearCardDeadline(ctx context.Context, cardID int64) (err error)
+	GetBoardAutomationRules(ctx context.Context, boardID int64) (rules []models.AutomationRule, err error)
+	CreateAutomationRule(ctx context.Context, boardID int64, userID int64, data *models.AutomationRuleRequest) (newRule *models.AutomationRule, err error)
+	UpdateAutomationRule(ctx context.Context, ruleID int64, data *models.AutomationRuleRequest) (updatedRule *models.AutomationRule, err error)
+	DeleteAutomationRule(ctx context.Context, ruleID int64) (err error)
+	GetMemberFromAutomationRule(ctx context.Context, userID int64, ruleID int64) (role string, boardID int64, err error)
+	AddCardLabel(ctx context.Context, cardID int64, label string) (err error)
+	GetCardLabels(ctx context.Context, cardID int64) (labels []string, err error)
+	GetOverdueAutomationCards(ctx context.Context) (cards []models.OverdueCard, err error)
+	TryLockDeadlineAutomations(ctx context.Context) (release func(), acquired bool, err error)
+	MarkDeadlineAutomationFired(ctx context.Context, cardID int64, deadline time.Time) (err error)
+	CreateSubtaskCard(ctx context.Context, fieldID int64, columnID int64) (newCard *models.Card, err error)
+	GetCardSubtasks(ctx context.Context, cardID int64) (subtasks []models.Subtask, err error)
 	SetCardCover(ctx context.Context, userID int64, cardID int64, file *models.UploadedFile) (updatedCard *models.Card, err error)
 	RemoveCardCover(ctx context.Context, cardID int64) (err error)
 	AddAttachment(ctx context.Context, userID int64, cardID int64, file *models.UploadedFile) (newAttachment *models.Attachment, err error)
@@ -98,6 +157,5 @@ type BoardRepo interface {
 	DeleteInviteLink(ctx context.Context, userID int64, boardID int64) (err error)
 	FetchInvite(ctx context.Context, inviteUUID string) (board *models.Board, err error)
 	AcceptInvite(ctx context.Context, userID int64, boardID int64, invitedUserID int64, inviteUUID string) (board *models.Board, err error)
-	DeduplicateFile(ctx context.Context, file *models.UploadedFile) (fileNames []string, fileIDs []int64, err error)
-	RegisterFile(ctx context.Context, file *models.UploadedFile) error
+	RegisterFile(ctx context.Context, file *models.UploadedFile) (isNew bool, err error)
 }
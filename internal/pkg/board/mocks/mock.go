@@ -8,6 +8,7 @@ import (
 	models "RPO_back/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -65,6 +66,21 @@ func (mr *MockBoardUsecaseMockRecorder) AddAttachment(ctx, userID, cardID, file
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockBoardUsecase)(nil).AddAttachment), ctx, userID, cardID, file)
 }
 
+// AddCheckList mocks base method.
+func (m *MockBoardUsecase) AddCheckList(ctx context.Context, userID, cardID int64, checkListReq *models.CheckListRequest) (*models.CheckList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCheckList", ctx, userID, cardID, checkListReq)
+	ret0, _ := ret[0].(*models.CheckList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCheckList indicates an expected call of AddCheckList.
+func (mr *MockBoardUsecaseMockRecorder) AddCheckList(ctx, userID, cardID, checkListReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCheckList", reflect.TypeOf((*MockBoardUsecase)(nil).AddCheckList), ctx, userID, cardID, checkListReq)
+}
+
 // AddCheckListField mocks base method.
 func (m *MockBoardUsecase) AddCheckListField(ctx context.Context, userID, cardID int64, fieldReq *models.CheckListFieldPostRequest) (*models.CheckListField, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +126,21 @@ func (mr *MockBoardUsecaseMockRecorder) AddMember(ctx, userID, boardID, addReque
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockBoardUsecase)(nil).AddMember), ctx, userID, boardID, addRequest)
 }
 
+// AddWorkLog mocks base method.
+func (m *MockBoardUsecase) AddWorkLog(ctx context.Context, userID, cardID int64, workLog *models.WorkLogRequest) (*models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkLog", ctx, userID, cardID, workLog)
+	ret0, _ := ret[0].(*models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWorkLog indicates an expected call of AddWorkLog.
+func (mr *MockBoardUsecaseMockRecorder) AddWorkLog(ctx, userID, cardID, workLog interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkLog", reflect.TypeOf((*MockBoardUsecase)(nil).AddWorkLog), ctx, userID, cardID, workLog)
+}
+
 // AssignUser mocks base method.
 func (m *MockBoardUsecase) AssignUser(ctx context.Context, userID, cardID, assignedUserID int64) (*models.UserProfile, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +156,21 @@ func (mr *MockBoardUsecaseMockRecorder) AssignUser(ctx, userID, cardID, assigned
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUser", reflect.TypeOf((*MockBoardUsecase)(nil).AssignUser), ctx, userID, cardID, assignedUserID)
 }
 
+// CreateAutomationRule mocks base method.
+func (m *MockBoardUsecase) CreateAutomationRule(ctx context.Context, userID, boardID int64, data *models.AutomationRuleRequest) (*models.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAutomationRule", ctx, userID, boardID, data)
+	ret0, _ := ret[0].(*models.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAutomationRule indicates an expected call of CreateAutomationRule.
+func (mr *MockBoardUsecaseMockRecorder) CreateAutomationRule(ctx, userID, boardID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAutomationRule", reflect.TypeOf((*MockBoardUsecase)(nil).CreateAutomationRule), ctx, userID, boardID, data)
+}
+
 // CreateColumn mocks base method.
 func (m *MockBoardUsecase) CreateColumn(ctx context.Context, userID, boardID int64, data *models.ColumnRequest) (*models.Column, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +186,21 @@ func (mr *MockBoardUsecaseMockRecorder) CreateColumn(ctx, userID, boardID, data
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateColumn", reflect.TypeOf((*MockBoardUsecase)(nil).CreateColumn), ctx, userID, boardID, data)
 }
 
+// CreateCustomField mocks base method.
+func (m *MockBoardUsecase) CreateCustomField(ctx context.Context, userID, boardID int64, data *models.CustomFieldPostRequest) (*models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomField", ctx, userID, boardID, data)
+	ret0, _ := ret[0].(*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockBoardUsecaseMockRecorder) CreateCustomField(ctx, userID, boardID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*MockBoardUsecase)(nil).CreateCustomField), ctx, userID, boardID, data)
+}
+
 // CreateNewBoard mocks base method.
 func (m *MockBoardUsecase) CreateNewBoard(ctx context.Context, userID int64, data models.BoardRequest) (*models.Board, error) {
 	m.ctrl.T.Helper()
@@ -198,6 +259,20 @@ func (mr *MockBoardUsecaseMockRecorder) DeleteAttachment(ctx, userID, attachment
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockBoardUsecase)(nil).DeleteAttachment), ctx, userID, attachmentID)
 }
 
+// DeleteAutomationRule mocks base method.
+func (m *MockBoardUsecase) DeleteAutomationRule(ctx context.Context, userID, ruleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAutomationRule", ctx, userID, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAutomationRule indicates an expected call of DeleteAutomationRule.
+func (mr *MockBoardUsecaseMockRecorder) DeleteAutomationRule(ctx, userID, ruleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAutomationRule", reflect.TypeOf((*MockBoardUsecase)(nil).DeleteAutomationRule), ctx, userID, ruleID)
+}
+
 // DeleteBoard mocks base method.
 func (m *MockBoardUsecase) DeleteBoard(ctx context.Context, userID, boardID int64) error {
 	m.ctrl.T.Helper()
@@ -240,6 +315,20 @@ func (mr *MockBoardUsecaseMockRecorder) DeleteCardCover(ctx, userID, cardID inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardCover", reflect.TypeOf((*MockBoardUsecase)(nil).DeleteCardCover), ctx, userID, cardID)
 }
 
+// DeleteCheckList mocks base method.
+func (m *MockBoardUsecase) DeleteCheckList(ctx context.Context, userID, checkListID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCheckList", ctx, userID, checkListID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCheckList indicates an expected call of DeleteCheckList.
+func (mr *MockBoardUsecaseMockRecorder) DeleteCheckList(ctx, userID, checkListID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCheckList", reflect.TypeOf((*MockBoardUsecase)(nil).DeleteCheckList), ctx, userID, checkListID)
+}
+
 // DeleteCheckListField mocks base method.
 func (m *MockBoardUsecase) DeleteCheckListField(ctx context.Context, userID, fieldID int64) error {
 	m.ctrl.T.Helper()
@@ -282,6 +371,20 @@ func (mr *MockBoardUsecaseMockRecorder) DeleteComment(ctx, userID, commentID int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockBoardUsecase)(nil).DeleteComment), ctx, userID, commentID)
 }
 
+// DeleteCustomField mocks base method.
+func (m *MockBoardUsecase) DeleteCustomField(ctx context.Context, userID, fieldID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomField", ctx, userID, fieldID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomField indicates an expected call of DeleteCustomField.
+func (mr *MockBoardUsecaseMockRecorder) DeleteCustomField(ctx, userID, fieldID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomField", reflect.TypeOf((*MockBoardUsecase)(nil).DeleteCustomField), ctx, userID, fieldID)
+}
+
 // DeleteInviteLink mocks base method.
 func (m *MockBoardUsecase) DeleteInviteLink(ctx context.Context, userID, boardID int64) error {
 	m.ctrl.T.Helper()
@@ -296,6 +399,35 @@ func (mr *MockBoardUsecaseMockRecorder) DeleteInviteLink(ctx, userID, boardID in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInviteLink", reflect.TypeOf((*MockBoardUsecase)(nil).DeleteInviteLink), ctx, userID, boardID)
 }
 
+// DeleteTimeEntry mocks base method.
+func (m *MockBoardUsecase) DeleteTimeEntry(ctx context.Context, userID, entryID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimeEntry", ctx, userID, entryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTimeEntry indicates an expected call of DeleteTimeEntry.
+func (mr *MockBoardUsecaseMockRecorder) DeleteTimeEntry(ctx, userID, entryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimeEntry", reflect.TypeOf((*MockBoardUsecase)(nil).DeleteTimeEntry), ctx, userID, entryID)
+}
+
+// ExportBoard mocks base method.
+func (m *MockBoardUsecase) ExportBoard(ctx context.Context, userID, boardID int64) (*models.BoardExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportBoard", ctx, userID, boardID)
+	ret0, _ := ret[0].(*models.BoardExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportBoard indicates an expected call of ExportBoard.
+func (mr *MockBoardUsecaseMockRecorder) ExportBoard(ctx, userID, boardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBoard", reflect.TypeOf((*MockBoardUsecase)(nil).ExportBoard), ctx, userID, boardID)
+}
+
 // FetchInvite mocks base method.
 func (m *MockBoardUsecase) FetchInvite(ctx context.Context, inviteUUID string) (*models.Board, error) {
 	m.ctrl.T.Helper()
@@ -311,6 +443,21 @@ func (mr *MockBoardUsecaseMockRecorder) FetchInvite(ctx, inviteUUID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchInvite", reflect.TypeOf((*MockBoardUsecase)(nil).FetchInvite), ctx, inviteUUID)
 }
 
+// GetAutomationRules mocks base method.
+func (m *MockBoardUsecase) GetAutomationRules(ctx context.Context, userID, boardID int64) ([]models.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutomationRules", ctx, userID, boardID)
+	ret0, _ := ret[0].([]models.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutomationRules indicates an expected call of GetAutomationRules.
+func (mr *MockBoardUsecaseMockRecorder) GetAutomationRules(ctx, userID, boardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutomationRules", reflect.TypeOf((*MockBoardUsecase)(nil).GetAutomationRules), ctx, userID, boardID)
+}
+
 // GetBoardContent mocks base method.
 func (m *MockBoardUsecase) GetBoardContent(ctx context.Context, userID, boardID int64) (*models.BoardContent, error) {
 	m.ctrl.T.Helper()
@@ -326,6 +473,51 @@ func (mr *MockBoardUsecaseMockRecorder) GetBoardContent(ctx, userID, boardID int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardContent", reflect.TypeOf((*MockBoardUsecase)(nil).GetBoardContent), ctx, userID, boardID)
 }
 
+// GetBoardCustomFields mocks base method.
+func (m *MockBoardUsecase) GetBoardCustomFields(ctx context.Context, userID, boardID int64) ([]models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCustomFields", ctx, userID, boardID)
+	ret0, _ := ret[0].([]models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCustomFields indicates an expected call of GetBoardCustomFields.
+func (mr *MockBoardUsecaseMockRecorder) GetBoardCustomFields(ctx, userID, boardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCustomFields", reflect.TypeOf((*MockBoardUsecase)(nil).GetBoardCustomFields), ctx, userID, boardID)
+}
+
+// GetBoardTimesheet mocks base method.
+func (m *MockBoardUsecase) GetBoardTimesheet(ctx context.Context, userID, boardID int64, from, to time.Time) (*models.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardTimesheet", ctx, userID, boardID, from, to)
+	ret0, _ := ret[0].(*models.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardTimesheet indicates an expected call of GetBoardTimesheet.
+func (mr *MockBoardUsecaseMockRecorder) GetBoardTimesheet(ctx, userID, boardID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardTimesheet", reflect.TypeOf((*MockBoardUsecase)(nil).GetBoardTimesheet), ctx, userID, boardID, from, to)
+}
+
+// GetCardDetails mocks base method.
+func (m *MockBoardUsecase) GetCardDetails(ctx context.Context, userID, cardID int64) (*models.CardDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardDetails", ctx, userID, cardID)
+	ret0, _ := ret[0].(*models.CardDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardDetails indicates an expected call of GetCardDetails.
+func (mr *MockBoardUsecaseMockRecorder) GetCardDetails(ctx, userID, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardDetails", reflect.TypeOf((*MockBoardUsecase)(nil).GetCardDetails), ctx, userID, cardID)
+}
+
 // GetMembersPermissions mocks base method.
 func (m *MockBoardUsecase) GetMembersPermissions(ctx context.Context, userID, boardID int64) ([]models.MemberWithPermissions, error) {
 	m.ctrl.T.Helper()
@@ -356,6 +548,21 @@ func (mr *MockBoardUsecaseMockRecorder) GetMyBoards(ctx, userID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyBoards", reflect.TypeOf((*MockBoardUsecase)(nil).GetMyBoards), ctx, userID)
 }
 
+// GetRunningTimer mocks base method.
+func (m *MockBoardUsecase) GetRunningTimer(ctx context.Context, userID int64) (*models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningTimer", ctx, userID)
+	ret0, _ := ret[0].(*models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningTimer indicates an expected call of GetRunningTimer.
+func (mr *MockBoardUsecaseMockRecorder) GetRunningTimer(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTimer", reflect.TypeOf((*MockBoardUsecase)(nil).GetRunningTimer), ctx, userID)
+}
+
 // GetSharedCard mocks base method.
 func (m *MockBoardUsecase) GetSharedCard(ctx context.Context, userID int64, cardUuid string) (*models.SharedCardFoundResponse, *models.SharedCardDummyResponse, error) {
 	m.ctrl.T.Helper()
@@ -400,6 +607,21 @@ func (mr *MockBoardUsecaseMockRecorder) MoveColumn(ctx, userID, columnID, moveRe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveColumn", reflect.TypeOf((*MockBoardUsecase)(nil).MoveColumn), ctx, userID, columnID, moveReq)
 }
 
+// PromoteCheckListField mocks base method.
+func (m *MockBoardUsecase) PromoteCheckListField(ctx context.Context, userID, fieldID int64, promoteReq *models.CheckListFieldPromoteRequest) (*models.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteCheckListField", ctx, userID, fieldID, promoteReq)
+	ret0, _ := ret[0].(*models.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteCheckListField indicates an expected call of PromoteCheckListField.
+func (mr *MockBoardUsecaseMockRecorder) PromoteCheckListField(ctx, userID, fieldID, promoteReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteCheckListField", reflect.TypeOf((*MockBoardUsecase)(nil).PromoteCheckListField), ctx, userID, fieldID, promoteReq)
+}
+
 // RaiseInviteLink mocks base method.
 func (m *MockBoardUsecase) RaiseInviteLink(ctx context.Context, userID, boardID int64) (*models.InviteLink, error) {
 	m.ctrl.T.Helper()
@@ -429,6 +651,20 @@ func (mr *MockBoardUsecaseMockRecorder) RemoveMember(ctx, userID, boardID, membe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockBoardUsecase)(nil).RemoveMember), ctx, userID, boardID, memberID)
 }
 
+// RunDeadlineAutomations mocks base method.
+func (m *MockBoardUsecase) RunDeadlineAutomations(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunDeadlineAutomations", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunDeadlineAutomations indicates an expected call of RunDeadlineAutomations.
+func (mr *MockBoardUsecaseMockRecorder) RunDeadlineAutomations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDeadlineAutomations", reflect.TypeOf((*MockBoardUsecase)(nil).RunDeadlineAutomations), ctx)
+}
+
 // SetBoardBackground mocks base method.
 func (m *MockBoardUsecase) SetBoardBackground(ctx context.Context, userID, boardID int64, file *models.UploadedFile) (*models.Board, error) {
 	m.ctrl.T.Helper()
@@ -459,6 +695,66 @@ func (mr *MockBoardUsecaseMockRecorder) SetCardCover(ctx, userID, cardID, file i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCardCover", reflect.TypeOf((*MockBoardUsecase)(nil).SetCardCover), ctx, userID, cardID, file)
 }
 
+// SetCardCustomFieldValue mocks base method.
+func (m *MockBoardUsecase) SetCardCustomFieldValue(ctx context.Context, userID, cardID, fieldID int64, data *models.CustomFieldValueRequest) (*models.CustomFieldValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCardCustomFieldValue", ctx, userID, cardID, fieldID, data)
+	ret0, _ := ret[0].(*models.CustomFieldValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCardCustomFieldValue indicates an expected call of SetCardCustomFieldValue.
+func (mr *MockBoardUsecaseMockRecorder) SetCardCustomFieldValue(ctx, userID, cardID, fieldID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCardCustomFieldValue", reflect.TypeOf((*MockBoardUsecase)(nil).SetCardCustomFieldValue), ctx, userID, cardID, fieldID, data)
+}
+
+// StartTimer mocks base method.
+func (m *MockBoardUsecase) StartTimer(ctx context.Context, userID, cardID int64) (*models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", ctx, userID, cardID)
+	ret0, _ := ret[0].(*models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockBoardUsecaseMockRecorder) StartTimer(ctx, userID, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockBoardUsecase)(nil).StartTimer), ctx, userID, cardID)
+}
+
+// StopTimer mocks base method.
+func (m *MockBoardUsecase) StopTimer(ctx context.Context, userID, cardID int64) (*models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", ctx, userID, cardID)
+	ret0, _ := ret[0].(*models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockBoardUsecaseMockRecorder) StopTimer(ctx, userID, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockBoardUsecase)(nil).StopTimer), ctx, userID, cardID)
+}
+
+// UpdateAutomationRule mocks base method.
+func (m *MockBoardUsecase) UpdateAutomationRule(ctx context.Context, userID, ruleID int64, data *models.AutomationRuleRequest) (*models.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAutomationRule", ctx, userID, ruleID, data)
+	ret0, _ := ret[0].(*models.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAutomationRule indicates an expected call of UpdateAutomationRule.
+func (mr *MockBoardUsecaseMockRecorder) UpdateAutomationRule(ctx, userID, ruleID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAutomationRule", reflect.TypeOf((*MockBoardUsecase)(nil).UpdateAutomationRule), ctx, userID, ruleID, data)
+}
+
 // UpdateBoard mocks base method.
 func (m *MockBoardUsecase) UpdateBoard(ctx context.Context, userID, boardID int64, data models.BoardRequest) (*models.Board, error) {
 	m.ctrl.T.Helper()
@@ -489,6 +785,21 @@ func (mr *MockBoardUsecaseMockRecorder) UpdateCard(ctx, userID, cardID, data int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCard", reflect.TypeOf((*MockBoardUsecase)(nil).UpdateCard), ctx, userID, cardID, data)
 }
 
+// UpdateCheckList mocks base method.
+func (m *MockBoardUsecase) UpdateCheckList(ctx context.Context, userID, checkListID int64, checkListReq *models.CheckListRequest) (*models.CheckList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCheckList", ctx, userID, checkListID, checkListReq)
+	ret0, _ := ret[0].(*models.CheckList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCheckList indicates an expected call of UpdateCheckList.
+func (mr *MockBoardUsecaseMockRecorder) UpdateCheckList(ctx, userID, checkListID, checkListReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCheckList", reflect.TypeOf((*MockBoardUsecase)(nil).UpdateCheckList), ctx, userID, checkListID, checkListReq)
+}
+
 // UpdateCheckListField mocks base method.
 func (m *MockBoardUsecase) UpdateCheckListField(ctx context.Context, userID, fieldID int64, fieldReq *models.CheckListFieldPatchRequest) (*models.CheckListField, error) {
 	m.ctrl.T.Helper()
@@ -534,6 +845,21 @@ func (mr *MockBoardUsecaseMockRecorder) UpdateComment(ctx, userID, commentID, co
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockBoardUsecase)(nil).UpdateComment), ctx, userID, commentID, commentReq)
 }
 
+// UpdateCustomField mocks base method.
+func (m *MockBoardUsecase) UpdateCustomField(ctx context.Context, userID, fieldID int64, data *models.CustomFieldPutRequest) (*models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomField", ctx, userID, fieldID, data)
+	ret0, _ := ret[0].(*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockBoardUsecaseMockRecorder) UpdateCustomField(ctx, userID, fieldID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*MockBoardUsecase)(nil).UpdateCustomField), ctx, userID, fieldID, data)
+}
+
 // UpdateMemberRole mocks base method.
 func (m *MockBoardUsecase) UpdateMemberRole(ctx context.Context, userID, boardID, memberID int64, newRole string) (*models.MemberWithPermissions, error) {
 	m.ctrl.T.Helper()
@@ -602,6 +928,20 @@ func (mr *MockBoardRepoMockRecorder) AddAttachment(ctx, userID, cardID, file int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockBoardRepo)(nil).AddAttachment), ctx, userID, cardID, file)
 }
 
+// AddCardLabel mocks base method.
+func (m *MockBoardRepo) AddCardLabel(ctx context.Context, cardID int64, label string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCardLabel", ctx, cardID, label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCardLabel indicates an expected call of AddCardLabel.
+func (mr *MockBoardRepoMockRecorder) AddCardLabel(ctx, cardID, label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCardLabel", reflect.TypeOf((*MockBoardRepo)(nil).AddCardLabel), ctx, cardID, label)
+}
+
 // AddMember mocks base method.
 func (m *MockBoardRepo) AddMember(ctx context.Context, boardID, adderID, memberUserID int64) (*models.MemberWithPermissions, error) {
 	m.ctrl.T.Helper()
@@ -632,6 +972,49 @@ func (mr *MockBoardRepoMockRecorder) AssignUserToCard(ctx, cardID, assignedUserI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUserToCard", reflect.TypeOf((*MockBoardRepo)(nil).AssignUserToCard), ctx, cardID, assignedUserID)
 }
 
+// ClearCardCustomFieldValue mocks base method.
+func (m *MockBoardRepo) ClearCardCustomFieldValue(ctx context.Context, cardID, fieldID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCardCustomFieldValue", ctx, cardID, fieldID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCardCustomFieldValue indicates an expected call of ClearCardCustomFieldValue.
+func (mr *MockBoardRepoMockRecorder) ClearCardCustomFieldValue(ctx, cardID, fieldID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCardCustomFieldValue", reflect.TypeOf((*MockBoardRepo)(nil).ClearCardCustomFieldValue), ctx, cardID, fieldID)
+}
+
+// ClearCardDeadline mocks base method.
+func (m *MockBoardRepo) ClearCardDeadline(ctx context.Context, cardID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCardDeadline", ctx, cardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCardDeadline indicates an expected call of ClearCardDeadline.
+func (mr *MockBoardRepoMockRecorder) ClearCardDeadline(ctx, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCardDeadline", reflect.TypeOf((*MockBoardRepo)(nil).ClearCardDeadline), ctx, cardID)
+}
+
+// CreateAutomationRule mocks base method.
+func (m *MockBoardRepo) CreateAutomationRule(ctx context.Context, boardID, userID int64, data *models.AutomationRuleRequest) (*models.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAutomationRule", ctx, boardID, userID, data)
+	ret0, _ := ret[0].(*models.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAutomationRule indicates an expected call of CreateAutomationRule.
+func (mr *MockBoardRepoMockRecorder) CreateAutomationRule(ctx, boardID, userID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAutomationRule", reflect.TypeOf((*MockBoardRepo)(nil).CreateAutomationRule), ctx, boardID, userID, data)
+}
+
 // CreateBoard mocks base method.
 func (m *MockBoardRepo) CreateBoard(ctx context.Context, name string, userID int64) (*models.Board, error) {
 	m.ctrl.T.Helper()
@@ -647,19 +1030,34 @@ func (mr *MockBoardRepoMockRecorder) CreateBoard(ctx, name, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoard", reflect.TypeOf((*MockBoardRepo)(nil).CreateBoard), ctx, name, userID)
 }
 
+// CreateCheckList mocks base method.
+func (m *MockBoardRepo) CreateCheckList(ctx context.Context, cardID int64, title string) (*models.CheckList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCheckList", ctx, cardID, title)
+	ret0, _ := ret[0].(*models.CheckList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCheckList indicates an expected call of CreateCheckList.
+func (mr *MockBoardRepoMockRecorder) CreateCheckList(ctx, cardID, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckList", reflect.TypeOf((*MockBoardRepo)(nil).CreateCheckList), ctx, cardID, title)
+}
+
 // CreateCheckListField mocks base method.
-func (m *MockBoardRepo) CreateCheckListField(ctx context.Context, cardID int64, field *models.CheckListFieldPostRequest) (*models.CheckListField, error) {
+func (m *MockBoardRepo) CreateCheckListField(ctx context.Context, checkListID int64, field *models.CheckListFieldPostRequest) (*models.CheckListField, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCheckListField", ctx, cardID, field)
+	ret := m.ctrl.Call(m, "CreateCheckListField", ctx, checkListID, field)
 	ret0, _ := ret[0].(*models.CheckListField)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateCheckListField indicates an expected call of CreateCheckListField.
-func (mr *MockBoardRepoMockRecorder) CreateCheckListField(ctx, cardID, field interface{}) *gomock.Call {
+func (mr *MockBoardRepoMockRecorder) CreateCheckListField(ctx, checkListID, field interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckListField", reflect.TypeOf((*MockBoardRepo)(nil).CreateCheckListField), ctx, cardID, field)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckListField", reflect.TypeOf((*MockBoardRepo)(nil).CreateCheckListField), ctx, checkListID, field)
 }
 
 // CreateColumn mocks base method.
@@ -692,19 +1090,64 @@ func (mr *MockBoardRepoMockRecorder) CreateComment(ctx, userID, cardID, comment
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockBoardRepo)(nil).CreateComment), ctx, userID, cardID, comment)
 }
 
+// CreateCustomField mocks base method.
+func (m *MockBoardRepo) CreateCustomField(ctx context.Context, boardID int64, data *models.CustomFieldPostRequest) (*models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomField", ctx, boardID, data)
+	ret0, _ := ret[0].(*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockBoardRepoMockRecorder) CreateCustomField(ctx, boardID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*MockBoardRepo)(nil).CreateCustomField), ctx, boardID, data)
+}
+
 // CreateNewCard mocks base method.
-func (m *MockBoardRepo) CreateNewCard(ctx context.Context, columnID int64, title string) (*models.Card, error) {
+func (m *MockBoardRepo) CreateNewCard(ctx context.Context, userID, columnID int64, title string) (*models.Card, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNewCard", ctx, columnID, title)
+	ret := m.ctrl.Call(m, "CreateNewCard", ctx, userID, columnID, title)
 	ret0, _ := ret[0].(*models.Card)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateNewCard indicates an expected call of CreateNewCard.
-func (mr *MockBoardRepoMockRecorder) CreateNewCard(ctx, columnID, title interface{}) *gomock.Call {
+func (mr *MockBoardRepoMockRecorder) CreateNewCard(ctx, userID, columnID, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewCard", reflect.TypeOf((*MockBoardRepo)(nil).CreateNewCard), ctx, userID, columnID, title)
+}
+
+// CreateSubtaskCard mocks base method.
+func (m *MockBoardRepo) CreateSubtaskCard(ctx context.Context, fieldID, columnID int64) (*models.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubtaskCard", ctx, fieldID, columnID)
+	ret0, _ := ret[0].(*models.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubtaskCard indicates an expected call of CreateSubtaskCard.
+func (mr *MockBoardRepoMockRecorder) CreateSubtaskCard(ctx, fieldID, columnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubtaskCard", reflect.TypeOf((*MockBoardRepo)(nil).CreateSubtaskCard), ctx, fieldID, columnID)
+}
+
+// CreateWorkLog mocks base method.
+func (m *MockBoardRepo) CreateWorkLog(ctx context.Context, userID, cardID int64, workLog *models.WorkLogRequest) (*models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkLog", ctx, userID, cardID, workLog)
+	ret0, _ := ret[0].(*models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkLog indicates an expected call of CreateWorkLog.
+func (mr *MockBoardRepoMockRecorder) CreateWorkLog(ctx, userID, cardID, workLog interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewCard", reflect.TypeOf((*MockBoardRepo)(nil).CreateNewCard), ctx, columnID, title)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkLog", reflect.TypeOf((*MockBoardRepo)(nil).CreateWorkLog), ctx, userID, cardID, workLog)
 }
 
 // DeassignUserFromCard mocks base method.
@@ -721,20 +1164,18 @@ func (mr *MockBoardRepoMockRecorder) DeassignUserFromCard(ctx, cardID, assignedU
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeassignUserFromCard", reflect.TypeOf((*MockBoardRepo)(nil).DeassignUserFromCard), ctx, cardID, assignedUserID)
 }
 
-// DeduplicateFile mocks base method.
-func (m *MockBoardRepo) DeduplicateFile(ctx context.Context, file *models.UploadedFile) ([]string, []int64, error) {
+// DeleteAutomationRule mocks base method.
+func (m *MockBoardRepo) DeleteAutomationRule(ctx context.Context, ruleID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeduplicateFile", ctx, file)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].([]int64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "DeleteAutomationRule", ctx, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// DeduplicateFile indicates an expected call of DeduplicateFile.
-func (mr *MockBoardRepoMockRecorder) DeduplicateFile(ctx, file interface{}) *gomock.Call {
+// DeleteAutomationRule indicates an expected call of DeleteAutomationRule.
+func (mr *MockBoardRepoMockRecorder) DeleteAutomationRule(ctx, ruleID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeduplicateFile", reflect.TypeOf((*MockBoardRepo)(nil).DeduplicateFile), ctx, file)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAutomationRule", reflect.TypeOf((*MockBoardRepo)(nil).DeleteAutomationRule), ctx, ruleID)
 }
 
 // DeleteBoard mocks base method.
@@ -765,22 +1206,50 @@ func (mr *MockBoardRepoMockRecorder) DeleteCard(ctx, cardID interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCard", reflect.TypeOf((*MockBoardRepo)(nil).DeleteCard), ctx, cardID)
 }
 
-// DeleteColumn mocks base method.
-func (m *MockBoardRepo) DeleteColumn(ctx context.Context, columnID int64) error {
+// DeleteCheckList mocks base method.
+func (m *MockBoardRepo) DeleteCheckList(ctx context.Context, checkListID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteColumn", ctx, columnID)
+	ret := m.ctrl.Call(m, "DeleteCheckList", ctx, checkListID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteColumn indicates an expected call of DeleteColumn.
-func (mr *MockBoardRepoMockRecorder) DeleteColumn(ctx, columnID interface{}) *gomock.Call {
+// DeleteCheckList indicates an expected call of DeleteCheckList.
+func (mr *MockBoardRepoMockRecorder) DeleteCheckList(ctx, checkListID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteColumn", reflect.TypeOf((*MockBoardRepo)(nil).DeleteColumn), ctx, columnID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCheckList", reflect.TypeOf((*MockBoardRepo)(nil).DeleteCheckList), ctx, checkListID)
 }
 
-// DeleteComment mocks base method.
-func (m *MockBoardRepo) DeleteComment(ctx context.Context, commentID int64) error {
+// DeleteCheckListField mocks base method.
+func (m *MockBoardRepo) DeleteCheckListField(ctx context.Context, fieldID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCheckListField", ctx, fieldID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCheckListField indicates an expected call of DeleteCheckListField.
+func (mr *MockBoardRepoMockRecorder) DeleteCheckListField(ctx, fieldID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCheckListField", reflect.TypeOf((*MockBoardRepo)(nil).DeleteCheckListField), ctx, fieldID)
+}
+
+// DeleteColumn mocks base method.
+func (m *MockBoardRepo) DeleteColumn(ctx context.Context, columnID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteColumn", ctx, columnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteColumn indicates an expected call of DeleteColumn.
+func (mr *MockBoardRepoMockRecorder) DeleteColumn(ctx, columnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteColumn", reflect.TypeOf((*MockBoardRepo)(nil).DeleteColumn), ctx, columnID)
+}
+
+// DeleteComment mocks base method.
+func (m *MockBoardRepo) DeleteComment(ctx context.Context, commentID int64) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteComment", ctx, commentID)
 	ret0, _ := ret[0].(error)
@@ -793,6 +1262,20 @@ func (mr *MockBoardRepoMockRecorder) DeleteComment(ctx, commentID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockBoardRepo)(nil).DeleteComment), ctx, commentID)
 }
 
+// DeleteCustomField mocks base method.
+func (m *MockBoardRepo) DeleteCustomField(ctx context.Context, fieldID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomField", ctx, fieldID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomField indicates an expected call of DeleteCustomField.
+func (mr *MockBoardRepoMockRecorder) DeleteCustomField(ctx, fieldID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomField", reflect.TypeOf((*MockBoardRepo)(nil).DeleteCustomField), ctx, fieldID)
+}
+
 // DeleteInviteLink mocks base method.
 func (m *MockBoardRepo) DeleteInviteLink(ctx context.Context, userID, boardID int64) error {
 	m.ctrl.T.Helper()
@@ -807,6 +1290,20 @@ func (mr *MockBoardRepoMockRecorder) DeleteInviteLink(ctx, userID, boardID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInviteLink", reflect.TypeOf((*MockBoardRepo)(nil).DeleteInviteLink), ctx, userID, boardID)
 }
 
+// DeleteTimeEntry mocks base method.
+func (m *MockBoardRepo) DeleteTimeEntry(ctx context.Context, userID, entryID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimeEntry", ctx, userID, entryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTimeEntry indicates an expected call of DeleteTimeEntry.
+func (mr *MockBoardRepoMockRecorder) DeleteTimeEntry(ctx, userID, entryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimeEntry", reflect.TypeOf((*MockBoardRepo)(nil).DeleteTimeEntry), ctx, userID, entryID)
+}
+
 // FetchInvite mocks base method.
 func (m *MockBoardRepo) FetchInvite(ctx context.Context, inviteUUID string) (*models.Board, error) {
 	m.ctrl.T.Helper()
@@ -837,6 +1334,66 @@ func (mr *MockBoardRepoMockRecorder) GetBoard(ctx, boardID, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockBoardRepo)(nil).GetBoard), ctx, boardID, userID)
 }
 
+// GetBoardAutomationRules mocks base method.
+func (m *MockBoardRepo) GetBoardAutomationRules(ctx context.Context, boardID int64) ([]models.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardAutomationRules", ctx, boardID)
+	ret0, _ := ret[0].([]models.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardAutomationRules indicates an expected call of GetBoardAutomationRules.
+func (mr *MockBoardRepoMockRecorder) GetBoardAutomationRules(ctx, boardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAutomationRules", reflect.TypeOf((*MockBoardRepo)(nil).GetBoardAutomationRules), ctx, boardID)
+}
+
+// GetBoardCustomFieldValues mocks base method.
+func (m *MockBoardRepo) GetBoardCustomFieldValues(ctx context.Context, boardID int64) ([]models.CustomFieldValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCustomFieldValues", ctx, boardID)
+	ret0, _ := ret[0].([]models.CustomFieldValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCustomFieldValues indicates an expected call of GetBoardCustomFieldValues.
+func (mr *MockBoardRepoMockRecorder) GetBoardCustomFieldValues(ctx, boardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCustomFieldValues", reflect.TypeOf((*MockBoardRepo)(nil).GetBoardCustomFieldValues), ctx, boardID)
+}
+
+// GetBoardCustomFields mocks base method.
+func (m *MockBoardRepo) GetBoardCustomFields(ctx context.Context, boardID int64) ([]models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCustomFields", ctx, boardID)
+	ret0, _ := ret[0].([]models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCustomFields indicates an expected call of GetBoardCustomFields.
+func (mr *MockBoardRepoMockRecorder) GetBoardCustomFields(ctx, boardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCustomFields", reflect.TypeOf((*MockBoardRepo)(nil).GetBoardCustomFields), ctx, boardID)
+}
+
+// GetBoardTimesheet mocks base method.
+func (m *MockBoardRepo) GetBoardTimesheet(ctx context.Context, boardID int64, from, to time.Time) ([]models.TimesheetRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardTimesheet", ctx, boardID, from, to)
+	ret0, _ := ret[0].([]models.TimesheetRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardTimesheet indicates an expected call of GetBoardTimesheet.
+func (mr *MockBoardRepoMockRecorder) GetBoardTimesheet(ctx, boardID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardTimesheet", reflect.TypeOf((*MockBoardRepo)(nil).GetBoardTimesheet), ctx, boardID, from, to)
+}
+
 // GetBoardsForUser mocks base method.
 func (m *MockBoardRepo) GetBoardsForUser(ctx context.Context, userID int64) ([]models.Board, error) {
 	m.ctrl.T.Helper()
@@ -852,6 +1409,21 @@ func (mr *MockBoardRepoMockRecorder) GetBoardsForUser(ctx, userID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsForUser", reflect.TypeOf((*MockBoardRepo)(nil).GetBoardsForUser), ctx, userID)
 }
 
+// GetCard mocks base method.
+func (m *MockBoardRepo) GetCard(ctx context.Context, cardID int64) (*models.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCard", ctx, cardID)
+	ret0, _ := ret[0].(*models.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCard indicates an expected call of GetCard.
+func (mr *MockBoardRepoMockRecorder) GetCard(ctx, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCard", reflect.TypeOf((*MockBoardRepo)(nil).GetCard), ctx, cardID)
+}
+
 // GetCardAssignedUsers mocks base method.
 func (m *MockBoardRepo) GetCardAssignedUsers(ctx context.Context, cardID int64) ([]models.UserProfile, error) {
 	m.ctrl.T.Helper()
@@ -882,19 +1454,19 @@ func (mr *MockBoardRepoMockRecorder) GetCardAttachments(ctx, cardID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardAttachments", reflect.TypeOf((*MockBoardRepo)(nil).GetCardAttachments), ctx, cardID)
 }
 
-// GetCardCheckList mocks base method.
-func (m *MockBoardRepo) GetCardCheckList(ctx context.Context, cardID int64) ([]models.CheckListField, error) {
+// GetCardCheckLists mocks base method.
+func (m *MockBoardRepo) GetCardCheckLists(ctx context.Context, cardID int64) ([]models.CheckList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCardCheckList", ctx, cardID)
-	ret0, _ := ret[0].([]models.CheckListField)
+	ret := m.ctrl.Call(m, "GetCardCheckLists", ctx, cardID)
+	ret0, _ := ret[0].([]models.CheckList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCardCheckList indicates an expected call of GetCardCheckList.
-func (mr *MockBoardRepoMockRecorder) GetCardCheckList(ctx, cardID interface{}) *gomock.Call {
+// GetCardCheckLists indicates an expected call of GetCardCheckLists.
+func (mr *MockBoardRepoMockRecorder) GetCardCheckLists(ctx, cardID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardCheckList", reflect.TypeOf((*MockBoardRepo)(nil).GetCardCheckList), ctx, cardID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardCheckLists", reflect.TypeOf((*MockBoardRepo)(nil).GetCardCheckLists), ctx, cardID)
 }
 
 // GetCardComments mocks base method.
@@ -912,6 +1484,66 @@ func (mr *MockBoardRepoMockRecorder) GetCardComments(ctx, cardID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardComments", reflect.TypeOf((*MockBoardRepo)(nil).GetCardComments), ctx, cardID)
 }
 
+// GetCardCustomFieldValues mocks base method.
+func (m *MockBoardRepo) GetCardCustomFieldValues(ctx context.Context, cardID int64) ([]models.CustomFieldValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardCustomFieldValues", ctx, cardID)
+	ret0, _ := ret[0].([]models.CustomFieldValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardCustomFieldValues indicates an expected call of GetCardCustomFieldValues.
+func (mr *MockBoardRepoMockRecorder) GetCardCustomFieldValues(ctx, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardCustomFieldValues", reflect.TypeOf((*MockBoardRepo)(nil).GetCardCustomFieldValues), ctx, cardID)
+}
+
+// GetCardLabels mocks base method.
+func (m *MockBoardRepo) GetCardLabels(ctx context.Context, cardID int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardLabels", ctx, cardID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardLabels indicates an expected call of GetCardLabels.
+func (mr *MockBoardRepoMockRecorder) GetCardLabels(ctx, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardLabels", reflect.TypeOf((*MockBoardRepo)(nil).GetCardLabels), ctx, cardID)
+}
+
+// GetCardSubtasks mocks base method.
+func (m *MockBoardRepo) GetCardSubtasks(ctx context.Context, cardID int64) ([]models.Subtask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardSubtasks", ctx, cardID)
+	ret0, _ := ret[0].([]models.Subtask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardSubtasks indicates an expected call of GetCardSubtasks.
+func (mr *MockBoardRepoMockRecorder) GetCardSubtasks(ctx, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardSubtasks", reflect.TypeOf((*MockBoardRepo)(nil).GetCardSubtasks), ctx, cardID)
+}
+
+// GetCardTimeEntries mocks base method.
+func (m *MockBoardRepo) GetCardTimeEntries(ctx context.Context, cardID int64) ([]models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardTimeEntries", ctx, cardID)
+	ret0, _ := ret[0].([]models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardTimeEntries indicates an expected call of GetCardTimeEntries.
+func (mr *MockBoardRepoMockRecorder) GetCardTimeEntries(ctx, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardTimeEntries", reflect.TypeOf((*MockBoardRepo)(nil).GetCardTimeEntries), ctx, cardID)
+}
+
 // GetCardsForBoard mocks base method.
 func (m *MockBoardRepo) GetCardsForBoard(ctx context.Context, boardID int64) ([]models.Card, error) {
 	m.ctrl.T.Helper()
@@ -943,6 +1575,21 @@ func (mr *MockBoardRepoMockRecorder) GetCardsForMove(ctx, col1ID, col2ID interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsForMove", reflect.TypeOf((*MockBoardRepo)(nil).GetCardsForMove), ctx, col1ID, col2ID)
 }
 
+// GetCheckListFields mocks base method.
+func (m *MockBoardRepo) GetCheckListFields(ctx context.Context, checkListID int64) ([]models.CheckListField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckListFields", ctx, checkListID)
+	ret0, _ := ret[0].([]models.CheckListField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckListFields indicates an expected call of GetCheckListFields.
+func (mr *MockBoardRepoMockRecorder) GetCheckListFields(ctx, checkListID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckListFields", reflect.TypeOf((*MockBoardRepo)(nil).GetCheckListFields), ctx, checkListID)
+}
+
 // GetColumnsForBoard mocks base method.
 func (m *MockBoardRepo) GetColumnsForBoard(ctx context.Context, boardID int64) ([]models.Column, error) {
 	m.ctrl.T.Helper()
@@ -973,6 +1620,21 @@ func (mr *MockBoardRepoMockRecorder) GetColumnsForMove(ctx, boardID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumnsForMove", reflect.TypeOf((*MockBoardRepo)(nil).GetColumnsForMove), ctx, boardID)
 }
 
+// GetCustomField mocks base method.
+func (m *MockBoardRepo) GetCustomField(ctx context.Context, fieldID int64) (*models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomField", ctx, fieldID)
+	ret0, _ := ret[0].(*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomField indicates an expected call of GetCustomField.
+func (mr *MockBoardRepoMockRecorder) GetCustomField(ctx, fieldID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomField", reflect.TypeOf((*MockBoardRepo)(nil).GetCustomField), ctx, fieldID)
+}
+
 // GetMemberFromAttachment mocks base method.
 func (m *MockBoardRepo) GetMemberFromAttachment(ctx context.Context, userID, attachmentID int64) (string, int64, int64, error) {
 	m.ctrl.T.Helper()
@@ -990,6 +1652,22 @@ func (mr *MockBoardRepoMockRecorder) GetMemberFromAttachment(ctx, userID, attach
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberFromAttachment", reflect.TypeOf((*MockBoardRepo)(nil).GetMemberFromAttachment), ctx, userID, attachmentID)
 }
 
+// GetMemberFromAutomationRule mocks base method.
+func (m *MockBoardRepo) GetMemberFromAutomationRule(ctx context.Context, userID, ruleID int64) (string, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberFromAutomationRule", ctx, userID, ruleID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMemberFromAutomationRule indicates an expected call of GetMemberFromAutomationRule.
+func (mr *MockBoardRepoMockRecorder) GetMemberFromAutomationRule(ctx, userID, ruleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberFromAutomationRule", reflect.TypeOf((*MockBoardRepo)(nil).GetMemberFromAutomationRule), ctx, userID, ruleID)
+}
+
 // GetMemberFromCard mocks base method.
 func (m *MockBoardRepo) GetMemberFromCard(ctx context.Context, userID, cardID int64) (string, int64, error) {
 	m.ctrl.T.Helper()
@@ -1006,6 +1684,23 @@ func (mr *MockBoardRepoMockRecorder) GetMemberFromCard(ctx, userID, cardID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberFromCard", reflect.TypeOf((*MockBoardRepo)(nil).GetMemberFromCard), ctx, userID, cardID)
 }
 
+// GetMemberFromCheckList mocks base method.
+func (m *MockBoardRepo) GetMemberFromCheckList(ctx context.Context, userID, checkListID int64) (string, int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberFromCheckList", ctx, userID, checkListID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetMemberFromCheckList indicates an expected call of GetMemberFromCheckList.
+func (mr *MockBoardRepoMockRecorder) GetMemberFromCheckList(ctx, userID, checkListID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberFromCheckList", reflect.TypeOf((*MockBoardRepo)(nil).GetMemberFromCheckList), ctx, userID, checkListID)
+}
+
 // GetMemberFromCheckListField mocks base method.
 func (m *MockBoardRepo) GetMemberFromCheckListField(ctx context.Context, userID, fieldID int64) (string, int64, int64, error) {
 	m.ctrl.T.Helper()
@@ -1056,6 +1751,39 @@ func (mr *MockBoardRepoMockRecorder) GetMemberFromComment(ctx, userID, commentID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberFromComment", reflect.TypeOf((*MockBoardRepo)(nil).GetMemberFromComment), ctx, userID, commentID)
 }
 
+// GetMemberFromCustomField mocks base method.
+func (m *MockBoardRepo) GetMemberFromCustomField(ctx context.Context, userID, fieldID int64) (string, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberFromCustomField", ctx, userID, fieldID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMemberFromCustomField indicates an expected call of GetMemberFromCustomField.
+func (mr *MockBoardRepoMockRecorder) GetMemberFromCustomField(ctx, userID, fieldID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberFromCustomField", reflect.TypeOf((*MockBoardRepo)(nil).GetMemberFromCustomField), ctx, userID, fieldID)
+}
+
+// GetMemberFromTimeEntry mocks base method.
+func (m *MockBoardRepo) GetMemberFromTimeEntry(ctx context.Context, userID, entryID int64) (string, int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberFromTimeEntry", ctx, userID, entryID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetMemberFromTimeEntry indicates an expected call of GetMemberFromTimeEntry.
+func (mr *MockBoardRepoMockRecorder) GetMemberFromTimeEntry(ctx, userID, entryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberFromTimeEntry", reflect.TypeOf((*MockBoardRepo)(nil).GetMemberFromTimeEntry), ctx, userID, entryID)
+}
+
 // GetMemberPermissions mocks base method.
 func (m *MockBoardRepo) GetMemberPermissions(ctx context.Context, boardID, memberUserID int64, getAdderInfo bool) (*models.MemberWithPermissions, error) {
 	m.ctrl.T.Helper()
@@ -1086,6 +1814,36 @@ func (mr *MockBoardRepoMockRecorder) GetMembersWithPermissions(ctx, boardID, use
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersWithPermissions", reflect.TypeOf((*MockBoardRepo)(nil).GetMembersWithPermissions), ctx, boardID, userID)
 }
 
+// GetOverdueAutomationCards mocks base method.
+func (m *MockBoardRepo) GetOverdueAutomationCards(ctx context.Context) ([]models.OverdueCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueAutomationCards", ctx)
+	ret0, _ := ret[0].([]models.OverdueCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueAutomationCards indicates an expected call of GetOverdueAutomationCards.
+func (mr *MockBoardRepoMockRecorder) GetOverdueAutomationCards(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueAutomationCards", reflect.TypeOf((*MockBoardRepo)(nil).GetOverdueAutomationCards), ctx)
+}
+
+// GetRunningTimer mocks base method.
+func (m *MockBoardRepo) GetRunningTimer(ctx context.Context, userID int64) (*models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningTimer", ctx, userID)
+	ret0, _ := ret[0].(*models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningTimer indicates an expected call of GetRunningTimer.
+func (mr *MockBoardRepoMockRecorder) GetRunningTimer(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTimer", reflect.TypeOf((*MockBoardRepo)(nil).GetRunningTimer), ctx, userID)
+}
+
 // GetUserByNickname mocks base method.
 func (m *MockBoardRepo) GetUserByNickname(ctx context.Context, nickname string) (*models.UserProfile, error) {
 	m.ctrl.T.Helper()
@@ -1116,47 +1874,61 @@ func (mr *MockBoardRepoMockRecorder) GetUserProfile(ctx, userID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockBoardRepo)(nil).GetUserProfile), ctx, userID)
 }
 
-// PullInviteLink mocks base method.
-func (m *MockBoardRepo) PullInviteLink(ctx context.Context, userID, boardID int64) (*models.InviteLink, error) {
+// MarkDeadlineAutomationFired mocks base method.
+func (m *MockBoardRepo) MarkDeadlineAutomationFired(ctx context.Context, cardID int64, deadline time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PullInviteLink", ctx, userID, boardID)
-	ret0, _ := ret[0].(*models.InviteLink)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "MarkDeadlineAutomationFired", ctx, cardID, deadline)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// PullInviteLink indicates an expected call of PullInviteLink.
-func (mr *MockBoardRepoMockRecorder) PullInviteLink(ctx, userID, boardID interface{}) *gomock.Call {
+// MarkDeadlineAutomationFired indicates an expected call of MarkDeadlineAutomationFired.
+func (mr *MockBoardRepoMockRecorder) MarkDeadlineAutomationFired(ctx, cardID, deadline interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullInviteLink", reflect.TypeOf((*MockBoardRepo)(nil).PullInviteLink), ctx, userID, boardID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDeadlineAutomationFired", reflect.TypeOf((*MockBoardRepo)(nil).MarkDeadlineAutomationFired), ctx, cardID, deadline)
 }
 
-// RearrangeCards mocks base method.
-func (m *MockBoardRepo) RearrangeCards(ctx context.Context, columnID int64, cards []models.Card) error {
+// MoveCard mocks base method.
+func (m *MockBoardRepo) MoveCard(ctx context.Context, targetColumnID int64, targetCards []models.Card, sourceColumnID int64, sourceCards []models.Card) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RearrangeCards", ctx, columnID, cards)
+	ret := m.ctrl.Call(m, "MoveCard", ctx, targetColumnID, targetCards, sourceColumnID, sourceCards)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RearrangeCards indicates an expected call of RearrangeCards.
-func (mr *MockBoardRepoMockRecorder) RearrangeCards(ctx, columnID, cards interface{}) *gomock.Call {
+// MoveCard indicates an expected call of MoveCard.
+func (mr *MockBoardRepoMockRecorder) MoveCard(ctx, targetColumnID, targetCards, sourceColumnID, sourceCards interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RearrangeCards", reflect.TypeOf((*MockBoardRepo)(nil).RearrangeCards), ctx, columnID, cards)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCard", reflect.TypeOf((*MockBoardRepo)(nil).MoveCard), ctx, targetColumnID, targetCards, sourceColumnID, sourceCards)
 }
 
-// RearrangeCheckList mocks base method.
-func (m *MockBoardRepo) RearrangeCheckList(ctx context.Context, fields []models.CheckListField) error {
+// MoveCheckListField mocks base method.
+func (m *MockBoardRepo) MoveCheckListField(ctx context.Context, targetListID int64, targetFields []models.CheckListField, sourceListID int64, sourceFields []models.CheckListField) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RearrangeCheckList", ctx, fields)
+	ret := m.ctrl.Call(m, "MoveCheckListField", ctx, targetListID, targetFields, sourceListID, sourceFields)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RearrangeCheckList indicates an expected call of RearrangeCheckList.
-func (mr *MockBoardRepoMockRecorder) RearrangeCheckList(ctx, fields interface{}) *gomock.Call {
+// MoveCheckListField indicates an expected call of MoveCheckListField.
+func (mr *MockBoardRepoMockRecorder) MoveCheckListField(ctx, targetListID, targetFields, sourceListID, sourceFields interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RearrangeCheckList", reflect.TypeOf((*MockBoardRepo)(nil).RearrangeCheckList), ctx, fields)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCheckListField", reflect.TypeOf((*MockBoardRepo)(nil).MoveCheckListField), ctx, targetListID, targetFields, sourceListID, sourceFields)
+}
+
+// PullInviteLink mocks base method.
+func (m *MockBoardRepo) PullInviteLink(ctx context.Context, userID, boardID int64) (*models.InviteLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullInviteLink", ctx, userID, boardID)
+	ret0, _ := ret[0].(*models.InviteLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PullInviteLink indicates an expected call of PullInviteLink.
+func (mr *MockBoardRepoMockRecorder) PullInviteLink(ctx, userID, boardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullInviteLink", reflect.TypeOf((*MockBoardRepo)(nil).PullInviteLink), ctx, userID, boardID)
 }
 
 // RearrangeColumns mocks base method.
@@ -1174,11 +1946,12 @@ func (mr *MockBoardRepoMockRecorder) RearrangeColumns(ctx, columns interface{})
 }
 
 // RegisterFile mocks base method.
-func (m *MockBoardRepo) RegisterFile(ctx context.Context, file *models.UploadedFile) error {
+func (m *MockBoardRepo) RegisterFile(ctx context.Context, file *models.UploadedFile) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RegisterFile", ctx, file)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // RegisterFile indicates an expected call of RegisterFile.
@@ -1259,6 +2032,21 @@ func (mr *MockBoardRepoMockRecorder) SetCardCover(ctx, userID, cardID, file inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCardCover", reflect.TypeOf((*MockBoardRepo)(nil).SetCardCover), ctx, userID, cardID, file)
 }
 
+// SetCardCustomFieldValue mocks base method.
+func (m *MockBoardRepo) SetCardCustomFieldValue(ctx context.Context, cardID, fieldID int64, value string) (*models.CustomFieldValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCardCustomFieldValue", ctx, cardID, fieldID, value)
+	ret0, _ := ret[0].(*models.CustomFieldValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCardCustomFieldValue indicates an expected call of SetCardCustomFieldValue.
+func (mr *MockBoardRepoMockRecorder) SetCardCustomFieldValue(ctx, cardID, fieldID, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCardCustomFieldValue", reflect.TypeOf((*MockBoardRepo)(nil).SetCardCustomFieldValue), ctx, cardID, fieldID, value)
+}
+
 // SetMemberRole mocks base method.
 func (m *MockBoardRepo) SetMemberRole(ctx context.Context, userID, boardID, memberUserID int64, newRole string) (*models.MemberWithPermissions, error) {
 	m.ctrl.T.Helper()
@@ -1274,6 +2062,67 @@ func (mr *MockBoardRepoMockRecorder) SetMemberRole(ctx, userID, boardID, memberU
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberRole", reflect.TypeOf((*MockBoardRepo)(nil).SetMemberRole), ctx, userID, boardID, memberUserID, newRole)
 }
 
+// StartTimer mocks base method.
+func (m *MockBoardRepo) StartTimer(ctx context.Context, userID, cardID int64) (*models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", ctx, userID, cardID)
+	ret0, _ := ret[0].(*models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockBoardRepoMockRecorder) StartTimer(ctx, userID, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockBoardRepo)(nil).StartTimer), ctx, userID, cardID)
+}
+
+// StopTimer mocks base method.
+func (m *MockBoardRepo) StopTimer(ctx context.Context, userID, cardID int64) (*models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", ctx, userID, cardID)
+	ret0, _ := ret[0].(*models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockBoardRepoMockRecorder) StopTimer(ctx, userID, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockBoardRepo)(nil).StopTimer), ctx, userID, cardID)
+}
+
+// TryLockDeadlineAutomations mocks base method.
+func (m *MockBoardRepo) TryLockDeadlineAutomations(ctx context.Context) (func(), bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLockDeadlineAutomations", ctx)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TryLockDeadlineAutomations indicates an expected call of TryLockDeadlineAutomations.
+func (mr *MockBoardRepoMockRecorder) TryLockDeadlineAutomations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLockDeadlineAutomations", reflect.TypeOf((*MockBoardRepo)(nil).TryLockDeadlineAutomations), ctx)
+}
+
+// UpdateAutomationRule mocks base method.
+func (m *MockBoardRepo) UpdateAutomationRule(ctx context.Context, ruleID int64, data *models.AutomationRuleRequest) (*models.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAutomationRule", ctx, ruleID, data)
+	ret0, _ := ret[0].(*models.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAutomationRule indicates an expected call of UpdateAutomationRule.
+func (mr *MockBoardRepoMockRecorder) UpdateAutomationRule(ctx, ruleID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAutomationRule", reflect.TypeOf((*MockBoardRepo)(nil).UpdateAutomationRule), ctx, ruleID, data)
+}
+
 // UpdateBoard mocks base method.
 func (m *MockBoardRepo) UpdateBoard(ctx context.Context, boardID, userID int64, data *models.BoardRequest) (*models.Board, error) {
 	m.ctrl.T.Helper()
@@ -1304,6 +2153,21 @@ func (mr *MockBoardRepoMockRecorder) UpdateCard(ctx, cardID, data interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCard", reflect.TypeOf((*MockBoardRepo)(nil).UpdateCard), ctx, cardID, data)
 }
 
+// UpdateCheckList mocks base method.
+func (m *MockBoardRepo) UpdateCheckList(ctx context.Context, checkListID int64, title string) (*models.CheckList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCheckList", ctx, checkListID, title)
+	ret0, _ := ret[0].(*models.CheckList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCheckList indicates an expected call of UpdateCheckList.
+func (mr *MockBoardRepoMockRecorder) UpdateCheckList(ctx, checkListID, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCheckList", reflect.TypeOf((*MockBoardRepo)(nil).UpdateCheckList), ctx, checkListID, title)
+}
+
 // UpdateCheckListField mocks base method.
 func (m *MockBoardRepo) UpdateCheckListField(ctx context.Context, fieldID int64, update *models.CheckListFieldPatchRequest) (*models.CheckListField, error) {
 	m.ctrl.T.Helper()
@@ -1349,16 +2213,17 @@ func (mr *MockBoardRepoMockRecorder) UpdateComment(ctx, commentID, update interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockBoardRepo)(nil).UpdateComment), ctx, commentID, update)
 }
 
-// UpdateLastVisit mocks base method.
-func (m *MockBoardRepo) UpdateLastVisit(ctx context.Context, userID, boardID int64) error {
+// UpdateCustomField mocks base method.
+func (m *MockBoardRepo) UpdateCustomField(ctx context.Context, fieldID int64, data *models.CustomFieldPutRequest) (*models.CustomField, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateLastVisit", ctx, userID, boardID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateCustomField", ctx, fieldID, data)
+	ret0, _ := ret[0].(*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateLastVisit indicates an expected call of UpdateLastVisit.
-func (mr *MockBoardRepoMockRecorder) UpdateLastVisit(ctx, userID, boardID interface{}) *gomock.Call {
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockBoardRepoMockRecorder) UpdateCustomField(ctx, fieldID, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastVisit", reflect.TypeOf((*MockBoardRepo)(nil).UpdateLastVisit), ctx, userID, boardID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*MockBoardRepo)(nil).UpdateCustomField), ctx, fieldID, data)
 }
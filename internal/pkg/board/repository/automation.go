@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// scanAutomationRule читает правило в порядке полей
+// id, board_id, title, trigger_type, trigger_column_id, actions, is_enabled, created_by, created_at, updated_at
+func scanAutomationRule(row pgx.Row) (rule *models.AutomationRule, err error) {
+	rule = &models.AutomationRule{}
+	err = row.Scan(
+		&rule.ID,
+		&rule.BoardID,
+		&rule.Title,
+		&rule.Trigger.Type,
+		&rule.Trigger.ColumnID,
+		&rule.Actions,
+		&rule.IsEnabled,
+		&rule.CreatedBy,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// GetBoardAutomationRules получает правила автоматизации доски
+func (r *BoardRepository) GetBoardAutomationRules(ctx context.Context, boardID int64) (rules []models.AutomationRule, err error) {
+	funcName := "GetBoardAutomationRules"
+	query := `
+	SELECT rule_id, board_id, title, trigger_type::text, trigger_column_id, actions,
+		is_enabled, COALESCE(created_by, 0), created_at, updated_at
+	FROM automation_rule
+	WHERE board_id = $1
+	ORDER BY rule_id;
+	`
+
+	rows, err := r.db.Query(ctx, query, boardID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	rules = make([]models.AutomationRule, 0)
+	for rows.Next() {
+		rule, err := scanAutomationRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		rules = append(rules, *rule)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return rules, nil
+}
+
+// CreateAutomationRule создаёт правило автоматизации на доске
+func (r *BoardRepository) CreateAutomationRule(ctx context.Context, boardID int64, userID int64, data *models.AutomationRuleRequest) (newRule *models.AutomationRule, err error) {
+	funcName := "CreateAutomationRule"
+	query := `
+	INSERT INTO automation_rule (board_id, title, trigger_type, trigger_column_id, actions, is_enabled, created_by)
+	VALUES ($1, $2, $3::automation_trigger, $4, $5, COALESCE($6, TRUE), $7)
+	RETURNING rule_id, board_id, title, trigger_type::text, trigger_column_id, actions,
+		is_enabled, COALESCE(created_by, 0), created_at, updated_at;
+	`
+
+	newRule, err = scanAutomationRule(r.db.QueryRow(ctx, query, boardID, data.Title,
+		data.Trigger.Type, data.Trigger.ColumnID, data.Actions, data.IsEnabled, userID))
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return newRule, nil
+}
+
+// UpdateAutomationRule полностью заменяет правило автоматизации
+func (r *BoardRepository) UpdateAutomationRule(ctx context.Context, ruleID int64, data *models.AutomationRuleRequest) (updatedRule *models.AutomationRule, err error) {
+	funcName := "UpdateAutomationRule"
+	query := `
+	UPDATE automation_rule
+	SET title=$2, trigger_type=$3::automation_trigger, trigger_column_id=$4, actions=$5,
+		is_enabled=COALESCE($6, is_enabled), updated_at=CURRENT_TIMESTAMP
+	WHERE rule_id=$1
+	RETURNING rule_id, board_id, title, trigger_type::text, trigger_column_id, actions,
+		is_enabled, COALESCE(created_by, 0), created_at, updated_at;
+	`
+
+	updatedRule, err = scanAutomationRule(r.db.QueryRow(ctx, query, ruleID, data.Title,
+		data.Trigger.Type, data.Trigger.ColumnID, data.Actions, data.IsEnabled))
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return updatedRule, nil
+}
+
+// DeleteAutomationRule удаляет правило автоматизации
+func (r *BoardRepository) DeleteAutomationRule(ctx context.Context, ruleID int64) (err error) {
+	funcName := "DeleteAutomationRule"
+	query := `
+	DELETE FROM automation_rule
+	WHERE rule_id=$1;
+	`
+
+	tag, err := r.db.Exec(ctx, query, ruleID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+	}
+	return nil
+}
+
+// GetMemberFromAutomationRule получает права пользователя из ID правила автоматизации
+func (r *BoardRepository) GetMemberFromAutomationRule(ctx context.Context, userID int64, ruleID int64) (role string, boardID int64, err error) {
+	funcName := "GetMemberFromAutomationRule"
+	query := `
+	SELECT utb.role, ar.board_id
+	FROM automation_rule AS ar
+	JOIN user_to_board AS utb ON utb.board_id = ar.board_id
+	WHERE utb.u_id = $1 AND ar.rule_id = $2;
+	`
+
+	err = r.db.QueryRow(ctx, query, userID, ruleID).Scan(
+		&role, &boardID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", 0, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return "", 0, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+
+	return role, boardID, err
+}
+
+// AddCardLabel вешает метку на карточку (повторная метка игнорируется)
+func (r *BoardRepository) AddCardLabel(ctx context.Context, cardID int64, label string) (err error) {
+	funcName := "AddCardLabel"
+	query := `
+	WITH insert_label AS (
+		INSERT INTO card_label (card_id, title)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	)
+	UPDATE "card" SET updated_at=CURRENT_TIMESTAMP WHERE card_id=$1;
+	`
+
+	_, err = r.db.Exec(ctx, query, cardID, label)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return nil
+}
+
+// GetCardLabels получает метки карточки
+func (r *BoardRepository) GetCardLabels(ctx context.Context, cardID int64) (labels []string, err error) {
+	funcName := "GetCardLabels"
+	query := `
+	SELECT title
+	FROM card_label
+	WHERE card_id = $1
+	ORDER BY created_at, title;
+	`
+
+	rows, err := r.db.Query(ctx, query, cardID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	labels = make([]string, 0)
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		labels = append(labels, label)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return labels, nil
+}
+
+// GetOverdueAutomationCards получает невыполненные карточки с прошедшим дедлайном
+// на досках с правилами deadline_passed, для которых правила ещё не срабатывали
+func (r *BoardRepository) GetOverdueAutomationCards(ctx context.Context) (cards []models.OverdueCard, err error) {
+	funcName := "GetOverdueAutomationCards"
+	query := `
+	SELECT c.card_id, kc.board_id, c.col_id, c.deadline
+	FROM card AS c
+	JOIN kanban_column AS kc ON kc.col_id = c.col_id
+	LEFT JOIN card_deadline_automation AS cda ON cda.card_id = c.card_id
+	WHERE c.deadline < CURRENT_TIMESTAMP
+		AND c.is_done = FALSE
+		AND (cda.deadline IS NULL OR cda.deadline <> c.deadline)
+		AND EXISTS (
+			SELECT 1 FROM automation_rule AS ar
+			WHERE ar.board_id = kc.board_id AND ar.is_enabled AND ar.trigger_type = 'deadline_passed'
+		)
+	ORDER BY c.deadline;
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	cards = make([]models.OverdueCard, 0)
+	for rows.Next() {
+		card := models.OverdueCard{}
+		if err := rows.Scan(&card.CardID, &card.BoardID, &card.ColumnID, &card.Deadline); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		cards = append(cards, card)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return cards, nil
+}
+
+// deadlineAutomationLockKey - ключ advisory-блокировки прогона автоматизаций по дедлайнам
+const deadlineAutomationLockKey int64 = 20241203091522
+
+// TryLockDeadlineAutomations берёт транзакционную advisory-блокировку прогона автоматизаций по дедлайнам,
+// чтобы при нескольких экземплярах сервиса правила отрабатывал только один из них.
+// Если блокировку держит другой экземпляр, возвращает acquired = false. Блокировка снимается вызовом release
+func (r *BoardRepository) TryLockDeadlineAutomations(ctx context.Context) (release func(), acquired bool, err error) {
+	funcName := "TryLockDeadlineAutomations"
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s (begin): %w", funcName, err)
+	}
+
+	err = tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1);`, deadlineAutomationLockKey).Scan(&acquired)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil || !acquired {
+		tx.Rollback(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s (query): %w", funcName, err)
+		}
+		return nil, false, nil
+	}
+
+	// Транзакция ничего не меняет, откат только освобождает блокировку и соединение
+	release = func() { tx.Rollback(context.Background()) }
+	return release, true, nil
+}
+
+// MarkDeadlineAutomationFired запоминает дедлайн, для которого отработали правила
+func (r *BoardRepository) MarkDeadlineAutomationFired(ctx context.Context, cardID int64, deadline time.Time) (err error) {
+	funcName := "MarkDeadlineAutomationFired"
+	query := `
+	INSERT INTO card_deadline_automation (card_id, deadline)
+	VALUES ($1, $2)
+	ON CONFLICT (card_id) DO UPDATE SET deadline=EXCLUDED.deadline, fired_at=CURRENT_TIMESTAMP;
+	`
+
+	_, err = r.db.Exec(ctx, query, cardID, deadline)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return nil
+}
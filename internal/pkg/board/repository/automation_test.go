@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryLockDeadlineAutomations(t *testing.T) {
+	lockQuery := regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock($1);`)
+
+	t.Run("acquired until release", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		repo := CreateBoardRepository(mock)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(deadlineAutomationLockKey).
+			WillReturnRows(pgxmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+		mock.ExpectRollback()
+
+		release, acquired, err := repo.TryLockDeadlineAutomations(context.Background())
+		require.NoError(t, err)
+		assert.True(t, acquired)
+		release()
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("held by another instance", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		repo := CreateBoardRepository(mock)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(deadlineAutomationLockKey).
+			WillReturnRows(pgxmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+		mock.ExpectRollback()
+
+		release, acquired, err := repo.TryLockDeadlineAutomations(context.Background())
+		require.NoError(t, err)
+		assert.False(t, acquired)
+		assert.Nil(t, release)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
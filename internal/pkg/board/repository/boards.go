@@ -85,7 +85,8 @@ func (r *BoardRepository) GetBoard(ctx context.Context, boardID int64, userID in
 		return nil, err
 	}
 
-	board.BackgroundImageURL = uploads.JoinFileURL(fileUUID, fileExtension, uploads.DefaultBackgroundURL)
+	board.BackgroundImageURL = uploads.JoinImageURL(fileUUID, fileExtension, uploads.DefaultBackgroundURL)
+	board.BackgroundImageVariants = uploads.JoinVariantURLs(fileUUID, fileExtension, uploads.DefaultBackgroundURL)
 	return &board, nil
 }
 
@@ -167,7 +168,8 @@ func (r *BoardRepository) GetBoardsForUser(ctx context.Context, userID int64) (b
 		if err != nil {
 			return nil, fmt.Errorf("GetBoardsForUser (for rows): %w", err)
 		}
-		board.BackgroundImageURL = uploads.JoinFileURL(fileUUID, fileExtension, uploads.DefaultBackgroundURL)
+		board.BackgroundImageURL = uploads.JoinImageURL(fileUUID, fileExtension, uploads.DefaultBackgroundURL)
+		board.BackgroundImageVariants = uploads.JoinVariantURLs(fileUUID, fileExtension, uploads.DefaultBackgroundURL)
 		boardArray = append(boardArray, board)
 	}
 
@@ -212,6 +214,7 @@ func (r *BoardRepository) SetBoardBackground(ctx context.Context, userID int64,
 	if err != nil {
 		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
-	newBoard.BackgroundImageURL = uploads.JoinFileURL(fileUUID, fileExtension, uploads.DefaultBackgroundURL)
+	newBoard.BackgroundImageURL = uploads.JoinImageURL(fileUUID, fileExtension, uploads.DefaultBackgroundURL)
+	newBoard.BackgroundImageVariants = uploads.JoinVariantURLs(fileUUID, fileExtension, uploads.DefaultBackgroundURL)
 	return newBoard, nil
 }
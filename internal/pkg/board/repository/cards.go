@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"RPO_back/internal/errs"
 	"RPO_back/internal/models"
 	"RPO_back/internal/pkg/utils/logging"
 	"context"
@@ -68,13 +69,13 @@ func (r *BoardRepository) GetCardsForBoard(ctx context.Context, boardID int64) (
 	return cards, nil
 }
 
-// CreateNewCard создаёт новую карточку
-func (r *BoardRepository) CreateNewCard(ctx context.Context, columnID int64, title string) (newCard *models.Card, err error) {
+// CreateNewCard создаёт новую карточку от имени пользователя userID
+func (r *BoardRepository) CreateNewCard(ctx context.Context, userID int64, columnID int64, title string) (newCard *models.Card, err error) {
 	funcName := "CreateNewCard"
 	query := `
 	WITH new_card AS (
-		INSERT INTO card (col_id, order_index, title)
-		VALUES ($1, (SELECT COUNT(*) FROM "card" WHERE col_id=$1), $2)
+		INSERT INTO card (col_id, order_index, title, created_by)
+		VALUES ($1, (SELECT COUNT(*) FROM "card" WHERE col_id=$1), $2, $3)
 		RETURNING card_id, card_uuid, col_id, title, created_at, updated_at
 	), update_board AS (
 		UPDATE board
@@ -90,7 +91,7 @@ func (r *BoardRepository) CreateNewCard(ctx context.Context, columnID int64, tit
 	`
 
 	newCard = &models.Card{}
-	err = r.db.QueryRow(ctx, query, columnID, title).Scan(
+	err = r.db.QueryRow(ctx, query, columnID, title, userID).Scan(
 		&newCard.ID,
 		&newCard.UUID,
 		&newCard.ColumnID,
@@ -119,6 +120,11 @@ func (r *BoardRepository) UpdateCard(ctx context.Context, cardID int64, data mod
 		updated_at = CURRENT_TIMESTAMP
 		WHERE card_id=$1
 		RETURNING card_id
+	), complete_parent_field AS (
+		-- Если карточка - подзадача, то строка чеклиста родителя выполняется вместе с ней
+		UPDATE checklist_field
+		SET is_done=TRUE
+		WHERE subtask_card_id=$1 AND $4::boolean IS TRUE
 	), update_board AS (
 		UPDATE board
 		SET updated_at=CURRENT_TIMESTAMP
@@ -168,6 +174,71 @@ func (r *BoardRepository) UpdateCard(ctx context.Context, cardID int64, data mod
 	return updateCard, nil
 }
 
+// GetCard получает карточку по ID
+func (r *BoardRepository) GetCard(ctx context.Context, cardID int64) (card *models.Card, err error) {
+	funcName := "GetCard"
+	query := `
+	SELECT
+		c.card_id,
+		c.col_id,
+		c.title,
+		c.created_at,
+		c.updated_at,
+		c.deadline,
+		c.is_done,
+		(SELECT (NOT COUNT(*)=0) FROM checklist_field AS f WHERE f.card_id=c.card_id),
+		(SELECT (NOT COUNT(*)=0) FROM card_attachment AS f WHERE f.card_id=c.card_id),
+		(SELECT (NOT COUNT(*)=0 )FROM card_user_assignment AS f WHERE f.card_id=c.card_id),
+		(SELECT (NOT COUNT(*)=0) FROM card_comment AS f WHERE f.card_id=c.card_id)
+	FROM card AS c
+	WHERE c.card_id=$1;
+	`
+	card = &models.Card{}
+
+	err = r.db.QueryRow(ctx, query, cardID).Scan(
+		&card.ID,
+		&card.ColumnID,
+		&card.Title,
+		&card.CreatedAt,
+		&card.UpdatedAt,
+		&card.Deadine,
+		&card.IsDone,
+		&card.HasCheckList,
+		&card.HasAttachments,
+		&card.HasAssignedUsers,
+		&card.HasComments,
+	)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+
+	return card, nil
+}
+
+// ClearCardDeadline убирает дедлайн карточки
+func (r *BoardRepository) ClearCardDeadline(ctx context.Context, cardID int64) (err error) {
+	funcName := "ClearCardDeadline"
+	query := `
+	UPDATE card
+	SET deadline=NULL, updated_at=CURRENT_TIMESTAMP
+	WHERE card_id=$1;
+	`
+
+	tag, err := r.db.Exec(ctx, query, cardID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+	}
+	return nil
+}
+
 // DeleteCard удаляет карточку
 func (r *BoardRepository) DeleteCard(ctx context.Context, cardID int64) (err error) {
 	funcName := "DeleteCard"
@@ -18,7 +18,7 @@ func TestGetCardsForBoard(t *testing.T) {
 	repo := CreateBoardRepository(mock)
 
 	mock.ExpectQuery("SELECT c.card_id,").
-		WithArgs(1).
+		WithArgs(int64(1)).
 		WillReturnError(errors.New("some error"))
 
 	_, err = repo.GetCardsForBoard(context.Background(), 1)
@@ -32,8 +32,8 @@ func TestCreateNewCard(t *testing.T) {
 
 	repo := CreateBoardRepository(mock)
 
-	mock.ExpectQuery("WITH col_check AS").
-		WithArgs(1, 1, "New Card").
+	mock.ExpectQuery("WITH new_card AS").
+		WithArgs(int64(1), "New Card", int64(1)).
 		WillReturnError(errors.New("some error"))
 
 	_, err = repo.CreateNewCard(context.Background(), 1, 1, "New Card")
@@ -47,16 +47,16 @@ func TestUpdateCard(t *testing.T) {
 
 	repo := CreateBoardRepository(mock)
 
-	mock.ExpectQuery("UPDATE card").
-		WithArgs("Updated Title", 2, 1, 1).
-		WillReturnError(errors.New("some error"))
-
+	title := "Updated Title"
 	data := models.CardPatchRequest{
-		NewTitle:    "Updated Title",
-		NewColumnID: 2,
+		NewTitle: &title,
 	}
 
-	_, err = repo.UpdateCard(context.Background(), 1, 1, data)
+	mock.ExpectQuery("UPDATE card").
+		WithArgs(int64(1), data.NewTitle, data.NewDeadline, data.IsDone).
+		WillReturnError(errors.New("some error"))
+
+	_, err = repo.UpdateCard(context.Background(), 1, data)
 	assert.Error(t, err)
 }
 
@@ -68,10 +68,10 @@ func TestDeleteCard(t *testing.T) {
 	repo := CreateBoardRepository(mock)
 
 	mock.ExpectExec("DELETE FROM card").
-		WithArgs(1, 1).
+		WithArgs(int64(1)).
 		WillReturnError(errors.New("some error"))
 
-	err = repo.DeleteCard(context.Background(), 1, 1)
+	err = repo.DeleteCard(context.Background(), 1)
 	assert.Error(t, err)
 }
 
@@ -81,8 +81,8 @@ func TestGetColumnsForBoard_Error(t *testing.T) {
 
 	boardRepo := CreateBoardRepository(dbMock)
 
-	dbMock.ExpectQuery("SELECT col_id, title FROM kanban_column WHERE board_id = \\$1;").
-		WithArgs(1).
+	dbMock.ExpectQuery("FROM kanban_column\\s+WHERE board_id = \\$1;").
+		WithArgs(int64(1)).
 		WillReturnError(errors.New("some error"))
 
 	_, err = boardRepo.GetColumnsForBoard(context.Background(), 1)
@@ -97,8 +97,8 @@ func TestCreateColumn_Error(t *testing.T) {
 
 	boardRepo := CreateBoardRepository(dbMock)
 
-	dbMock.ExpectQuery("INSERT INTO kanban_column \\(board_id, title, created_at, updated_at\\) VALUES \\(\\$1, \\$2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP\\) RETURNING col_id, title;").
-		WithArgs(1, "Test Column").
+	dbMock.ExpectQuery("INSERT INTO kanban_column \\(board_id, title, order_index\\)").
+		WithArgs(int64(1), "Test Column").
 		WillReturnError(errors.New("some error"))
 
 	_, err = boardRepo.CreateColumn(context.Background(), 1, "Test Column")
@@ -113,11 +113,11 @@ func TestUpdateColumn_Error(t *testing.T) {
 
 	boardRepo := CreateBoardRepository(dbMock)
 
-	dbMock.ExpectQuery("UPDATE kanban_column SET title = \\$1, updated_at = CURRENT_TIMESTAMP WHERE col_id = \\$2 AND board_id = \\$3 RETURNING col_id, title;").
-		WithArgs("Updated Title", 1, 1).
+	dbMock.ExpectQuery("UPDATE kanban_column\\s+SET title = \\$1, updated_at = CURRENT_TIMESTAMP\\s+WHERE col_id = \\$2").
+		WithArgs("Updated Title", int64(1)).
 		WillReturnError(errors.New("some error"))
 
-	_, err = boardRepo.UpdateColumn(context.Background(), 1, 1, models.ColumnRequest{NewTitle: "Updated Title"})
+	_, err = boardRepo.UpdateColumn(context.Background(), 1, models.ColumnRequest{NewTitle: "Updated Title"})
 
 	assert.Error(t, err)
 	assert.NoError(t, dbMock.ExpectationsWereMet())
@@ -129,12 +129,34 @@ func TestDeleteColumn_Error(t *testing.T) {
 
 	boardRepo := CreateBoardRepository(dbMock)
 
-	dbMock.ExpectExec("DELETE FROM kanban_column WHERE col_id = \\$1 AND board_id = \\$2;").
-		WithArgs(1, 1).
+	dbMock.ExpectExec("DELETE FROM kanban_column\\s+WHERE col_id = \\$1;").
+		WithArgs(int64(1)).
 		WillReturnError(errors.New("some error"))
 
-	err = boardRepo.DeleteColumn(context.Background(), 1, 1)
+	err = boardRepo.DeleteColumn(context.Background(), 1)
 
 	assert.Error(t, err)
 	assert.NoError(t, dbMock.ExpectationsWereMet())
 }
+
+func TestMoveCard(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mock.Close()
+
+	repo := CreateBoardRepository(mock)
+	query := "UPDATE card SET order_index = \\$1, col_id = \\$2 WHERE card_id = \\$3"
+
+	// Обе колонки переставляются в одной транзакции
+	mock.ExpectBegin()
+	batch := mock.ExpectBatch()
+	batch.ExpectExec(query).WithArgs(0, int64(4), int64(10)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	batch.ExpectExec(query).WithArgs(1, int64(4), int64(7)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	batch.ExpectExec(query).WithArgs(0, int64(3), int64(8)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+	mock.ExpectRollback()
+
+	err = repo.MoveCard(context.Background(), 4, []models.Card{{ID: 10}, {ID: 7}}, 3, []models.Card{{ID: 8}})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
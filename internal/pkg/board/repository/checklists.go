@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateCheckList создаёт пустой именованный чеклист в конце карточки
+func (r *BoardRepository) CreateCheckList(ctx context.Context, cardID int64, title string) (newCheckList *models.CheckList, err error) {
+	funcName := "CreateCheckList"
+	query := `
+	WITH insert_checklist AS (
+		INSERT INTO checklist (card_id, title, order_index)
+		VALUES ($1, $2, (SELECT COUNT(*) FROM checklist WHERE card_id=$1))
+		RETURNING checklist_id, title, created_at, order_index
+	),
+	update_card AS (
+		UPDATE "card" SET updated_at=CURRENT_TIMESTAMP WHERE card_id = $1
+	)
+	SELECT checklist_id, title, created_at, order_index FROM insert_checklist;
+	`
+
+	newCheckList = &models.CheckList{Fields: make([]models.CheckListField, 0)}
+	err = r.db.QueryRow(ctx, query, cardID, title).Scan(
+		&newCheckList.ID,
+		&newCheckList.Title,
+		&newCheckList.CreatedAt,
+		&newCheckList.OrderIndex,
+	)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return newCheckList, nil
+}
+
+// GetCheckListFields получает поля одного чеклиста в порядке отображения
+func (r *BoardRepository) GetCheckListFields(ctx context.Context, checkListID int64) (fields []models.CheckListField, err error) {
+	funcName := "GetCheckListFields"
+	query := `
+	SELECT checklist_field_id, checklist_id, title, created_at, is_done, subtask_card_id, order_index
+	FROM checklist_field
+	WHERE checklist_id = $1
+	ORDER BY order_index, checklist_field_id;
+	`
+
+	rows, err := r.db.Query(ctx, query, checkListID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	fields = make([]models.CheckListField, 0)
+	for rows.Next() {
+		field := models.CheckListField{}
+		if err := rows.Scan(&field.ID, &field.CheckListID, &field.Title, &field.CreatedAt,
+			&field.IsDone, &field.SubtaskCardID, &field.OrderIndex); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		fields = append(fields, field)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return fields, nil
+}
+
+// UpdateCheckList переименовывает чеклист
+func (r *BoardRepository) UpdateCheckList(ctx context.Context, checkListID int64, title string) (updatedCheckList *models.CheckList, err error) {
+	funcName := "UpdateCheckList"
+	query := `
+	WITH update_checklist AS (
+		UPDATE checklist SET title=$2 WHERE checklist_id=$1
+		RETURNING checklist_id, title, created_at, order_index, card_id
+	),
+	update_card AS (
+		UPDATE "card" SET updated_at=CURRENT_TIMESTAMP WHERE card_id = (
+			SELECT card_id FROM update_checklist
+		)
+	)
+	SELECT checklist_id, title, created_at, order_index FROM update_checklist;
+	`
+
+	updatedCheckList = &models.CheckList{}
+	err = r.db.QueryRow(ctx, query, checkListID, title).Scan(
+		&updatedCheckList.ID,
+		&updatedCheckList.Title,
+		&updatedCheckList.CreatedAt,
+		&updatedCheckList.OrderIndex,
+	)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+
+	updatedCheckList.Fields, err = r.GetCheckListFields(ctx, checkListID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (get fields): %w", funcName, err)
+	}
+	updatedCheckList.CompletionPercent = checkListCompletion(updatedCheckList.Fields)
+	return updatedCheckList, nil
+}
+
+// DeleteCheckList удаляет чеклист вместе с его полями
+func (r *BoardRepository) DeleteCheckList(ctx context.Context, checkListID int64) (err error) {
+	funcName := "DeleteCheckList"
+	query := `
+	DELETE FROM checklist
+	WHERE checklist_id=$1;
+	`
+
+	tag, err := r.db.Exec(ctx, query, checkListID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+	}
+	// Поля чеклиста удалятся каскадно (за счёт ограничения FOREIGN KEY)
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"RPO_back/internal/models"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveCheckListField(t *testing.T) {
+	query := `UPDATE checklist_field\s+SET order_index=\$1, checklist_id=\$2\s+WHERE checklist_field_id=\$3`
+	targetFields := []models.CheckListField{{ID: 40}, {ID: 20}}
+	sourceFields := []models.CheckListField{{ID: 21}}
+
+	t.Run("both checklists in one transaction", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		repo := CreateBoardRepository(mock)
+
+		mock.ExpectBegin()
+		batch := mock.ExpectBatch()
+		batch.ExpectExec(query).WithArgs(0, int64(31), int64(40)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		batch.ExpectExec(query).WithArgs(1, int64(31), int64(20)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		batch.ExpectExec(query).WithArgs(0, int64(30), int64(21)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectCommit()
+		mock.ExpectRollback()
+
+		require.NoError(t, repo.MoveCheckListField(context.Background(), 31, targetFields, 30, sourceFields))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed update is rolled back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		repo := CreateBoardRepository(mock)
+
+		mock.ExpectBegin()
+		batch := mock.ExpectBatch()
+		batch.ExpectExec(query).WithArgs(0, int64(31), int64(40)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		batch.ExpectExec(query).WithArgs(1, int64(31), int64(20)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		batch.ExpectExec(query).WithArgs(0, int64(30), int64(21)).WillReturnError(errors.New("deadlock"))
+		mock.ExpectRollback()
+
+		err = repo.MoveCheckListField(context.Background(), 31, targetFields, 30, sourceFields)
+		assert.ErrorContains(t, err, "deadlock")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetBoardCustomFields получает пользовательские поля доски
+func (r *BoardRepository) GetBoardCustomFields(ctx context.Context, boardID int64) (fields []models.CustomField, err error) {
+	funcName := "GetBoardCustomFields"
+	query := `
+	SELECT custom_field_id, title, field_type::text, options, order_index
+	FROM board_custom_field
+	WHERE board_id = $1
+	ORDER BY order_index, custom_field_id;
+	`
+
+	rows, err := r.db.Query(ctx, query, boardID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	fields = make([]models.CustomField, 0)
+	for rows.Next() {
+		field := models.CustomField{}
+		if err := rows.Scan(&field.ID, &field.Title, &field.Type, &field.Options, &field.OrderIndex); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		fields = append(fields, field)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return fields, nil
+}
+
+// GetCustomField получает пользовательское поле по ID
+func (r *BoardRepository) GetCustomField(ctx context.Context, fieldID int64) (field *models.CustomField, err error) {
+	funcName := "GetCustomField"
+	query := `
+	SELECT custom_field_id, title, field_type::text, options, order_index
+	FROM board_custom_field
+	WHERE custom_field_id = $1;
+	`
+
+	field = &models.CustomField{}
+	err = r.db.QueryRow(ctx, query, fieldID).Scan(
+		&field.ID, &field.Title, &field.Type, &field.Options, &field.OrderIndex,
+	)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return field, nil
+}
+
+// CreateCustomField добавляет пользовательское поле в конец списка полей доски
+func (r *BoardRepository) CreateCustomField(ctx context.Context, boardID int64, data *models.CustomFieldPostRequest) (newField *models.CustomField, err error) {
+	funcName := "CreateCustomField"
+	query := `
+	WITH insert_field AS (
+		INSERT INTO board_custom_field (board_id, title, field_type, options, order_index)
+		VALUES ($1, $2, $3::custom_field_type, $4,
+			(SELECT COUNT(*) FROM board_custom_field WHERE board_id=$1))
+		RETURNING custom_field_id, title, field_type, options, order_index
+	),
+	update_board AS (
+		UPDATE board SET updated_at=CURRENT_TIMESTAMP WHERE board_id=$1
+	)
+	SELECT custom_field_id, title, field_type::text, options, order_index FROM insert_field;
+	`
+
+	options := data.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	newField = &models.CustomField{}
+	err = r.db.QueryRow(ctx, query, boardID, data.Title, data.Type, options).Scan(
+		&newField.ID, &newField.Title, &newField.Type, &newField.Options, &newField.OrderIndex,
+	)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return newField, nil
+}
+
+// UpdateCustomField переименовывает поле и меняет варианты выбора.
+// Значения карточек, которых больше нет среди вариантов, удаляются
+func (r *BoardRepository) UpdateCustomField(ctx context.Context, fieldID int64, data *models.CustomFieldPutRequest) (updatedField *models.CustomField, err error) {
+	funcName := "UpdateCustomField"
+	query := `
+	WITH update_field AS (
+		UPDATE board_custom_field
+		SET title=$2, options=CASE WHEN field_type='dropdown' THEN $3 ELSE options END
+		WHERE custom_field_id=$1
+		RETURNING custom_field_id, board_id, title, field_type, options, order_index
+	),
+	delete_stale_values AS (
+		DELETE FROM card_custom_field_value AS v
+		USING update_field AS f
+		WHERE v.custom_field_id=f.custom_field_id
+			AND f.field_type='dropdown'
+			AND NOT (v.value = ANY($3))
+	),
+	update_board AS (
+		UPDATE board SET updated_at=CURRENT_TIMESTAMP WHERE board_id=(SELECT board_id FROM update_field)
+	)
+	SELECT custom_field_id, title, field_type::text, options, order_index FROM update_field;
+	`
+
+	options := data.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	updatedField = &models.CustomField{}
+	err = r.db.QueryRow(ctx, query, fieldID, data.Title, options).Scan(
+		&updatedField.ID, &updatedField.Title, &updatedField.Type, &updatedField.Options, &updatedField.OrderIndex,
+	)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return updatedField, nil
+}
+
+// DeleteCustomField удаляет пользовательское поле доски
+func (r *BoardRepository) DeleteCustomField(ctx context.Context, fieldID int64) (err error) {
+	funcName := "DeleteCustomField"
+	query := `
+	DELETE FROM board_custom_field
+	WHERE custom_field_id=$1;
+	`
+
+	tag, err := r.db.Exec(ctx, query, fieldID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+	}
+	// Значения на карточках удалятся каскадно (за счёт ограничения FOREIGN KEY)
+	return nil
+}
+
+// GetMemberFromCustomField получает права пользователя из ID пользовательского поля
+func (r *BoardRepository) GetMemberFromCustomField(ctx context.Context, userID int64, fieldID int64) (role string, boardID int64, err error) {
+	funcName := "GetMemberFromCustomField"
+	query := `
+	SELECT utb.role, cf.board_id
+	FROM board_custom_field AS cf
+	JOIN user_to_board AS utb ON utb.board_id = cf.board_id
+	WHERE utb.u_id = $1 AND cf.custom_field_id = $2;
+	`
+
+	err = r.db.QueryRow(ctx, query, userID, fieldID).Scan(
+		&role, &boardID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", 0, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return "", 0, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+
+	return role, boardID, err
+}
+
+// SetCardCustomFieldValue задаёт значение пользовательского поля на карточке
+func (r *BoardRepository) SetCardCustomFieldValue(ctx context.Context, cardID int64, fieldID int64, value string) (fieldValue *models.CustomFieldValue, err error) {
+	funcName := "SetCardCustomFieldValue"
+	query := `
+	WITH upsert_value AS (
+		INSERT INTO card_custom_field_value (card_id, custom_field_id, value)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (card_id, custom_field_id)
+		DO UPDATE SET value=EXCLUDED.value, updated_at=CURRENT_TIMESTAMP
+		RETURNING card_id, custom_field_id, value
+	),
+	update_card AS (
+		UPDATE "card" SET updated_at=CURRENT_TIMESTAMP WHERE card_id=$1
+	),
+	update_board AS (
+		UPDATE board SET updated_at=CURRENT_TIMESTAMP WHERE board_id=(
+			SELECT board_id FROM board_custom_field WHERE custom_field_id=$2
+		)
+	)
+	SELECT card_id, custom_field_id, value FROM upsert_value;
+	`
+
+	fieldValue = &models.CustomFieldValue{}
+	err = r.db.QueryRow(ctx, query, cardID, fieldID, value).Scan(
+		&fieldValue.CardID, &fieldValue.FieldID, &fieldValue.Value,
+	)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return fieldValue, nil
+}
+
+// ClearCardCustomFieldValue очищает значение пользовательского поля на карточке
+func (r *BoardRepository) ClearCardCustomFieldValue(ctx context.Context, cardID int64, fieldID int64) (err error) {
+	funcName := "ClearCardCustomFieldValue"
+	query := `
+	WITH delete_value AS (
+		DELETE FROM card_custom_field_value
+		WHERE card_id=$1 AND custom_field_id=$2
+	)
+	UPDATE "card" SET updated_at=CURRENT_TIMESTAMP WHERE card_id=$1;
+	`
+
+	_, err = r.db.Exec(ctx, query, cardID, fieldID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return nil
+}
+
+// GetCardCustomFieldValues получает значения пользовательских полей карточки
+func (r *BoardRepository) GetCardCustomFieldValues(ctx context.Context, cardID int64) (values []models.CustomFieldValue, err error) {
+	funcName := "GetCardCustomFieldValues"
+	query := `
+	SELECT v.card_id, v.custom_field_id, v.value
+	FROM card_custom_field_value AS v
+	JOIN board_custom_field AS cf ON cf.custom_field_id = v.custom_field_id
+	WHERE v.card_id = $1
+	ORDER BY cf.order_index;
+	`
+
+	return r.queryCustomFieldValues(ctx, funcName, query, cardID)
+}
+
+// GetBoardCustomFieldValues получает значения пользовательских полей всех карточек доски
+func (r *BoardRepository) GetBoardCustomFieldValues(ctx context.Context, boardID int64) (values []models.CustomFieldValue, err error) {
+	funcName := "GetBoardCustomFieldValues"
+	query := `
+	SELECT v.card_id, v.custom_field_id, v.value
+	FROM card_custom_field_value AS v
+	JOIN board_custom_field AS cf ON cf.custom_field_id = v.custom_field_id
+	WHERE cf.board_id = $1
+	ORDER BY v.card_id, cf.order_index;
+	`
+
+	return r.queryCustomFieldValues(ctx, funcName, query, boardID)
+}
+
+func (r *BoardRepository) queryCustomFieldValues(ctx context.Context, funcName string, query string, args ...any) (values []models.CustomFieldValue, err error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	values = make([]models.CustomFieldValue, 0)
+	for rows.Next() {
+		value := models.CustomFieldValue{}
+		if err := rows.Scan(&value.CardID, &value.FieldID, &value.Value); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		values = append(values, value)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return values, nil
+}
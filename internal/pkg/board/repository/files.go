@@ -6,9 +6,6 @@ import (
 	"context"
 )
 
-func (r *BoardRepository) DeduplicateFile(ctx context.Context, file *models.UploadedFile) (fileNames []string, fileIDs []int64, err error) {
-	return uploads.DeduplicateFile(ctx, r.db, file)
-}
-func (r *BoardRepository) RegisterFile(ctx context.Context, file *models.UploadedFile) error {
+func (r *BoardRepository) RegisterFile(ctx context.Context, file *models.UploadedFile) (isNew bool, err error) {
 	return uploads.RegisterFile(ctx, r.db, file)
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
@@ -42,7 +43,8 @@ func (r *BoardRepository) GetUserProfile(ctx context.Context, userID int64) (use
 		}
 		return nil, fmt.Errorf("GetUserProfile: %w", err)
 	}
-	user.AvatarImageURL = uploads.JoinFileURL(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
+	user.AvatarImageURL = uploads.JoinImageURL(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
+	user.AvatarImageVariants = uploads.JoinVariantURLs(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
 	return user, nil
 }
 
@@ -195,17 +197,21 @@ func (r *BoardRepository) GetMembersWithPermissions(ctx context.Context, boardID
 			}
 			return nil, fmt.Errorf("GetMembersWithPermissions: %w", err)
 		}
+		field.User.AvatarImageURL = uploads.JoinImageURL(memberAvatarUUID, memberAvatarExt, uploads.DefaultAvatarURL)
+		field.User.AvatarImageVariants = uploads.JoinVariantURLs(memberAvatarUUID, memberAvatarExt, uploads.DefaultAvatarURL)
 		if field.AddedBy.ID == -1 {
 			field.AddedBy = nil
+		} else {
+			field.AddedBy.AvatarImageURL = uploads.JoinImageURL(adderAvatarUUID, adderAvatarExt, uploads.DefaultAvatarURL)
+			field.AddedBy.AvatarImageVariants = uploads.JoinVariantURLs(adderAvatarUUID, adderAvatarExt, uploads.DefaultAvatarURL)
 		}
 		if field.UpdatedBy.ID == -1 {
 			field.UpdatedBy = nil
+		} else {
+			field.UpdatedBy.AvatarImageURL = uploads.JoinImageURL(updaterAvatarUUID, updaterAvatarExt, uploads.DefaultAvatarURL)
+			field.UpdatedBy.AvatarImageVariants = uploads.JoinVariantURLs(updaterAvatarUUID, updaterAvatarExt, uploads.DefaultAvatarURL)
 		}
 
-		field.User.AvatarImageURL = uploads.JoinFileURL(memberAvatarUUID, memberAvatarExt, uploads.DefaultAvatarURL)
-		field.AddedBy.AvatarImageURL = uploads.JoinFileURL(adderAvatarUUID, adderAvatarExt, uploads.DefaultAvatarURL)
-		field.UpdatedBy.AvatarImageURL = uploads.JoinFileURL(updaterAvatarUUID, updaterAvatarExt, uploads.DefaultAvatarURL)
-
 		members = append(members, field)
 	}
 	return members, nil
@@ -357,6 +363,33 @@ func (r *BoardRepository) GetMemberFromCheckListField(ctx context.Context, userI
 	return role, boardID, cardID, err
 }
 
+// GetMemberFromCheckList получает права пользователя из ID чеклиста
+func (r *BoardRepository) GetMemberFromCheckList(ctx context.Context, userID int64, checkListID int64) (role string, boardID int64, cardID int64, err error) {
+	funcName := "GetMemberFromCheckList"
+	query := `
+	SELECT
+	utb.role, b.board_id, c.card_id
+	FROM checklist AS cl
+	JOIN card AS c ON cl.card_id = c.card_id
+	JOIN kanban_column AS kc ON kc.col_id = c.col_id
+	JOIN board AS b ON b.board_id = kc.board_id
+	JOIN user_to_board AS utb ON utb.board_id = b.board_id
+	WHERE utb.u_id = $1 AND cl.checklist_id = $2;
+	`
+
+	err = r.db.QueryRow(ctx, query, userID, checkListID).Scan(
+		&role, &boardID, &cardID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", 0, 0, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return "", 0, 0, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+
+	return role, boardID, cardID, err
+}
+
 // GetMemberFromAttachment получает права пользователя из ID вложения
 func (r *BoardRepository) GetMemberFromAttachment(ctx context.Context, userID int64, attachmentID int64) (role string, boardID int64, cardID int64, err error) {
 	funcName := "GetMemberFromAttachment"
@@ -433,38 +466,80 @@ func (r *BoardRepository) GetMemberFromComment(ctx context.Context, userID int64
 	return role, boardID, cardID, err
 }
 
-// GetCardCheckList получает чеклисты для карточки
-func (r *BoardRepository) GetCardCheckList(ctx context.Context, cardID int64) (checkList []models.CheckListField, err error) {
-	funcName := "GetCardCheckList"
+// GetCardCheckLists получает чеклисты карточки вместе с их полями
+func (r *BoardRepository) GetCardCheckLists(ctx context.Context, cardID int64) (checkLists []models.CheckList, err error) {
+	funcName := "GetCardCheckLists"
 	query := `
-		SELECT cf.checklist_field_id, cf.title, cf.created_at, cf.is_done
-		FROM checklist_field AS cf
-		JOIN card AS c ON cf.card_id = cf.card_id
-		WHERE c.card_id = $1
-		ORDER BY cf.order_index;
+		SELECT cl.checklist_id, cl.title, cl.created_at, cl.order_index,
+		cf.checklist_field_id, cf.title, cf.created_at, cf.is_done, cf.subtask_card_id
+		FROM checklist AS cl
+		LEFT JOIN checklist_field AS cf ON cf.checklist_id = cl.checklist_id
+		WHERE cl.card_id = $1
+		ORDER BY cl.order_index, cl.checklist_id, cf.order_index, cf.checklist_field_id;
 	`
 
-	checkList = make([]models.CheckListField, 0)
+	checkLists = make([]models.CheckList, 0)
 
 	rows, err := r.db.Query(ctx, query, cardID)
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
-		}
 		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		field := models.CheckListField{}
-		if err := rows.Scan(&field.ID, &field.Title, &field.CreatedAt, &field.IsDone); err != nil {
+		list := models.CheckList{}
+		var fieldID *int64
+		var fieldTitle *string
+		var fieldCreatedAt *time.Time
+		var fieldIsDone *bool
+		var subtaskCardID *int64
+		if err := rows.Scan(&list.ID, &list.Title, &list.CreatedAt, &list.OrderIndex,
+			&fieldID, &fieldTitle, &fieldCreatedAt, &fieldIsDone, &subtaskCardID); err != nil {
 			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
 		}
 
-		checkList = append(checkList, field)
+		if len(checkLists) == 0 || checkLists[len(checkLists)-1].ID != list.ID {
+			list.Fields = make([]models.CheckListField, 0)
+			checkLists = append(checkLists, list)
+		}
+		if fieldID == nil {
+			continue
+		}
+		current := &checkLists[len(checkLists)-1]
+		current.Fields = append(current.Fields, models.CheckListField{
+			ID:            *fieldID,
+			CheckListID:   list.ID,
+			Title:         *fieldTitle,
+			CreatedAt:     *fieldCreatedAt,
+			IsDone:        *fieldIsDone,
+			SubtaskCardID: subtaskCardID,
+			OrderIndex:    int64(len(current.Fields)),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
 	}
 
-	return checkList, nil
+	for i := range checkLists {
+		checkLists[i].CompletionPercent = checkListCompletion(checkLists[i].Fields)
+	}
+
+	return checkLists, nil
+}
+
+// checkListCompletion считает процент выполненных полей чеклиста
+func checkListCompletion(fields []models.CheckListField) int {
+	if len(fields) == 0 {
+		return 0
+	}
+	done := 0
+	for _, field := range fields {
+		if field.IsDone {
+			done++
+		}
+	}
+	return done * 100 / len(fields)
 }
 
 // GetCardAssignedUsers получает пользователей, назначенных на карточку
@@ -508,7 +583,8 @@ func (r *BoardRepository) GetCardAssignedUsers(ctx context.Context, cardID int64
 			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
 		}
 
-		assignedProfile.AvatarImageURL = uploads.JoinFileURL(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
+		assignedProfile.AvatarImageURL = uploads.JoinImageURL(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
+		assignedProfile.AvatarImageVariants = uploads.JoinVariantURLs(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
 		assignedUsers = append(assignedUsers, assignedProfile)
 	}
 
@@ -524,16 +600,16 @@ func (r *BoardRepository) GetCardComments(ctx context.Context, cardID int64) (co
 		cc.created_at,
 		cc.is_edited,
 
-		u.u_id,
-		u.nickname,
-		u.email,
-		u.joined_at,
-		u.updated_at,
+		COALESCE(u.u_id, 0),
+		COALESCE(u.nickname, 'deleted'),
+		COALESCE(u.email, ''),
+		COALESCE(u.joined_at, cc.created_at),
+		COALESCE(u.updated_at, cc.created_at),
 		COALESCE(f.file_uuid::text, ''),
 		COALESCE(f.file_extension::text, '')
 
 		FROM card_comment AS cc
-		JOIN "user" AS u ON cc.created_by=u.u_id
+		LEFT JOIN "user" AS u ON cc.created_by=u.u_id
 		LEFT JOIN user_uploaded_file AS f ON f.file_id=u.avatar_file_id
 		WHERE cc.card_id = $1;
 	`
@@ -559,7 +635,8 @@ func (r *BoardRepository) GetCardComments(ctx context.Context, cardID int64) (co
 			&uP.UpdatedAt, &avatarUUID, &avatarExt); err != nil {
 			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
 		}
-		uP.AvatarImageURL = uploads.JoinFileURL(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
+		uP.AvatarImageURL = uploads.JoinImageURL(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
+		uP.AvatarImageVariants = uploads.JoinVariantURLs(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
 		c.CreatedBy = &uP
 
 		comments = append(comments, c)
@@ -625,11 +702,12 @@ func (r *BoardRepository) GetCardsForMove(ctx context.Context, col1ID int64, col
 		}
 		return nil, nil, fmt.Errorf("GetCardsForMove (query): %w", err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		c := models.Card{}
 
-		if err := rows.Scan(&c.ID, &c.ColumnID, c.OrderIndex); err != nil {
+		if err := rows.Scan(&c.ID, &c.ColumnID, &c.OrderIndex); err != nil {
 			return nil, nil, fmt.Errorf("GetCardsForMove (scan): %w", err)
 		}
 
@@ -675,12 +753,15 @@ func (r *BoardRepository) GetColumnsForMove(ctx context.Context, boardID int64)
 	return columns, nil
 }
 
-// RearrangeCards обновляет позиции всех карточек колонки, чтобы сделать порядок, как в слайсе
-func (r *BoardRepository) RearrangeCards(ctx context.Context, columnID int64, cards []models.Card) (err error) {
-	funcName := "RearrangeCards"
+// MoveCard в одной транзакции устанавливает порядок карточек целевой колонки как в targetCards
+// (перенесённая карточка переезжает в targetColumnID) и, если карточка пришла из другой колонки,
+// уплотняет порядок оставшихся в ней карточек sourceCards
+func (r *BoardRepository) MoveCard(ctx context.Context, targetColumnID int64, targetCards []models.Card,
+	sourceColumnID int64, sourceCards []models.Card) (err error) {
+	funcName := "MoveCard"
 	query := `
 	WITH update_position_cards AS (
-		UPDATE card SET order_index = $1 WHERE col_id = $2
+		UPDATE card SET order_index = $1, col_id = $2 WHERE card_id = $3
 	),
 	update_board AS (
 		UPDATE board SET updated_at = CURRENT_TIMESTAMP WHERE board_id = (
@@ -690,16 +771,31 @@ func (r *BoardRepository) RearrangeCards(ctx context.Context, columnID int64, ca
 	SELECT;
 	`
 	batch := &pgx.Batch{}
-	for _, card := range cards {
-		batch.Queue(query, card.OrderIndex)
+	for idx, card := range targetCards {
+		batch.Queue(query, idx, targetColumnID, card.ID)
+	}
+	if sourceColumnID != targetColumnID {
+		for idx, card := range sourceCards {
+			batch.Queue(query, idx, sourceColumnID, card.ID)
+		}
 	}
 
-	br := r.db.SendBatch(ctx, batch)
-	err = br.Close()
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s (begin): %w", funcName, err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.SendBatch(ctx, batch).Close()
 	logging.Debug(ctx, funcName, " batch query has err: ", err)
 	if err != nil {
 		return fmt.Errorf("%s (batch query): %w", funcName, err)
 	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("%s (commit): %w", funcName, err)
+	}
 	return nil
 }
 
@@ -722,22 +818,43 @@ func (r *BoardRepository) RearrangeColumns(ctx context.Context, columns []models
 	return nil
 }
 
-// RearrangeCheckList устанавливает порядок полей чеклиста как в слайсе
-func (r *BoardRepository) RearrangeCheckList(ctx context.Context, fields []models.CheckListField) (err error) {
-	panic("not implemented")
-	funcName := "RearrangeCheckList"
-	query := ``
+// MoveCheckListField в одной транзакции устанавливает порядок полей целевого чеклиста как в targetFields
+// (перенесённое поле переезжает в targetListID) и, если поле пришло из другого чеклиста,
+// уплотняет порядок оставшихся в нём полей sourceFields
+func (r *BoardRepository) MoveCheckListField(ctx context.Context, targetListID int64, targetFields []models.CheckListField,
+	sourceListID int64, sourceFields []models.CheckListField) (err error) {
+	funcName := "MoveCheckListField"
+	query := `
+		UPDATE checklist_field
+		SET order_index=$1, checklist_id=$2
+		WHERE checklist_field_id=$3;
+	`
 	batch := &pgx.Batch{}
-	for _, col := range fields {
-		batch.Queue(query, col.OrderIndex)
+	for idx, field := range targetFields {
+		batch.Queue(query, idx, targetListID, field.ID)
+	}
+	if sourceListID != targetListID {
+		for idx, field := range sourceFields {
+			batch.Queue(query, idx, sourceListID, field.ID)
+		}
 	}
 
-	br := r.db.SendBatch(ctx, batch)
-	err = br.Close()
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s (begin): %w", funcName, err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.SendBatch(ctx, batch).Close()
 	logging.Debug(ctx, funcName, " batch query has err: ", err)
 	if err != nil {
 		return fmt.Errorf("%s (batch query): %w", funcName, err)
 	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("%s (commit): %w", funcName, err)
+	}
 	return nil
 }
 
@@ -745,8 +862,12 @@ func (r *BoardRepository) RearrangeCheckList(ctx context.Context, fields []model
 func (r *BoardRepository) AssignUserToCard(ctx context.Context, cardID int64, assignedUserID int64) (assignedUser *models.UserProfile, err error) {
 	funcName := "AssignUserToCard"
 	query := `
-		WITH update_card_user_assignment AS (
-			UPDATE card_user_assignment SET u_id = $2 WHERE card_id = $1
+		WITH insert_card_user_assignment AS (
+			INSERT INTO card_user_assignment (card_id, u_id)
+			SELECT $1, $2
+			WHERE NOT EXISTS (
+				SELECT 1 FROM card_user_assignment WHERE card_id = $1 AND u_id = $2
+			)
 		),
 		update_card AS (
 			UPDATE "card" SET updated_at=CURRENT_TIMESTAMP WHERE card_id = $1
@@ -762,20 +883,31 @@ func (r *BoardRepository) AssignUserToCard(ctx context.Context, cardID int64, as
 		)
 		SELECT u.u_id, u.nickname, u.email, u.joined_at, u.updated_at,
 		COALESCE(f.file_uuid::text, ''), COALESCE(f.file_extension::text, '')
-		FROM card_user_assignment AS cua
-		JOIN "user" AS u ON cua.u_id = u.u_id
+		FROM "user" AS u
 		LEFT JOIN user_uploaded_file AS f ON f.file_id=u.avatar_file_id
-		WHERE cua.card_id = $1;
+		WHERE u.u_id = $2;
 	`
 
-	tag, err := r.db.Exec(ctx, query)
+	assignedUser = &models.UserProfile{}
+	var avatarUUID, avatarExt string
+	err = r.db.QueryRow(ctx, query, cardID, assignedUserID).Scan(
+		&assignedUser.ID,
+		&assignedUser.Name,
+		&assignedUser.Email,
+		&assignedUser.JoinedAt,
+		&assignedUser.UpdatedAt,
+		&avatarUUID,
+		&avatarExt,
+	)
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
 		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
-	if tag.RowsAffected() == 0 {
-		return nil, fmt.Errorf("%s (query): no rows affected", funcName)
-	}
+	assignedUser.AvatarImageURL = uploads.JoinImageURL(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
+	assignedUser.AvatarImageVariants = uploads.JoinVariantURLs(avatarUUID, avatarExt, uploads.DefaultAvatarURL)
 	return assignedUser, nil
 }
 
@@ -861,10 +993,11 @@ func (r *BoardRepository) CreateComment(ctx context.Context, userID int64, cardI
 		&fileUUID,
 		&fileExtension,
 	)
-	newComment.CreatedBy.AvatarImageURL = uploads.JoinFileURL(fileUUID, fileExtension, uploads.DefaultAvatarURL)
+	newComment.CreatedBy.AvatarImageURL = uploads.JoinImageURL(fileUUID, fileExtension, uploads.DefaultAvatarURL)
+	newComment.CreatedBy.AvatarImageVariants = uploads.JoinVariantURLs(fileUUID, fileExtension, uploads.DefaultAvatarURL)
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		return nil, fmt.Errorf("%s (query): %w", err)
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
 	return newComment, err
 }
@@ -909,7 +1042,7 @@ func (r *BoardRepository) UpdateComment(ctx context.Context, commentID int64, up
 	err = row.Scan()
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		return nil, fmt.Errorf("%s (query): %w", err)
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
 	return updatedComment, nil
 }
@@ -950,24 +1083,34 @@ func (r *BoardRepository) DeleteComment(ctx context.Context, commentID int64) (e
 }
 
 // CreateCheckListField создаёт поле чеклиста и добавляет его в конец
-func (r *BoardRepository) CreateCheckListField(ctx context.Context, cardID int64, field *models.CheckListFieldPostRequest) (newField *models.CheckListField, err error) {
+func (r *BoardRepository) CreateCheckListField(ctx context.Context, checkListID int64, field *models.CheckListFieldPostRequest) (newField *models.CheckListField, err error) {
 	funcName := "CreateCheckListField"
 	query := `
 	WITH insert_field AS (
-		INSERT INTO checklist_field (card_id, title, order_index) VALUES ($1, $2, 12345)
-		RETURNING checklist_field_id AS id
+		INSERT INTO checklist_field (card_id, checklist_id, title, order_index)
+		SELECT cl.card_id, cl.checklist_id, $2,
+			(SELECT COUNT(*) FROM checklist_field WHERE checklist_id=$1)
+		FROM checklist AS cl
+		WHERE cl.checklist_id=$1
+		RETURNING checklist_field_id, checklist_id, title, created_at, is_done, card_id
+	),
+	update_card AS (
+		UPDATE "card" SET updated_at=CURRENT_TIMESTAMP WHERE card_id = (
+			SELECT card_id FROM insert_field
+		)
 	)
-	SELECT id FROM insert_field;
+	SELECT checklist_field_id, checklist_id, title, created_at, is_done FROM insert_field;
 	`
 
 	newField = &models.CheckListField{}
-	row := r.db.QueryRow(ctx, query, cardID, field.Title)
-	err = row.Scan(&newField.ID) //&newField.Title, &newField.CreatedAt, &newField.IsDone,
-	newField.Title = field.Title
-
+	row := r.db.QueryRow(ctx, query, checkListID, field.Title)
+	err = row.Scan(&newField.ID, &newField.CheckListID, &newField.Title, &newField.CreatedAt, &newField.IsDone)
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		return nil, fmt.Errorf("%s (query): %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
 	return newField, nil
 }
@@ -994,17 +1137,18 @@ func (r *BoardRepository) UpdateCheckListField(ctx context.Context, fieldID int6
 			WHERE cf.checklist_field_id=$1
 		)
 	)
-	SELECT f.checklist_field_id, f.title, f.created_at, f.is_done
+	SELECT f.checklist_field_id, f.checklist_id, f.title, f.created_at, f.is_done, f.subtask_card_id
 	FROM checklist_field AS f
 	WHERE f.checklist_field_id=$1;
 	`
 
 	updatedField = &models.CheckListField{}
 	row := r.db.QueryRow(ctx, query, fieldID, update.Title, update.IsDone)
-	err = row.Scan(&updatedField.ID, &updatedField.Title, &updatedField.CreatedAt, &updatedField.IsDone)
+	err = row.Scan(&updatedField.ID, &updatedField.CheckListID, &updatedField.Title,
+		&updatedField.CreatedAt, &updatedField.IsDone, &updatedField.SubtaskCardID)
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		return nil, fmt.Errorf("%s (query): %w", err)
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
 	if update.IsDone != nil {
 		updatedField.IsDone = *update.IsDone
@@ -1016,7 +1160,7 @@ func (r *BoardRepository) UpdateCheckListField(ctx context.Context, fieldID int6
 }
 
 func (r *BoardRepository) DeleteCheckListField(ctx context.Context, fieldID int64) error {
-	funcName := "UpdateCheckListField"
+	funcName := "DeleteCheckListField"
 	query := `
 	DELETE FROM checklist_field
 	WHERE checklist_field_id=$1;`
@@ -1045,19 +1189,24 @@ func (r *BoardRepository) SetCardCover(ctx context.Context, userID int64, cardID
 			FROM card AS c
 			JOIN kanban_column AS kc ON c.col_id=kc.col_id
 			JOIN board AS b ON b.board_id = kc.board_id
-			WHERE c.card_id=$1
+			WHERE c.card_id=$2
 		)
 	)
-	SELECT;
+	SELECT file_uuid::text, COALESCE(file_extension, '')
+	FROM user_uploaded_file
+	WHERE file_id=$1;
 	`
 
-	updatedCard = &models.Card{}
-	row := r.db.QueryRow(ctx, query)
-	err = row.Scan()
+	updatedCard = &models.Card{ID: cardID}
+	var fileUUID, fileExtension string
+	row := r.db.QueryRow(ctx, query, file.FileID, cardID)
+	err = row.Scan(&fileUUID, &fileExtension)
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		return nil, fmt.Errorf("%s (query): %w", err)
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
+	updatedCard.CoverImageURL = uploads.JoinImageURL(fileUUID, fileExtension, "")
+	updatedCard.CoverImageVariants = uploads.JoinVariantURLs(fileUUID, fileExtension, "")
 	return updatedCard, nil
 }
 
@@ -1097,34 +1246,33 @@ func (r *BoardRepository) AddAttachment(ctx context.Context, userID int64, cardI
 	query := `
 	WITH insert_attachment AS (
 		INSERT INTO card_attachment (card_id, file_id, original_name, attached_by) VALUES ($1, $2, $3, $4)
-		RETURNING attachment_id
+		RETURNING attachment_id, file_id, original_name, created_at
 	),
 	update_card AS (
 		UPDATE "card" SET updated_at=CURRENT_TIMESTAMP WHERE card_id = $1
 	),
 	update_board AS (
 		UPDATE board SET updated_at=CURRENT_TIMESTAMP WHERE board_id = (
-			SELECT b.board_id
-			FROM card_attachment AS ca
-			JOIN card AS c ON ca.card_id=c.card_id
+			SELECT kc.board_id
+			FROM card AS c
 			JOIN kanban_column AS kc ON c.col_id=kc.col_id
-			JOIN board AS b ON b.board_id = kc.board_id
-			WHERE ca.attachment_id=$1
+			WHERE c.card_id=$1
 		)
 	)
-	SELECT uuf.file_uuid, uuf.file_extension
-	FROM card_attachment AS ca
-	JOIN user_uploaded_file AS uuf ON ca.file_id = uuf.file_id
-	WHERE ca.file_id = $2;
+	SELECT ia.attachment_id, ia.original_name, ia.created_at, uuf.file_uuid::text, COALESCE(uuf.file_extension, '')
+	FROM insert_attachment AS ia
+	JOIN user_uploaded_file AS uuf ON ia.file_id = uuf.file_id;
 	`
 
 	newAttachment = &models.Attachment{}
-	row := r.db.QueryRow(ctx, query)
-	err = row.Scan()
+	var fileUUID, fileExtension string
+	row := r.db.QueryRow(ctx, query, cardID, file.FileID, file.OriginalName, userID)
+	err = row.Scan(&newAttachment.ID, &newAttachment.OriginalName, &newAttachment.CreatedAt, &fileUUID, &fileExtension)
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		return nil, fmt.Errorf("%s (query): %w", err)
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
+	newAttachment.FileName = uploads.JoinFileURL(fileUUID, fileExtension, "")
 	return newAttachment, nil
 }
 
@@ -1178,7 +1326,7 @@ func (r *BoardRepository) PullInviteLink(ctx context.Context, userID int64, boar
 	err = row.Scan()
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		return nil, fmt.Errorf("%s (query): %w", err)
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
 	return link, nil
 }
@@ -1221,7 +1369,7 @@ func (r *BoardRepository) FetchInvite(ctx context.Context, inviteUUID string) (b
 	err = row.Scan()
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		return nil, fmt.Errorf("%s (query): %w", err)
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
 	return board, nil
 }
@@ -1255,7 +1403,7 @@ func (r *BoardRepository) AcceptInvite(ctx context.Context, userID int64, boardI
 	err = row.Scan()
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		return nil, fmt.Errorf("%s (query): %w", err)
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
 	}
 	return board, nil
 }
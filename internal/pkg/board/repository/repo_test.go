@@ -1,400 +1,189 @@
 package repository
 
 import (
-	"RPO_back/internal/models"
+	"RPO_back/internal/errs"
 	"RPO_back/internal/pkg/utils/uploads"
 	"context"
 	"errors"
-	"fmt"
-	"regexp"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+var boardColumns = []string{
+	"board_id", "name", "created_at", "updated_at", "last_visit_at", "file_uuid", "file_extension",
+}
+
 func TestCreateBoard(t *testing.T) {
-	// Создаем mock SQL базу данных
 	mock, err := pgxmock.NewPool()
-	if err != nil {
-		t.Fatalf("unexpected error: %s", err)
-	}
+	require.NoError(t, err)
 	defer mock.Close()
 
 	repo := CreateBoardRepository(mock)
-
 	ctx := context.Background()
+	now := time.Now()
 
-	name := "Sample Board"
-	userID := 1
-
-	expectedBoardID := 1
-	expectedCreatedAt := time.Now()
-	expectedUpdatedAt := time.Now()
-
-	mock.ExpectQuery(regexp.QuoteMeta(`
-	INSERT INTO board (name, created_by)
-	VALUES ($1, $2)
-	RETURNING board_id, name, created_at, updated_at
-`)).
-		WithArgs(name, "", userID).
-		WillReturnRows(pgxmock.NewRows([]string{"board_id", "name", "created_at", "updated_at"}).
-			AddRow(expectedBoardID, name, expectedCreatedAt, expectedUpdatedAt))
-
-	board, err := repo.CreateBoard(ctx, name, userID)
-	assert.NoError(t, err)
-	assert.Equal(t, expectedBoardID, board.ID)
-	assert.Equal(t, name, board.Name)
-	assert.Equal(t, expectedCreatedAt, board.CreatedAt)
-	assert.Equal(t, expectedUpdatedAt, board.UpdatedAt)
-	assert.Equal(t, uploads.DefaultBackgroundURL, board.BackgroundImageURL)
+	mock.ExpectQuery("INSERT INTO board \\(name, created_by\\)").
+		WithArgs("Sample Board", int64(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"board_id"}).AddRow(int64(10)))
+	mock.ExpectQuery("FROM board AS b").
+		WithArgs(int64(1), int64(10)).
+		WillReturnRows(pgxmock.NewRows(boardColumns).
+			AddRow(int64(10), "Sample Board", now, now, now, "", ""))
 
-	err = mock.ExpectationsWereMet()
-	assert.NoError(t, err)
+	board, err := repo.CreateBoard(ctx, "Sample Board", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), board.ID)
+	assert.Equal(t, "Sample Board", board.Name)
+	assert.Equal(t, uploads.DefaultBackgroundURL, board.BackgroundImageURL)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetBoard(t *testing.T) {
 	mock, err := pgxmock.NewPool()
-	assert.NoError(t, err)
+	require.NoError(t, err)
+	defer mock.Close()
 
+	repo := CreateBoardRepository(mock)
 	ctx := context.Background()
-	boardID := 1
-	userID := 1
+	now := time.Now()
 
-	rows := pgxmock.NewRows([]string{
-		"board_id", "name", "description", "created_at", "updated_at", "file_uuid", "file_extension",
-	}).AddRow(
-		boardID, "Test Board", "Description", time.Now(), time.Now(), "uuid-1234", ".jpg",
-	)
+	t.Run("with background", func(t *testing.T) {
+		mock.ExpectQuery("FROM board AS b").
+			WithArgs(int64(2), int64(1)).
+			WillReturnRows(pgxmock.NewRows(boardColumns).
+				AddRow(int64(1), "Test Board", now, now, now, "uuid-1234", ".jpg"))
 
-	mock.ExpectQuery("^SELECT (.+) FROM board AS b LEFT JOIN user_uploaded_file AS file ON file.file_uuid=b.background_image_uuid WHERE b.board_id = \\$1;").
-		WithArgs(boardID).
-		WillReturnRows(rows)
+		board, err := repo.GetBoard(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "Test Board", board.Name)
+		assert.Equal(t, uploads.JoinImageURL("uuid-1234", ".jpg", uploads.DefaultBackgroundURL), board.BackgroundImageURL)
+	})
 
-	boardRepo := CreateBoardRepository(mock)
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery("FROM board AS b").
+			WithArgs(int64(2), int64(1)).
+			WillReturnError(pgx.ErrNoRows)
 
-	board, err := boardRepo.GetBoard(ctx, boardID, userID)
-	assert.NoError(t, err)
-	assert.NotNil(t, board)
-}
+		_, err := repo.GetBoard(ctx, 1, 2)
+		assert.True(t, errors.Is(err, errs.ErrNotFound))
+	})
 
-func TestGetBoard_NotFound(t *testing.T) {
-	mock, err := pgxmock.NewPool()
-	assert.NoError(t, err)
-
-	ctx := context.Background()
-	boardID := 1
-	userID := 1
-
-	mock.ExpectQuery("^SELECT (.+) FROM board AS b LEFT JOIN user_uploaded_file AS file ON file.file_uuid=b.background_image_uuid WHERE b.board_id = \\$1;").
-		WithArgs(boardID).
-		WillReturnError(pgx.ErrNoRows)
-
-	boardRepo := CreateBoardRepository(mock)
-
-	board, err := boardRepo.GetBoard(ctx, boardID, userID)
-	assert.Error(t, err)
-	assert.Nil(t, board)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetBoard_QueryError(t *testing.T) {
+func TestDeleteBoard(t *testing.T) {
 	mock, err := pgxmock.NewPool()
-	assert.NoError(t, err)
+	require.NoError(t, err)
+	defer mock.Close()
 
+	repo := CreateBoardRepository(mock)
 	ctx := context.Background()
-	boardID := 1
-	userID := 1
-
-	mock.ExpectQuery("^SELECT (.+) FROM board AS b LEFT JOIN user_uploaded_file AS file ON file.file_uuid=b.background_image_uuid WHERE b.board_id = \\$1;").
-		WithArgs(boardID).
-		WillReturnError(fmt.Errorf("some query error"))
-
-	boardRepo := CreateBoardRepository(mock)
-
-	board, err := boardRepo.GetBoard(ctx, boardID, userID)
-	assert.Error(t, err)
-	assert.Nil(t, board)
-}
-
-func TestGetMembersWithPermissions(t *testing.T) {
-	mock, err := pgxmock.NewPool()
-	assert.NoError(t, err)
 
-	boardID := 1
-	userID := 1
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM board").
+			WithArgs(int64(1)).
+			WillReturnResult(pgconn.NewCommandTag("DELETE 1"))
 
-	// Устанавливаем ожидания для запроса GetBoard.
-	mock.ExpectQuery("SELECT (.+) FROM board").
-		WithArgs(boardID).
-		WillReturnRows(pgxmock.NewRows([]string{
-			"board_id", "name", "description", "created_at", "updated_at", "file_uuid", "file_extension",
-		}).AddRow(
-			boardID, "Test Board", "Description", time.Now(), time.Now(), "uuid-1234", ".jpg",
-		))
-
-	// Настраиваем mock для основного SQL-запроса функции GetMembersWithPermissions.
-	query := `
-	SELECT (.+) FROM "user"
-	`
-
-	// Создаем строки, которые будут возвращены в ответ на вызов метода Query.
-	rows := pgxmock.NewRows([]string{
-		"u_id", "nickname", "email", "description", "joined_at", "updated_at",
-		"role", "added_at", "updated_at",
-		"adder_id", "adder_nickname", "adder_email", "adder_description", "adder_joined_at", "adder_updated_at",
-		"updater_id", "updater_nickname", "updater_email", "updater_description", "updater_joined_at", "updater_updated_at",
-		"member_avatar_uuid", "member_avatar_ext",
-		"adder_avatar_uuid", "adder_avatar_ext",
-		"updater_avatar_uuid", "updater_avatar_ext",
-	}).AddRow(
-		1, "User1", "user1@example.com", "Description1", time.Now(), time.Now(),
-		"admin", time.Now(), time.Now(),
-		2, "Adder1", "adder1@example.com", "Adder Description", time.Now(), time.Now(),
-		3, "Updater1", "updater1@example.com", "Updater Description", time.Now(), time.Now(),
-		"", "", "", "", "", "", // UUIDs and file extensions
-	)
-
-	mock.ExpectQuery(query).
-		WithArgs(boardID).
-		WillReturnRows(rows)
+		assert.NoError(t, repo.DeleteBoard(ctx, 1))
+	})
 
-	repo := CreateBoardRepository(mock)
-	_, err = repo.GetMembersWithPermissions(context.Background(), boardID, userID)
-	assert.NoError(t, err)
-
-	err = mock.ExpectationsWereMet()
-	assert.NoError(t, err)
-}
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM board").
+			WithArgs(int64(1)).
+			WillReturnResult(pgconn.NewCommandTag("DELETE 0"))
 
-func TestSetMemberRole_Success(t *testing.T) {
-	mock, err := pgxmock.NewPool()
-	require.NoError(t, err)
-
-	ctx := context.Background()
-	repo := CreateBoardRepository(mock)
+		assert.True(t, errors.Is(repo.DeleteBoard(ctx, 1), errs.ErrNotFound))
+	})
 
-	uid := 123
-	bid := 456
-
-	mock.ExpectExec(`
-	UPDATE user_to_board`).
-		WithArgs(uid, bid).
-		WillReturnResult(pgxmock.NewResult("1", 1))
-
-	mock.ExpectQuery(`SELECT (.*) FROM "user"`).
-		WithArgs(pgxmock.AnyArg()).
-		WillReturnRows(pgxmock.NewRows([]string{"u_id", "nickname", "email",
-			"description",
-			"joined_at",
-			"updated_at",
-			"file_uuid",
-			"file_extension"}).AddRow(2, "RVasily", "rvasily@yandex.ru", "Hello! I am user of Pumpkin", time.Now(), time.Now(), "", ""))
-
-	mock.ExpectQuery("WITH board_check").
-		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
-		WillReturnRows(pgxmock.NewRows([]string{"role", "added_at", "updated_at", "added_by", "updated_by"}).
-			AddRow("admin", time.Now(), time.Now(), 2, 3))
-
-	mock.ExpectQuery(`SELECT (.*) FROM "user"`).
-		WithArgs(pgxmock.AnyArg()).
-		WillReturnRows(pgxmock.NewRows([]string{"u_id", "nickname", "email",
-			"description",
-			"joined_at",
-			"updated_at",
-			"file_uuid",
-			"file_extension"}).AddRow(2, "RVasily", "rvasily@yandex.ru", "Hello! I am user of Pumpkin", time.Now(), time.Now(), "", ""))
-	mock.ExpectQuery(`SELECT (.*) FROM "user"`).
-		WithArgs(pgxmock.AnyArg()).
-		WillReturnRows(pgxmock.NewRows([]string{"u_id", "nickname", "email",
-			"description",
-			"joined_at",
-			"updated_at",
-			"file_uuid",
-			"file_extension"}).AddRow(2, "RVasily", "rvasily@yandex.ru", "Hello! I am user of Pumpkin", time.Now(), time.Now(), "", ""))
-
-	member, err := repo.SetMemberRole(ctx, bid, uid, "viewer")
-	assert.NoError(t, err)
-	assert.NotNil(t, member)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestRemoveMember_Success(t *testing.T) {
+func TestGetBoardsForUser(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
+	defer mock.Close()
 
-	ctx := context.Background()
 	repo := CreateBoardRepository(mock)
+	ctx := context.Background()
+	now := time.Now()
 
-	mock.ExpectExec(`DELETE FROM`).
-		WithArgs(123, 456).
-		WillReturnResult(pgxmock.NewResult("1", 1))
-
-	err = repo.RemoveMember(ctx, 123, 456)
-	assert.NoError(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
-}
+	mock.ExpectQuery("FROM user_to_board AS ub").
+		WithArgs(int64(1)).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"board_id", "name", "created_at", "updated_at", "file_uuid", "file_extension",
+		}).
+			AddRow(int64(1), "Board 1", now, now, "", "").
+			AddRow(int64(2), "Board 2", now, now, "uuid-1", ".png"))
 
-func TestAddMember_Success(t *testing.T) {
-	mock, err := pgxmock.NewPool()
+	boards, err := repo.GetBoardsForUser(ctx, 1)
 	require.NoError(t, err)
-	repo := CreateBoardRepository(mock)
-
-	mock.ExpectQuery(`SELECT (.*) FROM "user"`).
-		WithArgs(pgxmock.AnyArg()).
-		WillReturnRows(pgxmock.NewRows([]string{"u_id", "nickname", "email",
-			"description",
-			"joined_at",
-			"updated_at",
-			"file_uuid",
-			"file_extension"}).AddRow(2, "RVasily", "rvasily@yandex.ru", "Hello! I am user of Pumpkin", time.Now(), time.Now(), "", ""))
-	mock.ExpectQuery("WITH board_check").
-		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
-		WillReturnError(pgx.ErrNoRows)
-
-	mock.ExpectExec(`INSERT INTO user_to_board`).
-		WithArgs(123, 456, 789).WillReturnResult(pgxmock.NewResult("1", 1))
-
-	mock.ExpectQuery(`SELECT (.*) FROM "user"`).
-		WithArgs(pgxmock.AnyArg()).
-		WillReturnRows(pgxmock.NewRows([]string{"u_id", "nickname", "email",
-			"description",
-			"joined_at",
-			"updated_at",
-			"file_uuid",
-			"file_extension"}).AddRow(2, "RVasily", "rvasily@yandex.ru", "Hello! I am user of Pumpkin", time.Now(), time.Now(), "", ""))
-
-	mock.ExpectQuery("WITH board_check").
-		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
-		WillReturnRows(pgxmock.NewRows([]string{"role", "added_at", "updated_at", "added_by", "updated_by"}).
-			AddRow("admin", time.Now(), time.Now(), 2, 3))
-
-	mock.ExpectQuery(`SELECT (.*) FROM "user"`).
-		WithArgs(pgxmock.AnyArg()).
-		WillReturnRows(pgxmock.NewRows([]string{"u_id", "nickname", "email",
-			"description",
-			"joined_at",
-			"updated_at",
-			"file_uuid",
-			"file_extension"}).AddRow(2, "RVasily", "rvasily@yandex.ru", "Hello! I am user of Pumpkin", time.Now(), time.Now(), "", ""))
-
-	mock.ExpectQuery(`SELECT (.*) FROM "user"`).
-		WithArgs(pgxmock.AnyArg()).
-		WillReturnRows(pgxmock.NewRows([]string{"u_id", "nickname", "email",
-			"description",
-			"joined_at",
-			"updated_at",
-			"file_uuid",
-			"file_extension"}).AddRow(2, "RVasily", "rvasily@yandex.ru", "Hello! I am user of Pumpkin", time.Now(), time.Now(), "", ""))
-
-	member, err := repo.AddMember(context.Background(), 456, 789, 123)
-	assert.NoError(t, err)
-	assert.NotNil(t, member)
+	require.Len(t, boards, 2)
+	assert.Equal(t, uploads.DefaultBackgroundURL, boards[0].BackgroundImageURL)
+	assert.Equal(t, uploads.JoinImageURL("uuid-1", ".png", uploads.DefaultBackgroundURL), boards[1].BackgroundImageURL)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetUserByNickname_Success(t *testing.T) {
+func TestRemoveMember(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
+	defer mock.Close()
 
-	ctx := context.Background()
 	repo := CreateBoardRepository(mock)
-
-	rows := pgxmock.NewRows([]string{"u_id", "nickname", "email", "description", "joined_at", "updated_at"}).
-		AddRow(1, "testuser", "test@example.com", "description", time.Now(), time.Now())
-
-	mock.ExpectQuery(`SELECT u_id, nickname, email, description, joined_at, updated_at FROM "user" WHERE nickname=\$1`).
-		WithArgs("testuser").
-		WillReturnRows(rows)
-
-	user, err := repo.GetUserByNickname(ctx, "testuser")
-	assert.NoError(t, err)
-	assert.NotNil(t, user)
-	assert.NoError(t, mock.ExpectationsWereMet())
-}
-
-func TestUpdateBoard(t *testing.T) {
-	mock, err := pgxmock.NewConn()
-	assert.NoError(t, err)
-	defer mock.Close(context.Background())
-
-	boardRepo := CreateBoardRepository(mock)
-
 	ctx := context.Background()
-	boardID := 1
-	userID := 1
-	data := &models.BoardRequest{
-		NewName:        "Updated Name",
-		NewDescription: "Updated Description",
-	}
-
-	mock.ExpectExec(`UPDATE board SET name=\$1, description=\$2, updated_at = CURRENT_TIMESTAMP WHERE board_id = \$3;`).
-		WithArgs(data.NewName, data.NewDescription, boardID).
-		WillReturnError(errors.New("test error"))
-
-	_, err = boardRepo.UpdateBoard(ctx, boardID, userID, data)
-	assert.Error(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
-}
 
-func TestDeleteBoard(t *testing.T) {
-	mock, err := pgxmock.NewConn()
-	assert.NoError(t, err)
-	defer mock.Close(context.Background())
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM user_to_board").
+			WithArgs(int64(1), int64(2)).
+			WillReturnResult(pgconn.NewCommandTag("DELETE 1"))
 
-	boardRepo := CreateBoardRepository(mock)
+		assert.NoError(t, repo.RemoveMember(ctx, 1, 2))
+	})
 
-	ctx := context.Background()
-	boardID := 1
+	t.Run("not a member", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM user_to_board").
+			WithArgs(int64(1), int64(2)).
+			WillReturnResult(pgconn.NewCommandTag("DELETE 0"))
 
-	mock.ExpectExec(`DELETE FROM board WHERE board_id = \$1;`).
-		WithArgs(boardID).
-		WillReturnError(errors.New("test error"))
+		assert.True(t, errors.Is(repo.RemoveMember(ctx, 1, 2), errs.ErrNotFound))
+	})
 
-	err = boardRepo.DeleteBoard(ctx, boardID)
-	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetBoardsForUser(t *testing.T) {
-	mock, err := pgxmock.NewConn()
-	assert.NoError(t, err)
-	defer mock.Close(context.Background())
-
-	boardRepo := CreateBoardRepository(mock)
+func TestGetUserByNickname(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
 
+	repo := CreateBoardRepository(mock)
 	ctx := context.Background()
-	userID := 1
+	now := time.Now()
 
-	mock.ExpectQuery(`SELECT b.board_id, b.name, b.description, b.created_at, b.updated_at, COALESCE\(f.file_uuid::text, ''\), COALESCE\(f.file_extension, ''\) FROM user_to_board AS ub JOIN board AS b ON b.board_id = ub.board_id LEFT JOIN user_uploaded_file AS f ON f.file_uuid=b.background_image_uuid WHERE ub.u_id = \$1`).
-		WithArgs(userID).
-		WillReturnError(errors.New("test error"))
+	t.Run("found", func(t *testing.T) {
+		mock.ExpectQuery("FROM \"user\"").
+			WithArgs("alice").
+			WillReturnRows(pgxmock.NewRows([]string{"u_id", "nickname", "email", "joined_at", "updated_at"}).
+				AddRow(int64(3), "alice", "alice@example.com", now, now))
 
-	_, err = boardRepo.GetBoardsForUser(ctx, userID)
-	assert.Error(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
-}
-
-func TestSetBoardBackground(t *testing.T) {
-	mock, err := pgxmock.NewConn()
-	assert.NoError(t, err)
-	defer mock.Close(context.Background())
+		user, err := repo.GetUserByNickname(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), user.ID)
+	})
 
-	boardRepo := CreateBoardRepository(mock)
-
-	ctx := context.Background()
-	userID := 1
-	boardID := 1
-	fileExtension := "jpg"
-	fileSize := 1024
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery("FROM \"user\"").
+			WithArgs("bob").
+			WillReturnError(pgx.ErrNoRows)
 
-	mock.ExpectQuery(`INSERT INTO user_uploaded_file \(file_extension, created_at, created_by, "size"\) VALUES \(\$1, CURRENT_TIMESTAMP, \$2, \$3\) RETURNING file_uuid::text;`).
-		WithArgs(fileExtension, userID, fileSize).
-		WillReturnError(errors.New("test error"))
+		_, err := repo.GetUserByNickname(ctx, "bob")
+		assert.True(t, errors.Is(err, errs.ErrNotFound))
+	})
 
-	_, err = boardRepo.SetBoardBackground(ctx, userID, boardID, fileExtension, fileSize)
-	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateSubtaskCard создаёт в колонке карточку из строки чеклиста и связывает их.
+// Если строка уже превращена в карточку, возвращает errs.ErrAlreadyExists
+func (r *BoardRepository) CreateSubtaskCard(ctx context.Context, fieldID int64, columnID int64) (newCard *models.Card, err error) {
+	funcName := "CreateSubtaskCard"
+	query := `
+	WITH field AS (
+		SELECT checklist_field_id, title, is_done
+		FROM checklist_field
+		WHERE checklist_field_id=$1 AND subtask_card_id IS NULL
+		FOR UPDATE
+	), new_card AS (
+		INSERT INTO card (col_id, order_index, title, is_done)
+		SELECT $2, (SELECT COUNT(*) FROM "card" WHERE col_id=$2), f.title, f.is_done
+		FROM field AS f
+		RETURNING card_id, card_uuid, col_id, title, created_at, updated_at, is_done
+	), link_field AS (
+		UPDATE checklist_field AS cf
+		SET subtask_card_id=nc.card_id
+		FROM new_card AS nc
+		WHERE cf.checklist_field_id=$1
+	), update_board AS (
+		UPDATE board
+		SET updated_at=CURRENT_TIMESTAMP
+		WHERE board_id=(SELECT board_id FROM kanban_column WHERE col_id=$2)
+	)
+	SELECT card_id, card_uuid::text, col_id, title, created_at, updated_at, is_done FROM new_card;
+	`
+
+	newCard = &models.Card{}
+	err = r.db.QueryRow(ctx, query, fieldID, columnID).Scan(
+		&newCard.ID,
+		&newCard.UUID,
+		&newCard.ColumnID,
+		&newCard.Title,
+		&newCard.CreatedAt,
+		&newCard.UpdatedAt,
+		&newCard.IsDone,
+	)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+
+	return newCard, nil
+}
+
+// GetCardSubtasks получает дочерние карточки, созданные из чеклиста карточки
+func (r *BoardRepository) GetCardSubtasks(ctx context.Context, cardID int64) (subtasks []models.Subtask, err error) {
+	funcName := "GetCardSubtasks"
+	query := `
+	SELECT c.card_id, cf.checklist_field_id, c.title, c.col_id, c.is_done
+	FROM checklist_field AS cf
+	JOIN card AS c ON c.card_id = cf.subtask_card_id
+	WHERE cf.card_id = $1
+	ORDER BY cf.order_index;
+	`
+
+	rows, err := r.db.Query(ctx, query, cardID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	subtasks = make([]models.Subtask, 0)
+	for rows.Next() {
+		subtask := models.Subtask{}
+		if err := rows.Scan(
+			&subtask.CardID,
+			&subtask.CheckListFieldID,
+			&subtask.Title,
+			&subtask.ColumnID,
+			&subtask.IsDone,
+		); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		subtasks = append(subtasks, subtask)
+	}
+
+	return subtasks, nil
+}
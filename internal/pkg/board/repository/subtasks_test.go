@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSubtaskCardTwice(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreateBoardRepository(mock)
+	ctx := context.Background()
+	now := time.Now()
+
+	// Связь ставится только из новой карточки: при повторном превращении new_card пуст,
+	// и link_field не должен обнулить subtask_card_id у уже связанной строки
+	promoteQuery := `(?s)WHERE checklist_field_id=\$1 AND subtask_card_id IS NULL.*` +
+		`link_field AS \(\s*UPDATE checklist_field AS cf\s*SET subtask_card_id=nc.card_id\s*FROM new_card AS nc\s*WHERE cf.checklist_field_id=\$1`
+
+	mock.ExpectQuery(promoteQuery).WithArgs(int64(3), int64(5)).
+		WillReturnRows(pgxmock.NewRows([]string{"card_id", "card_uuid", "col_id", "title", "created_at", "updated_at", "is_done"}).
+			AddRow(int64(10), "uuid-10", int64(5), "Subtask", now, now, false))
+	mock.ExpectQuery(promoteQuery).WithArgs(int64(3), int64(5)).
+		WillReturnError(pgx.ErrNoRows)
+
+	card, err := repo.CreateSubtaskCard(ctx, 3, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), card.ID)
+
+	_, err = repo.CreateSubtaskCard(ctx, 3, 5)
+	assert.ErrorIs(t, err, errs.ErrAlreadyExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateCardCompletesParentField(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreateBoardRepository(mock)
+	now := time.Now()
+	isDone := true
+
+	// Строка чеклиста родителя отмечается выполненной только вместе с дочерней карточкой
+	mock.ExpectQuery(`complete_parent_field AS \(.*UPDATE checklist_field\s+SET is_done=TRUE\s+WHERE subtask_card_id=\$1 AND \$4::boolean IS TRUE`).
+		WithArgs(int64(10), pgxmock.AnyArg(), pgxmock.AnyArg(), &isDone).
+		WillReturnRows(pgxmock.NewRows([]string{"card_id", "col_id", "title", "created_at", "updated_at", "deadline",
+			"is_done", "has_checklist", "has_attachments", "has_assigned_users", "has_comments"}).
+			AddRow(int64(10), int64(5), "Subtask", now, now, (*time.Time)(nil), true, false, false, false, false))
+
+	card, err := repo.UpdateCard(context.Background(), 10, models.CardPatchRequest{IsDone: &isDone})
+	require.NoError(t, err)
+	assert.True(t, card.IsDone)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCardSubtasks(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreateBoardRepository(mock)
+
+	// Строки, чья карточка удалена (subtask_card_id обнулён), в подзадачи не попадают
+	mock.ExpectQuery(`JOIN card AS c ON c.card_id = cf.subtask_card_id\s+WHERE cf.card_id = \$1`).WithArgs(int64(3)).
+		WillReturnRows(pgxmock.NewRows([]string{"card_id", "checklist_field_id", "title", "col_id", "is_done"}).
+			AddRow(int64(10), int64(20), "Subtask", int64(5), true))
+
+	subtasks, err := repo.GetCardSubtasks(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Subtask{{CardID: 10, CheckListFieldID: 20, Title: "Subtask", ColumnID: 5, IsDone: true}}, subtasks)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
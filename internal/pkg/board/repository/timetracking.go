@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// pgUniqueViolation - код ошибки Postgres при нарушении уникальности
+const pgUniqueViolation = "23505"
+
+// scanTimeEntry читает запись учёта времени в порядке полей
+// id, card_id, u_id, nickname, started_at, ended_at, comment, duration
+func scanTimeEntry(row pgx.Row) (entry *models.TimeEntry, err error) {
+	entry = &models.TimeEntry{}
+	err = row.Scan(
+		&entry.ID,
+		&entry.CardID,
+		&entry.UserID,
+		&entry.Nickname,
+		&entry.StartedAt,
+		&entry.EndedAt,
+		&entry.Comment,
+		&entry.DurationSeconds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	entry.IsRunning = entry.EndedAt == nil
+	return entry, nil
+}
+
+// StartTimer запускает таймер пользователя на карточке.
+// Если у пользователя уже есть запущенный таймер, возвращает errs.ErrAlreadyExists
+func (r *BoardRepository) StartTimer(ctx context.Context, userID int64, cardID int64) (entry *models.TimeEntry, err error) {
+	funcName := "StartTimer"
+	query := `
+	WITH insert_entry AS (
+		INSERT INTO card_time_entry (card_id, u_id, started_at)
+		SELECT $2, $1, CURRENT_TIMESTAMP
+		WHERE NOT EXISTS (
+			SELECT 1 FROM card_time_entry WHERE u_id=$1 AND ended_at IS NULL
+		)
+		RETURNING time_entry_id, card_id, u_id, started_at, ended_at, comment
+	)
+	SELECT te.time_entry_id, te.card_id, te.u_id, u.nickname, te.started_at, te.ended_at, te.comment, 0::bigint
+	FROM insert_entry AS te
+	JOIN "user" AS u ON u.u_id=te.u_id;
+	`
+
+	entry, err = scanTimeEntry(r.db.QueryRow(ctx, query, userID, cardID))
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return entry, nil
+}
+
+// StopTimer останавливает запущенный пользователем таймер на карточке
+func (r *BoardRepository) StopTimer(ctx context.Context, userID int64, cardID int64) (entry *models.TimeEntry, err error) {
+	funcName := "StopTimer"
+	query := `
+	WITH stop_entry AS (
+		UPDATE card_time_entry
+		SET ended_at=GREATEST(CURRENT_TIMESTAMP, started_at + INTERVAL '1 second')
+		WHERE u_id=$1 AND card_id=$2 AND ended_at IS NULL
+		RETURNING time_entry_id, card_id, u_id, started_at, ended_at, comment
+	)
+	SELECT te.time_entry_id, te.card_id, te.u_id, u.nickname, te.started_at, te.ended_at, te.comment,
+		EXTRACT(EPOCH FROM te.ended_at - te.started_at)::bigint
+	FROM stop_entry AS te
+	JOIN "user" AS u ON u.u_id=te.u_id;
+	`
+
+	entry, err = scanTimeEntry(r.db.QueryRow(ctx, query, userID, cardID))
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return entry, nil
+}
+
+// GetRunningTimer получает запущенный таймер пользователя
+func (r *BoardRepository) GetRunningTimer(ctx context.Context, userID int64) (entry *models.TimeEntry, err error) {
+	funcName := "GetRunningTimer"
+	query := `
+	SELECT te.time_entry_id, te.card_id, te.u_id, u.nickname, te.started_at, te.ended_at, te.comment,
+		EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - te.started_at)::bigint
+	FROM card_time_entry AS te
+	JOIN "user" AS u ON u.u_id=te.u_id
+	WHERE te.u_id=$1 AND te.ended_at IS NULL;
+	`
+
+	entry, err = scanTimeEntry(r.db.QueryRow(ctx, query, userID))
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return entry, nil
+}
+
+// CreateWorkLog добавляет запись о потраченном времени вручную
+func (r *BoardRepository) CreateWorkLog(ctx context.Context, userID int64, cardID int64, workLog *models.WorkLogRequest) (entry *models.TimeEntry, err error) {
+	funcName := "CreateWorkLog"
+	query := `
+	WITH insert_entry AS (
+		INSERT INTO card_time_entry (card_id, u_id, started_at, ended_at, comment)
+		VALUES ($2, $1, $3, $4, $5)
+		RETURNING time_entry_id, card_id, u_id, started_at, ended_at, comment
+	)
+	SELECT te.time_entry_id, te.card_id, te.u_id, u.nickname, te.started_at, te.ended_at, te.comment,
+		EXTRACT(EPOCH FROM te.ended_at - te.started_at)::bigint
+	FROM insert_entry AS te
+	JOIN "user" AS u ON u.u_id=te.u_id;
+	`
+
+	entry, err = scanTimeEntry(r.db.QueryRow(ctx, query, userID, cardID,
+		workLog.StartedAt, workLog.EndedAt, workLog.Comment))
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	return entry, nil
+}
+
+// DeleteTimeEntry удаляет запись учёта времени, созданную пользователем
+func (r *BoardRepository) DeleteTimeEntry(ctx context.Context, userID int64, entryID int64) (err error) {
+	funcName := "DeleteTimeEntry"
+	query := `
+	DELETE FROM card_time_entry
+	WHERE time_entry_id=$1 AND u_id=$2;
+	`
+
+	tag, err := r.db.Exec(ctx, query, entryID, userID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+	}
+	return nil
+}
+
+// GetCardTimeEntries получает все записи учёта времени на карточке.
+// У записей пользователей, удаливших аккаунт, ID 0 и имя deleted
+func (r *BoardRepository) GetCardTimeEntries(ctx context.Context, cardID int64) (entries []models.TimeEntry, err error) {
+	funcName := "GetCardTimeEntries"
+	query := `
+	SELECT te.time_entry_id, te.card_id, COALESCE(te.u_id, 0), COALESCE(u.nickname, 'deleted'),
+		te.started_at, te.ended_at, te.comment,
+		EXTRACT(EPOCH FROM COALESCE(te.ended_at, CURRENT_TIMESTAMP) - te.started_at)::bigint
+	FROM card_time_entry AS te
+	LEFT JOIN "user" AS u ON u.u_id=te.u_id
+	WHERE te.card_id=$1
+	ORDER BY te.started_at;
+	`
+
+	rows, err := r.db.Query(ctx, query, cardID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	entries = make([]models.TimeEntry, 0)
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		entries = append(entries, *entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return entries, nil
+}
+
+// GetMemberFromTimeEntry получает права пользователя из ID записи учёта времени
+func (r *BoardRepository) GetMemberFromTimeEntry(ctx context.Context, userID int64, entryID int64) (role string, boardID int64, cardID int64, err error) {
+	funcName := "GetMemberFromTimeEntry"
+	query := `
+	SELECT utb.role, b.board_id, c.card_id
+	FROM card_time_entry AS te
+	JOIN card AS c ON te.card_id = c.card_id
+	JOIN kanban_column AS kc ON kc.col_id = c.col_id
+	JOIN board AS b ON b.board_id = kc.board_id
+	JOIN user_to_board AS utb ON utb.board_id = b.board_id
+	WHERE utb.u_id = $1 AND te.time_entry_id = $2;
+	`
+
+	err = r.db.QueryRow(ctx, query, userID, entryID).Scan(
+		&role, &boardID, &cardID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", 0, 0, fmt.Errorf("%s (query): %w", funcName, errs.ErrNotFound)
+		}
+		return "", 0, 0, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+
+	return role, boardID, cardID, err
+}
+
+// GetBoardTimesheet суммирует время на доске по пользователям и карточкам.
+// Учитывается только часть записи, попавшая в период [from, to). Время удалённых пользователей
+// собирается в строки с ID 0 и именем deleted
+func (r *BoardRepository) GetBoardTimesheet(ctx context.Context, boardID int64, from time.Time, to time.Time) (timesheet []models.TimesheetRow, err error) {
+	funcName := "GetBoardTimesheet"
+	query := `
+	SELECT COALESCE(u.u_id, 0), COALESCE(u.nickname, 'deleted') AS nickname, c.card_id, c.title,
+		SUM(EXTRACT(EPOCH FROM
+			LEAST(COALESCE(te.ended_at, CURRENT_TIMESTAMP), $3::timestamptz) - GREATEST(te.started_at, $2::timestamptz)
+		))::bigint AS total_seconds
+	FROM card_time_entry AS te
+	JOIN card AS c ON c.card_id = te.card_id
+	JOIN kanban_column AS kc ON kc.col_id = c.col_id
+	LEFT JOIN "user" AS u ON u.u_id = te.u_id
+	WHERE kc.board_id = $1
+		AND te.started_at < $3::timestamptz
+		AND COALESCE(te.ended_at, CURRENT_TIMESTAMP) > $2::timestamptz
+	GROUP BY u.u_id, u.nickname, c.card_id, c.title
+	ORDER BY nickname, c.title;
+	`
+
+	rows, err := r.db.Query(ctx, query, boardID, from, to)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	timesheet = make([]models.TimesheetRow, 0)
+	for rows.Next() {
+		row := models.TimesheetRow{}
+		if err := rows.Scan(&row.UserID, &row.Nickname, &row.CardID, &row.CardTitle, &row.TotalSeconds); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		timesheet = append(timesheet, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return timesheet, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var timeEntryColumns = []string{
+	"time_entry_id", "card_id", "u_id", "nickname", "started_at", "ended_at", "comment", "duration",
+}
+
+func TestGetCardTimeEntriesOfDeletedUser(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreateBoardRepository(mock)
+
+	startedAt := time.Date(2024, 12, 2, 9, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(time.Hour)
+	// Запись удалённого пользователя остаётся на карточке: автор подставляется через LEFT JOIN
+	mock.ExpectQuery(`COALESCE\(te.u_id, 0\), COALESCE\(u.nickname, 'deleted'\).*LEFT JOIN "user" AS u`).WithArgs(int64(10)).
+		WillReturnRows(pgxmock.NewRows(timeEntryColumns).
+			AddRow(int64(1), int64(10), int64(0), "deleted", startedAt, &endedAt, "", int64(3600)))
+
+	entries, err := repo.GetCardTimeEntries(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(0), entries[0].UserID)
+	assert.Equal(t, "deleted", entries[0].Nickname)
+	assert.False(t, entries[0].IsRunning)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStartTimer(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreateBoardRepository(mock)
+	ctx := context.Background()
+	now := time.Now()
+
+	// Таймер не вставляется, если у пользователя уже есть запущенный
+	query := `INSERT INTO card_time_entry \(card_id, u_id, started_at\).*WHERE NOT EXISTS \(\s*SELECT 1 FROM card_time_entry WHERE u_id=\$1 AND ended_at IS NULL`
+
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(10)).
+		WillReturnRows(pgxmock.NewRows(timeEntryColumns).
+			AddRow(int64(5), int64(10), int64(1), "alice", now, (*time.Time)(nil), "", int64(0)))
+	entry, err := repo.StartTimer(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.True(t, entry.IsRunning)
+
+	// Второй таймер, в том числе на другой карточке, не запускается
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(11)).WillReturnError(pgx.ErrNoRows)
+	_, err = repo.StartTimer(ctx, 1, 11)
+	assert.ErrorIs(t, err, errs.ErrAlreadyExists)
+
+	// Одновременный запуск упирается в уникальный индекс запущенных таймеров
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(11)).WillReturnError(&pgconn.PgError{Code: pgUniqueViolation})
+	_, err = repo.StartTimer(ctx, 1, 11)
+	assert.ErrorIs(t, err, errs.ErrAlreadyExists)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStopTimer(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreateBoardRepository(mock)
+	ctx := context.Background()
+	startedAt := time.Now().Add(-time.Hour)
+	endedAt := startedAt.Add(time.Hour)
+
+	query := `SET ended_at=GREATEST\(CURRENT_TIMESTAMP, started_at \+ INTERVAL '1 second'\)\s+WHERE u_id=\$1 AND card_id=\$2 AND ended_at IS NULL`
+
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(10)).
+		WillReturnRows(pgxmock.NewRows(timeEntryColumns).
+			AddRow(int64(5), int64(10), int64(1), "alice", startedAt, &endedAt, "", int64(3600)))
+	entry, err := repo.StopTimer(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.False(t, entry.IsRunning)
+	assert.Equal(t, int64(3600), entry.DurationSeconds)
+
+	// Таймер на другой карточке этим запросом не останавливается
+	mock.ExpectQuery(query).WithArgs(int64(1), int64(11)).WillReturnError(pgx.ErrNoRows)
+	_, err = repo.StopTimer(ctx, 1, 11)
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBoardTimesheetClipsToPeriod(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreateBoardRepository(mock)
+
+	from := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	// Записи, пересекающие границы периода, учитываются только своей частью внутри [from, to)
+	mock.ExpectQuery(`LEAST\(COALESCE\(te.ended_at, CURRENT_TIMESTAMP\), \$3::timestamptz\) - GREATEST\(te.started_at, \$2::timestamptz\)`+
+		`.*te.started_at < \$3::timestamptz\s+AND COALESCE\(te.ended_at, CURRENT_TIMESTAMP\) > \$2::timestamptz`).
+		WithArgs(int64(2), from, to).
+		WillReturnRows(pgxmock.NewRows([]string{"u_id", "nickname", "card_id", "title", "total_seconds"}).
+			AddRow(int64(1), "alice", int64(10), "Landing", int64(5400)))
+
+	rows, err := repo.GetBoardTimesheet(context.Background(), 2, from, to)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(5400), rows[0].TotalSeconds)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// maxAutomationSteps ограничивает число срабатываний правил за одно изменение,
+// чтобы правила, вызывающие друг друга, не зациклились
+const maxAutomationSteps = 20
+
+// automationEvent - изменение на доске, на которое могут отреагировать правила
+type automationEvent struct {
+	Trigger string
+	BoardID int64
+	CardID  int64
+}
+
+// runAutomations выполняет правила доски, подходящие под событие, и все вызванные ими события.
+// Каждое правило срабатывает для карточки не больше одного раза за цепочку.
+// Ошибки правил не отменяют исходное изменение и только пишутся в лог
+func (uc *BoardUsecase) runAutomations(ctx context.Context, event automationEvent) {
+	funcName := "runAutomations"
+	rules, err := uc.boardRepository.GetBoardAutomationRules(ctx, event.BoardID)
+	if err != nil {
+		logging.Error(ctx, funcName, " (get rules): ", err)
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	type ruleOnCard struct{ ruleID, cardID int64 }
+	fired := make(map[ruleOnCard]bool)
+	queue := []automationEvent{event}
+	steps := 0
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		card, err := uc.boardRepository.GetCard(ctx, current.CardID)
+		if err != nil {
+			logging.Warn(ctx, funcName, " (get card): ", err)
+			continue
+		}
+
+		for _, rule := range rules {
+			if !rule.IsEnabled || !automationTriggerMatches(rule.Trigger, current.Trigger, card.ColumnID) {
+				continue
+			}
+			key := ruleOnCard{ruleID: rule.ID, cardID: current.CardID}
+			if fired[key] {
+				logging.Warn(ctx, funcName, ": rule ", rule.ID, " already fired for card ", current.CardID, ", skipping to avoid a loop")
+				continue
+			}
+			steps++
+			if steps > maxAutomationSteps {
+				logging.Warn(ctx, funcName, ": too many automation steps on board ", event.BoardID, ", stopping")
+				return
+			}
+			fired[key] = true
+
+			for _, action := range rule.Actions {
+				next, err := uc.applyAutomationAction(ctx, &rule, action, current)
+				if err != nil {
+					logging.Warn(ctx, funcName, " (rule ", rule.ID, ", action ", action.Type, "): ", err)
+					break
+				}
+				queue = append(queue, next...)
+			}
+		}
+	}
+}
+
+// automationTriggerMatches проверяет, подходит ли событие под триггер правила
+func automationTriggerMatches(trigger models.AutomationTrigger, eventTrigger string, cardColumnID int64) bool {
+	if trigger.Type != eventTrigger {
+		return false
+	}
+	return trigger.ColumnID == nil || *trigger.ColumnID == cardColumnID
+}
+
+// applyAutomationAction выполняет одно действие правила и возвращает вызванные им события
+func (uc *BoardUsecase) applyAutomationAction(ctx context.Context, rule *models.AutomationRule, action models.AutomationAction, event automationEvent) (next []automationEvent, err error) {
+	switch action.Type {
+	case models.ActionSetDone:
+		isDone := true
+		_, err = uc.boardRepository.UpdateCard(ctx, event.CardID, models.CardPatchRequest{IsDone: &isDone})
+	case models.ActionClearDeadline:
+		err = uc.boardRepository.ClearCardDeadline(ctx, event.CardID)
+	case models.ActionAssignUser:
+		_, err = uc.boardRepository.AssignUserToCard(ctx, event.CardID, *action.UserID)
+	case models.ActionMoveCard:
+		var fromColumnID int64
+		fromColumnID, err = uc.moveCard(ctx, event.CardID, *action.ColumnID, nil, nil)
+		if err == nil && fromColumnID != *action.ColumnID {
+			next = append(next, automationEvent{Trigger: models.TriggerCardMoved, BoardID: event.BoardID, CardID: event.CardID})
+		}
+	case models.ActionAddLabel:
+		err = uc.boardRepository.AddCardLabel(ctx, event.CardID, *action.Label)
+	case models.ActionPostComment:
+		if rule.CreatedBy == 0 {
+			return next, fmt.Errorf("rule %d has no author to post the comment", rule.ID)
+		}
+		_, err = uc.boardRepository.CreateComment(ctx, rule.CreatedBy, event.CardID, &models.CommentRequest{Text: *action.Text})
+	default:
+		err = fmt.Errorf("unknown action %q", action.Type)
+	}
+	return next, err
+}
+
+// checkListCompletedAutomations запускает правила checklist_completed, если все строки чеклиста выполнены
+func (uc *BoardUsecase) checkListCompletedAutomations(ctx context.Context, boardID int64, cardID int64, checkListID int64) {
+	fields, err := uc.boardRepository.GetCheckListFields(ctx, checkListID)
+	if err != nil {
+		logging.Warn(ctx, "checkListCompletedAutomations (get fields): ", err)
+		return
+	}
+	for _, field := range fields {
+		if !field.IsDone {
+			return
+		}
+	}
+	uc.runAutomations(ctx, automationEvent{Trigger: models.TriggerCheckListCompleted, BoardID: boardID, CardID: cardID})
+}
+
+// RunDeadlineAutomations запускает правила deadline_passed для карточек с прошедшим дедлайном.
+// Для каждого дедлайна карточки правила срабатывают один раз; при нескольких экземплярах сервиса
+// прогон выполняет только тот, кто взял блокировку
+func (uc *BoardUsecase) RunDeadlineAutomations(ctx context.Context) (err error) {
+	funcName := "RunDeadlineAutomations"
+	release, acquired, err := uc.boardRepository.TryLockDeadlineAutomations(ctx)
+	if err != nil {
+		return fmt.Errorf("%s (lock): %w", funcName, err)
+	}
+	if !acquired {
+		logging.Debug(ctx, funcName, " skipped: another instance is running deadline automations")
+		return nil
+	}
+	defer release()
+
+	cards, err := uc.boardRepository.GetOverdueAutomationCards(ctx)
+	if err != nil {
+		return fmt.Errorf("%s (get cards): %w", funcName, err)
+	}
+
+	for _, card := range cards {
+		uc.runAutomations(ctx, automationEvent{Trigger: models.TriggerDeadlinePassed, BoardID: card.BoardID, CardID: card.CardID})
+		if err := uc.boardRepository.MarkDeadlineAutomationFired(ctx, card.CardID, card.Deadline); err != nil {
+			return fmt.Errorf("%s (mark fired): %w", funcName, err)
+		}
+	}
+	return nil
+}
+
+// GetAutomationRules возвращает правила автоматизации доски
+func (uc *BoardUsecase) GetAutomationRules(ctx context.Context, userID int64, boardID int64) (rules []models.AutomationRule, err error) {
+	funcName := "GetAutomationRules"
+	_, err = uc.boardRepository.GetMemberPermissions(ctx, boardID, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s (permissions): %w", funcName, err)
+	}
+
+	rules, err = uc.boardRepository.GetBoardAutomationRules(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (get): %w", funcName, err)
+	}
+	return rules, nil
+}
+
+// CreateAutomationRule создаёт правило автоматизации (нужна роль не ниже editor_chief)
+func (uc *BoardUsecase) CreateAutomationRule(ctx context.Context, userID int64, boardID int64, data *models.AutomationRuleRequest) (newRule *models.AutomationRule, err error) {
+	funcName := "CreateAutomationRule"
+	perms, err := uc.boardRepository.GetMemberPermissions(ctx, boardID, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s (permissions): %w", funcName, err)
+	}
+	if roleLevels[perms.Role] < roleLevels["editor_chief"] {
+		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	if err = uc.validateAutomationRule(ctx, boardID, data); err != nil {
+		return nil, fmt.Errorf("%s (validate): %w", funcName, err)
+	}
+
+	newRule, err = uc.boardRepository.CreateAutomationRule(ctx, boardID, userID, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s (create): %w", funcName, err)
+	}
+	return newRule, nil
+}
+
+// UpdateAutomationRule заменяет правило автоматизации (нужна роль не ниже editor_chief)
+func (uc *BoardUsecase) UpdateAutomationRule(ctx context.Context, userID int64, ruleID int64, data *models.AutomationRuleRequest) (updatedRule *models.AutomationRule, err error) {
+	funcName := "UpdateAutomationRule"
+	role, boardID, err := uc.boardRepository.GetMemberFromAutomationRule(ctx, userID, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if roleLevels[role] < roleLevels["editor_chief"] {
+		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	if err = uc.validateAutomationRule(ctx, boardID, data); err != nil {
+		return nil, fmt.Errorf("%s (validate): %w", funcName, err)
+	}
+
+	updatedRule, err = uc.boardRepository.UpdateAutomationRule(ctx, ruleID, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s (update): %w", funcName, err)
+	}
+	return updatedRule, nil
+}
+
+// DeleteAutomationRule удаляет правило автоматизации (нужна роль не ниже editor_chief)
+func (uc *BoardUsecase) DeleteAutomationRule(ctx context.Context, userID int64, ruleID int64) (err error) {
+	funcName := "DeleteAutomationRule"
+	role, _, err := uc.boardRepository.GetMemberFromAutomationRule(ctx, userID, ruleID)
+	if err != nil {
+		return fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if roleLevels[role] < roleLevels["editor_chief"] {
+		return fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	err = uc.boardRepository.DeleteAutomationRule(ctx, ruleID)
+	if err != nil {
+		return fmt.Errorf("%s (delete): %w", funcName, err)
+	}
+	return nil
+}
+
+// validateAutomationRule проверяет, что у действий есть нужные параметры,
+// а колонки и пользователи из правила относятся к доске
+func (uc *BoardUsecase) validateAutomationRule(ctx context.Context, boardID int64, data *models.AutomationRuleRequest) error {
+	columns, err := uc.boardRepository.GetColumnsForBoard(ctx, boardID)
+	if err != nil {
+		return fmt.Errorf("validateAutomationRule (columns): %w", err)
+	}
+	isBoardColumn := func(columnID int64) bool {
+		for _, column := range columns {
+			if int64(column.ID) == columnID {
+				return true
+			}
+		}
+		return false
+	}
+
+	if data.Trigger.ColumnID != nil && !isBoardColumn(*data.Trigger.ColumnID) {
+		return fmt.Errorf("trigger column %d is not on the board: %w", *data.Trigger.ColumnID, errs.ErrBadRequest)
+	}
+
+	for _, action := range data.Actions {
+		switch action.Type {
+		case models.ActionMoveCard:
+			if action.ColumnID == nil || !isBoardColumn(*action.ColumnID) {
+				return fmt.Errorf("move_card needs a column on the board: %w", errs.ErrBadRequest)
+			}
+		case models.ActionAssignUser:
+			if action.UserID == nil {
+				return fmt.Errorf("assign_user needs userId: %w", errs.ErrBadRequest)
+			}
+			_, err := uc.boardRepository.GetMemberPermissions(ctx, boardID, *action.UserID, false)
+			if errors.Is(err, errs.ErrNotFound) || errors.Is(err, errs.ErrNotPermitted) {
+				return fmt.Errorf("user %d is not a board member: %w", *action.UserID, errs.ErrBadRequest)
+			}
+			if err != nil {
+				return fmt.Errorf("validateAutomationRule (member): %w", err)
+			}
+		case models.ActionAddLabel:
+			if action.Label == nil {
+				return fmt.Errorf("add_label needs label: %w", errs.ErrBadRequest)
+			}
+		case models.ActionPostComment:
+			if action.Text == nil {
+				return fmt.Errorf("post_comment needs text: %w", errs.ErrBadRequest)
+			}
+		}
+	}
+	return nil
+}
@@ -4,10 +4,17 @@ import (
 	"RPO_back/internal/errs"
 	"RPO_back/internal/models"
 	"RPO_back/internal/pkg/board"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/storage"
 	"RPO_back/internal/pkg/utils/uploads"
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 var roleLevels = map[string]int{
@@ -17,13 +24,18 @@ var roleLevels = map[string]int{
 	"admin":        3,
 }
 
+// defaultCheckListTitle - название чеклиста, создаваемого автоматически
+const defaultCheckListTitle = "Чеклист"
+
 type BoardUsecase struct {
 	boardRepository board.BoardRepo
+	fileStorage     storage.FileStorage
 }
 
-func CreateBoardUsecase(boardRepository board.BoardRepo) *BoardUsecase {
+func CreateBoardUsecase(boardRepository board.BoardRepo, fileStorage storage.FileStorage) *BoardUsecase {
 	return &BoardUsecase{
 		boardRepository: boardRepository,
+		fileStorage:     fileStorage,
 	}
 }
 
@@ -192,14 +204,70 @@ func (uc *BoardUsecase) GetBoardContent(ctx context.Context, userID int64, board
 		return nil, fmt.Errorf("GetBoardContent (add GetBoard): %w", err)
 	}
 
+	customFields, err := uc.boardRepository.GetBoardCustomFields(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("GetBoardContent (add GetBoardCustomFields): %w", err)
+	}
+
+	customFieldValues, err := uc.boardRepository.GetBoardCustomFieldValues(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("GetBoardContent (add GetBoardCustomFieldValues): %w", err)
+	}
+
 	return &models.BoardContent{
-		Cards:     cards,
-		Columns:   cols,
-		BoardInfo: info,
-		MyRole:    userPermissions.Role,
+		Cards:             cards,
+		Columns:           cols,
+		CustomFields:      customFields,
+		CustomFieldValues: customFieldValues,
+		BoardInfo:         info,
+		MyRole:            userPermissions.Role,
 	}, nil
 }
 
+// ExportBoard собирает выгрузку доски: колонки, пользовательские поля и карточки с их значениями
+func (uc *BoardUsecase) ExportBoard(ctx context.Context, userID int64, boardID int64) (export *models.BoardExport, err error) {
+	funcName := "ExportBoard"
+	content, err := uc.GetBoardContent(ctx, userID, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	columnTitles := make(map[int64]string, len(content.Columns))
+	for _, col := range content.Columns {
+		columnTitles[int64(col.ID)] = col.Title
+	}
+	cardValues := make(map[int64][]models.CustomFieldValue)
+	for _, value := range content.CustomFieldValues {
+		cardValues[value.CardID] = append(cardValues[value.CardID], value)
+	}
+
+	export = &models.BoardExport{
+		Board:        content.BoardInfo,
+		ExportedAt:   time.Now(),
+		Columns:      content.Columns,
+		CustomFields: content.CustomFields,
+		Cards:        make([]models.CardExport, 0, len(content.Cards)),
+	}
+	for _, card := range content.Cards {
+		values := cardValues[card.ID]
+		if values == nil {
+			values = []models.CustomFieldValue{}
+		}
+		export.Cards = append(export.Cards, models.CardExport{
+			ID:                card.ID,
+			Title:             card.Title,
+			ColumnID:          card.ColumnID,
+			ColumnTitle:       columnTitles[card.ColumnID],
+			Deadline:          card.Deadine,
+			IsDone:            card.IsDone,
+			CreatedAt:         card.CreatedAt,
+			UpdatedAt:         card.UpdatedAt,
+			CustomFieldValues: values,
+		})
+	}
+	return export, nil
+}
+
 // CreateNewCard создаёт новую карточку и возвращает её
 func (uc *BoardUsecase) CreateNewCard(ctx context.Context, userID int64, boardID int64, data *models.CardPostRequest) (newCard *models.Card, err error) {
 	perms, err := uc.boardRepository.GetMemberPermissions(ctx, boardID, userID, false)
@@ -217,11 +285,13 @@ func (uc *BoardUsecase) CreateNewCard(ctx context.Context, userID int64, boardID
 		return nil, fmt.Errorf("CreateNewCard (check): %w", errs.ErrNotPermitted)
 	}
 
-	card, err := uc.boardRepository.CreateNewCard(ctx, *data.ColumnID, *data.Title)
+	card, err := uc.boardRepository.CreateNewCard(ctx, userID, *data.ColumnID, *data.Title)
 	if err != nil {
 		return nil, fmt.Errorf("CreateNewCard (create): %w", err)
 	}
 
+	uc.runAutomations(ctx, automationEvent{Trigger: models.TriggerCardCreated, BoardID: boardID, CardID: card.ID})
+
 	return &models.Card{
 		ID:        card.ID,
 		Title:     card.Title,
@@ -348,31 +418,50 @@ func (uc *BoardUsecase) SetBoardBackground(ctx context.Context, userID int64, bo
 		return nil, fmt.Errorf("UpdateColumn (check): %w", errs.ErrNotPermitted)
 	}
 
-	fileNames, fileIDs, err := uc.boardRepository.DeduplicateFile(ctx, file)
+	err = uc.storeUploadedFile(ctx, file, true)
 	if err != nil {
-		return nil, fmt.Errorf("%s (deduplicate): %w", funcName, err)
+		return nil, fmt.Errorf("%s (store file): %w", funcName, err)
 	}
 
-	fileID, err := uploads.CompareFiles(fileNames, fileIDs, file)
+	newBoard, err := uc.boardRepository.SetBoardBackground(ctx, userID, boardID, file)
 	if err != nil {
-		return nil, fmt.Errorf("%s (compare): %w", funcName, err)
+		return nil, fmt.Errorf("%s (): %w", funcName, err)
 	}
 
-	if fileID == nil {
-		err = uc.boardRepository.RegisterFile(ctx, file)
+	return newBoard, nil
+}
+
+// storeUploadedFile потоком кладёт файл в хранилище и заполняет file.FileID.
+// Если файл с таким же хешем уже загружали, новая копия удаляется и используется существующая запись.
+// withVariants - для картинок сразу построить уменьшенные копии
+func (uc *BoardUsecase) storeUploadedFile(ctx context.Context, file *models.UploadedFile, withVariants bool) error {
+	funcName := "storeUploadedFile"
+	if withVariants {
+		// Оригинал отдаётся публично, поэтому EXIF (геолокация, модель камеры) вырезается до сохранения
+		err := uploads.StripImageMetadata(file)
 		if err != nil {
-			return nil, fmt.Errorf("%s (save file): %w", funcName, err)
+			return fmt.Errorf("%s (strip metadata): %w", funcName, err)
 		}
-		fileID = file.FileID
 	}
-	file.FileID = fileID
-
-	newBoard, err := uc.boardRepository.SetBoardBackground(ctx, userID, boardID, file)
+	err := uploads.StoreFile(ctx, uc.fileStorage, file)
 	if err != nil {
-		return nil, fmt.Errorf("%s (): %w", funcName, err)
+		return fmt.Errorf("%s (store): %w", funcName, err)
 	}
+	storedName := uploads.JoinFilePath(*file.UUID, file.FileExtension)
 
-	return newBoard, nil
+	isNew, err := uc.boardRepository.RegisterFile(ctx, file)
+	if err != nil || !isNew {
+		if deleteErr := uc.fileStorage.Delete(ctx, storedName); deleteErr != nil {
+			logging.Warn(ctx, funcName, " (delete duplicate): ", deleteErr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s (register): %w", funcName, err)
+	}
+	if isNew && withVariants {
+		uploads.GenerateImageVariants(ctx, uc.fileStorage, file)
+	}
+	return nil
 }
 
 // AssignUser назначает карточку пользователю
@@ -475,7 +564,8 @@ func (uc *BoardUsecase) DeleteComment(ctx context.Context, userID int64, comment
 	return nil
 }
 
-// AddCheckListField добавляет строку чеклиста в конец списка
+// AddCheckListField добавляет строку в конец указанного чеклиста.
+// Если чеклист не указан, строка попадает в первый чеклист карточки (он создаётся при необходимости)
 func (uc *BoardUsecase) AddCheckListField(ctx context.Context, userID int64, cardID int64, fieldReq *models.CheckListFieldPostRequest) (newField *models.CheckListField, err error) {
 	funcName := "AddCheckListField"
 	role, _, err := uc.boardRepository.GetMemberFromCard(ctx, userID, cardID)
@@ -486,17 +576,44 @@ func (uc *BoardUsecase) AddCheckListField(ctx context.Context, userID int64, car
 		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
 	}
 
-	field, err := uc.boardRepository.CreateCheckListField(ctx, cardID, fieldReq)
+	var checkListID int64
+	if fieldReq.CheckListID != nil {
+		_, _, listCardID, err := uc.boardRepository.GetMemberFromCheckList(ctx, userID, *fieldReq.CheckListID)
+		if err != nil {
+			return nil, fmt.Errorf("%s (checklist): %w", funcName, err)
+		}
+		if listCardID != cardID {
+			return nil, fmt.Errorf("%s (check checklist): %w", funcName, errs.ErrNotPermitted)
+		}
+		checkListID = *fieldReq.CheckListID
+	} else {
+		checkLists, err := uc.boardRepository.GetCardCheckLists(ctx, cardID)
+		if err != nil {
+			return nil, fmt.Errorf("%s (get checklists): %w", funcName, err)
+		}
+		if len(checkLists) > 0 {
+			checkListID = checkLists[0].ID
+		} else {
+			checkList, err := uc.boardRepository.CreateCheckList(ctx, cardID, defaultCheckListTitle)
+			if err != nil {
+				return nil, fmt.Errorf("%s (create checklist): %w", funcName, err)
+			}
+			checkListID = checkList.ID
+		}
+	}
+
+	field, err := uc.boardRepository.CreateCheckListField(ctx, checkListID, fieldReq)
 	if err != nil {
 		return nil, fmt.Errorf("%s (create): %w", funcName, err)
 	}
 	return field, nil
 }
 
-// UpdateCheckListField обновляет строку чеклиста и/или её положение
+// UpdateCheckListField обновляет строку чеклиста и/или её положение.
+// Строку можно переставить внутри чеклиста или перенести в другой чеклист той же карточки
 func (uc *BoardUsecase) UpdateCheckListField(ctx context.Context, userID int64, fieldID int64, fieldReq *models.CheckListFieldPatchRequest) (updatedField *models.CheckListField, err error) {
 	funcName := "UpdateCheckListField"
-	role, _, _, err := uc.boardRepository.GetMemberFromCheckListField(ctx, userID, fieldID)
+	role, boardID, cardID, err := uc.boardRepository.GetMemberFromCheckListField(ctx, userID, fieldID)
 	if err != nil {
 		return nil, fmt.Errorf("%s (member): %w", funcName, err)
 	}
@@ -508,9 +625,91 @@ func (uc *BoardUsecase) UpdateCheckListField(ctx context.Context, userID int64,
 	if err != nil {
 		return nil, fmt.Errorf("%s (update): %w", funcName, err)
 	}
+
+	if fieldReq.IsDone != nil && *fieldReq.IsDone {
+		uc.checkListCompletedAutomations(ctx, boardID, cardID, field.CheckListID)
+	}
+
+	if fieldReq.CheckListID == nil && fieldReq.PreviousFieldID == nil && fieldReq.NextFieldID == nil {
+		return field, nil
+	}
+
+	sourceListID := field.CheckListID
+	targetListID := sourceListID
+	if fieldReq.CheckListID != nil && *fieldReq.CheckListID != sourceListID {
+		_, _, listCardID, err := uc.boardRepository.GetMemberFromCheckList(ctx, userID, *fieldReq.CheckListID)
+		if err != nil {
+			return nil, fmt.Errorf("%s (target checklist): %w", funcName, err)
+		}
+		if listCardID != cardID {
+			return nil, fmt.Errorf("%s (check checklist): %w", funcName, errs.ErrNotPermitted)
+		}
+		targetListID = *fieldReq.CheckListID
+	}
+
+	targetFields, err := uc.boardRepository.GetCheckListFields(ctx, targetListID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (get target fields): %w", funcName, err)
+	}
+	targetFields, err = moveItem(targetFields, *field, func(f models.CheckListField) int64 { return f.ID },
+		fieldReq.PreviousFieldID, fieldReq.NextFieldID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (move): %w", funcName, err)
+	}
+
+	var sourceFields []models.CheckListField
+	if targetListID != sourceListID {
+		// Порядок строк в чеклисте, из которого строку забрали, уплотняется
+		sourceFields, err = uc.boardRepository.GetCheckListFields(ctx, sourceListID)
+		if err != nil {
+			return nil, fmt.Errorf("%s (get source fields): %w", funcName, err)
+		}
+		sourceFields = slices.DeleteFunc(sourceFields, func(f models.CheckListField) bool { return f.ID == field.ID })
+	}
+
+	err = uc.boardRepository.MoveCheckListField(ctx, targetListID, targetFields, sourceListID, sourceFields)
+	if err != nil {
+		return nil, fmt.Errorf("%s (move field): %w", funcName, err)
+	}
+
+	field.CheckListID = targetListID
 	return field, nil
 }
 
+// moveItem вставляет элемент после previousID или перед nextID. Если заданы оба, элемент встаёт после
+// previousID, а nextID используется, когда previousID в списке нет (например, соседа только что удалили).
+// Если не задано ни то ни другое, элемент уходит в конец списка
+func moveItem[T any](items []T, item T, getID func(T) int64, previousID *int64, nextID *int64) ([]T, error) {
+	itemID := getID(item)
+	result := make([]T, 0, len(items)+1)
+	for _, it := range items {
+		if getID(it) != itemID {
+			result = append(result, it)
+		}
+	}
+
+	insertAt := len(result)
+	if previousID != nil || nextID != nil {
+		insertAt = -1
+		if previousID != nil {
+			if idx := slices.IndexFunc(result, func(it T) bool { return getID(it) == *previousID }); idx != -1 {
+				insertAt = idx + 1
+			}
+		}
+		if insertAt == -1 && nextID != nil {
+			if idx := slices.IndexFunc(result, func(it T) bool { return getID(it) == *nextID }); idx != -1 {
+				insertAt = idx
+			}
+		}
+		if insertAt == -1 {
+			return nil, errs.ErrNotFound
+		}
+	}
+
+	result = slices.Insert(result, insertAt, item)
+	return result, nil
+}
+
 // DeleteCheckListField удаляет строку из чеклиста
 func (uc *BoardUsecase) DeleteCheckListField(ctx context.Context, userID int64, fieldID int64) (err error) {
 	funcName := "DeleteCheckListField"
@@ -529,6 +728,88 @@ func (uc *BoardUsecase) DeleteCheckListField(ctx context.Context, userID int64,
 	return nil
 }
 
+// AddCheckList создаёт на карточке новый именованный чеклист
+func (uc *BoardUsecase) AddCheckList(ctx context.Context, userID int64, cardID int64, checkListReq *models.CheckListRequest) (newCheckList *models.CheckList, err error) {
+	funcName := "AddCheckList"
+	role, _, err := uc.boardRepository.GetMemberFromCard(ctx, userID, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	newCheckList, err = uc.boardRepository.CreateCheckList(ctx, cardID, checkListReq.Title)
+	if err != nil {
+		return nil, fmt.Errorf("%s (create): %w", funcName, err)
+	}
+	return newCheckList, nil
+}
+
+// UpdateCheckList переименовывает чеклист
+func (uc *BoardUsecase) UpdateCheckList(ctx context.Context, userID int64, checkListID int64, checkListReq *models.CheckListRequest) (updatedCheckList *models.CheckList, err error) {
+	funcName := "UpdateCheckList"
+	role, _, _, err := uc.boardRepository.GetMemberFromCheckList(ctx, userID, checkListID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	updatedCheckList, err = uc.boardRepository.UpdateCheckList(ctx, checkListID, checkListReq.Title)
+	if err != nil {
+		return nil, fmt.Errorf("%s (update): %w", funcName, err)
+	}
+	return updatedCheckList, nil
+}
+
+// DeleteCheckList удаляет чеклист со всеми его строками
+func (uc *BoardUsecase) DeleteCheckList(ctx context.Context, userID int64, checkListID int64) (err error) {
+	funcName := "DeleteCheckList"
+	role, _, _, err := uc.boardRepository.GetMemberFromCheckList(ctx, userID, checkListID)
+	if err != nil {
+		return fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	err = uc.boardRepository.DeleteCheckList(ctx, checkListID)
+	if err != nil {
+		return fmt.Errorf("%s (delete): %w", funcName, err)
+	}
+	return nil
+}
+
+// PromoteCheckListField превращает строку чеклиста в дочернюю карточку в указанной колонке
+func (uc *BoardUsecase) PromoteCheckListField(ctx context.Context, userID int64, fieldID int64, promoteReq *models.CheckListFieldPromoteRequest) (newCard *models.Card, err error) {
+	funcName := "PromoteCheckListField"
+	role, boardID, _, err := uc.boardRepository.GetMemberFromCheckListField(ctx, userID, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	_, columnBoardID, err := uc.boardRepository.GetMemberFromColumn(ctx, userID, *promoteReq.ColumnID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (column): %w", funcName, err)
+	}
+	if columnBoardID != boardID {
+		return nil, fmt.Errorf("%s (check column): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	newCard, err = uc.boardRepository.CreateSubtaskCard(ctx, fieldID, *promoteReq.ColumnID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (create): %w", funcName, err)
+	}
+
+	uc.runAutomations(ctx, automationEvent{Trigger: models.TriggerCardCreated, BoardID: boardID, CardID: newCard.ID})
+	return newCard, nil
+}
+
 // SetCardCover устанавливает обложку для карточки
 func (uc *BoardUsecase) SetCardCover(ctx context.Context, userID int64, cardID int64, file *models.UploadedFile) (updatedCard *models.Card, err error) {
 	funcName := "SetCardCover"
@@ -541,6 +822,11 @@ func (uc *BoardUsecase) SetCardCover(ctx context.Context, userID int64, cardID i
 		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
 	}
 
+	err = uc.storeUploadedFile(ctx, file, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s (store file): %w", funcName, err)
+	}
+
 	updatedCard, err = uc.boardRepository.SetCardCover(ctx, userID, cardID, file)
 	if err != nil {
 		return nil, fmt.Errorf("%s (update): %w", funcName, err)
@@ -581,6 +867,11 @@ func (uc *BoardUsecase) AddAttachment(ctx context.Context, userID int64, cardID
 		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
 	}
 
+	err = uc.storeUploadedFile(ctx, file, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s (store file): %w", funcName, err)
+	}
+
 	newAttachment, err = uc.boardRepository.AddAttachment(ctx, userID, cardID, file)
 	if err != nil {
 		return nil, fmt.Errorf("%s (update): %w", funcName, err)
@@ -611,7 +902,71 @@ func (uc *BoardUsecase) DeleteAttachment(ctx context.Context, userID int64, atta
 
 // MoveCard перемещает карточку на доске
 func (uc *BoardUsecase) MoveCard(ctx context.Context, userID int64, cardID int64, moveReq *models.CardMoveRequest) (err error) {
-	panic("not implemented")
+	funcName := "MoveCard"
+	role, boardID, err := uc.boardRepository.GetMemberFromCard(ctx, userID, cardID)
+	if err != nil {
+		return fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	_, columnBoardID, err := uc.boardRepository.GetMemberFromColumn(ctx, userID, *moveReq.NewColumnID)
+	if err != nil {
+		return fmt.Errorf("%s (column): %w", funcName, err)
+	}
+	if columnBoardID != boardID {
+		return fmt.Errorf("%s (check column): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	fromColumnID, err := uc.moveCard(ctx, cardID, *moveReq.NewColumnID, neighbourID(moveReq.PreviousCardID), neighbourID(moveReq.NextCardID))
+	if err != nil {
+		return fmt.Errorf("%s (move): %w", funcName, err)
+	}
+
+	if fromColumnID != *moveReq.NewColumnID {
+		uc.runAutomations(ctx, automationEvent{Trigger: models.TriggerCardMoved, BoardID: boardID, CardID: cardID})
+	}
+	return nil
+}
+
+// neighbourID переводит ID соседней карточки из запроса в аргумент moveItem:
+// неположительный ID означает, что соседа с этой стороны нет
+func neighbourID(id *int64) *int64 {
+	if id == nil || *id <= 0 {
+		return nil
+	}
+	return id
+}
+
+// moveCard переставляет карточку без проверки прав и возвращает колонку, в которой она была
+func (uc *BoardUsecase) moveCard(ctx context.Context, cardID int64, columnID int64, previousID *int64, nextID *int64) (fromColumnID int64, err error) {
+	funcName := "moveCard"
+	card, err := uc.boardRepository.GetCard(ctx, cardID)
+	if err != nil {
+		return 0, fmt.Errorf("%s (get card): %w", funcName, err)
+	}
+	fromColumnID = card.ColumnID
+
+	var sourceColumnID *int64
+	if fromColumnID != columnID {
+		sourceColumnID = &fromColumnID
+	}
+	targetCards, sourceCards, err := uc.boardRepository.GetCardsForMove(ctx, columnID, sourceColumnID)
+	if err != nil {
+		return 0, fmt.Errorf("%s (get cards): %w", funcName, err)
+	}
+	targetCards, err = moveItem(targetCards, *card, func(c models.Card) int64 { return c.ID }, previousID, nextID)
+	if err != nil {
+		return 0, fmt.Errorf("%s (insert): %w", funcName, err)
+	}
+	// Порядок карточек в колонке, из которой карточку забрали, уплотняется
+	sourceCards = slices.DeleteFunc(sourceCards, func(c models.Card) bool { return c.ID == cardID })
+
+	if err = uc.boardRepository.MoveCard(ctx, columnID, targetCards, fromColumnID, sourceCards); err != nil {
+		return 0, fmt.Errorf("%s (move card): %w", funcName, err)
+	}
+	return fromColumnID, nil
 }
 
 // MoveColumn перемещает колонку на доске
@@ -683,7 +1038,7 @@ func (uc *BoardUsecase) AcceptInvite(ctx context.Context, userID int64, inviteUU
 // GetCardDetails возвращает подробное содержание карточки
 func (d *BoardUsecase) GetCardDetails(ctx context.Context, userID int64, cardID int64) (details *models.CardDetails, err error) {
 	funcName := "GetCardDetails"
-	_, _, err = d.boardRepository.GetMemberFromCard(ctx, userID, cardID)
+	_, boardID, err := d.boardRepository.GetMemberFromCard(ctx, userID, cardID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", funcName, err)
 	}
@@ -698,9 +1053,9 @@ func (d *BoardUsecase) GetCardDetails(ctx context.Context, userID int64, cardID
 		return nil, fmt.Errorf("%s (attachments): %w", funcName, err)
 	}
 
-	checkList, err := d.boardRepository.GetCardCheckList(ctx, cardID)
+	checkLists, err := d.boardRepository.GetCardCheckLists(ctx, cardID)
 	if err != nil {
-		return nil, fmt.Errorf("%s (checklist): %w", funcName, err)
+		return nil, fmt.Errorf("%s (checklists): %w", funcName, err)
 	}
 
 	comments, err := d.boardRepository.GetCardComments(ctx, cardID)
@@ -708,6 +1063,31 @@ func (d *BoardUsecase) GetCardDetails(ctx context.Context, userID int64, cardID
 		return nil, fmt.Errorf("%s (comments): %w", funcName, err)
 	}
 
+	subtasks, err := d.boardRepository.GetCardSubtasks(ctx, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (subtasks): %w", funcName, err)
+	}
+
+	timeEntries, err := d.boardRepository.GetCardTimeEntries(ctx, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (time entries): %w", funcName, err)
+	}
+
+	customFields, err := d.boardRepository.GetBoardCustomFields(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (custom fields): %w", funcName, err)
+	}
+
+	customFieldValues, err := d.boardRepository.GetCardCustomFieldValues(ctx, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (custom field values): %w", funcName, err)
+	}
+
+	labels, err := d.boardRepository.GetCardLabels(ctx, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (labels): %w", funcName, err)
+	}
+
 	//TODO убрать это позорище
 	card, err := d.boardRepository.UpdateCard(ctx, cardID, models.CardPatchRequest{})
 	if err != nil {
@@ -715,10 +1095,293 @@ func (d *BoardUsecase) GetCardDetails(ctx context.Context, userID int64, cardID
 	}
 
 	return &models.CardDetails{
-		Attachments:   attachments,
-		CheckList:     checkList,
-		Comments:      comments,
-		AssignedUsers: assignedUsers,
-		Card:          card,
+		Attachments:       attachments,
+		CheckList:         flattenCheckLists(checkLists),
+		CheckLists:        checkLists,
+		Comments:          comments,
+		AssignedUsers:     assignedUsers,
+		Subtasks:          subtasks,
+		TimeEntries:       timeEntries,
+		CustomFields:      customFields,
+		CustomFieldValues: customFieldValues,
+		Labels:            labels,
+		Card:              card,
 	}, nil
 }
+
+// flattenCheckLists собирает строки всех чеклистов карточки в один список в порядке отображения
+func flattenCheckLists(checkLists []models.CheckList) []models.CheckListField {
+	fields := make([]models.CheckListField, 0)
+	for _, checkList := range checkLists {
+		fields = append(fields, checkList.Fields...)
+	}
+	return fields
+}
+
+// StartTimer запускает таймер пользователя на карточке (у пользователя может быть только один таймер)
+func (uc *BoardUsecase) StartTimer(ctx context.Context, userID int64, cardID int64) (entry *models.TimeEntry, err error) {
+	funcName := "StartTimer"
+	role, _, err := uc.boardRepository.GetMemberFromCard(ctx, userID, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	entry, err = uc.boardRepository.StartTimer(ctx, userID, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (start): %w", funcName, err)
+	}
+	return entry, nil
+}
+
+// StopTimer останавливает таймер пользователя на карточке
+func (uc *BoardUsecase) StopTimer(ctx context.Context, userID int64, cardID int64) (entry *models.TimeEntry, err error) {
+	funcName := "StopTimer"
+	_, _, err = uc.boardRepository.GetMemberFromCard(ctx, userID, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (member): %w", funcName, err)
+	}
+
+	entry, err = uc.boardRepository.StopTimer(ctx, userID, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (stop): %w", funcName, err)
+	}
+	return entry, nil
+}
+
+// GetRunningTimer возвращает запущенный таймер пользователя
+func (uc *BoardUsecase) GetRunningTimer(ctx context.Context, userID int64) (entry *models.TimeEntry, err error) {
+	funcName := "GetRunningTimer"
+	entry, err = uc.boardRepository.GetRunningTimer(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", funcName, err)
+	}
+	return entry, nil
+}
+
+// AddWorkLog добавляет запись о потраченном на карточку времени вручную
+func (uc *BoardUsecase) AddWorkLog(ctx context.Context, userID int64, cardID int64, workLog *models.WorkLogRequest) (entry *models.TimeEntry, err error) {
+	funcName := "AddWorkLog"
+	role, _, err := uc.boardRepository.GetMemberFromCard(ctx, userID, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	entry, err = uc.boardRepository.CreateWorkLog(ctx, userID, cardID, workLog)
+	if err != nil {
+		return nil, fmt.Errorf("%s (create): %w", funcName, err)
+	}
+	return entry, nil
+}
+
+// DeleteTimeEntry удаляет запись учёта времени (удалить можно только свою запись)
+func (uc *BoardUsecase) DeleteTimeEntry(ctx context.Context, userID int64, entryID int64) (err error) {
+	funcName := "DeleteTimeEntry"
+	role, _, _, err := uc.boardRepository.GetMemberFromTimeEntry(ctx, userID, entryID)
+	if err != nil {
+		return fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	err = uc.boardRepository.DeleteTimeEntry(ctx, userID, entryID)
+	if err != nil {
+		return fmt.Errorf("%s (delete): %w", funcName, err)
+	}
+	return nil
+}
+
+// GetBoardTimesheet собирает отчёт о потраченном на доске времени за период [from, to)
+func (uc *BoardUsecase) GetBoardTimesheet(ctx context.Context, userID int64, boardID int64, from time.Time, to time.Time) (timesheet *models.Timesheet, err error) {
+	funcName := "GetBoardTimesheet"
+	_, err = uc.boardRepository.GetMemberPermissions(ctx, boardID, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s (permissions): %w", funcName, err)
+	}
+
+	rows, err := uc.boardRepository.GetBoardTimesheet(ctx, boardID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s (get): %w", funcName, err)
+	}
+
+	timesheet = &models.Timesheet{
+		BoardID: boardID,
+		From:    from,
+		To:      to,
+		Rows:    rows,
+	}
+	for _, row := range rows {
+		timesheet.TotalSeconds += row.TotalSeconds
+	}
+	return timesheet, nil
+}
+
+// GetBoardCustomFields возвращает пользовательские поля доски
+func (uc *BoardUsecase) GetBoardCustomFields(ctx context.Context, userID int64, boardID int64) (fields []models.CustomField, err error) {
+	funcName := "GetBoardCustomFields"
+	_, err = uc.boardRepository.GetMemberPermissions(ctx, boardID, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s (permissions): %w", funcName, err)
+	}
+
+	fields, err = uc.boardRepository.GetBoardCustomFields(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (get): %w", funcName, err)
+	}
+	return fields, nil
+}
+
+// CreateCustomField добавляет на доску пользовательское поле
+func (uc *BoardUsecase) CreateCustomField(ctx context.Context, userID int64, boardID int64, data *models.CustomFieldPostRequest) (newField *models.CustomField, err error) {
+	funcName := "CreateCustomField"
+	perms, err := uc.boardRepository.GetMemberPermissions(ctx, boardID, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s (permissions): %w", funcName, err)
+	}
+	if perms.Role == "viewer" {
+		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	if data.Type == models.CustomFieldDropdown && len(data.Options) == 0 {
+		return nil, fmt.Errorf("%s (check options): dropdown needs options: %w", funcName, errs.ErrBadRequest)
+	}
+	if data.Type != models.CustomFieldDropdown {
+		data.Options = nil
+	}
+
+	newField, err = uc.boardRepository.CreateCustomField(ctx, boardID, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s (create): %w", funcName, err)
+	}
+	return newField, nil
+}
+
+// UpdateCustomField переименовывает пользовательское поле и меняет варианты выбора
+func (uc *BoardUsecase) UpdateCustomField(ctx context.Context, userID int64, fieldID int64, data *models.CustomFieldPutRequest) (updatedField *models.CustomField, err error) {
+	funcName := "UpdateCustomField"
+	role, _, err := uc.boardRepository.GetMemberFromCustomField(ctx, userID, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	field, err := uc.boardRepository.GetCustomField(ctx, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (get): %w", funcName, err)
+	}
+	if field.Type == models.CustomFieldDropdown && len(data.Options) == 0 {
+		return nil, fmt.Errorf("%s (check options): dropdown needs options: %w", funcName, errs.ErrBadRequest)
+	}
+
+	updatedField, err = uc.boardRepository.UpdateCustomField(ctx, fieldID, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s (update): %w", funcName, err)
+	}
+	return updatedField, nil
+}
+
+// DeleteCustomField удаляет пользовательское поле вместе со значениями на карточках
+func (uc *BoardUsecase) DeleteCustomField(ctx context.Context, userID int64, fieldID int64) (err error) {
+	funcName := "DeleteCustomField"
+	role, _, err := uc.boardRepository.GetMemberFromCustomField(ctx, userID, fieldID)
+	if err != nil {
+		return fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	err = uc.boardRepository.DeleteCustomField(ctx, fieldID)
+	if err != nil {
+		return fmt.Errorf("%s (delete): %w", funcName, err)
+	}
+	return nil
+}
+
+// SetCardCustomFieldValue задаёт (или очищает) значение пользовательского поля на карточке.
+// Значение проверяется и нормализуется в соответствии с типом поля
+func (uc *BoardUsecase) SetCardCustomFieldValue(ctx context.Context, userID int64, cardID int64, fieldID int64, data *models.CustomFieldValueRequest) (fieldValue *models.CustomFieldValue, err error) {
+	funcName := "SetCardCustomFieldValue"
+	role, cardBoardID, err := uc.boardRepository.GetMemberFromCard(ctx, userID, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (member): %w", funcName, err)
+	}
+	if role == "viewer" {
+		return nil, fmt.Errorf("%s (check): %w", funcName, errs.ErrNotPermitted)
+	}
+
+	_, fieldBoardID, err := uc.boardRepository.GetMemberFromCustomField(ctx, userID, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (field member): %w", funcName, err)
+	}
+	if fieldBoardID != cardBoardID {
+		return nil, fmt.Errorf("%s (check field): %w", funcName, errs.ErrNotFound)
+	}
+
+	if data.Value == nil {
+		err = uc.boardRepository.ClearCardCustomFieldValue(ctx, cardID, fieldID)
+		if err != nil {
+			return nil, fmt.Errorf("%s (clear): %w", funcName, err)
+		}
+		return &models.CustomFieldValue{CardID: cardID, FieldID: fieldID}, nil
+	}
+
+	field, err := uc.boardRepository.GetCustomField(ctx, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("%s (get field): %w", funcName, err)
+	}
+	value, err := normalizeCustomFieldValue(field, *data.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%s (normalize): %w", funcName, err)
+	}
+
+	fieldValue, err = uc.boardRepository.SetCardCustomFieldValue(ctx, cardID, fieldID, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s (set): %w", funcName, err)
+	}
+	return fieldValue, nil
+}
+
+// normalizeCustomFieldValue проверяет значение по типу поля и приводит его к виду для хранения
+func normalizeCustomFieldValue(field *models.CustomField, raw string) (value string, err error) {
+	raw = strings.TrimSpace(raw)
+	switch field.Type {
+	case models.CustomFieldText:
+		return raw, nil
+	case models.CustomFieldNumber:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+			return "", fmt.Errorf("value %q is not a number: %w", raw, errs.ErrBadRequest)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case models.CustomFieldDate:
+		date, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			date, err = time.Parse(time.RFC3339, raw)
+		}
+		if err != nil {
+			return "", fmt.Errorf("value %q is not a date: %w", raw, errs.ErrBadRequest)
+		}
+		return date.Format(time.DateOnly), nil
+	case models.CustomFieldDropdown:
+		if !slices.Contains(field.Options, raw) {
+			return "", fmt.Errorf("value %q is not one of the options: %w", raw, errs.ErrBadRequest)
+		}
+		return raw, nil
+	case models.CustomFieldCheckbox:
+		checked, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "", fmt.Errorf("value %q is not a boolean: %w", raw, errs.ErrBadRequest)
+		}
+		return strconv.FormatBool(checked), nil
+	}
+	return "", fmt.Errorf("unknown field type %q: %w", field.Type, errs.ErrBadRequest)
+}
@@ -2,9 +2,12 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Эта структура нужна, чтобы все 3 сервиса могли
@@ -17,32 +20,138 @@ type Config struct {
 	PostgresDSN   string
 	MaxUploadSize int64
 	AuthURL       string
+	PollURL       string // Адрес GRPC сервиса опросов
 	UploadsDir    string
 	ServerPort    string // Порт для всех TCP Listen-ов, в том числе GRPC
+	PollGRPCPort  string // Сервис опросов слушает GRPC отдельно от HTTP
 	CorsOriging   string
 
-	Auth  *AuthConfig
-	User  *UserConfig
-	Board *BoardConfig
+	// Адреса обратных прокси (TRUSTED_PROXIES через запятую, IP или CIDR). Только от них
+	// принимаются заголовки X-Real-IP и X-Forwarded-For с настоящим адресом клиента
+	TrustedProxies []netip.Prefix
+
+	// Сессия живёт SessionIdleTimeout с последнего запроса, но не дольше SessionMaxLifetime с момента входа
+	SessionIdleTimeout time.Duration
+	SessionMaxLifetime time.Duration
+
+	Auth   *AuthConfig
+	User   *UserConfig
+	Board  *BoardConfig
+	Mail   *MailConfig
+	OIDC   *OIDCConfig // nil, если вход через OpenID Connect не настроен
+	Poll   *PollConfig
+	Files  *FileStorageConfig
+	Limits *UploadLimitsConfig
 }
 
 type AuthConfig struct {
 	PostgresPoolSize int
 	LogFile          string
+	PasswordResetURL string // Страница фронтенда, на которую ведёт ссылка из письма о сбросе пароля
 }
 type UserConfig struct {
-	PostgresPoolSize int
-	LogFile          string
+	PostgresPoolSize     int
+	LogFile              string
+	EmailVerificationURL string // Страница фронтенда, на которую ведёт ссылка из письма для подтверждения email
+	AllowUnverifiedLogin bool   // Пускать ли пользователей с неподтверждённым email (UNVERIFIED_LOGIN_POLICY=allow|deny)
 }
 type BoardConfig struct {
 	PostgresPoolSize int
 	LogFile          string
 }
 
+// Настройки отправки писем. Если SMTPAddr не задан,
+// письма не отправляются, а пишутся в файл SinkFile
+type MailConfig struct {
+	SMTPAddr     string
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	SinkFile     string
+}
+
+// Настройки входа через внешний OpenID Connect провайдер (OIDC_ISSUER и т.д.)
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // Адрес /auth/oidc/callback, зарегистрированный у провайдера
+	PostLoginURL string // Страница фронтенда, куда возвращается пользователь после входа
+	AllowSignup  bool   // Создавать ли пользователя, если аккаунта с таким email ещё нет
+}
+
+// Где хранятся загруженные файлы: в каталоге UploadsDir (FILE_STORAGE=local)
+// или в бакете S3-совместимого хранилища (FILE_STORAGE=s3). Бакет должен быть открыт
+// на чтение без авторизации: клиенты скачивают файлы по USER_UPLOADS_URL напрямую
+type FileStorageConfig struct {
+	Backend     string
+	S3Endpoint  string // Например, http://minio:9000
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+// Ограничения размера загружаемых изображений (в байтах). Вложения ограничены
+// MaxUploadSize, и ни один лимит не больше него
+type UploadLimitsConfig struct {
+	MaxAvatarSize     int64
+	MaxBackgroundSize int64
+	MaxCoverSize      int64
+}
+
+// Когда показывать пользователю CSAT-опрос. Опрос показывается не чаще раза в Cooldown
+// и не больше MaxPrompts раз, и только после срабатывания одного из триггеров:
+// аккаунту не меньше TriggerAccountAge или создано не меньше TriggerCardsCreated карточек.
+// Нулевой триггер выключен; если выключены оба, опрос показывается всем
+type PollConfig struct {
+	Cooldown            time.Duration
+	SnoozePeriod        time.Duration // "Напомнить позже"
+	DismissCooldown     time.Duration // "Не сейчас" - пауза дольше обычной
+	MaxPrompts          int           // 0 - без ограничения
+	TriggerAccountAge   time.Duration
+	TriggerCardsCreated int
+
+	// Обезличенная выгрузка ответов: u_id заменяется хешем с солью ExportSalt
+	// (если соль не задана, она своя у каждой выгрузки), а текстовые ответы короче
+	// ExportMinTextLength символов отбрасываются
+	ExportSalt          string
+	ExportMinTextLength int
+}
+
 var (
 	CurrentConfig *Config
 )
 
+const (
+	defaultSessionIdleTimeout = 7 * 24 * time.Hour
+	defaultSessionMaxLifetime = 30 * 24 * time.Hour
+
+	defaultPollCooldown            = 7 * 24 * time.Hour
+	defaultPollSnoozePeriod        = 24 * time.Hour
+	defaultPollDismissCooldown     = 30 * 24 * time.Hour
+	defaultPollMaxPrompts          = 6
+	defaultPollTriggerAccountDays  = 7
+	defaultPollTriggerCardsCreated = 10
+	defaultPollExportMinTextLength = 20
+
+	defaultPollURL      = "poll_service:8889"
+	defaultPollGRPCPort = "8889"
+
+	defaultS3Region = "us-east-1"
+
+	defaultMaxUploadSize     = 10 << 20
+	defaultMaxAvatarSize     = 2 << 20
+	defaultMaxBackgroundSize = 5 << 20
+	defaultMaxCoverSize      = 5 << 20
+)
+
+// Значения FILE_STORAGE
+const (
+	FileStorageLocal = "local"
+	FileStorageS3    = "s3"
+)
+
 // Проверить, есть ли данные переменные в env
 func checkEnv(envVars []string) error {
 
@@ -95,6 +204,57 @@ func stringToInt(s string) int {
 	return int(i)
 }
 
+// stringToNonNegativeInt разбирает целое число >= 0; пустое или неверное значение - defaultValue
+func stringToNonNegativeInt(s string, defaultValue int) int {
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return defaultValue
+	}
+	return i
+}
+
+// stringToSize разбирает размер в байтах > 0; пустое или неверное значение - defaultValue
+func stringToSize(s string, defaultValue int64) int64 {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || i <= 0 {
+		return defaultValue
+	}
+	return i
+}
+
+// stringToDuration разбирает длительность вида 168h; пустое или неверное значение - defaultValue
+func stringToDuration(s string, defaultValue time.Duration) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return defaultValue
+	}
+	return d
+}
+
+// parseTrustedProxies разбирает список IP-адресов и подсетей через запятую; адрес без маски - подсеть из одного адреса
+func parseTrustedProxies(s string) (proxies []netip.Prefix, err error) {
+	for _, raw := range strings.Split(s, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		if strings.Contains(raw, "/") {
+			prefix, err := netip.ParsePrefix(raw)
+			if err != nil {
+				return nil, fmt.Errorf("parseTrustedProxies: %w", err)
+			}
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parseTrustedProxies: %w", err)
+		}
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
 func LoadConfig() (err error) {
 	err = ValidateEnv()
 	if err != nil {
@@ -105,15 +265,27 @@ func LoadConfig() (err error) {
 	CurrentConfig.Auth = &AuthConfig{}
 	CurrentConfig.User = &UserConfig{}
 	CurrentConfig.Board = &BoardConfig{}
+	CurrentConfig.Mail = &MailConfig{}
+	CurrentConfig.Poll = &PollConfig{}
+	CurrentConfig.Files = &FileStorageConfig{}
+	CurrentConfig.Limits = &UploadLimitsConfig{}
 
 	logRoot := os.Getenv("LOG_ROOT")
 
 	CurrentConfig.PostgresDSN = os.Getenv("POSTGRES_URL")
 	CurrentConfig.RedisDSN = os.Getenv("REDIS_URL")
-	CurrentConfig.MaxUploadSize = int64(stringToInt(os.Getenv("MAX_UPLOAD_SIZE")))
+	CurrentConfig.MaxUploadSize = stringToSize(os.Getenv("MAX_UPLOAD_SIZE"), defaultMaxUploadSize)
 	CurrentConfig.AuthURL = os.Getenv("AUTH_GRPC_URL")
+	CurrentConfig.PollURL = os.Getenv("POLL_GRPC_URL")
+	if CurrentConfig.PollURL == "" {
+		CurrentConfig.PollURL = defaultPollURL
+	}
 	CurrentConfig.UploadsDir = os.Getenv("UPLOAD_DIR")
 	CurrentConfig.ServerPort = os.Getenv("SERVER_PORT")
+	CurrentConfig.PollGRPCPort = os.Getenv("POLL_GRPC_PORT")
+	if CurrentConfig.PollGRPCPort == "" {
+		CurrentConfig.PollGRPCPort = defaultPollGRPCPort
+	}
 	CurrentConfig.Auth.PostgresPoolSize = stringToInt(os.Getenv("AUTH_POSTGRES_MAX_CONNS"))
 	CurrentConfig.User.PostgresPoolSize = stringToInt(os.Getenv("USER_POSTGRES_MAX_CONNS"))
 	CurrentConfig.Board.PostgresPoolSize = stringToInt(os.Getenv("BOARD_POSTGRES_MAX_CONNS"))
@@ -121,6 +293,83 @@ func LoadConfig() (err error) {
 	CurrentConfig.User.LogFile = filepath.Join(logRoot, os.Getenv("USER_LOG_FILE"))
 	CurrentConfig.Board.LogFile = filepath.Join(logRoot, os.Getenv("BOARD_LOG_FILE"))
 	CurrentConfig.CorsOriging = os.Getenv("CORS_ORIGIN")
+	CurrentConfig.TrustedProxies, err = parseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		return fmt.Errorf("LoadConfig (TRUSTED_PROXIES): %w", err)
+	}
+	CurrentConfig.SessionIdleTimeout = stringToDuration(os.Getenv("SESSION_IDLE_TIMEOUT"), defaultSessionIdleTimeout)
+	CurrentConfig.SessionMaxLifetime = stringToDuration(os.Getenv("SESSION_MAX_LIFETIME"), defaultSessionMaxLifetime)
+	if CurrentConfig.SessionIdleTimeout > CurrentConfig.SessionMaxLifetime {
+		CurrentConfig.SessionIdleTimeout = CurrentConfig.SessionMaxLifetime
+	}
+	CurrentConfig.Auth.PasswordResetURL = os.Getenv("PASSWORD_RESET_URL")
+	CurrentConfig.User.EmailVerificationURL = os.Getenv("EMAIL_VERIFICATION_URL")
+	CurrentConfig.User.AllowUnverifiedLogin = os.Getenv("UNVERIFIED_LOGIN_POLICY") != "deny"
+
+	// Почта необязательна: без SMTP письма складываются в файл
+	CurrentConfig.Mail.SMTPAddr = os.Getenv("SMTP_ADDR")
+	CurrentConfig.Mail.SMTPUsername = os.Getenv("SMTP_USERNAME")
+	CurrentConfig.Mail.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	CurrentConfig.Mail.From = os.Getenv("MAIL_FROM")
+	if CurrentConfig.Mail.From == "" {
+		CurrentConfig.Mail.From = "noreply@pumpkin.local"
+	}
+	CurrentConfig.Mail.SinkFile = filepath.Join(logRoot, "mail.log")
+	if sinkFile := os.Getenv("MAIL_SINK_FILE"); sinkFile != "" {
+		CurrentConfig.Mail.SinkFile = filepath.Join(logRoot, sinkFile)
+	}
+
+	CurrentConfig.Poll.Cooldown = stringToDuration(os.Getenv("POLL_COOLDOWN"), defaultPollCooldown)
+	CurrentConfig.Poll.SnoozePeriod = stringToDuration(os.Getenv("POLL_SNOOZE_PERIOD"), defaultPollSnoozePeriod)
+	CurrentConfig.Poll.DismissCooldown = stringToDuration(os.Getenv("POLL_DISMISS_COOLDOWN"), defaultPollDismissCooldown)
+	CurrentConfig.Poll.MaxPrompts = stringToNonNegativeInt(os.Getenv("POLL_MAX_PROMPTS"), defaultPollMaxPrompts)
+	CurrentConfig.Poll.TriggerAccountAge = 24 * time.Hour *
+		time.Duration(stringToNonNegativeInt(os.Getenv("POLL_TRIGGER_ACCOUNT_AGE_DAYS"), defaultPollTriggerAccountDays))
+	CurrentConfig.Poll.TriggerCardsCreated = stringToNonNegativeInt(os.Getenv("POLL_TRIGGER_CARDS_CREATED"), defaultPollTriggerCardsCreated)
+	CurrentConfig.Poll.ExportSalt = os.Getenv("POLL_EXPORT_SALT")
+	CurrentConfig.Poll.ExportMinTextLength = stringToNonNegativeInt(os.Getenv("POLL_EXPORT_MIN_TEXT_LENGTH"), defaultPollExportMinTextLength)
+
+	CurrentConfig.Limits.MaxAvatarSize = min(stringToSize(os.Getenv("MAX_AVATAR_SIZE"), defaultMaxAvatarSize), CurrentConfig.MaxUploadSize)
+	CurrentConfig.Limits.MaxBackgroundSize = min(stringToSize(os.Getenv("MAX_BACKGROUND_SIZE"), defaultMaxBackgroundSize), CurrentConfig.MaxUploadSize)
+	CurrentConfig.Limits.MaxCoverSize = min(stringToSize(os.Getenv("MAX_COVER_SIZE"), defaultMaxCoverSize), CurrentConfig.MaxUploadSize)
+
+	CurrentConfig.Files.Backend = os.Getenv("FILE_STORAGE")
+	if CurrentConfig.Files.Backend == "" {
+		CurrentConfig.Files.Backend = FileStorageLocal
+	}
+	CurrentConfig.Files.S3Endpoint = os.Getenv("S3_ENDPOINT")
+	CurrentConfig.Files.S3Region = os.Getenv("S3_REGION")
+	if CurrentConfig.Files.S3Region == "" {
+		CurrentConfig.Files.S3Region = defaultS3Region
+	}
+	CurrentConfig.Files.S3Bucket = os.Getenv("S3_BUCKET")
+	CurrentConfig.Files.S3AccessKey = os.Getenv("S3_ACCESS_KEY")
+	CurrentConfig.Files.S3SecretKey = os.Getenv("S3_SECRET_KEY")
+	switch CurrentConfig.Files.Backend {
+	case FileStorageLocal:
+	case FileStorageS3:
+		err = checkEnv([]string{"S3_ENDPOINT", "S3_BUCKET", "S3_ACCESS_KEY", "S3_SECRET_KEY"})
+		if err != nil {
+			return fmt.Errorf("LoadConfig (FILE_STORAGE=s3): %w", err)
+		}
+	default:
+		return fmt.Errorf("LoadConfig: unknown FILE_STORAGE %q", CurrentConfig.Files.Backend)
+	}
+
+	// Вход через OIDC включается, только если задан издатель
+	if issuer := os.Getenv("OIDC_ISSUER"); issuer != "" {
+		CurrentConfig.OIDC = &OIDCConfig{
+			Issuer:       issuer,
+			ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+			ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+			PostLoginURL: os.Getenv("OIDC_POST_LOGIN_URL"),
+			AllowSignup:  os.Getenv("OIDC_AUTO_CREATE") == "true",
+		}
+		if CurrentConfig.OIDC.PostLoginURL == "" {
+			CurrentConfig.OIDC.PostLoginURL = "/"
+		}
+	}
 
 	return nil
 }
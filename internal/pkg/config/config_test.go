@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 // Тест для функции checkEnv
@@ -77,3 +78,95 @@ func TestValidateEnv(t *testing.T) {
 		t.Errorf("ValidateEnv() error = %v, expected invalid SERVER_PORT error", err)
 	}
 }
+
+// Тест для функции stringToDuration
+func TestStringToDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{name: "hours", value: "168h", expected: 168 * time.Hour},
+		{name: "minutes", value: "90m", expected: 90 * time.Minute},
+		{name: "empty", value: "", expected: time.Hour},
+		{name: "invalid", value: "7d", expected: time.Hour},
+		{name: "negative", value: "-1h", expected: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stringToDuration(tt.value, time.Hour); got != tt.expected {
+				t.Errorf("stringToDuration(%q) = %v, expected %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+// Тест для функции stringToNonNegativeInt
+func TestStringToNonNegativeInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected int
+	}{
+		{name: "number", value: "10", expected: 10},
+		{name: "zero", value: "0", expected: 0},
+		{name: "empty", value: "", expected: 5},
+		{name: "invalid", value: "ten", expected: 5},
+		{name: "negative", value: "-1", expected: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stringToNonNegativeInt(tt.value, 5); got != tt.expected {
+				t.Errorf("stringToNonNegativeInt(%q) = %d, expected %d", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+// Тест для функции stringToSize
+func TestStringToSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected int64
+	}{
+		{name: "number", value: "10485760", expected: 10485760},
+		{name: "zero", value: "0", expected: 512},
+		{name: "empty", value: "", expected: 512},
+		{name: "invalid", value: "10MB", expected: 512},
+		{name: "negative", value: "-1", expected: 512},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stringToSize(tt.value, 512); got != tt.expected {
+				t.Errorf("stringToSize(%q) = %d, expected %d", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := parseTrustedProxies(" 10.0.0.5, 172.18.3.0/16,,::1 ")
+	if err != nil {
+		t.Fatalf("parseTrustedProxies: unexpected error %v", err)
+	}
+	expected := []string{"10.0.0.5/32", "172.18.0.0/16", "::1/128"}
+	if len(proxies) != len(expected) {
+		t.Fatalf("parseTrustedProxies: got %v, expected %v", proxies, expected)
+	}
+	for i, prefix := range proxies {
+		if prefix.String() != expected[i] {
+			t.Errorf("parseTrustedProxies: got %s at %d, expected %s", prefix, i, expected[i])
+		}
+	}
+
+	if _, err := parseTrustedProxies("10.0.0.300"); err == nil {
+		t.Error("parseTrustedProxies: expected error for invalid address")
+	}
+	if proxies, _ := parseTrustedProxies(""); len(proxies) != 0 {
+		t.Errorf("parseTrustedProxies: expected no proxies, got %v", proxies)
+	}
+}
@@ -1,6 +1,7 @@
 package csrf
 
 import (
+	"RPO_back/internal/pkg/middleware/session"
 	"RPO_back/internal/pkg/utils/encrypt"
 	"RPO_back/internal/pkg/utils/responses"
 	"net/http"
@@ -48,6 +49,13 @@ func SetCSRFToken(w http.ResponseWriter) {
 
 func CSRFMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Запросы с персональным токеном не несут cookie, поэтому подделать их со стороннего сайта нельзя.
+		// Middleware сессии должен стоять раньше, иначе флаг ещё не выставлен
+		if session.IsTokenAuthenticated(r.Context()) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		if (r.Method == http.MethodPatch) || (r.Method == http.MethodPost) ||
 			(r.Method == http.MethodPut) || (r.Method == http.MethodDelete) {
 
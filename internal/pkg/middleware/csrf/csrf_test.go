@@ -1,6 +1,8 @@
 package csrf
 
 import (
+	"RPO_back/internal/pkg/middleware/session"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -74,3 +76,22 @@ func TestPOSTRequestWithValidHeaderAndCookie(t *testing.T) {
 		t.Errorf("expected status %v, got %v", http.StatusOK, rr.Code)
 	}
 }
+
+func TestPOSTRequestWithAccessTokenSkipsCSRF(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
+	req = req.WithContext(context.WithValue(req.Context(), session.TokenAuthContextKey, true))
+	rr := httptest.NewRecorder()
+
+	CSRFMiddleware(handler).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %v, got %v", http.StatusOK, rr.Code)
+	}
+	if len(rr.Result().Cookies()) != 0 {
+		t.Errorf("expected no csrf cookie for token-authenticated request")
+	}
+}
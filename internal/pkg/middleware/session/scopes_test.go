@@ -0,0 +1,36 @@
+package session
+
+import (
+	auth "RPO_back/internal/pkg/auth"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{method: "GET", path: "/boards/my", expected: auth.ScopeBoardsRead},
+		{method: "GET", path: "/cardDetails/card_1", expected: auth.ScopeBoardsRead},
+		{method: "GET", path: "/boards/board_1/export", expected: auth.ScopeBoardsRead},
+		{method: "GET", path: "/users/me", expected: auth.ScopeAdmin},
+		{method: "GET", path: "/users/me/export", expected: auth.ScopeAdmin},
+		{method: "GET", path: "/users/me/tokens", expected: auth.ScopeAdmin},
+		{method: "GET", path: "/poll/admin/export", expected: auth.ScopeAdmin},
+		{method: "POST", path: "/comments/card_1", expected: auth.ScopeCardsWrite},
+		{method: "PUT", path: "/cardOrder/card_1", expected: auth.ScopeCardsWrite},
+		{method: "DELETE", path: "/boards/board_1", expected: auth.ScopeAdmin},
+		{method: "PUT", path: "/users/me", expected: auth.ScopeAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			assert.Equal(t, tt.expected, RequiredScope(r))
+		})
+	}
+}
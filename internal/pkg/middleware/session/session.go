@@ -3,16 +3,34 @@ package session
 import (
 	auth "RPO_back/internal/pkg/auth"
 	AuthGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	"RPO_back/internal/pkg/utils/responses"
 	"context"
 	"net/http"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
 )
 
 type contextKey string
 
 const (
-	UserIDContextKey contextKey = "userID"
+	UserIDContextKey    contextKey = "userID"
+	TokenAuthContextKey contextKey = "tokenAuth" // Запрос авторизован персональным токеном, а не cookie сессии
 )
 
+// boardResources - первые сегменты путей досок и карточек, чтение которых разрешено с правом boards:read
+var boardResources = []string{
+	"boards", "userPermissions", "cards", "cardDetails", "columns", "customFields", "automations",
+	"timer", "sharedCard", "joinBoard", "images",
+}
+
+// cardResources - первые сегменты путей, изменения по которым разрешены с правом cards:write
+var cardResources = []string{
+	"cards", "assignedUser", "comments", "checkList", "checkLists", "subtask",
+	"cardCustomFields", "timer", "workLog", "cardCover", "attachments", "cardOrder",
+}
+
 type SessionMiddleware struct {
 	authGRPC AuthGRPC.AuthClient
 }
@@ -25,16 +43,23 @@ func CreateSessionMiddleware(authGRPC AuthGRPC.AuthClient) *SessionMiddleware {
 
 func (mw *SessionMiddleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+			mw.serveWithAccessToken(w, r, next, authHeader)
+			return
+		}
+
 		cookie, err := r.Cookie(auth.SessionCookieName)
 		if err != nil {
 			next.ServeHTTP(w, r)
 			return
 		}
 
+		// Отозванная или истёкшая сессия приходит без ошибки gRPC, но с кодом ошибки в ответе
 		responce, err := mw.authGRPC.CheckSession(r.Context(), &AuthGRPC.CheckSessionRequest{SessionID: cookie.Value})
-		if err != nil {
+		if err != nil || responce.GetError() != AuthGRPC.Error_NONE {
 			http.SetCookie(w, &http.Cookie{
 				Name:   auth.SessionCookieName,
+				Path:   "/",
 				MaxAge: -1,
 			})
 			next.ServeHTTP(w, r)
@@ -49,6 +74,65 @@ func (mw *SessionMiddleware) Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// serveWithAccessToken авторизует запрос персональным токеном из заголовка Authorization: Bearer.
+// Cookie сессии при этом не используется; неверный токен - 401, недостаточно прав - 403
+func (mw *SessionMiddleware) serveWithAccessToken(w http.ResponseWriter, r *http.Request, next http.Handler, authHeader string) {
+	token, ok := strings.CutPrefix(authHeader, "Bearer ")
+	if !ok || token == "" {
+		responses.DoBadResponse(w, http.StatusUnauthorized, "unsupported authorization scheme")
+		log.Warn(r.URL.Path, " token auth: unsupported authorization scheme")
+		return
+	}
+
+	responce, err := mw.authGRPC.CheckAccessToken(r.Context(), &AuthGRPC.AccessTokenRequest{Token: token})
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusInternalServerError, "internal error")
+		log.Error(r.URL.Path, " token auth: ", err)
+		return
+	}
+	if responce.GetError() != AuthGRPC.Error_NONE {
+		responses.DoBadResponse(w, http.StatusUnauthorized, "invalid token")
+		log.Warn(r.URL.Path, " token auth: invalid token")
+		return
+	}
+
+	requiredScope := RequiredScope(r)
+	if !slices.Contains(responce.GetScopes(), auth.ScopeAdmin) && !slices.Contains(responce.GetScopes(), requiredScope) {
+		responses.DoBadResponse(w, http.StatusForbidden, "token has no "+requiredScope+" scope")
+		log.Warn(r.URL.Path, " token auth: no scope ", requiredScope)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), UserIDContextKey, responce.GetUserID())
+	ctx = context.WithValue(ctx, TokenAuthContextKey, true)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RequiredScope возвращает право, нужное токену для запроса: чтение досок и карточек - boards:read,
+// изменение карточек и их содержимого - cards:write, всё остальное (в том числе чтение профиля,
+// выгрузки данных и токенов пользователя) - admin
+func RequiredScope(r *http.Request) string {
+	resource, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		if slices.Contains(boardResources, resource) {
+			return auth.ScopeBoardsRead
+		}
+		return auth.ScopeAdmin
+	}
+
+	if slices.Contains(cardResources, resource) {
+		return auth.ScopeCardsWrite
+	}
+	return auth.ScopeAdmin
+}
+
+// IsTokenAuthenticated проверяет, авторизован ли запрос персональным токеном
+func IsTokenAuthenticated(ctx context.Context) bool {
+	tokenAuth, _ := ctx.Value(TokenAuthContextKey).(bool)
+	return tokenAuth
+}
+
 // UserIDFromContext получает userID из контекста запроса
 func UserIDFromContext(ctx context.Context) (int64, bool) {
 	userID, ok := ctx.Value(UserIDContextKey).(int64)
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	auth "RPO_back/internal/pkg/auth"
+	AuthGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
 	mocks "RPO_back/internal/pkg/auth/mocks"
 
 	gomock "github.com/golang/mock/gomock"
@@ -17,14 +18,13 @@ func TestMiddleware_NoCookie(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
+	mockAuthClient := mocks.NewMockAuthClient(ctrl)
 
-	mw := CreateSessionMiddleware(mockAuthRepo)
+	mw := CreateSessionMiddleware(mockAuthClient)
 
 	handler := mw.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-
-		userIDFromContext, _ := UserIDFromContext(r.Context())
-		assert.Equal(t, 0, userIDFromContext)
+		_, ok := UserIDFromContext(r.Context())
+		assert.False(t, ok)
 	}))
 
 	req, _ := http.NewRequest("GET", "/", nil)
@@ -37,14 +37,15 @@ func TestMiddleware_InvalidCookie(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
-	mockAuthRepo.EXPECT().RetrieveUserIDFromSession(gomock.Any(), "invalid-session-id").Return(0, errors.New("Invalid session id"))
+	mockAuthClient := mocks.NewMockAuthClient(ctrl)
+	mockAuthClient.EXPECT().CheckSession(gomock.Any(), &AuthGRPC.CheckSessionRequest{SessionID: "invalid-session-id"}).
+		Return(nil, errors.New("Invalid session id"))
 
-	mw := CreateSessionMiddleware(mockAuthRepo)
+	mw := CreateSessionMiddleware(mockAuthClient)
 
 	handler := mw.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		userIDFromContext, _ := UserIDFromContext(r.Context())
-		assert.Equal(t, 0, userIDFromContext)
+		_, ok := UserIDFromContext(r.Context())
+		assert.False(t, ok)
 	}))
 
 	req, _ := http.NewRequest("GET", "/", nil)
@@ -58,11 +59,12 @@ func TestMiddleware_ValidCookie(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAuthRepo := mocks.NewMockAuthRepo(ctrl)
-	userID := 123
-	mockAuthRepo.EXPECT().RetrieveUserIDFromSession(gomock.Any(), "valid-session-id").Return(userID, nil)
+	mockAuthClient := mocks.NewMockAuthClient(ctrl)
+	userID := int64(123)
+	mockAuthClient.EXPECT().CheckSession(gomock.Any(), &AuthGRPC.CheckSessionRequest{SessionID: "valid-session-id"}).
+		Return(&AuthGRPC.UserDataResponse{UserID: userID}, nil)
 
-	mw := CreateSessionMiddleware(mockAuthRepo)
+	mw := CreateSessionMiddleware(mockAuthClient)
 
 	handler := mw.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		id, ok := UserIDFromContext(r.Context())
@@ -76,3 +78,35 @@ func TestMiddleware_ValidCookie(t *testing.T) {
 
 	handler.ServeHTTP(rec, req)
 }
+
+func TestMiddleware_RevokedSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthClient := mocks.NewMockAuthClient(ctrl)
+	mockAuthClient.EXPECT().CheckSession(gomock.Any(), &AuthGRPC.CheckSessionRequest{SessionID: "revoked-session-id"}).
+		Return(&AuthGRPC.UserDataResponse{Error: AuthGRPC.Error_INVALID_CREDENTIALS}, nil)
+
+	mw := CreateSessionMiddleware(mockAuthClient)
+
+	called := false
+	handler := mw.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		_, ok := UserIDFromContext(r.Context())
+		assert.False(t, ok)
+	}))
+
+	req, _ := http.NewRequest("GET", "/users/me", nil)
+	req.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: "revoked-session-id"})
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.True(t, called)
+	cookies := rec.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, auth.SessionCookieName, cookies[0].Name)
+		assert.Equal(t, "/", cookies[0].Path)
+		assert.True(t, cookies[0].MaxAge < 0)
+	}
+}
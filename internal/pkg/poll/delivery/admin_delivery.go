@@ -0,0 +1,138 @@
+package delivery
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"net/http"
+)
+
+// GetPollQuestions возвращает администратору все вопросы опроса
+func (d *PollDelivery) GetPollQuestions(w http.ResponseWriter, r *http.Request) {
+	funcName := "GetPollQuestions"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	questions, err := d.pollUC.GetPollQuestions(r.Context(), userID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, questions, http.StatusOK)
+}
+
+// CreatePollQuestion добавляет вопрос в опрос
+func (d *PollDelivery) CreatePollQuestion(w http.ResponseWriter, r *http.Request) {
+	funcName := "CreatePollQuestion"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	data := &models.PollQuestionPostRequest{}
+	err := requests.GetRequestData(r, data)
+	if err != nil {
+		logging.Warn(r.Context(), funcName, ": ", err)
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	newQuestion, err := d.pollUC.CreatePollQuestion(r.Context(), userID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, newQuestion, http.StatusCreated)
+}
+
+// UpdatePollQuestion изменяет вопрос опроса
+func (d *PollDelivery) UpdatePollQuestion(w http.ResponseWriter, r *http.Request) {
+	funcName := "UpdatePollQuestion"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	questionID, err := requests.GetIDFromRequest(r, "questionID", "question_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	data := &models.PollQuestionPutRequest{}
+	err = requests.GetRequestData(r, data)
+	if err != nil {
+		logging.Warn(r.Context(), funcName, ": ", err)
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	updatedQuestion, err := d.pollUC.UpdatePollQuestion(r.Context(), userID, questionID, data)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, updatedQuestion, http.StatusOK)
+}
+
+// ActivatePollQuestion включает показ вопроса пользователям
+func (d *PollDelivery) ActivatePollQuestion(w http.ResponseWriter, r *http.Request) {
+	d.setPollQuestionActive(w, r, "ActivatePollQuestion", true)
+}
+
+// DeactivatePollQuestion выключает показ вопроса, старые ответы на него сохраняются
+func (d *PollDelivery) DeactivatePollQuestion(w http.ResponseWriter, r *http.Request) {
+	d.setPollQuestionActive(w, r, "DeactivatePollQuestion", false)
+}
+
+func (d *PollDelivery) setPollQuestionActive(w http.ResponseWriter, r *http.Request, funcName string, isActive bool) {
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	questionID, err := requests.GetIDFromRequest(r, "questionID", "question_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	err = d.pollUC.SetPollQuestionActive(r.Context(), userID, questionID, isActive)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
+
+// ReorderPollQuestions задаёт порядок показа вопросов
+func (d *PollDelivery) ReorderPollQuestions(w http.ResponseWriter, r *http.Request) {
+	funcName := "ReorderPollQuestions"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	data := &models.PollQuestionOrderRequest{}
+	err := requests.GetRequestData(r, data)
+	if err != nil {
+		logging.Warn(r.Context(), funcName, ": ", err)
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	err = d.pollUC.ReorderPollQuestions(r.Context(), userID, data.QuestionIDs)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
@@ -0,0 +1,194 @@
+package delivery
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/csvexport"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Раз в сколько строк выгрузка сбрасывается клиенту
+const exportFlushRows = 100
+
+// ExportPollResults отдаёт администратору все ответы за период в CSV (по умолчанию) или JSON.
+// Query-параметры: format=csv|json, anonymized=true, from, to (как у GetPollResults)
+func (d *PollDelivery) ExportPollResults(w http.ResponseWriter, r *http.Request) {
+	funcName := "ExportPollResults"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+	anonymized := query.Get("anonymized") == "true"
+
+	filter, err := parseResultsFilter(r)
+	if err != nil {
+		logging.Warn(r.Context(), funcName, ": ", err)
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	writer := &pollExportWriter{w: w, format: format, anonymized: anonymized}
+	err = d.pollUC.ExportPollResults(r.Context(), userID, filter, anonymized, writer.writeRow)
+	if err != nil {
+		if !writer.started {
+			responses.ResponseErrorAndLog(w, err, funcName)
+			return
+		}
+		// Статус уже отправлен - остаётся только оборвать выгрузку
+		logging.Error(r.Context(), funcName, ": export interrupted: ", err)
+		return
+	}
+
+	if err := writer.finish(); err != nil {
+		logging.Warn(r.Context(), funcName, ": ", err)
+	}
+}
+
+// pollExportWriter пишет строки выгрузки в ответ по мере получения.
+// Заголовки отправляются с первой строкой, чтобы до неё можно было ответить ошибкой
+type pollExportWriter struct {
+	w          http.ResponseWriter
+	format     string
+	anonymized bool
+
+	started bool
+	rows    int
+	csv     *csvexport.Writer
+}
+
+func (e *pollExportWriter) start() error {
+	e.started = true
+
+	fileName := "poll_results." + e.format
+	if e.anonymized {
+		fileName = "poll_results_anonymized." + e.format
+	}
+	contentType := "text/csv; charset=utf-8"
+	if e.format == "json" {
+		contentType = "application/json"
+	}
+	e.w.Header().Set("Content-Type", contentType)
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+	e.w.WriteHeader(http.StatusOK)
+
+	if e.format == "json" {
+		_, err := e.w.Write([]byte("["))
+		return err
+	}
+
+	userColumn := "user_id"
+	if e.anonymized {
+		userColumn = "user_hash"
+	}
+	e.csv = csvexport.NewWriter(e.w)
+	return e.csv.Write([]string{"result_id", "question_id", "question", "question_type", userColumn,
+		"rating", "text", "created_at"})
+}
+
+func (e *pollExportWriter) writeRow(row *models.PollExportRow) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if e.format == "json" {
+		err = e.writeJSONRow(row)
+	} else {
+		err = e.writeCSVRow(row)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+func (e *pollExportWriter) writeJSONRow(row *models.PollExportRow) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	if e.rows > 0 {
+		if _, err := e.w.Write([]byte(",")); err != nil {
+			return err
+		}
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *pollExportWriter) writeCSVRow(row *models.PollExportRow) error {
+	user := row.UserHash
+	if row.UserID != nil {
+		user = strconv.FormatInt(*row.UserID, 10)
+	}
+	rating := ""
+	if row.Rating != nil {
+		rating = strconv.Itoa(*row.Rating)
+	}
+	text := ""
+	if row.Text != nil {
+		text = *row.Text
+	}
+
+	return e.csv.Write([]string{
+		strconv.FormatInt(row.ResultID, 10),
+		strconv.FormatInt(row.QuestionID, 10),
+		row.Question,
+		row.QuestionType,
+		user,
+		rating,
+		text,
+		row.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (e *pollExportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if flusher, ok := e.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// finish дописывает выгрузку; пустая выгрузка тоже получает заголовки
+func (e *pollExportWriter) finish() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	if e.format == "json" {
+		if _, err := e.w.Write([]byte("]")); err != nil {
+			return err
+		}
+	}
+	return e.flush()
+}
@@ -0,0 +1,1205 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: poll.proto
+
+package gen
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Error int32
+
+const (
+	Error_NONE                  Error = 0
+	Error_INTERNAL_SERVER_ERROR Error = 1
+	Error_BAD_REQUEST           Error = 2
+	Error_NOT_FOUND             Error = 3
+	Error_NOT_PERMITTED         Error = 4
+)
+
+// Enum value maps for Error.
+var (
+	Error_name = map[int32]string{
+		0: "NONE",
+		1: "INTERNAL_SERVER_ERROR",
+		2: "BAD_REQUEST",
+		3: "NOT_FOUND",
+		4: "NOT_PERMITTED",
+	}
+	Error_value = map[string]int32{
+		"NONE":                  0,
+		"INTERNAL_SERVER_ERROR": 1,
+		"BAD_REQUEST":           2,
+		"NOT_FOUND":             3,
+		"NOT_PERMITTED":         4,
+	}
+)
+
+func (x Error) Enum() *Error {
+	p := new(Error)
+	*p = x
+	return p
+}
+
+func (x Error) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Error) Descriptor() protoreflect.EnumDescriptor {
+	return file_poll_proto_enumTypes[0].Descriptor()
+}
+
+func (Error) Type() protoreflect.EnumType {
+	return &file_poll_proto_enumTypes[0]
+}
+
+func (x Error) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Error.Descriptor instead.
+func (Error) EnumDescriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{0}
+}
+
+type PickPollRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID int64 `protobuf:"varint,1,opt,name=userID,proto3" json:"userID,omitempty"`
+}
+
+func (x *PickPollRequest) Reset() {
+	*x = PickPollRequest{}
+	mi := &file_poll_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickPollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickPollRequest) ProtoMessage() {}
+
+func (x *PickPollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickPollRequest.ProtoReflect.Descriptor instead.
+func (*PickPollRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PickPollRequest) GetUserID() int64 {
+	if x != nil {
+		return x.UserID
+	}
+	return 0
+}
+
+type PollQuestion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuestionID   int64  `protobuf:"varint,1,opt,name=questionID,proto3" json:"questionID,omitempty"`
+	QuestionText string `protobuf:"bytes,2,opt,name=questionText,proto3" json:"questionText,omitempty"`
+	QuestionType string `protobuf:"bytes,3,opt,name=questionType,proto3" json:"questionType,omitempty"`
+	RatingMin    int32  `protobuf:"varint,4,opt,name=ratingMin,proto3" json:"ratingMin,omitempty"`
+	RatingMax    int32  `protobuf:"varint,5,opt,name=ratingMax,proto3" json:"ratingMax,omitempty"`
+}
+
+func (x *PollQuestion) Reset() {
+	*x = PollQuestion{}
+	mi := &file_poll_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollQuestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollQuestion) ProtoMessage() {}
+
+func (x *PollQuestion) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollQuestion.ProtoReflect.Descriptor instead.
+func (*PollQuestion) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PollQuestion) GetQuestionID() int64 {
+	if x != nil {
+		return x.QuestionID
+	}
+	return 0
+}
+
+func (x *PollQuestion) GetQuestionText() string {
+	if x != nil {
+		return x.QuestionText
+	}
+	return ""
+}
+
+func (x *PollQuestion) GetQuestionType() string {
+	if x != nil {
+		return x.QuestionType
+	}
+	return ""
+}
+
+func (x *PollQuestion) GetRatingMin() int32 {
+	if x != nil {
+		return x.RatingMin
+	}
+	return 0
+}
+
+func (x *PollQuestion) GetRatingMax() int32 {
+	if x != nil {
+		return x.RatingMax
+	}
+	return 0
+}
+
+type PollQuestions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Questions []*PollQuestion `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	Error     Error           `protobuf:"varint,2,opt,name=error,proto3,enum=poll.Error" json:"error,omitempty"`
+}
+
+func (x *PollQuestions) Reset() {
+	*x = PollQuestions{}
+	mi := &file_poll_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollQuestions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollQuestions) ProtoMessage() {}
+
+func (x *PollQuestions) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollQuestions.ProtoReflect.Descriptor instead.
+func (*PollQuestions) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PollQuestions) GetQuestions() []*PollQuestion {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+func (x *PollQuestions) GetError() Error {
+	if x != nil {
+		return x.Error
+	}
+	return Error_NONE
+}
+
+type SubmitAnswerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID       int64   `protobuf:"varint,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	QuestionID   int64   `protobuf:"varint,2,opt,name=questionID,proto3" json:"questionID,omitempty"`
+	QuestionType string  `protobuf:"bytes,3,opt,name=questionType,proto3" json:"questionType,omitempty"`
+	Rating       *int32  `protobuf:"varint,4,opt,name=rating,proto3,oneof" json:"rating,omitempty"`
+	Text         *string `protobuf:"bytes,5,opt,name=text,proto3,oneof" json:"text,omitempty"`
+}
+
+func (x *SubmitAnswerRequest) Reset() {
+	*x = SubmitAnswerRequest{}
+	mi := &file_poll_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAnswerRequest) ProtoMessage() {}
+
+func (x *SubmitAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAnswerRequest.ProtoReflect.Descriptor instead.
+func (*SubmitAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SubmitAnswerRequest) GetUserID() int64 {
+	if x != nil {
+		return x.UserID
+	}
+	return 0
+}
+
+func (x *SubmitAnswerRequest) GetQuestionID() int64 {
+	if x != nil {
+		return x.QuestionID
+	}
+	return 0
+}
+
+func (x *SubmitAnswerRequest) GetQuestionType() string {
+	if x != nil {
+		return x.QuestionType
+	}
+	return ""
+}
+
+func (x *SubmitAnswerRequest) GetRating() int32 {
+	if x != nil && x.Rating != nil {
+		return *x.Rating
+	}
+	return 0
+}
+
+func (x *SubmitAnswerRequest) GetText() string {
+	if x != nil && x.Text != nil {
+		return *x.Text
+	}
+	return ""
+}
+
+type SubmitAnswerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FollowUpQuestion *PollQuestion `protobuf:"bytes,1,opt,name=followUpQuestion,proto3" json:"followUpQuestion,omitempty"`
+	Error            Error         `protobuf:"varint,2,opt,name=error,proto3,enum=poll.Error" json:"error,omitempty"`
+}
+
+func (x *SubmitAnswerResponse) Reset() {
+	*x = SubmitAnswerResponse{}
+	mi := &file_poll_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitAnswerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAnswerResponse) ProtoMessage() {}
+
+func (x *SubmitAnswerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAnswerResponse.ProtoReflect.Descriptor instead.
+func (*SubmitAnswerResponse) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SubmitAnswerResponse) GetFollowUpQuestion() *PollQuestion {
+	if x != nil {
+		return x.FollowUpQuestion
+	}
+	return nil
+}
+
+func (x *SubmitAnswerResponse) GetError() Error {
+	if x != nil {
+		return x.Error
+	}
+	return Error_NONE
+}
+
+type ResultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From   int64  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To     int64  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Period string `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+}
+
+func (x *ResultsRequest) Reset() {
+	*x = ResultsRequest{}
+	mi := &file_poll_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultsRequest) ProtoMessage() {}
+
+func (x *ResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultsRequest.ProtoReflect.Descriptor instead.
+func (*ResultsRequest) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResultsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ResultsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ResultsRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+type RatingBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rating int32 `protobuf:"varint,1,opt,name=rating,proto3" json:"rating,omitempty"`
+	Count  int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *RatingBucket) Reset() {
+	*x = RatingBucket{}
+	mi := &file_poll_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingBucket) ProtoMessage() {}
+
+func (x *RatingBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingBucket.ProtoReflect.Descriptor instead.
+func (*RatingBucket) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RatingBucket) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *RatingBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type RatingTrendBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeriodStart int64   `protobuf:"varint,1,opt,name=periodStart,proto3" json:"periodStart,omitempty"`
+	Average     float64 `protobuf:"fixed64,2,opt,name=average,proto3" json:"average,omitempty"`
+	Count       int64   `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *RatingTrendBucket) Reset() {
+	*x = RatingTrendBucket{}
+	mi := &file_poll_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingTrendBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingTrendBucket) ProtoMessage() {}
+
+func (x *RatingTrendBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingTrendBucket.ProtoReflect.Descriptor instead.
+func (*RatingTrendBucket) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RatingTrendBucket) GetPeriodStart() int64 {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return 0
+}
+
+func (x *RatingTrendBucket) GetAverage() float64 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+func (x *RatingTrendBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type RatingResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuestionID int64                `protobuf:"varint,1,opt,name=questionID,proto3" json:"questionID,omitempty"`
+	Question   string               `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	RatingMin  int32                `protobuf:"varint,3,opt,name=ratingMin,proto3" json:"ratingMin,omitempty"`
+	RatingMax  int32                `protobuf:"varint,4,opt,name=ratingMax,proto3" json:"ratingMax,omitempty"`
+	Average    float64              `protobuf:"fixed64,5,opt,name=average,proto3" json:"average,omitempty"`
+	Count      int64                `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	Histogram  []*RatingBucket      `protobuf:"bytes,7,rep,name=histogram,proto3" json:"histogram,omitempty"`
+	Trend      []*RatingTrendBucket `protobuf:"bytes,8,rep,name=trend,proto3" json:"trend,omitempty"`
+}
+
+func (x *RatingResult) Reset() {
+	*x = RatingResult{}
+	mi := &file_poll_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingResult) ProtoMessage() {}
+
+func (x *RatingResult) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingResult.ProtoReflect.Descriptor instead.
+func (*RatingResult) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RatingResult) GetQuestionID() int64 {
+	if x != nil {
+		return x.QuestionID
+	}
+	return 0
+}
+
+func (x *RatingResult) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *RatingResult) GetRatingMin() int32 {
+	if x != nil {
+		return x.RatingMin
+	}
+	return 0
+}
+
+func (x *RatingResult) GetRatingMax() int32 {
+	if x != nil {
+		return x.RatingMax
+	}
+	return 0
+}
+
+func (x *RatingResult) GetAverage() float64 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+func (x *RatingResult) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RatingResult) GetHistogram() []*RatingBucket {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+func (x *RatingResult) GetTrend() []*RatingTrendBucket {
+	if x != nil {
+		return x.Trend
+	}
+	return nil
+}
+
+type NPSTrendBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeriodStart int64   `protobuf:"varint,1,opt,name=periodStart,proto3" json:"periodStart,omitempty"`
+	Score       float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Count       int64   `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Promoters   int64   `protobuf:"varint,4,opt,name=promoters,proto3" json:"promoters,omitempty"`
+	Detractors  int64   `protobuf:"varint,5,opt,name=detractors,proto3" json:"detractors,omitempty"`
+}
+
+func (x *NPSTrendBucket) Reset() {
+	*x = NPSTrendBucket{}
+	mi := &file_poll_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NPSTrendBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NPSTrendBucket) ProtoMessage() {}
+
+func (x *NPSTrendBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NPSTrendBucket.ProtoReflect.Descriptor instead.
+func (*NPSTrendBucket) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NPSTrendBucket) GetPeriodStart() int64 {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return 0
+}
+
+func (x *NPSTrendBucket) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *NPSTrendBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *NPSTrendBucket) GetPromoters() int64 {
+	if x != nil {
+		return x.Promoters
+	}
+	return 0
+}
+
+func (x *NPSTrendBucket) GetDetractors() int64 {
+	if x != nil {
+		return x.Detractors
+	}
+	return 0
+}
+
+type NPSResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuestionID int64             `protobuf:"varint,1,opt,name=questionID,proto3" json:"questionID,omitempty"`
+	Question   string            `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	Score      float64           `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Count      int64             `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Promoters  int64             `protobuf:"varint,5,opt,name=promoters,proto3" json:"promoters,omitempty"`
+	Passives   int64             `protobuf:"varint,6,opt,name=passives,proto3" json:"passives,omitempty"`
+	Detractors int64             `protobuf:"varint,7,opt,name=detractors,proto3" json:"detractors,omitempty"`
+	Histogram  []*RatingBucket   `protobuf:"bytes,8,rep,name=histogram,proto3" json:"histogram,omitempty"`
+	Trend      []*NPSTrendBucket `protobuf:"bytes,9,rep,name=trend,proto3" json:"trend,omitempty"`
+}
+
+func (x *NPSResult) Reset() {
+	*x = NPSResult{}
+	mi := &file_poll_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NPSResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NPSResult) ProtoMessage() {}
+
+func (x *NPSResult) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NPSResult.ProtoReflect.Descriptor instead.
+func (*NPSResult) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NPSResult) GetQuestionID() int64 {
+	if x != nil {
+		return x.QuestionID
+	}
+	return 0
+}
+
+func (x *NPSResult) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *NPSResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *NPSResult) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *NPSResult) GetPromoters() int64 {
+	if x != nil {
+		return x.Promoters
+	}
+	return 0
+}
+
+func (x *NPSResult) GetPassives() int64 {
+	if x != nil {
+		return x.Passives
+	}
+	return 0
+}
+
+func (x *NPSResult) GetDetractors() int64 {
+	if x != nil {
+		return x.Detractors
+	}
+	return 0
+}
+
+func (x *NPSResult) GetHistogram() []*RatingBucket {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+func (x *NPSResult) GetTrend() []*NPSTrendBucket {
+	if x != nil {
+		return x.Trend
+	}
+	return nil
+}
+
+type TextResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Question string   `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	Text     []string `protobuf:"bytes,2,rep,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *TextResult) Reset() {
+	*x = TextResult{}
+	mi := &file_poll_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextResult) ProtoMessage() {}
+
+func (x *TextResult) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextResult.ProtoReflect.Descriptor instead.
+func (*TextResult) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TextResult) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *TextResult) GetText() []string {
+	if x != nil {
+		return x.Text
+	}
+	return nil
+}
+
+type PollResults struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Period        string          `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	RatingResults []*RatingResult `protobuf:"bytes,2,rep,name=ratingResults,proto3" json:"ratingResults,omitempty"`
+	NpsResults    []*NPSResult    `protobuf:"bytes,3,rep,name=npsResults,proto3" json:"npsResults,omitempty"`
+	TextResults   []*TextResult   `protobuf:"bytes,4,rep,name=textResults,proto3" json:"textResults,omitempty"`
+	Error         Error           `protobuf:"varint,5,opt,name=error,proto3,enum=poll.Error" json:"error,omitempty"`
+}
+
+func (x *PollResults) Reset() {
+	*x = PollResults{}
+	mi := &file_poll_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollResults) ProtoMessage() {}
+
+func (x *PollResults) ProtoReflect() protoreflect.Message {
+	mi := &file_poll_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollResults.ProtoReflect.Descriptor instead.
+func (*PollResults) Descriptor() ([]byte, []int) {
+	return file_poll_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PollResults) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *PollResults) GetRatingResults() []*RatingResult {
+	if x != nil {
+		return x.RatingResults
+	}
+	return nil
+}
+
+func (x *PollResults) GetNpsResults() []*NPSResult {
+	if x != nil {
+		return x.NpsResults
+	}
+	return nil
+}
+
+func (x *PollResults) GetTextResults() []*TextResult {
+	if x != nil {
+		return x.TextResults
+	}
+	return nil
+}
+
+func (x *PollResults) GetError() Error {
+	if x != nil {
+		return x.Error
+	}
+	return Error_NONE
+}
+
+var File_poll_proto protoreflect.FileDescriptor
+
+var file_poll_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x70, 0x6f,
+	0x6c, 0x6c, 0x22, 0x29, 0x0a, 0x0f, 0x50, 0x69, 0x63, 0x6b, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x22, 0xb2, 0x01,
+	0x0a, 0x0c, 0x50, 0x6f, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e,
+	0x0a, 0x0a, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x22,
+	0x0a, 0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x65, 0x78, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x65,
+	0x78, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x4d, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x4d, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x61,
+	0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d,
+	0x61, 0x78, 0x22, 0x64, 0x0a, 0x0d, 0x50, 0x6f, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x30, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x50, 0x6f,
+	0x6c, 0x6c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xbb, 0x01, 0x0a, 0x13, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x06,
+	0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x06,
+	0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x88,
+	0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x07, 0x0a,
+	0x05, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x22, 0x79, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e,
+	0x0a, 0x10, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x55, 0x70, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e,
+	0x50, 0x6f, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x66, 0x6f,
+	0x6c, 0x6c, 0x6f, 0x77, 0x55, 0x70, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e,
+	0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x4c, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22,
+	0x3c, 0x0a, 0x0c, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x65, 0x0a,
+	0x11, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x42, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x97, 0x02, 0x0a, 0x0c, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x69, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x69, 0x6e, 0x12,
+	0x1c, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x78, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x78, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07,
+	0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x30, 0x0a,
+	0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x52, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12,
+	0x2d, 0x0a, 0x05, 0x74, 0x72, 0x65, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x65, 0x6e,
+	0x64, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x05, 0x74, 0x72, 0x65, 0x6e, 0x64, 0x22, 0x9c,
+	0x01, 0x0a, 0x0e, 0x4e, 0x50, 0x53, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x64, 0x65, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x64, 0x65, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0xab, 0x02,
+	0x0a, 0x09, 0x4e, 0x50, 0x53, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x69, 0x76, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x69, 0x76, 0x65, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x64, 0x65, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x64, 0x65, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x30, 0x0a,
+	0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x52, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12,
+	0x2a, 0x0a, 0x05, 0x74, 0x72, 0x65, 0x6e, 0x64, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x4e, 0x50, 0x53, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x42, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x52, 0x05, 0x74, 0x72, 0x65, 0x6e, 0x64, 0x22, 0x3c, 0x0a, 0x0a, 0x54,
+	0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0xe7, 0x01, 0x0a, 0x0b, 0x50, 0x6f,
+	0x6c, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x12, 0x38, 0x0a, 0x0d, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e,
+	0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0d, 0x72, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x0a, 0x6e,
+	0x70, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x4e, 0x50, 0x53, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x0a, 0x6e, 0x70, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x0b,
+	0x74, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x0b, 0x74, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x12, 0x21, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0b, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x2a, 0x5f, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x08, 0x0a, 0x04,
+	0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e,
+	0x41, 0x4c, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
+	0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x41, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54,
+	0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10,
+	0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x54, 0x5f, 0x50, 0x45, 0x52, 0x4d, 0x49, 0x54, 0x54,
+	0x45, 0x44, 0x10, 0x04, 0x32, 0xcb, 0x01, 0x0a, 0x04, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x41, 0x0a,
+	0x11, 0x50, 0x69, 0x63, 0x6b, 0x50, 0x6f, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x15, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x50, 0x69, 0x63, 0x6b, 0x50, 0x6f,
+	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x6f, 0x6c, 0x6c,
+	0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00,
+	0x12, 0x47, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x12, 0x19, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x6f,
+	0x6c, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x70, 0x6f, 0x6c, 0x6c, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x22, 0x00, 0x42, 0x2d, 0x5a, 0x2b, 0x2e, 0x2e, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x6f, 0x6c, 0x6c, 0x2f, 0x64, 0x65, 0x6c, 0x69, 0x76,
+	0x65, 0x72, 0x79, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x3b, 0x67, 0x65,
+	0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_poll_proto_rawDescOnce sync.Once
+	file_poll_proto_rawDescData = file_poll_proto_rawDesc
+)
+
+func file_poll_proto_rawDescGZIP() []byte {
+	file_poll_proto_rawDescOnce.Do(func() {
+		file_poll_proto_rawDescData = protoimpl.X.CompressGZIP(file_poll_proto_rawDescData)
+	})
+	return file_poll_proto_rawDescData
+}
+
+var file_poll_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_poll_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_poll_proto_goTypes = []any{
+	(Error)(0),                   // 0: poll.Error
+	(*PickPollRequest)(nil),      // 1: poll.PickPollRequest
+	(*PollQuestion)(nil),         // 2: poll.PollQuestion
+	(*PollQuestions)(nil),        // 3: poll.PollQuestions
+	(*SubmitAnswerRequest)(nil),  // 4: poll.SubmitAnswerRequest
+	(*SubmitAnswerResponse)(nil), // 5: poll.SubmitAnswerResponse
+	(*ResultsRequest)(nil),       // 6: poll.ResultsRequest
+	(*RatingBucket)(nil),         // 7: poll.RatingBucket
+	(*RatingTrendBucket)(nil),    // 8: poll.RatingTrendBucket
+	(*RatingResult)(nil),         // 9: poll.RatingResult
+	(*NPSTrendBucket)(nil),       // 10: poll.NPSTrendBucket
+	(*NPSResult)(nil),            // 11: poll.NPSResult
+	(*TextResult)(nil),           // 12: poll.TextResult
+	(*PollResults)(nil),          // 13: poll.PollResults
+}
+var file_poll_proto_depIdxs = []int32{
+	2,  // 0: poll.PollQuestions.questions:type_name -> poll.PollQuestion
+	0,  // 1: poll.PollQuestions.error:type_name -> poll.Error
+	2,  // 2: poll.SubmitAnswerResponse.followUpQuestion:type_name -> poll.PollQuestion
+	0,  // 3: poll.SubmitAnswerResponse.error:type_name -> poll.Error
+	7,  // 4: poll.RatingResult.histogram:type_name -> poll.RatingBucket
+	8,  // 5: poll.RatingResult.trend:type_name -> poll.RatingTrendBucket
+	7,  // 6: poll.NPSResult.histogram:type_name -> poll.RatingBucket
+	10, // 7: poll.NPSResult.trend:type_name -> poll.NPSTrendBucket
+	9,  // 8: poll.PollResults.ratingResults:type_name -> poll.RatingResult
+	11, // 9: poll.PollResults.npsResults:type_name -> poll.NPSResult
+	12, // 10: poll.PollResults.textResults:type_name -> poll.TextResult
+	0,  // 11: poll.PollResults.error:type_name -> poll.Error
+	1,  // 12: poll.Poll.PickPollQuestions:input_type -> poll.PickPollRequest
+	4,  // 13: poll.Poll.SubmitAnswer:input_type -> poll.SubmitAnswerRequest
+	6,  // 14: poll.Poll.GetResults:input_type -> poll.ResultsRequest
+	3,  // 15: poll.Poll.PickPollQuestions:output_type -> poll.PollQuestions
+	5,  // 16: poll.Poll.SubmitAnswer:output_type -> poll.SubmitAnswerResponse
+	13, // 17: poll.Poll.GetResults:output_type -> poll.PollResults
+	15, // [15:18] is the sub-list for method output_type
+	12, // [12:15] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_poll_proto_init() }
+func file_poll_proto_init() {
+	if File_poll_proto != nil {
+		return
+	}
+	file_poll_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_poll_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_poll_proto_goTypes,
+		DependencyIndexes: file_poll_proto_depIdxs,
+		EnumInfos:         file_poll_proto_enumTypes,
+		MessageInfos:      file_poll_proto_msgTypes,
+	}.Build()
+	File_poll_proto = out.File
+	file_poll_proto_rawDesc = nil
+	file_poll_proto_goTypes = nil
+	file_poll_proto_depIdxs = nil
+}
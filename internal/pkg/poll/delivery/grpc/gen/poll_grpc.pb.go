@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: poll.proto
+
+package gen
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Poll_PickPollQuestions_FullMethodName = "/poll.Poll/PickPollQuestions"
+	Poll_SubmitAnswer_FullMethodName      = "/poll.Poll/SubmitAnswer"
+	Poll_GetResults_FullMethodName        = "/poll.Poll/GetResults"
+)
+
+// PollClient is the client API for Poll service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PollClient interface {
+	PickPollQuestions(ctx context.Context, in *PickPollRequest, opts ...grpc.CallOption) (*PollQuestions, error)
+	SubmitAnswer(ctx context.Context, in *SubmitAnswerRequest, opts ...grpc.CallOption) (*SubmitAnswerResponse, error)
+	GetResults(ctx context.Context, in *ResultsRequest, opts ...grpc.CallOption) (*PollResults, error)
+}
+
+type pollClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPollClient(cc grpc.ClientConnInterface) PollClient {
+	return &pollClient{cc}
+}
+
+func (c *pollClient) PickPollQuestions(ctx context.Context, in *PickPollRequest, opts ...grpc.CallOption) (*PollQuestions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PollQuestions)
+	err := c.cc.Invoke(ctx, Poll_PickPollQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollClient) SubmitAnswer(ctx context.Context, in *SubmitAnswerRequest, opts ...grpc.CallOption) (*SubmitAnswerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitAnswerResponse)
+	err := c.cc.Invoke(ctx, Poll_SubmitAnswer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollClient) GetResults(ctx context.Context, in *ResultsRequest, opts ...grpc.CallOption) (*PollResults, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PollResults)
+	err := c.cc.Invoke(ctx, Poll_GetResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PollServer is the server API for Poll service.
+// All implementations must embed UnimplementedPollServer
+// for forward compatibility.
+type PollServer interface {
+	PickPollQuestions(context.Context, *PickPollRequest) (*PollQuestions, error)
+	SubmitAnswer(context.Context, *SubmitAnswerRequest) (*SubmitAnswerResponse, error)
+	GetResults(context.Context, *ResultsRequest) (*PollResults, error)
+	mustEmbedUnimplementedPollServer()
+}
+
+// UnimplementedPollServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPollServer struct{}
+
+func (UnimplementedPollServer) PickPollQuestions(context.Context, *PickPollRequest) (*PollQuestions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PickPollQuestions not implemented")
+}
+func (UnimplementedPollServer) SubmitAnswer(context.Context, *SubmitAnswerRequest) (*SubmitAnswerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitAnswer not implemented")
+}
+func (UnimplementedPollServer) GetResults(context.Context, *ResultsRequest) (*PollResults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResults not implemented")
+}
+func (UnimplementedPollServer) mustEmbedUnimplementedPollServer() {}
+func (UnimplementedPollServer) testEmbeddedByValue()              {}
+
+// UnsafePollServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PollServer will
+// result in compilation errors.
+type UnsafePollServer interface {
+	mustEmbedUnimplementedPollServer()
+}
+
+func RegisterPollServer(s grpc.ServiceRegistrar, srv PollServer) {
+	// If the following call pancis, it indicates UnimplementedPollServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Poll_ServiceDesc, srv)
+}
+
+func _Poll_PickPollQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PickPollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServer).PickPollQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Poll_PickPollQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServer).PickPollQuestions(ctx, req.(*PickPollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Poll_SubmitAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServer).SubmitAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Poll_SubmitAnswer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServer).SubmitAnswer(ctx, req.(*SubmitAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Poll_GetResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServer).GetResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Poll_GetResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServer).GetResults(ctx, req.(*ResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Poll_ServiceDesc is the grpc.ServiceDesc for Poll service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Poll_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "poll.Poll",
+	HandlerType: (*PollServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PickPollQuestions",
+			Handler:    _Poll_PickPollQuestions_Handler,
+		},
+		{
+			MethodName: "SubmitAnswer",
+			Handler:    _Poll_SubmitAnswer_Handler,
+		},
+		{
+			MethodName: "GetResults",
+			Handler:    _Poll_GetResults_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "poll.proto",
+}
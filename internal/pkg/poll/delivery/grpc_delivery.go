@@ -0,0 +1,167 @@
+package delivery
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/poll"
+	"RPO_back/internal/pkg/poll/delivery/grpc/gen"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"time"
+)
+
+// PollServer - gRPC API сервиса опросов для других сервисов
+type PollServer struct {
+	gen.PollServer
+	pollUC poll.PollUsecase
+}
+
+func CreatePollServer(pollUC poll.PollUsecase) *PollServer {
+	return &PollServer{pollUC: pollUC}
+}
+
+func (s *PollServer) PickPollQuestions(ctx context.Context, request *gen.PickPollRequest) (*gen.PollQuestions, error) {
+	questions, err := s.pollUC.PickPollQuestions(ctx, request.UserID)
+	if err != nil {
+		return &gen.PollQuestions{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	response := &gen.PollQuestions{Error: gen.Error_NONE}
+	for i := range questions {
+		response.Questions = append(response.Questions, questionToGRPC(&questions[i]))
+	}
+	return response, nil
+}
+
+func (s *PollServer) SubmitAnswer(ctx context.Context, request *gen.SubmitAnswerRequest) (*gen.SubmitAnswerResponse, error) {
+	answer := &models.PollSubmit{
+		QuestionID:   request.QuestionID,
+		QuestionType: request.QuestionType,
+		Text:         request.Text,
+	}
+	if request.Rating != nil {
+		rating := int(*request.Rating)
+		answer.Rating = &rating
+	}
+
+	submitResponse, err := s.pollUC.SubmitPoll(ctx, request.UserID, answer)
+	if err != nil {
+		return &gen.SubmitAnswerResponse{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	response := &gen.SubmitAnswerResponse{Error: gen.Error_NONE}
+	if submitResponse.FollowUpQuestion != nil {
+		response.FollowUpQuestion = questionToGRPC(submitResponse.FollowUpQuestion)
+	}
+	return response, nil
+}
+
+func (s *PollServer) GetResults(ctx context.Context, request *gen.ResultsRequest) (*gen.PollResults, error) {
+	filter := &models.PollResultsFilter{Period: request.Period}
+	if request.From != 0 {
+		from := time.Unix(request.From, 0).UTC()
+		filter.From = &from
+	}
+	if request.To != 0 {
+		to := time.Unix(request.To, 0).UTC()
+		filter.To = &to
+	}
+
+	results, err := s.pollUC.GetPollResults(ctx, filter)
+	if err != nil {
+		return &gen.PollResults{Error: errorToGRPC(ctx, err)}, nil
+	}
+
+	return resultsToGRPC(results), nil
+}
+
+func questionToGRPC(question *models.PollQuestion) *gen.PollQuestion {
+	return &gen.PollQuestion{
+		QuestionID:   question.QuestionID,
+		QuestionText: question.QuestionText,
+		QuestionType: question.QuestionType,
+		RatingMin:    int32(question.RatingMin),
+		RatingMax:    int32(question.RatingMax),
+	}
+}
+
+func histogramToGRPC(histogram []models.RatingBucket) (buckets []*gen.RatingBucket) {
+	for _, bucket := range histogram {
+		buckets = append(buckets, &gen.RatingBucket{Rating: int32(bucket.Rating), Count: bucket.Count})
+	}
+	return buckets
+}
+
+func resultsToGRPC(results *models.PollResults) *gen.PollResults {
+	response := &gen.PollResults{Period: results.Period, Error: gen.Error_NONE}
+
+	for _, result := range results.RatingResults {
+		rating := &gen.RatingResult{
+			QuestionID: result.QuestionID,
+			Question:   result.Question,
+			RatingMin:  int32(result.RatingMin),
+			RatingMax:  int32(result.RatingMax),
+			Average:    result.Average,
+			Count:      result.Count,
+			Histogram:  histogramToGRPC(result.Histogram),
+		}
+		for _, bucket := range result.Trend {
+			rating.Trend = append(rating.Trend, &gen.RatingTrendBucket{
+				PeriodStart: bucket.PeriodStart.Unix(),
+				Average:     bucket.Average,
+				Count:       bucket.Count,
+			})
+		}
+		response.RatingResults = append(response.RatingResults, rating)
+	}
+
+	for _, result := range results.NPSResults {
+		nps := &gen.NPSResult{
+			QuestionID: result.QuestionID,
+			Question:   result.Question,
+			Score:      result.Score,
+			Count:      result.Count,
+			Promoters:  result.Promoters,
+			Passives:   result.Passives,
+			Detractors: result.Detractors,
+			Histogram:  histogramToGRPC(result.Histogram),
+		}
+		for _, bucket := range result.Trend {
+			nps.Trend = append(nps.Trend, &gen.NPSTrendBucket{
+				PeriodStart: bucket.PeriodStart.Unix(),
+				Score:       bucket.Score,
+				Count:       bucket.Count,
+				Promoters:   bucket.Promoters,
+				Detractors:  bucket.Detractors,
+			})
+		}
+		response.NpsResults = append(response.NpsResults, nps)
+	}
+
+	for _, result := range results.TextResults {
+		response.TextResults = append(response.TextResults, &gen.TextResult{
+			Question: result.Question,
+			Text:     result.Text,
+		})
+	}
+
+	return response
+}
+
+func errorToGRPC(ctx context.Context, err error) gen.Error {
+	if errors.Is(err, errs.ErrBadRequest) {
+		logging.Warn(ctx, err)
+		return gen.Error_BAD_REQUEST
+	}
+	if errors.Is(err, errs.ErrNotFound) {
+		logging.Warn(ctx, err)
+		return gen.Error_NOT_FOUND
+	}
+	if errors.Is(err, errs.ErrNotPermitted) {
+		logging.Warn(ctx, err)
+		return gen.Error_NOT_PERMITTED
+	}
+	logging.Error(ctx, err)
+	return gen.Error_INTERNAL_SERVER_ERROR
+}
@@ -6,7 +6,9 @@ import (
 	"RPO_back/internal/pkg/utils/logging"
 	"RPO_back/internal/pkg/utils/requests"
 	"RPO_back/internal/pkg/utils/responses"
+	"fmt"
 	"net/http"
+	"time"
 )
 
 type PollDelivery struct {
@@ -31,14 +33,13 @@ func (d *PollDelivery) SubmitPoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = d.pollUC.SubmitPoll(r.Context(), userID, &pollSubmit)
+	response, err := d.pollUC.SubmitPoll(r.Context(), userID, &pollSubmit)
 	if err != nil {
-		logging.Warn(r.Context(), err)
-		responses.DoBadResponse(w, http.StatusInternalServerError, "internal error")
+		responses.ResponseErrorAndLog(w, err, funcName)
 		return
 	}
 
-	responses.DoEmptyOkResponse(w)
+	responses.DoJSONResponse(w, response, http.StatusOK)
 }
 
 func (d *PollDelivery) GetPollResults(w http.ResponseWriter, r *http.Request) {
@@ -48,12 +49,59 @@ func (d *PollDelivery) GetPollResults(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pollResults, err := d.pollUC.GetPollResults(r.Context())
+	filter, err := parseResultsFilter(r)
+	if err != nil {
+		logging.Warn(r.Context(), funcName, ": ", err)
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		return
+	}
+
+	pollResults, err := d.pollUC.GetPollResults(r.Context(), filter)
 	if err != nil {
-		logging.Warn(r.Context(), err)
 		responses.ResponseErrorAndLog(w, err, funcName)
 		return
 	}
 
 	responses.DoJSONResponse(w, pollResults, http.StatusOK)
 }
+
+// parseResultsFilter достаёт фильтр результатов из query-параметров from, to и period.
+// Если to задан датой, день to включается в результаты целиком
+func parseResultsFilter(r *http.Request) (filter *models.PollResultsFilter, err error) {
+	query := r.URL.Query()
+	filter = &models.PollResultsFilter{Period: query.Get("period")}
+
+	if rawFrom := query.Get("from"); rawFrom != "" {
+		from, _, err := parseResultsTime(rawFrom)
+		if err != nil {
+			return nil, fmt.Errorf("parseResultsFilter (from): %w", err)
+		}
+		filter.From = &from
+	}
+
+	if rawTo := query.Get("to"); rawTo != "" {
+		to, isDate, err := parseResultsTime(rawTo)
+		if err != nil {
+			return nil, fmt.Errorf("parseResultsFilter (to): %w", err)
+		}
+		if isDate {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+
+	return filter, nil
+}
+
+// parseResultsTime разбирает время в формате RFC3339 или дату YYYY-MM-DD
+func parseResultsTime(raw string) (t time.Time, isDate bool, err error) {
+	t, err = time.Parse(time.RFC3339, raw)
+	if err == nil {
+		return t, false, nil
+	}
+	t, err = time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, true, nil
+}
@@ -0,0 +1,41 @@
+package delivery
+
+import (
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"net/http"
+)
+
+// SnoozePoll откладывает опрос ("напомнить позже")
+func (d *PollDelivery) SnoozePoll(w http.ResponseWriter, r *http.Request) {
+	funcName := "SnoozePoll"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	err := d.pollUC.SnoozePoll(r.Context(), userID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
+
+// DismissPoll закрывает опрос без ответа ("не сейчас")
+func (d *PollDelivery) DismissPoll(w http.ResponseWriter, r *http.Request) {
+	funcName := "DismissPoll"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	err := d.pollUC.DismissPoll(r.Context(), userID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
@@ -3,18 +3,44 @@ package poll
 import (
 	"RPO_back/internal/models"
 	"context"
+	"time"
 )
 
 //go:generate mockgen -source=interfaces.go -destination=mocks/mock.go
 type PollUsecase interface {
-	SubmitPoll(ctx context.Context, userID int64, pollQuestion *models.PollSubmit) error
-	GetPollResults(ctx context.Context) (pollResults *models.PollResults, err error)
+	SubmitPoll(ctx context.Context, userID int64, pollQuestion *models.PollSubmit) (response *models.PollSubmitResponse, err error)
+	GetPollResults(ctx context.Context, filter *models.PollResultsFilter) (pollResults *models.PollResults, err error)
+	PickPollQuestions(ctx context.Context, userID int64) (pollQuestions []models.PollQuestion, err error)
+	SnoozePoll(ctx context.Context, userID int64) error
+	DismissPoll(ctx context.Context, userID int64) error
+	ExportPollResults(ctx context.Context, userID int64, filter *models.PollResultsFilter, anonymized bool, handle func(row *models.PollExportRow) error) error
+	GetPollQuestions(ctx context.Context, userID int64) (questions []models.PollQuestionAdmin, err error)
+	CreatePollQuestion(ctx context.Context, userID int64, data *models.PollQuestionPostRequest) (newQuestion *models.PollQuestionAdmin, err error)
+	UpdatePollQuestion(ctx context.Context, userID int64, questionID int64, data *models.PollQuestionPutRequest) (updatedQuestion *models.PollQuestionAdmin, err error)
+	SetPollQuestionActive(ctx context.Context, userID int64, questionID int64, isActive bool) error
+	ReorderPollQuestions(ctx context.Context, userID int64, questionIDs []int64) error
 }
 
 type PollRepo interface {
 	SubmitPoll(ctx context.Context, userID int64, pollSubmit *models.PollSubmit) error
-	GetRatingResults(ctx context.Context) (results []models.RatingResults, err error)
-	GetTextResults(ctx context.Context) (results []models.AnswerResults, err error)
-	SetNextPollDT(ctx context.Context, userID int64) error
-	PickPollQuestions(ctx context.Context) (pollQuestions []models.PollQuestion, err error)
+	GetRatingResults(ctx context.Context, filter *models.PollResultsFilter) (results []models.RatingResults, err error)
+	GetRatingHistograms(ctx context.Context, filter *models.PollResultsFilter) (histograms map[int64][]models.RatingBucket, err error)
+	GetRatingTrends(ctx context.Context, filter *models.PollResultsFilter) (trends map[int64][]models.RatingTrendBucket, err error)
+	GetNPSResults(ctx context.Context, filter *models.PollResultsFilter) (results []models.NPSResults, err error)
+	GetNPSTrends(ctx context.Context, filter *models.PollResultsFilter) (trends map[int64][]models.NPSTrendBucket, err error)
+	GetTextResults(ctx context.Context, filter *models.PollResultsFilter) (results []models.AnswerResults, err error)
+	StreamPollResults(ctx context.Context, filter *models.PollResultsFilter, handle func(row *models.PollExportRow) error) error
+	GetPollState(ctx context.Context, userID int64) (state *models.PollState, err error)
+	RegisterPollPrompt(ctx context.Context, userID int64, nextPollAt time.Time) error
+	SnoozePoll(ctx context.Context, userID int64, snoozedUntil time.Time) error
+	DismissPoll(ctx context.Context, userID int64, nextPollAt time.Time) error
+	PickPollQuestions(ctx context.Context, userID int64) (pollQuestions []models.PollQuestion, err error)
+	IsSystemAdmin(ctx context.Context, userID int64) (isAdmin bool, err error)
+	GetPollQuestions(ctx context.Context) (questions []models.PollQuestionAdmin, err error)
+	GetPollQuestion(ctx context.Context, questionID int64) (question *models.PollQuestionAdmin, err error)
+	CreatePollQuestion(ctx context.Context, question *models.PollQuestionAdmin) (newQuestion *models.PollQuestionAdmin, err error)
+	UpdatePollQuestion(ctx context.Context, questionID int64, update *models.PollQuestionPutRequest) (updatedQuestion *models.PollQuestionAdmin, err error)
+	SetPollQuestionActive(ctx context.Context, questionID int64, isActive bool) error
+	ReorderPollQuestions(ctx context.Context, questionIDs []int64) error
+	GetFollowUpAccess(ctx context.Context, userID int64, questionID int64, period time.Duration) (isFollowUp bool, hasDetractorAnswer bool, err error)
 }
@@ -0,0 +1,212 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: poll_grpc.pb.go
+
+// Package mock_poll is a generated GoMock package.
+package mock_poll
+
+import (
+	gen "RPO_back/internal/pkg/poll/delivery/grpc/gen"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockPollClient is a mock of PollClient interface.
+type MockPollClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPollClientMockRecorder
+}
+
+// MockPollClientMockRecorder is the mock recorder for MockPollClient.
+type MockPollClientMockRecorder struct {
+	mock *MockPollClient
+}
+
+// NewMockPollClient creates a new mock instance.
+func NewMockPollClient(ctrl *gomock.Controller) *MockPollClient {
+	mock := &MockPollClient{ctrl: ctrl}
+	mock.recorder = &MockPollClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPollClient) EXPECT() *MockPollClientMockRecorder {
+	return m.recorder
+}
+
+// GetResults mocks base method.
+func (m *MockPollClient) GetResults(ctx context.Context, in *gen.ResultsRequest, opts ...grpc.CallOption) (*gen.PollResults, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetResults", varargs...)
+	ret0, _ := ret[0].(*gen.PollResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResults indicates an expected call of GetResults.
+func (mr *MockPollClientMockRecorder) GetResults(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResults", reflect.TypeOf((*MockPollClient)(nil).GetResults), varargs...)
+}
+
+// PickPollQuestions mocks base method.
+func (m *MockPollClient) PickPollQuestions(ctx context.Context, in *gen.PickPollRequest, opts ...grpc.CallOption) (*gen.PollQuestions, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PickPollQuestions", varargs...)
+	ret0, _ := ret[0].(*gen.PollQuestions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PickPollQuestions indicates an expected call of PickPollQuestions.
+func (mr *MockPollClientMockRecorder) PickPollQuestions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PickPollQuestions", reflect.TypeOf((*MockPollClient)(nil).PickPollQuestions), varargs...)
+}
+
+// SubmitAnswer mocks base method.
+func (m *MockPollClient) SubmitAnswer(ctx context.Context, in *gen.SubmitAnswerRequest, opts ...grpc.CallOption) (*gen.SubmitAnswerResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubmitAnswer", varargs...)
+	ret0, _ := ret[0].(*gen.SubmitAnswerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitAnswer indicates an expected call of SubmitAnswer.
+func (mr *MockPollClientMockRecorder) SubmitAnswer(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAnswer", reflect.TypeOf((*MockPollClient)(nil).SubmitAnswer), varargs...)
+}
+
+// MockPollServer is a mock of PollServer interface.
+type MockPollServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPollServerMockRecorder
+}
+
+// MockPollServerMockRecorder is the mock recorder for MockPollServer.
+type MockPollServerMockRecorder struct {
+	mock *MockPollServer
+}
+
+// NewMockPollServer creates a new mock instance.
+func NewMockPollServer(ctrl *gomock.Controller) *MockPollServer {
+	mock := &MockPollServer{ctrl: ctrl}
+	mock.recorder = &MockPollServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPollServer) EXPECT() *MockPollServerMockRecorder {
+	return m.recorder
+}
+
+// GetResults mocks base method.
+func (m *MockPollServer) GetResults(arg0 context.Context, arg1 *gen.ResultsRequest) (*gen.PollResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResults", arg0, arg1)
+	ret0, _ := ret[0].(*gen.PollResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResults indicates an expected call of GetResults.
+func (mr *MockPollServerMockRecorder) GetResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResults", reflect.TypeOf((*MockPollServer)(nil).GetResults), arg0, arg1)
+}
+
+// PickPollQuestions mocks base method.
+func (m *MockPollServer) PickPollQuestions(arg0 context.Context, arg1 *gen.PickPollRequest) (*gen.PollQuestions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PickPollQuestions", arg0, arg1)
+	ret0, _ := ret[0].(*gen.PollQuestions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PickPollQuestions indicates an expected call of PickPollQuestions.
+func (mr *MockPollServerMockRecorder) PickPollQuestions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PickPollQuestions", reflect.TypeOf((*MockPollServer)(nil).PickPollQuestions), arg0, arg1)
+}
+
+// SubmitAnswer mocks base method.
+func (m *MockPollServer) SubmitAnswer(arg0 context.Context, arg1 *gen.SubmitAnswerRequest) (*gen.SubmitAnswerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAnswer", arg0, arg1)
+	ret0, _ := ret[0].(*gen.SubmitAnswerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitAnswer indicates an expected call of SubmitAnswer.
+func (mr *MockPollServerMockRecorder) SubmitAnswer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAnswer", reflect.TypeOf((*MockPollServer)(nil).SubmitAnswer), arg0, arg1)
+}
+
+// mustEmbedUnimplementedPollServer mocks base method.
+func (m *MockPollServer) mustEmbedUnimplementedPollServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedPollServer")
+}
+
+// mustEmbedUnimplementedPollServer indicates an expected call of mustEmbedUnimplementedPollServer.
+func (mr *MockPollServerMockRecorder) mustEmbedUnimplementedPollServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedPollServer", reflect.TypeOf((*MockPollServer)(nil).mustEmbedUnimplementedPollServer))
+}
+
+// MockUnsafePollServer is a mock of UnsafePollServer interface.
+type MockUnsafePollServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafePollServerMockRecorder
+}
+
+// MockUnsafePollServerMockRecorder is the mock recorder for MockUnsafePollServer.
+type MockUnsafePollServerMockRecorder struct {
+	mock *MockUnsafePollServer
+}
+
+// NewMockUnsafePollServer creates a new mock instance.
+func NewMockUnsafePollServer(ctrl *gomock.Controller) *MockUnsafePollServer {
+	mock := &MockUnsafePollServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafePollServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafePollServer) EXPECT() *MockUnsafePollServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedPollServer mocks base method.
+func (m *MockUnsafePollServer) mustEmbedUnimplementedPollServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedPollServer")
+}
+
+// mustEmbedUnimplementedPollServer indicates an expected call of mustEmbedUnimplementedPollServer.
+func (mr *MockUnsafePollServerMockRecorder) mustEmbedUnimplementedPollServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedPollServer", reflect.TypeOf((*MockUnsafePollServer)(nil).mustEmbedUnimplementedPollServer))
+}
@@ -9,6 +9,9 @@ import (
 	"time"
 )
 
+// Пользователи, зарегистрированные меньше этого срока назад, относятся к аудитории new_users
+const newUserPeriod = 30 * 24 * time.Hour
+
 type PollRepository struct {
 	db pgxiface.PgxIface
 }
@@ -34,102 +37,28 @@ func (r *PollRepository) SubmitPoll(ctx context.Context, userID int64, PollSubmi
 	return nil
 }
 
-func (r *PollRepository) GetRatingResults(ctx context.Context) (results []models.RatingResults, err error) {
-	funcName := "GetRatingResults"
-	query := `
-	SELECT cq.question_text, AVG(cr.rating) AS rating FROM csat_results AS cr
-	JOIN csat_question AS cq ON cr.question_id = cq.question_id
-	WHERE cq.type='answer_rating'
-	AND cr.rating IS NOT NULL
-	GROUP BY cq.question_id, cr.rating;
-	`
-
-	results = make([]models.RatingResults, 0)
-
-	rows, err := r.db.Query(ctx, query)
-	logging.Debug(ctx, funcName, " query has err: ", err)
-	if err != nil {
-		return nil, fmt.Errorf("GetRatingResults (query): %w", err)
-	}
-
-	for rows.Next() {
-		result := models.RatingResults{}
-		if err := rows.Scan(&result.Question, &result.Rating); err != nil {
-			return nil, fmt.Errorf("GetRatingResults (scan): %w", err)
-		}
-		fmt.Printf("RATING RES: %#v\n", result)
-		results = append(results, result)
-	}
-
-	return results, nil
-}
-
-func (r *PollRepository) GetTextResults(ctx context.Context) (results []models.AnswerResults, err error) {
-	funcName := "GetTextResults"
-	query := `
-	SELECT cr.comment, cq.question_text FROM csat_results AS cr
-	JOIN csat_question AS cq ON cr.question_id = cq.question_id
-	WHERE cq.type='answer_text'
-	AND cr.comment IS NOT NULL
-	ORDER BY cq.question_id;
-	`
-
-	results = make([]models.AnswerResults, 0)
-	rows, err := r.db.Query(ctx, query)
-	logging.Debug(ctx, funcName, " query has err: ", err)
-	if err != nil {
-		return nil, fmt.Errorf("GetTextResults (query): %w", err)
-	}
-
-	for rows.Next() {
-		var q, a string
-		if err := rows.Scan(&a, &q); err != nil {
-			return nil, fmt.Errorf("GetTextResults (scan): %w", err)
-		}
-		fmt.Println("TEXT RESULT: ", q, "  ", a)
-		if len(results) == 0 {
-			results = append(results, models.AnswerResults{
-				Question: q,
-				Text:     []string{a},
-			})
-		} else {
-			if results[len(results)-1].Question == q {
-				results[len(results)-1].Text = append(results[len(results)-1].Text, a)
-			} else {
-				results = append(results, models.AnswerResults{
-					Question: q,
-					Text:     []string{a},
-				})
-			}
-		}
-	}
-
-	return results, nil
-}
-
-func (r *PollRepository) SetNextPollDT(ctx context.Context, userID int64) error {
-	funcName := "SetNextPollDate"
-	query := `
-	UPDATE "user" SET csat_poll_dt=(CURRENT_TIMESTAMP+$2) WHERE u_id=$1;
-	`
-
-	_, err := r.db.Exec(ctx, query, userID, 24*7*time.Hour)
-	logging.Debug(ctx, funcName, " query has err: ", err)
-	if err != nil {
-		return fmt.Errorf("SetNextPollDate (query): %w", err)
-	}
-
-	return nil
-}
-
-func (r *PollRepository) PickPollQuestions(ctx context.Context) (pollQuestions []models.PollQuestion, err error) {
+func (r *PollRepository) PickPollQuestions(ctx context.Context, userID int64) (pollQuestions []models.PollQuestion, err error) {
 	funcName := "PickPollQuestions"
 	query := `
-	SELECT cq.question_id, cq.question_text, cq.type
-	FROM csat_question AS cq;
+	SELECT cq.question_id, cq.question_text, cq.type, cq.rating_min, cq.rating_max
+	FROM csat_question AS cq
+	JOIN "user" AS u ON u.u_id=$1
+	WHERE cq.is_active
+	AND NOT EXISTS (
+		SELECT 1 FROM csat_question AS parent WHERE parent.follow_up_question_id=cq.question_id
+	)
+	AND (
+		cq.audience IS NULL
+		OR (cq.audience='new_users' AND u.joined_at > CURRENT_TIMESTAMP - $2::interval)
+		OR (cq.audience='experienced_users' AND u.joined_at <= CURRENT_TIMESTAMP - $2::interval)
+		OR (cq.audience='board_admins' AND EXISTS (
+			SELECT 1 FROM user_to_board AS ub WHERE ub.u_id=u.u_id AND ub.role='admin'
+		))
+	)
+	ORDER BY cq.order_index, cq.question_id;
 	`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := r.db.Query(ctx, query, userID, newUserPeriod)
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
 		return nil, fmt.Errorf("PickPollQuestions (query): %w", err)
@@ -137,7 +66,8 @@ func (r *PollRepository) PickPollQuestions(ctx context.Context) (pollQuestions [
 
 	for rows.Next() {
 		pollQuestion := models.PollQuestion{}
-		if err := rows.Scan(&pollQuestion.QuestionID, &pollQuestion.QuestionText, &pollQuestion.QuestionType); err != nil {
+		if err := rows.Scan(&pollQuestion.QuestionID, &pollQuestion.QuestionText, &pollQuestion.QuestionType,
+			&pollQuestion.RatingMin, &pollQuestion.RatingMax); err != nil {
 			return nil, fmt.Errorf("PickPollQuestions (scan): %w", err)
 		}
 		pollQuestions = append(pollQuestions, pollQuestion)
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Поля вопроса в порядке scanPollQuestion
+const pollQuestionFields = `question_id, question_text, type, is_active, order_index,
+	rating_min, rating_max, audience, created_at, updated_at, follow_up_question_id`
+
+func scanPollQuestion(row pgx.Row) (question *models.PollQuestionAdmin, err error) {
+	question = &models.PollQuestionAdmin{}
+	err = row.Scan(
+		&question.ID,
+		&question.Text,
+		&question.Type,
+		&question.IsActive,
+		&question.OrderIndex,
+		&question.RatingMin,
+		&question.RatingMax,
+		&question.Audience,
+		&question.CreatedAt,
+		&question.UpdatedAt,
+		&question.FollowUpQuestionID,
+	)
+	return question, err
+}
+
+// IsSystemAdmin проверяет, является ли пользователь администратором сервиса
+func (r *PollRepository) IsSystemAdmin(ctx context.Context, userID int64) (isAdmin bool, err error) {
+	funcName := "IsSystemAdmin"
+	query := `SELECT is_system_admin FROM "user" WHERE u_id=$1;`
+
+	err = r.db.QueryRow(ctx, query, userID).Scan(&isAdmin)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, fmt.Errorf("%s: %w", funcName, errs.ErrNotFound)
+		}
+		return false, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return isAdmin, nil
+}
+
+// GetPollQuestions возвращает все вопросы опроса, включая неактивные, в порядке показа
+func (r *PollRepository) GetPollQuestions(ctx context.Context) (questions []models.PollQuestionAdmin, err error) {
+	funcName := "GetPollQuestions"
+	query := `SELECT ` + pollQuestionFields + ` FROM csat_question ORDER BY order_index, question_id;`
+
+	rows, err := r.db.Query(ctx, query)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	questions = make([]models.PollQuestionAdmin, 0)
+	for rows.Next() {
+		question, err := scanPollQuestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		questions = append(questions, *question)
+	}
+
+	return questions, nil
+}
+
+// GetPollQuestion возвращает вопрос опроса по ID
+func (r *PollRepository) GetPollQuestion(ctx context.Context, questionID int64) (question *models.PollQuestionAdmin, err error) {
+	funcName := "GetPollQuestion"
+	query := `SELECT ` + pollQuestionFields + ` FROM csat_question WHERE question_id=$1;`
+
+	question, err = scanPollQuestion(r.db.QueryRow(ctx, query, questionID))
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return question, nil
+}
+
+// CreatePollQuestion добавляет вопрос в конец опроса
+func (r *PollRepository) CreatePollQuestion(ctx context.Context, question *models.PollQuestionAdmin) (newQuestion *models.PollQuestionAdmin, err error) {
+	funcName := "CreatePollQuestion"
+	query := `
+	INSERT INTO csat_question (question_text, type, is_active, rating_min, rating_max, audience,
+		follow_up_question_id, order_index)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT COALESCE(MAX(order_index), 0) + 1 FROM csat_question))
+	RETURNING ` + pollQuestionFields + `;`
+
+	newQuestion, err = scanPollQuestion(r.db.QueryRow(ctx, query, question.Text, question.Type, question.IsActive,
+		question.RatingMin, question.RatingMax, question.Audience, question.FollowUpQuestionID))
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return newQuestion, nil
+}
+
+// UpdatePollQuestion меняет текст, шкалу, аудиторию и уточняющий вопрос
+func (r *PollRepository) UpdatePollQuestion(ctx context.Context, questionID int64, update *models.PollQuestionPutRequest) (updatedQuestion *models.PollQuestionAdmin, err error) {
+	funcName := "UpdatePollQuestion"
+	query := `
+	UPDATE csat_question
+	SET question_text=$2, rating_min=$3, rating_max=$4, audience=$5, follow_up_question_id=$6,
+		updated_at=CURRENT_TIMESTAMP
+	WHERE question_id=$1
+	RETURNING ` + pollQuestionFields + `;`
+
+	updatedQuestion, err = scanPollQuestion(r.db.QueryRow(ctx, query, questionID, update.Text,
+		update.RatingMin, update.RatingMax, update.Audience, update.FollowUpQuestionID))
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return updatedQuestion, nil
+}
+
+// SetPollQuestionActive включает или выключает показ вопроса
+func (r *PollRepository) SetPollQuestionActive(ctx context.Context, questionID int64, isActive bool) error {
+	funcName := "SetPollQuestionActive"
+	query := `
+	UPDATE csat_question
+	SET is_active=$2, updated_at=CURRENT_TIMESTAMP
+	WHERE question_id=$1;
+	`
+
+	tag, err := r.db.Exec(ctx, query, questionID, isActive)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s: %w", funcName, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", funcName, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ReorderPollQuestions задаёт порядок вопросов. Если в списке есть не все вопросы,
+// есть несуществующие или повторяющиеся - ничего не меняется и возвращается errs.ErrBadRequest
+func (r *PollRepository) ReorderPollQuestions(ctx context.Context, questionIDs []int64) error {
+	funcName := "ReorderPollQuestions"
+	query := `
+	WITH new_order AS (
+		SELECT o.question_id, o.order_index
+		FROM unnest($1::bigint[]) WITH ORDINALITY AS o(question_id, order_index)
+	),
+	is_complete AS (
+		SELECT (SELECT COUNT(*) FROM csat_question) = COUNT(*)
+			AND (SELECT COUNT(*) FROM new_order) = COUNT(*)
+			AND COUNT(DISTINCT o.question_id) = COUNT(*) AS ok
+		FROM new_order AS o
+		JOIN csat_question AS cq ON cq.question_id=o.question_id
+	)
+	UPDATE csat_question AS cq
+	SET order_index=o.order_index, updated_at=CURRENT_TIMESTAMP
+	FROM new_order AS o, is_complete AS c
+	WHERE cq.question_id=o.question_id AND c.ok;
+	`
+
+	tag, err := r.db.Exec(ctx, query, questionIDs)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s: %w", funcName, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: question list must contain every question once: %w", funcName, errs.ErrBadRequest)
+	}
+
+	return nil
+}
+
+// GetFollowUpAccess проверяет, является ли вопрос уточняющим вопросом NPS, и если да - ставил ли
+// пользователь за последние period оценку критика в одном из NPS-вопросов, к которым он привязан
+func (r *PollRepository) GetFollowUpAccess(ctx context.Context, userID int64, questionID int64, period time.Duration) (isFollowUp bool, hasDetractorAnswer bool, err error) {
+	funcName := "GetFollowUpAccess"
+	query := `
+	SELECT
+		EXISTS (SELECT 1 FROM csat_question WHERE follow_up_question_id=$2),
+		EXISTS (
+			SELECT 1 FROM csat_results AS cr
+			JOIN csat_question AS cq ON cq.question_id=cr.question_id
+			WHERE cq.follow_up_question_id=$2 AND cq.type='answer_nps'
+			AND cr.u_id=$1 AND cr.rating <= $3
+			AND cr.created_at > CURRENT_TIMESTAMP - $4::interval
+		);
+	`
+
+	err = r.db.QueryRow(ctx, query, userID, questionID, models.NPSDetractorMax, period).Scan(&isFollowUp, &hasDetractorAnswer)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return false, false, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return isFollowUp, hasDetractorAnswer, nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"context"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorderPollQuestions(t *testing.T) {
+	// Список принимается, только если в нём каждый существующий вопрос ровно один раз
+	query := `(?s)SELECT \(SELECT COUNT\(\*\) FROM csat_question\) = COUNT\(\*\)\s+` +
+		`AND \(SELECT COUNT\(\*\) FROM new_order\) = COUNT\(\*\)\s+` +
+		`AND COUNT\(DISTINCT o.question_id\) = COUNT\(\*\) AS ok.*WHERE cq.question_id=o.question_id AND c.ok`
+
+	tests := []struct {
+		name         string
+		questionIDs  []int64
+		rowsAffected int64
+		expectedErr  error
+	}{
+		{name: "every question once", questionIDs: []int64{3, 1, 2}, rowsAffected: 3},
+		{name: "duplicate instead of missing question", questionIDs: []int64{1, 1, 2}, expectedErr: errs.ErrBadRequest},
+		{name: "unknown question", questionIDs: []int64{1, 2, 3, 99}, expectedErr: errs.ErrBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+			repo := CreatePollRepository(mock)
+
+			mock.ExpectExec(query).
+				WithArgs(tt.questionIDs).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rowsAffected))
+
+			err = repo.ReorderPollQuestions(context.Background(), tt.questionIDs)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"fmt"
+)
+
+// Условие на период ответа: $1 - начало (включительно), $2 - конец (не включительно)
+const resultsPeriodCondition = `($1::timestamptz IS NULL OR cr.created_at >= $1)
+	AND ($2::timestamptz IS NULL OR cr.created_at < $2)`
+
+// GetRatingResults возвращает среднюю оценку и число оценок по каждому вопросу
+// типа answer_rating. Вопросы без оценок за период тоже попадают в результат
+func (r *PollRepository) GetRatingResults(ctx context.Context, filter *models.PollResultsFilter) (results []models.RatingResults, err error) {
+	funcName := "GetRatingResults"
+	query := `
+	SELECT cq.question_id, cq.question_text, cq.rating_min, cq.rating_max,
+		COALESCE(AVG(cr.rating), 0)::float8, COUNT(cr.rating)
+	FROM csat_question AS cq
+	LEFT JOIN csat_results AS cr ON cr.question_id=cq.question_id
+		AND cr.rating IS NOT NULL
+		AND ` + resultsPeriodCondition + `
+	WHERE cq.type='answer_rating'
+	GROUP BY cq.question_id
+	ORDER BY cq.order_index, cq.question_id;
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.From, filter.To)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	results = make([]models.RatingResults, 0)
+	for rows.Next() {
+		result := models.RatingResults{}
+		err := rows.Scan(&result.QuestionID, &result.Question, &result.RatingMin, &result.RatingMax,
+			&result.Average, &result.Count)
+		if err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// GetRatingHistograms возвращает число ответов с каждой оценкой, сгруппированное по вопросам.
+// Оценки, которые никто не ставил, в ответ не попадают
+func (r *PollRepository) GetRatingHistograms(ctx context.Context, filter *models.PollResultsFilter) (histograms map[int64][]models.RatingBucket, err error) {
+	funcName := "GetRatingHistograms"
+	query := `
+	SELECT cr.question_id, cr.rating, COUNT(*)
+	FROM csat_results AS cr
+	WHERE cr.rating IS NOT NULL
+	AND ` + resultsPeriodCondition + `
+	GROUP BY cr.question_id, cr.rating
+	ORDER BY cr.question_id, cr.rating;
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.From, filter.To)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	histograms = make(map[int64][]models.RatingBucket)
+	for rows.Next() {
+		var questionID int64
+		bucket := models.RatingBucket{}
+		if err := rows.Scan(&questionID, &bucket.Rating, &bucket.Count); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		histograms[questionID] = append(histograms[questionID], bucket)
+	}
+
+	return histograms, nil
+}
+
+// GetRatingTrends возвращает среднюю оценку по неделям или месяцам (filter.Period),
+// сгруппированную по вопросам. Периоды без оценок пропускаются
+func (r *PollRepository) GetRatingTrends(ctx context.Context, filter *models.PollResultsFilter) (trends map[int64][]models.RatingTrendBucket, err error) {
+	funcName := "GetRatingTrends"
+	query := `
+	SELECT cr.question_id, date_trunc($3, cr.created_at, 'UTC') AS period_start,
+		AVG(cr.rating)::float8, COUNT(*)
+	FROM csat_results AS cr
+	WHERE cr.rating IS NOT NULL
+	AND ` + resultsPeriodCondition + `
+	GROUP BY cr.question_id, period_start
+	ORDER BY cr.question_id, period_start;
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.From, filter.To, filter.Period)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	trends = make(map[int64][]models.RatingTrendBucket)
+	for rows.Next() {
+		var questionID int64
+		bucket := models.RatingTrendBucket{}
+		if err := rows.Scan(&questionID, &bucket.PeriodStart, &bucket.Average, &bucket.Count); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		bucket.PeriodStart = bucket.PeriodStart.UTC()
+		trends[questionID] = append(trends[questionID], bucket)
+	}
+
+	return trends, nil
+}
+
+// GetNPSResults возвращает число промоутеров, нейтралов и критиков по каждому вопросу типа answer_nps
+func (r *PollRepository) GetNPSResults(ctx context.Context, filter *models.PollResultsFilter) (results []models.NPSResults, err error) {
+	funcName := "GetNPSResults"
+	query := `
+	SELECT cq.question_id, cq.question_text, COUNT(cr.rating),
+		COUNT(cr.rating) FILTER (WHERE cr.rating >= $3),
+		COUNT(cr.rating) FILTER (WHERE cr.rating > $4 AND cr.rating < $3),
+		COUNT(cr.rating) FILTER (WHERE cr.rating <= $4)
+	FROM csat_question AS cq
+	LEFT JOIN csat_results AS cr ON cr.question_id=cq.question_id
+		AND cr.rating IS NOT NULL
+		AND ` + resultsPeriodCondition + `
+	WHERE cq.type='answer_nps'
+	GROUP BY cq.question_id
+	ORDER BY cq.order_index, cq.question_id;
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.From, filter.To, models.NPSPromoterMin, models.NPSDetractorMax)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	results = make([]models.NPSResults, 0)
+	for rows.Next() {
+		result := models.NPSResults{}
+		err := rows.Scan(&result.QuestionID, &result.Question, &result.Count,
+			&result.Promoters, &result.Passives, &result.Detractors)
+		if err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// GetNPSTrends возвращает число промоутеров и критиков по неделям или месяцам (filter.Period),
+// сгруппированное по вопросам типа answer_nps
+func (r *PollRepository) GetNPSTrends(ctx context.Context, filter *models.PollResultsFilter) (trends map[int64][]models.NPSTrendBucket, err error) {
+	funcName := "GetNPSTrends"
+	query := `
+	SELECT cr.question_id, date_trunc($3, cr.created_at, 'UTC') AS period_start, COUNT(*),
+		COUNT(*) FILTER (WHERE cr.rating >= $4),
+		COUNT(*) FILTER (WHERE cr.rating <= $5)
+	FROM csat_results AS cr
+	JOIN csat_question AS cq ON cq.question_id=cr.question_id
+	WHERE cq.type='answer_nps'
+	AND cr.rating IS NOT NULL
+	AND ` + resultsPeriodCondition + `
+	GROUP BY cr.question_id, period_start
+	ORDER BY cr.question_id, period_start;
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.From, filter.To, filter.Period,
+		models.NPSPromoterMin, models.NPSDetractorMax)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	trends = make(map[int64][]models.NPSTrendBucket)
+	for rows.Next() {
+		var questionID int64
+		bucket := models.NPSTrendBucket{}
+		err := rows.Scan(&questionID, &bucket.PeriodStart, &bucket.Count, &bucket.Promoters, &bucket.Detractors)
+		if err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		bucket.PeriodStart = bucket.PeriodStart.UTC()
+		trends[questionID] = append(trends[questionID], bucket)
+	}
+
+	return trends, nil
+}
+
+// GetTextResults возвращает текстовые ответы за период, сгруппированные по вопросам
+func (r *PollRepository) GetTextResults(ctx context.Context, filter *models.PollResultsFilter) (results []models.AnswerResults, err error) {
+	funcName := "GetTextResults"
+	query := `
+	SELECT cr.comment, cq.question_text FROM csat_results AS cr
+	JOIN csat_question AS cq ON cr.question_id = cq.question_id
+	WHERE cq.type='answer_text'
+	AND cr.comment IS NOT NULL
+	AND ` + resultsPeriodCondition + `
+	ORDER BY cq.order_index, cq.question_id, cr.created_at;
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.From, filter.To)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	results = make([]models.AnswerResults, 0)
+	for rows.Next() {
+		var question, answer string
+		if err := rows.Scan(&answer, &question); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		if len(results) > 0 && results[len(results)-1].Question == question {
+			results[len(results)-1].Text = append(results[len(results)-1].Text, answer)
+			continue
+		}
+		results = append(results, models.AnswerResults{
+			Question: question,
+			Text:     []string{answer},
+		})
+	}
+
+	return results, nil
+}
+
+// StreamPollResults по одной передаёт в handle все ответы за период вместе с вопросами.
+// Строки не накапливаются в памяти; ошибка handle прерывает выгрузку
+func (r *PollRepository) StreamPollResults(ctx context.Context, filter *models.PollResultsFilter, handle func(row *models.PollExportRow) error) error {
+	funcName := "StreamPollResults"
+	query := `
+	SELECT cr.result_id, cq.question_id, cq.question_text, cq.type, cr.u_id, cr.rating, cr.comment, cr.created_at
+	FROM csat_results AS cr
+	JOIN csat_question AS cq ON cq.question_id=cr.question_id
+	WHERE ` + resultsPeriodCondition + `
+	ORDER BY cr.created_at, cr.result_id;
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.From, filter.To)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := &models.PollExportRow{}
+		var userID int64
+		err := rows.Scan(&row.ResultID, &row.QuestionID, &row.Question, &row.QuestionType,
+			&userID, &row.Rating, &row.Text, &row.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		row.UserID = &userID
+		if err := handle(row); err != nil {
+			return fmt.Errorf("%s (handle): %w", funcName, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetPollState возвращает данные для планировщика опросов: когда можно показать опрос,
+// сколько раз он уже показывался и насколько пользователь активен
+func (r *PollRepository) GetPollState(ctx context.Context, userID int64) (state *models.PollState, err error) {
+	funcName := "GetPollState"
+	query := `
+	SELECT u.joined_at, ps.next_poll_at, ps.snoozed_until, COALESCE(ps.prompt_count, 0),
+		(SELECT COUNT(*) FROM "card" AS c WHERE c.created_by=u.u_id)
+	FROM "user" AS u
+	LEFT JOIN csat_poll_state AS ps ON ps.u_id=u.u_id
+	WHERE u.u_id=$1;
+	`
+
+	state = &models.PollState{}
+	err = r.db.QueryRow(ctx, query, userID).Scan(
+		&state.JoinedAt,
+		&state.NextPollAt,
+		&state.SnoozedUntil,
+		&state.PromptCount,
+		&state.CardsCreated,
+	)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", funcName, errs.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return state, nil
+}
+
+// RegisterPollPrompt запоминает, что пользователю показан опрос, и откладывает следующий до nextPollAt
+func (r *PollRepository) RegisterPollPrompt(ctx context.Context, userID int64, nextPollAt time.Time) error {
+	funcName := "RegisterPollPrompt"
+	query := `
+	INSERT INTO csat_poll_state (u_id, prompt_count, last_prompted_at, next_poll_at)
+	VALUES ($1, 1, CURRENT_TIMESTAMP, $2)
+	ON CONFLICT (u_id) DO UPDATE
+	SET prompt_count=csat_poll_state.prompt_count+1,
+		last_prompted_at=CURRENT_TIMESTAMP,
+		next_poll_at=$2,
+		snoozed_until=NULL;
+	`
+
+	_, err := r.db.Exec(ctx, query, userID, nextPollAt)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+
+	return nil
+}
+
+// SnoozePoll откладывает опрос до snoozedUntil. Отложенный показ не учитывается в лимите показов,
+// а cool-down после него заменяется отсрочкой: иначе опрос вернулся бы только после полного cool-down.
+// Показ возвращается в лимит один раз: повторные отсрочки без нового показа счётчик не трогают
+func (r *PollRepository) SnoozePoll(ctx context.Context, userID int64, snoozedUntil time.Time) error {
+	funcName := "SnoozePoll"
+	query := `
+	INSERT INTO csat_poll_state (u_id, snoozed_until, last_snoozed_at, next_poll_at)
+	VALUES ($1, $2, CURRENT_TIMESTAMP, $2)
+	ON CONFLICT (u_id) DO UPDATE
+	SET snoozed_until=$2,
+		next_poll_at=$2,
+		prompt_count=CASE
+			WHEN csat_poll_state.last_prompted_at > COALESCE(csat_poll_state.last_snoozed_at, '-infinity')
+			THEN GREATEST(csat_poll_state.prompt_count-1, 0)
+			ELSE csat_poll_state.prompt_count
+		END,
+		last_snoozed_at=CURRENT_TIMESTAMP;
+	`
+
+	_, err := r.db.Exec(ctx, query, userID, snoozedUntil)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+
+	return nil
+}
+
+// DismissPoll запоминает отказ от опроса и не показывает его до nextPollAt
+func (r *PollRepository) DismissPoll(ctx context.Context, userID int64, nextPollAt time.Time) error {
+	funcName := "DismissPoll"
+	query := `
+	INSERT INTO csat_poll_state (u_id, dismiss_count, last_dismissed_at, next_poll_at)
+	VALUES ($1, 1, CURRENT_TIMESTAMP, $2)
+	ON CONFLICT (u_id) DO UPDATE
+	SET dismiss_count=csat_poll_state.dismiss_count+1,
+		last_dismissed_at=CURRENT_TIMESTAMP,
+		next_poll_at=$2,
+		snoozed_until=NULL;
+	`
+
+	_, err := r.db.Exec(ctx, query, userID, nextPollAt)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s (query): %w", funcName, err)
+	}
+
+	return nil
+}
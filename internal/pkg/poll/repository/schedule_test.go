@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnoozePollReplacesCooldown(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreatePollRepository(mock)
+
+	snoozedUntil := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`(?s)ON CONFLICT \(u_id\) DO UPDATE.*next_poll_at=\$2`).
+		WithArgs(int64(42), snoozedUntil).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.SnoozePoll(context.Background(), 42, snoozedUntil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSnoozePollRefundsPromptOnce(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreatePollRepository(mock)
+
+	// Счётчик уменьшается, только если после последней отсрочки был новый показ
+	snoozedUntil := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+	query := `(?s)prompt_count=CASE\s+WHEN csat_poll_state.last_prompted_at > COALESCE\(csat_poll_state.last_snoozed_at, '-infinity'\)\s+` +
+		`THEN GREATEST\(csat_poll_state.prompt_count-1, 0\)\s+ELSE csat_poll_state.prompt_count\s+END,\s+last_snoozed_at=CURRENT_TIMESTAMP`
+	mock.ExpectExec(query).
+		WithArgs(int64(42), snoozedUntil).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.SnoozePoll(context.Background(), 42, snoozedUntil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
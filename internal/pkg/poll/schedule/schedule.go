@@ -0,0 +1,72 @@
+package schedule
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/config"
+	"time"
+)
+
+// Policy - правила показа CSAT-опроса (см. config.PollConfig)
+type Policy struct {
+	Cooldown            time.Duration
+	SnoozePeriod        time.Duration
+	DismissCooldown     time.Duration
+	MaxPrompts          int
+	TriggerAccountAge   time.Duration
+	TriggerCardsCreated int
+}
+
+// CreatePolicy собирает правила показа опроса из конфига
+func CreatePolicy(cfg *config.PollConfig) Policy {
+	return Policy{
+		Cooldown:            cfg.Cooldown,
+		SnoozePeriod:        cfg.SnoozePeriod,
+		DismissCooldown:     cfg.DismissCooldown,
+		MaxPrompts:          cfg.MaxPrompts,
+		TriggerAccountAge:   cfg.TriggerAccountAge,
+		TriggerCardsCreated: cfg.TriggerCardsCreated,
+	}
+}
+
+// ShouldPrompt решает, показывать ли пользователю опрос сейчас
+func (p Policy) ShouldPrompt(state *models.PollState, now time.Time) bool {
+	if p.MaxPrompts > 0 && state.PromptCount >= p.MaxPrompts {
+		return false
+	}
+	if state.NextPollAt != nil && now.Before(*state.NextPollAt) {
+		return false
+	}
+	if state.SnoozedUntil != nil && now.Before(*state.SnoozedUntil) {
+		return false
+	}
+	return p.isTriggered(state, now)
+}
+
+// isTriggered проверяет, что сработал хотя бы один из включённых триггеров активности
+func (p Policy) isTriggered(state *models.PollState, now time.Time) bool {
+	if p.TriggerAccountAge == 0 && p.TriggerCardsCreated == 0 {
+		return true
+	}
+	if p.TriggerAccountAge > 0 && !now.Before(state.JoinedAt.Add(p.TriggerAccountAge)) {
+		return true
+	}
+	if p.TriggerCardsCreated > 0 && state.CardsCreated >= p.TriggerCardsCreated {
+		return true
+	}
+	return false
+}
+
+// NextPollAfterPrompt - когда можно показать опрос снова после показа в now
+func (p Policy) NextPollAfterPrompt(now time.Time) time.Time {
+	return now.Add(p.Cooldown)
+}
+
+// NextPollAfterDismiss - когда можно показать опрос снова, если пользователь от него отказался
+func (p Policy) NextPollAfterDismiss(now time.Time) time.Time {
+	return now.Add(p.DismissCooldown)
+}
+
+// SnoozedUntil - до какого времени не напоминать об опросе, отложенном в now
+func (p Policy) SnoozedUntil(now time.Time) time.Time {
+	return now.Add(p.SnoozePeriod)
+}
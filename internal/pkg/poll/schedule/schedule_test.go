@@ -0,0 +1,77 @@
+package schedule
+
+import (
+	"RPO_back/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldPrompt(t *testing.T) {
+	now := time.Date(2024, 12, 23, 12, 0, 0, 0, time.UTC)
+	policy := Policy{
+		Cooldown:            7 * 24 * time.Hour,
+		MaxPrompts:          3,
+		TriggerAccountAge:   7 * 24 * time.Hour,
+		TriggerCardsCreated: 10,
+	}
+	oldAccount := now.Add(-30 * 24 * time.Hour)
+	newAccount := now.Add(-2 * 24 * time.Hour)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		policy Policy
+		state  models.PollState
+		want   bool
+	}{
+		{"old account", policy, models.PollState{JoinedAt: oldAccount}, true},
+		{"new account without activity", policy, models.PollState{JoinedAt: newAccount, CardsCreated: 3}, false},
+		{"new account created enough cards", policy, models.PollState{JoinedAt: newAccount, CardsCreated: 10}, true},
+		{"account age trigger boundary", policy, models.PollState{JoinedAt: now.Add(-7 * 24 * time.Hour)}, true},
+		{"cool-down", policy, models.PollState{JoinedAt: oldAccount, NextPollAt: &later}, false},
+		{"cool-down is over", policy, models.PollState{JoinedAt: oldAccount, NextPollAt: &earlier}, true},
+		{"snoozed", policy, models.PollState{JoinedAt: oldAccount, SnoozedUntil: &later}, false},
+		{"snooze is over", policy, models.PollState{JoinedAt: oldAccount, SnoozedUntil: &earlier}, true},
+		{"max prompts reached", policy, models.PollState{JoinedAt: oldAccount, PromptCount: 3}, false},
+		{"no prompt limit", Policy{}, models.PollState{JoinedAt: newAccount, PromptCount: 100}, true},
+		{"cards trigger off", Policy{TriggerAccountAge: policy.TriggerAccountAge},
+			models.PollState{JoinedAt: newAccount, CardsCreated: 100}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.ShouldPrompt(&tt.state, now))
+		})
+	}
+}
+
+func TestNextPoll(t *testing.T) {
+	now := time.Date(2024, 12, 23, 12, 0, 0, 0, time.UTC)
+	policy := Policy{Cooldown: time.Hour, SnoozePeriod: 2 * time.Hour, DismissCooldown: 3 * time.Hour}
+
+	assert.Equal(t, now.Add(time.Hour), policy.NextPollAfterPrompt(now))
+	assert.Equal(t, now.Add(2*time.Hour), policy.SnoozedUntil(now))
+	assert.Equal(t, now.Add(3*time.Hour), policy.NextPollAfterDismiss(now))
+}
+
+// Опрос, отложенный сразу после показа, возвращается по окончании отсрочки, а не cool-down
+func TestSnoozeThenPrompt(t *testing.T) {
+	promptedAt := time.Date(2024, 12, 23, 12, 0, 0, 0, time.UTC)
+	policy := Policy{Cooldown: 7 * 24 * time.Hour, SnoozePeriod: 24 * time.Hour, MaxPrompts: 3}
+
+	// Состояние после показа (RegisterPollPrompt) и отсрочки (SnoozePoll): отложенный показ не считается,
+	// а cool-down заменяется отсрочкой
+	snoozedUntil := policy.SnoozedUntil(promptedAt)
+	state := models.PollState{
+		JoinedAt:     promptedAt.Add(-30 * 24 * time.Hour),
+		NextPollAt:   &snoozedUntil,
+		SnoozedUntil: &snoozedUntil,
+	}
+
+	assert.False(t, policy.ShouldPrompt(&state, promptedAt.Add(time.Hour)))
+	assert.True(t, policy.ShouldPrompt(&state, snoozedUntil))
+	assert.True(t, snoozedUntil.Before(policy.NextPollAfterPrompt(promptedAt)))
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	defaultRatingMin = 1
+	defaultRatingMax = 5
+)
+
+// checkSystemAdmin возвращает errs.ErrNotPermitted, если пользователь не администратор сервиса
+func (uc *PollUsecase) checkSystemAdmin(ctx context.Context, userID int64) error {
+	isAdmin, err := uc.pollRepo.IsSystemAdmin(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("checkSystemAdmin (IsSystemAdmin): %w", err)
+	}
+	if !isAdmin {
+		return fmt.Errorf("checkSystemAdmin: %w", errs.ErrNotPermitted)
+	}
+	return nil
+}
+
+// checkRatingScale проверяет, что шкала оценки задана правильно
+func checkRatingScale(ratingMin, ratingMax int) error {
+	if ratingMin >= ratingMax {
+		return fmt.Errorf("checkRatingScale: ratingMin must be less than ratingMax: %w", errs.ErrBadRequest)
+	}
+	return nil
+}
+
+// checkQuestionSettings проверяет настройки вопроса, зависящие от его типа:
+// у NPS шкала всегда от 0 до 10, а уточняющий вопрос есть только у NPS и должен быть
+// другим текстовым вопросом
+func (uc *PollUsecase) checkQuestionSettings(ctx context.Context, question *models.PollQuestionAdmin) error {
+	if err := checkRatingScale(question.RatingMin, question.RatingMax); err != nil {
+		return fmt.Errorf("checkQuestionSettings (checkRatingScale): %w", err)
+	}
+
+	if question.Type == models.PollQuestionNPS &&
+		(question.RatingMin != models.NPSRatingMin || question.RatingMax != models.NPSRatingMax) {
+		return fmt.Errorf("checkQuestionSettings: NPS scale must be from %d to %d: %w",
+			models.NPSRatingMin, models.NPSRatingMax, errs.ErrBadRequest)
+	}
+
+	if question.FollowUpQuestionID == nil {
+		return nil
+	}
+	if question.Type != models.PollQuestionNPS {
+		return fmt.Errorf("checkQuestionSettings: only NPS questions can have a follow-up: %w", errs.ErrBadRequest)
+	}
+	if *question.FollowUpQuestionID == question.ID {
+		return fmt.Errorf("checkQuestionSettings: question can't be its own follow-up: %w", errs.ErrBadRequest)
+	}
+
+	followUp, err := uc.pollRepo.GetPollQuestion(ctx, *question.FollowUpQuestionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("checkQuestionSettings (GetPollQuestion): follow-up not found: %w", errs.ErrBadRequest)
+		}
+		return fmt.Errorf("checkQuestionSettings (GetPollQuestion): %w", err)
+	}
+	if followUp.Type != models.PollQuestionText {
+		return fmt.Errorf("checkQuestionSettings: follow-up must be a text question: %w", errs.ErrBadRequest)
+	}
+
+	return nil
+}
+
+// checkPollAnswer проверяет, что ответ подходит к вопросу: вопрос активен,
+// тип ответа совпадает, а оценка попадает в шкалу вопроса
+func checkPollAnswer(question *models.PollQuestionAdmin, answer *models.PollSubmit) error {
+	if !question.IsActive {
+		return fmt.Errorf("checkPollAnswer: question is not active: %w", errs.ErrBadRequest)
+	}
+	if answer.QuestionType != question.Type {
+		return fmt.Errorf("checkPollAnswer: question type mismatch: %w", errs.ErrBadRequest)
+	}
+
+	switch question.Type {
+	case models.PollQuestionRating, models.PollQuestionNPS:
+		if answer.Rating == nil || *answer.Rating < question.RatingMin || *answer.Rating > question.RatingMax {
+			return fmt.Errorf("checkPollAnswer: rating is out of scale: %w", errs.ErrBadRequest)
+		}
+	case models.PollQuestionText:
+		if answer.Text == nil {
+			return fmt.Errorf("checkPollAnswer: text is missing: %w", errs.ErrBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// GetPollQuestions возвращает администратору все вопросы опроса
+func (uc *PollUsecase) GetPollQuestions(ctx context.Context, userID int64) (questions []models.PollQuestionAdmin, err error) {
+	if err := uc.checkSystemAdmin(ctx, userID); err != nil {
+		return nil, fmt.Errorf("GetPollQuestions (checkSystemAdmin): %w", err)
+	}
+
+	questions, err = uc.pollRepo.GetPollQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetPollQuestions (GetPollQuestions): %w", err)
+	}
+
+	return questions, nil
+}
+
+// CreatePollQuestion добавляет новый вопрос в конец опроса
+func (uc *PollUsecase) CreatePollQuestion(ctx context.Context, userID int64, data *models.PollQuestionPostRequest) (newQuestion *models.PollQuestionAdmin, err error) {
+	if err := uc.checkSystemAdmin(ctx, userID); err != nil {
+		return nil, fmt.Errorf("CreatePollQuestion (checkSystemAdmin): %w", err)
+	}
+
+	question := &models.PollQuestionAdmin{
+		Text:               data.Text,
+		Type:               data.Type,
+		IsActive:           true,
+		RatingMin:          defaultRatingMin,
+		RatingMax:          defaultRatingMax,
+		Audience:           data.Audience,
+		FollowUpQuestionID: data.FollowUpQuestionID,
+	}
+	if data.Type == models.PollQuestionNPS {
+		question.RatingMin, question.RatingMax = models.NPSRatingMin, models.NPSRatingMax
+	}
+	if data.RatingMin != nil {
+		question.RatingMin = *data.RatingMin
+	}
+	if data.RatingMax != nil {
+		question.RatingMax = *data.RatingMax
+	}
+	if data.IsActive != nil {
+		question.IsActive = *data.IsActive
+	}
+	if err := uc.checkQuestionSettings(ctx, question); err != nil {
+		return nil, fmt.Errorf("CreatePollQuestion (checkQuestionSettings): %w", err)
+	}
+
+	newQuestion, err = uc.pollRepo.CreatePollQuestion(ctx, question)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePollQuestion (CreatePollQuestion): %w", err)
+	}
+
+	return newQuestion, nil
+}
+
+// UpdatePollQuestion меняет текст, шкалу, аудиторию и уточняющий вопрос
+func (uc *PollUsecase) UpdatePollQuestion(ctx context.Context, userID int64, questionID int64, data *models.PollQuestionPutRequest) (updatedQuestion *models.PollQuestionAdmin, err error) {
+	if err := uc.checkSystemAdmin(ctx, userID); err != nil {
+		return nil, fmt.Errorf("UpdatePollQuestion (checkSystemAdmin): %w", err)
+	}
+
+	question, err := uc.pollRepo.GetPollQuestion(ctx, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("UpdatePollQuestion (GetPollQuestion): %w", err)
+	}
+	question.RatingMin, question.RatingMax = data.RatingMin, data.RatingMax
+	question.FollowUpQuestionID = data.FollowUpQuestionID
+	if err := uc.checkQuestionSettings(ctx, question); err != nil {
+		return nil, fmt.Errorf("UpdatePollQuestion (checkQuestionSettings): %w", err)
+	}
+
+	updatedQuestion, err = uc.pollRepo.UpdatePollQuestion(ctx, questionID, data)
+	if err != nil {
+		return nil, fmt.Errorf("UpdatePollQuestion (UpdatePollQuestion): %w", err)
+	}
+
+	return updatedQuestion, nil
+}
+
+// SetPollQuestionActive включает или выключает показ вопроса пользователям
+func (uc *PollUsecase) SetPollQuestionActive(ctx context.Context, userID int64, questionID int64, isActive bool) error {
+	if err := uc.checkSystemAdmin(ctx, userID); err != nil {
+		return fmt.Errorf("SetPollQuestionActive (checkSystemAdmin): %w", err)
+	}
+
+	if err := uc.pollRepo.SetPollQuestionActive(ctx, questionID, isActive); err != nil {
+		return fmt.Errorf("SetPollQuestionActive (SetPollQuestionActive): %w", err)
+	}
+
+	return nil
+}
+
+// ReorderPollQuestions задаёт новый порядок показа вопросов
+func (uc *PollUsecase) ReorderPollQuestions(ctx context.Context, userID int64, questionIDs []int64) error {
+	if err := uc.checkSystemAdmin(ctx, userID); err != nil {
+		return fmt.Errorf("ReorderPollQuestions (checkSystemAdmin): %w", err)
+	}
+
+	if err := uc.pollRepo.ReorderPollQuestions(ctx, questionIDs); err != nil {
+		return fmt.Errorf("ReorderPollQuestions (ReorderPollQuestions): %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPollAnswer(t *testing.T) {
+	ratingQuestion := &models.PollQuestionAdmin{Type: "answer_rating", IsActive: true, RatingMin: 0, RatingMax: 10}
+	textQuestion := &models.PollQuestionAdmin{Type: "answer_text", IsActive: true, RatingMin: 1, RatingMax: 5}
+	inactiveQuestion := &models.PollQuestionAdmin{Type: "answer_rating", IsActive: false, RatingMin: 1, RatingMax: 5}
+	npsQuestion := &models.PollQuestionAdmin{Type: "answer_nps", IsActive: true, RatingMin: 0, RatingMax: 10}
+
+	intPtr := func(v int) *int { return &v }
+	text := "всё отлично"
+
+	tests := []struct {
+		name     string
+		question *models.PollQuestionAdmin
+		answer   *models.PollSubmit
+		wantErr  bool
+	}{
+		{"rating in scale", ratingQuestion, &models.PollSubmit{QuestionType: "answer_rating", Rating: intPtr(0)}, false},
+		{"rating upper bound", ratingQuestion, &models.PollSubmit{QuestionType: "answer_rating", Rating: intPtr(10)}, false},
+		{"rating above scale", ratingQuestion, &models.PollSubmit{QuestionType: "answer_rating", Rating: intPtr(11)}, true},
+		{"rating missing", ratingQuestion, &models.PollSubmit{QuestionType: "answer_rating"}, true},
+		{"type mismatch", ratingQuestion, &models.PollSubmit{QuestionType: "answer_text", Text: &text}, true},
+		{"text answer", textQuestion, &models.PollSubmit{QuestionType: "answer_text", Text: &text}, false},
+		{"text missing", textQuestion, &models.PollSubmit{QuestionType: "answer_text"}, true},
+		{"nps in scale", npsQuestion, &models.PollSubmit{QuestionType: "answer_nps", Rating: intPtr(0)}, false},
+		{"nps above scale", npsQuestion, &models.PollSubmit{QuestionType: "answer_nps", Rating: intPtr(11)}, true},
+		{"inactive question", inactiveQuestion, &models.PollSubmit{QuestionType: "answer_rating", Rating: intPtr(3)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPollAnswer(tt.question, tt.answer)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errs.ErrBadRequest)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckRatingScale(t *testing.T) {
+	assert.NoError(t, checkRatingScale(1, 5))
+	assert.NoError(t, checkRatingScale(0, 10))
+	assert.ErrorIs(t, checkRatingScale(5, 5), errs.ErrBadRequest)
+	assert.ErrorIs(t, checkRatingScale(7, 3), errs.ErrBadRequest)
+}
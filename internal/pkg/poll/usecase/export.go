@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Длина соли, которая генерируется для выгрузки, если соль не задана в конфиге
+const exportSaltLength = 32
+
+// ExportPollResults передаёт администратору в handle все ответы за период. В обезличенном
+// режиме u_id заменяется солёным хешем, а короткие текстовые ответы отбрасываются
+func (uc *PollUsecase) ExportPollResults(ctx context.Context, userID int64, filter *models.PollResultsFilter, anonymized bool, handle func(row *models.PollExportRow) error) error {
+	if err := uc.checkSystemAdmin(ctx, userID); err != nil {
+		return fmt.Errorf("ExportPollResults (checkSystemAdmin): %w", err)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return fmt.Errorf("ExportPollResults: from must be before to: %w", errs.ErrBadRequest)
+	}
+
+	if !anonymized {
+		if err := uc.pollRepo.StreamPollResults(ctx, filter, handle); err != nil {
+			return fmt.Errorf("ExportPollResults (StreamPollResults): %w", err)
+		}
+		return nil
+	}
+
+	anonymizer, err := newExportAnonymizer(uc.exportSalt, uc.exportMinTextLength)
+	if err != nil {
+		return fmt.Errorf("ExportPollResults (newExportAnonymizer): %w", err)
+	}
+	err = uc.pollRepo.StreamPollResults(ctx, filter, func(row *models.PollExportRow) error {
+		if !anonymizer.anonymize(row) {
+			return nil
+		}
+		return handle(row)
+	})
+	if err != nil {
+		return fmt.Errorf("ExportPollResults (StreamPollResults): %w", err)
+	}
+
+	return nil
+}
+
+// exportAnonymizer обезличивает строки выгрузки
+type exportAnonymizer struct {
+	salt          []byte
+	minTextLength int
+}
+
+// newExportAnonymizer создаёт обезличиватель. Без соли генерируется случайная,
+// и хеши одного пользователя в разных выгрузках не совпадают
+func newExportAnonymizer(salt string, minTextLength int) (*exportAnonymizer, error) {
+	anonymizer := &exportAnonymizer{
+		salt:          []byte(salt),
+		minTextLength: minTextLength,
+	}
+	if salt == "" {
+		anonymizer.salt = make([]byte, exportSaltLength)
+		if _, err := rand.Read(anonymizer.salt); err != nil {
+			return nil, fmt.Errorf("newExportAnonymizer: %w", err)
+		}
+	}
+	return anonymizer, nil
+}
+
+// anonymize заменяет u_id хешем и убирает короткий текст ответа.
+// Возвращает false, если после этого от ответа ничего не осталось
+func (a *exportAnonymizer) anonymize(row *models.PollExportRow) (keep bool) {
+	if row.UserID != nil {
+		mac := hmac.New(sha256.New, a.salt)
+		mac.Write([]byte(strconv.FormatInt(*row.UserID, 10)))
+		row.UserHash = hex.EncodeToString(mac.Sum(nil)[:16])
+		row.UserID = nil
+	}
+
+	if row.Text != nil && utf8.RuneCountInString(strings.TrimSpace(*row.Text)) < a.minTextLength {
+		row.Text = nil
+	}
+
+	return row.Rating != nil || row.Text != nil
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"RPO_back/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAnonymizer(t *testing.T) {
+	anonymizer, err := newExportAnonymizer("salt", 10)
+	require.NoError(t, err)
+
+	userID := int64(42)
+	rating := 4
+	longText := "очень удобные доски"
+	shortText := "  ок  "
+
+	ratingRow := &models.PollExportRow{UserID: &userID, Rating: &rating}
+	assert.True(t, anonymizer.anonymize(ratingRow))
+	assert.Nil(t, ratingRow.UserID)
+	assert.Len(t, ratingRow.UserHash, 32)
+
+	sameUserRow := &models.PollExportRow{UserID: &userID, Text: &longText}
+	assert.True(t, anonymizer.anonymize(sameUserRow))
+	assert.Equal(t, ratingRow.UserHash, sameUserRow.UserHash)
+	assert.Equal(t, &longText, sameUserRow.Text)
+
+	otherUserID := int64(43)
+	shortTextRow := &models.PollExportRow{UserID: &otherUserID, Text: &shortText}
+	assert.False(t, anonymizer.anonymize(shortTextRow))
+	assert.NotEqual(t, ratingRow.UserHash, shortTextRow.UserHash)
+
+	otherSalt, err := newExportAnonymizer("other salt", 10)
+	require.NoError(t, err)
+	otherSaltRow := &models.PollExportRow{UserID: &userID, Rating: &rating}
+	otherSalt.anonymize(otherSaltRow)
+	assert.NotEqual(t, ratingRow.UserHash, otherSaltRow.UserHash)
+}
+
+func TestExportAnonymizer_RandomSalt(t *testing.T) {
+	first, err := newExportAnonymizer("", 0)
+	require.NoError(t, err)
+	second, err := newExportAnonymizer("", 0)
+	require.NoError(t, err)
+
+	userID := int64(42)
+	firstRow := &models.PollExportRow{UserID: &userID}
+	secondRow := &models.PollExportRow{UserID: &userID}
+	first.anonymize(firstRow)
+	second.anonymize(secondRow)
+
+	assert.NotEqual(t, firstRow.UserHash, secondRow.UserHash)
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"context"
+	"fmt"
+	"math"
+)
+
+// GetPollResults возвращает результаты опроса за период: по вопросам с оценкой -
+// среднее, число ответов, гистограмму и тренд по неделям или месяцам, по NPS-вопросам - NPS
+func (uc *PollUsecase) GetPollResults(ctx context.Context, filter *models.PollResultsFilter) (pollResults *models.PollResults, err error) {
+	if filter.Period == "" {
+		filter.Period = models.PollTrendWeek
+	}
+	if filter.Period != models.PollTrendWeek && filter.Period != models.PollTrendMonth {
+		return nil, fmt.Errorf("GetPollResults: unknown trend period %q: %w", filter.Period, errs.ErrBadRequest)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("GetPollResults: from must be before to: %w", errs.ErrBadRequest)
+	}
+
+	pollRating, err := uc.pollRepo.GetRatingResults(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("GetPollResults (GetRatingResults): %w", err)
+	}
+
+	histograms, err := uc.pollRepo.GetRatingHistograms(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("GetPollResults (GetRatingHistograms): %w", err)
+	}
+
+	trends, err := uc.pollRepo.GetRatingTrends(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("GetPollResults (GetRatingTrends): %w", err)
+	}
+
+	for i := range pollRating {
+		result := &pollRating[i]
+		result.Histogram = fillRatingHistogram(result.RatingMin, result.RatingMax, histograms[result.QuestionID])
+		result.Trend = trends[result.QuestionID]
+		if result.Trend == nil {
+			result.Trend = make([]models.RatingTrendBucket, 0)
+		}
+	}
+
+	npsResults, err := uc.pollRepo.GetNPSResults(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("GetPollResults (GetNPSResults): %w", err)
+	}
+
+	npsTrends, err := uc.pollRepo.GetNPSTrends(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("GetPollResults (GetNPSTrends): %w", err)
+	}
+
+	for i := range npsResults {
+		result := &npsResults[i]
+		result.Score = npsScore(result.Promoters, result.Detractors, result.Count)
+		result.Histogram = fillRatingHistogram(models.NPSRatingMin, models.NPSRatingMax, histograms[result.QuestionID])
+		result.Trend = npsTrends[result.QuestionID]
+		if result.Trend == nil {
+			result.Trend = make([]models.NPSTrendBucket, 0)
+		}
+		for j := range result.Trend {
+			bucket := &result.Trend[j]
+			bucket.Score = npsScore(bucket.Promoters, bucket.Detractors, bucket.Count)
+		}
+	}
+
+	pollText, err := uc.pollRepo.GetTextResults(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("GetPollResults (GetTextResults): %w", err)
+	}
+
+	pollResults = &models.PollResults{
+		From:          filter.From,
+		To:            filter.To,
+		Period:        filter.Period,
+		RatingResults: pollRating,
+		NPSResults:    npsResults,
+		TextResults:   pollText,
+	}
+
+	return pollResults, nil
+}
+
+// fillRatingHistogram дополняет гистограмму нулями до всей шкалы вопроса.
+// Оценки вне текущей шкалы (если шкалу меняли) сохраняются в конце по возрастанию
+func fillRatingHistogram(ratingMin, ratingMax int, counted []models.RatingBucket) (histogram []models.RatingBucket) {
+	histogram = make([]models.RatingBucket, 0, ratingMax-ratingMin+1)
+	for rating := ratingMin; rating <= ratingMax; rating++ {
+		histogram = append(histogram, models.RatingBucket{Rating: rating})
+	}
+
+	for _, bucket := range counted {
+		if bucket.Rating >= ratingMin && bucket.Rating <= ratingMax {
+			histogram[bucket.Rating-ratingMin].Count = bucket.Count
+			continue
+		}
+		histogram = append(histogram, bucket)
+	}
+
+	return histogram
+}
+
+// classifyNPS относит оценку NPS к промоутерам, нейтралам или критикам
+func classifyNPS(rating int) string {
+	switch {
+	case rating >= models.NPSPromoterMin:
+		return models.NPSPromoter
+	case rating <= models.NPSDetractorMax:
+		return models.NPSDetractor
+	default:
+		return models.NPSPassive
+	}
+}
+
+// npsScore считает NPS: процент промоутеров минус процент критиков, с точностью до 0.1
+func npsScore(promoters, detractors, count int64) float64 {
+	if count == 0 {
+		return 0
+	}
+	score := float64(promoters-detractors) * 100 / float64(count)
+	return math.Round(score*10) / 10
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"RPO_back/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillRatingHistogram(t *testing.T) {
+	tests := []struct {
+		name      string
+		ratingMin int
+		ratingMax int
+		counted   []models.RatingBucket
+		want      []models.RatingBucket
+	}{
+		{
+			name:      "no answers",
+			ratingMin: 1,
+			ratingMax: 3,
+			counted:   nil,
+			want:      []models.RatingBucket{{Rating: 1}, {Rating: 2}, {Rating: 3}},
+		},
+		{
+			name:      "gaps are filled with zeros",
+			ratingMin: 1,
+			ratingMax: 5,
+			counted:   []models.RatingBucket{{Rating: 2, Count: 4}, {Rating: 5, Count: 1}},
+			want: []models.RatingBucket{
+				{Rating: 1}, {Rating: 2, Count: 4}, {Rating: 3}, {Rating: 4}, {Rating: 5, Count: 1},
+			},
+		},
+		{
+			name:      "ratings outside of scale are kept",
+			ratingMin: 1,
+			ratingMax: 3,
+			counted:   []models.RatingBucket{{Rating: 0, Count: 2}, {Rating: 3, Count: 1}, {Rating: 5, Count: 7}},
+			want: []models.RatingBucket{
+				{Rating: 1}, {Rating: 2}, {Rating: 3, Count: 1}, {Rating: 0, Count: 2}, {Rating: 5, Count: 7},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fillRatingHistogram(tt.ratingMin, tt.ratingMax, tt.counted))
+		})
+	}
+}
+
+func TestClassifyNPS(t *testing.T) {
+	want := map[int]string{
+		0: models.NPSDetractor, 6: models.NPSDetractor,
+		7: models.NPSPassive, 8: models.NPSPassive,
+		9: models.NPSPromoter, 10: models.NPSPromoter,
+	}
+	for rating, group := range want {
+		assert.Equal(t, group, classifyNPS(rating), "rating %d", rating)
+	}
+}
+
+func TestNPSScore(t *testing.T) {
+	assert.Equal(t, 0.0, npsScore(0, 0, 0))
+	assert.Equal(t, 100.0, npsScore(4, 0, 4))
+	assert.Equal(t, -100.0, npsScore(0, 3, 3))
+	assert.Equal(t, 20.0, npsScore(5, 3, 10))
+	assert.Equal(t, 33.3, npsScore(1, 0, 3))
+}
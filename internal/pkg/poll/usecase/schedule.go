@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"RPO_back/internal/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+// SnoozePoll откладывает опрос ("напомнить позже")
+func (uc *PollUsecase) SnoozePoll(ctx context.Context, userID int64) error {
+	err := uc.pollRepo.SnoozePoll(ctx, userID, uc.pollPolicy.SnoozedUntil(time.Now()))
+	if err != nil {
+		return fmt.Errorf("SnoozePoll: %w", err)
+	}
+
+	return nil
+}
+
+// DismissPoll закрывает опрос ("не сейчас") и не показывает его дольше обычного
+func (uc *PollUsecase) DismissPoll(ctx context.Context, userID int64) error {
+	err := uc.pollRepo.DismissPoll(ctx, userID, uc.pollPolicy.NextPollAfterDismiss(time.Now()))
+	if err != nil {
+		return fmt.Errorf("DismissPoll: %w", err)
+	}
+
+	return nil
+}
+
+// PickPollQuestions возвращает вопросы опроса, если планировщик решил, что пользователю пора его показать.
+// Показ учитывается, только если нашлись подходящие пользователю вопросы
+func (uc *PollUsecase) PickPollQuestions(ctx context.Context, userID int64) (pollQuestions []models.PollQuestion, err error) {
+	state, err := uc.pollRepo.GetPollState(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("PickPollQuestions (GetPollState): %w", err)
+	}
+
+	now := time.Now()
+	if !uc.pollPolicy.ShouldPrompt(state, now) {
+		return nil, nil
+	}
+
+	pollQuestions, err = uc.pollRepo.PickPollQuestions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("PickPollQuestions (PickPollQuestions): %w", err)
+	}
+	if len(pollQuestions) == 0 {
+		return nil, nil
+	}
+
+	err = uc.pollRepo.RegisterPollPrompt(ctx, userID, uc.pollPolicy.NextPollAfterPrompt(now))
+	if err != nil {
+		return nil, fmt.Errorf("PickPollQuestions (RegisterPollPrompt): %w", err)
+	}
+
+	return pollQuestions, nil
+}
@@ -1,49 +1,86 @@
 package usecase
 
 import (
+	"RPO_back/internal/errs"
 	"RPO_back/internal/models"
 	authGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
 	"RPO_back/internal/pkg/poll"
+	"RPO_back/internal/pkg/poll/schedule"
 	"context"
+	"errors"
 	"fmt"
+	"time"
 )
 
+// Сколько времени после оценки критика можно ответить на уточняющий вопрос
+const followUpAnswerWindow = 24 * time.Hour
+
 type PollUsecase struct {
 	authClient authGRPC.AuthClient
 	pollRepo   poll.PollRepo
+	pollPolicy schedule.Policy
+
+	exportSalt          string // Соль для хеша u_id в обезличенной выгрузке
+	exportMinTextLength int
 }
 
-func CreatePollUsecase(pollRepo poll.PollRepo, authClient authGRPC.AuthClient) *PollUsecase {
+func CreatePollUsecase(pollRepo poll.PollRepo, authClient authGRPC.AuthClient, pollPolicy schedule.Policy,
+	exportSalt string, exportMinTextLength int) *PollUsecase {
 	return &PollUsecase{
-		authClient: authClient,
-		pollRepo:   pollRepo,
+		authClient:          authClient,
+		pollRepo:            pollRepo,
+		pollPolicy:          pollPolicy,
+		exportSalt:          exportSalt,
+		exportMinTextLength: exportMinTextLength,
 	}
 }
 
-func (uc *PollUsecase) SubmitPoll(ctx context.Context, userID int64, pollSubmit *models.PollSubmit) error {
-	err := uc.pollRepo.SubmitPoll(ctx, userID, pollSubmit)
+// SubmitPoll сохраняет ответ на вопрос опроса. Если критик ответил на NPS-вопрос
+// с уточняющим вопросом, уточняющий вопрос возвращается в ответе
+func (uc *PollUsecase) SubmitPoll(ctx context.Context, userID int64, pollSubmit *models.PollSubmit) (response *models.PollSubmitResponse, err error) {
+	question, err := uc.pollRepo.GetPollQuestion(ctx, pollSubmit.QuestionID)
 	if err != nil {
-		return fmt.Errorf("SubmitPoll: %w", err)
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, fmt.Errorf("SubmitPoll (GetPollQuestion): %w", errs.ErrBadRequest)
+		}
+		return nil, fmt.Errorf("SubmitPoll (GetPollQuestion): %w", err)
+	}
+	if err := checkPollAnswer(question, pollSubmit); err != nil {
+		return nil, fmt.Errorf("SubmitPoll (checkPollAnswer): %w", err)
 	}
 
-	return nil
-}
-
-func (uc *PollUsecase) GetPollResults(ctx context.Context) (pollResults *models.PollResults, err error) {
-	pollRating, err := uc.pollRepo.GetRatingResults(ctx)
+	isFollowUp, hasDetractorAnswer, err := uc.pollRepo.GetFollowUpAccess(ctx, userID, question.ID, followUpAnswerWindow)
 	if err != nil {
-		return nil, fmt.Errorf("GetPollResults (GetRatingResults): %w", err)
+		return nil, fmt.Errorf("SubmitPoll (GetFollowUpAccess): %w", err)
+	}
+	if isFollowUp && !hasDetractorAnswer {
+		return nil, fmt.Errorf("SubmitPoll: follow-up question is only for detractors: %w", errs.ErrNotPermitted)
 	}
 
-	pollText, err := uc.pollRepo.GetTextResults(ctx)
+	err = uc.pollRepo.SubmitPoll(ctx, userID, pollSubmit)
 	if err != nil {
-		return nil, fmt.Errorf("GetPollResults (GetTextResults): %w", err)
+		return nil, fmt.Errorf("SubmitPoll: %w", err)
+	}
+
+	response = &models.PollSubmitResponse{}
+	if question.Type != models.PollQuestionNPS || question.FollowUpQuestionID == nil ||
+		classifyNPS(*pollSubmit.Rating) != models.NPSDetractor {
+		return response, nil
 	}
 
-	pollResults = &models.PollResults{
-		RatingResults: pollRating,
-		TextResults:   pollText,
+	followUp, err := uc.pollRepo.GetPollQuestion(ctx, *question.FollowUpQuestionID)
+	if err != nil {
+		return nil, fmt.Errorf("SubmitPoll (GetPollQuestion follow-up): %w", err)
+	}
+	if followUp.IsActive {
+		response.FollowUpQuestion = &models.PollQuestion{
+			QuestionID:   followUp.ID,
+			QuestionText: followUp.Text,
+			QuestionType: followUp.Type,
+			RatingMin:    followUp.RatingMin,
+			RatingMax:    followUp.RatingMax,
+		}
 	}
 
-	return pollResults, nil
+	return response, nil
 }
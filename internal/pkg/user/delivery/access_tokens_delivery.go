@@ -0,0 +1,74 @@
+package delivery
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"net/http"
+)
+
+// ListAccessTokens возвращает персональные токены доступа пользователя
+func (d *UserDelivery) ListAccessTokens(w http.ResponseWriter, r *http.Request) {
+	funcName := "ListAccessTokens"
+	sessionID, ok := getSessionIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	tokens, err := d.userUC.ListAccessTokens(r.Context(), sessionID)
+	if err != nil {
+		doAuthErrorResponse(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, tokens, http.StatusOK)
+}
+
+// CreateAccessToken создаёт персональный токен доступа. Сам токен есть только в этом ответе.
+// Управлять токенами можно только из сессии (cookie), но не по другому токену
+func (d *UserDelivery) CreateAccessToken(w http.ResponseWriter, r *http.Request) {
+	funcName := "CreateAccessToken"
+	sessionID, ok := getSessionIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+	data := models.AccessTokenCreateRequest{}
+	err := requests.GetRequestData(r, &data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		logging.Warn(r.Context(), funcName, " (getting data): ", err)
+		return
+	}
+
+	token, err := d.userUC.CreateAccessToken(r.Context(), sessionID, &data)
+	if err != nil {
+		doAuthErrorResponse(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, token, http.StatusCreated)
+}
+
+// RevokeAccessToken отзывает персональный токен доступа
+func (d *UserDelivery) RevokeAccessToken(w http.ResponseWriter, r *http.Request) {
+	funcName := "RevokeAccessToken"
+	sessionID, ok := getSessionIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+	tokenID, err := requests.GetIDFromRequest(r, "tokenID", "token_")
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		logging.Warn(r.Context(), funcName, " (getting id): ", err)
+		return
+	}
+
+	err = d.userUC.RevokeAccessToken(r.Context(), sessionID, tokenID)
+	if err != nil {
+		doAuthErrorResponse(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
@@ -0,0 +1,143 @@
+package delivery
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/auth"
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"RPO_back/internal/pkg/utils/storage"
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ExportMyData отдаёт персональные данные пользователя zip-архивом
+// (только по сессии браузера, не по токену доступа)
+func (d *UserDelivery) ExportMyData(w http.ResponseWriter, r *http.Request) {
+	funcName := "ExportMyData"
+	if _, ok := getSessionIDOrFail(w, r, funcName); !ok {
+		return
+	}
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	export, err := d.userUC.ExportMyData(r.Context(), userID)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"pumpkin-export-%d.zip\"", userID))
+	w.WriteHeader(http.StatusOK)
+
+	// Заголовки уже отправлены, так что ошибку можно только залогировать
+	if err := writeDataExport(r.Context(), w, d.fileStorage, export); err != nil {
+		log.Error(funcName, " (writeDataExport): ", err)
+	}
+}
+
+// DeleteMyAccount удаляет аккаунт пользователя (нужен пароль или, без пароля, недавний вход) и сбрасывает cookie сессии
+func (d *UserDelivery) DeleteMyAccount(w http.ResponseWriter, r *http.Request) {
+	funcName := "DeleteMyAccount"
+	sessionID, ok := getSessionIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	var request models.AccountDeleteRequest
+	if err := requests.GetRequestData(r, &request); err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "Invalid request")
+		log.Warn(funcName, " (getting data): ", err)
+		return
+	}
+
+	result, err := d.userUC.DeleteMyAccount(r.Context(), sessionID, request.Password)
+	if err != nil {
+		doAuthErrorResponse(w, err, funcName)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookieName,
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
+	responses.DoJSONResponse(w, result, http.StatusOK)
+}
+
+// writeDataExport пишет архив: файлы вложений в attachments/ и JSON-файлы с данными.
+// Вложение, которое не удалось прочитать, попадает в attachments.json без archivePath
+func writeDataExport(ctx context.Context, w io.Writer, fileStorage storage.FileStorage, export *models.UserDataExport) error {
+	archive := zip.NewWriter(w)
+
+	for i := range export.Attachments {
+		attachment := &export.Attachments[i]
+		archivePath := fmt.Sprintf("attachments/%d_%s", attachment.ID, archiveFileName(attachment.OriginalName))
+		err := copyToArchive(ctx, archive, fileStorage, archivePath, attachment.StoredName)
+		if err != nil {
+			log.Warn("writeDataExport (copyToArchive): ", err)
+			continue
+		}
+		attachment.ArchivePath = archivePath
+	}
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", export.Profile},
+		{"boards.json", export.Boards},
+		{"comments.json", export.Comments},
+		{"attachments.json", export.Attachments},
+		{"time_entries.json", export.TimeEntries},
+	}
+	for _, file := range files {
+		fileWriter, err := archive.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("writeDataExport (Create %s): %w", file.name, err)
+		}
+		encoder := json.NewEncoder(fileWriter)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return fmt.Errorf("writeDataExport (Encode %s): %w", file.name, err)
+		}
+	}
+
+	return archive.Close()
+}
+
+// copyToArchive копирует загруженный файл в архив
+func copyToArchive(ctx context.Context, archive *zip.Writer, fileStorage storage.FileStorage, archivePath string, storedName string) error {
+	file, err := fileStorage.Get(ctx, storedName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fileWriter, err := archive.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fileWriter, file)
+	return err
+}
+
+// archiveFileName убирает из имени вложения всё, что может сделать из него путь
+func archiveFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" || name == ".." {
+		return "file"
+	}
+	return name
+}
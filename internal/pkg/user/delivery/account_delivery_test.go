@@ -0,0 +1,77 @@
+package delivery
+
+import (
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/storage"
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "report.pdf", expected: "report.pdf"},
+		{name: "../../etc/passwd", expected: "passwd"},
+		{name: "C:\\Users\\me\\photo.jpg", expected: "photo.jpg"},
+		{name: "/absolute/notes.txt", expected: "notes.txt"},
+		{name: "..", expected: "file"},
+		{name: "", expected: "file"},
+		{name: "/", expected: "file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, archiveFileName(tt.name))
+		})
+	}
+}
+
+func TestWriteDataExport(t *testing.T) {
+	ctx := context.Background()
+	fileStorage := storage.CreateLocalStorage(t.TempDir())
+	require.NoError(t, fileStorage.Put(ctx, "stored.pdf", strings.NewReader("pdf content"), 11, "application/pdf"))
+
+	export := &models.UserDataExport{
+		Profile: &models.UserProfile{ID: 42, Name: "alice"},
+		Attachments: []models.AttachmentExport{
+			{ID: 1, OriginalName: "../report.pdf", StoredName: "stored.pdf"},
+			{ID: 2, OriginalName: "lost.png", StoredName: "missing.png"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeDataExport(ctx, &buf, fileStorage, export))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		files[file.Name], err = io.ReadAll(reader)
+		reader.Close()
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, files, 6)
+	assert.Equal(t, "pdf content", string(files["attachments/1_report.pdf"]))
+	for _, name := range []string{"profile.json", "boards.json", "comments.json", "time_entries.json"} {
+		assert.Contains(t, files, name)
+	}
+
+	var attachments []models.AttachmentExport
+	require.NoError(t, json.Unmarshal(files["attachments.json"], &attachments))
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "attachments/1_report.pdf", attachments[0].ArchivePath)
+	assert.Empty(t, attachments[1].ArchivePath, "unreadable attachment has no archive path")
+}
@@ -4,7 +4,7 @@ import (
 	"RPO_back/internal/errs"
 	"RPO_back/internal/models"
 	"RPO_back/internal/pkg/auth"
-	mocks "RPO_back/internal/pkg/auth/mocks"
+	mock_user "RPO_back/internal/pkg/user/mocks"
 	"bytes"
 	"encoding/json"
 	"errors"
@@ -20,8 +20,8 @@ func TestLoginUser_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
-	authDelivery := AuthDelivery{authUsecase: mockAuthUsecase}
+	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
+	userDelivery := UserDelivery{userUC: mockUserUC}
 
 	loginRequest := models.LoginRequest{
 		Email:    "user@example.com",
@@ -30,12 +30,12 @@ func TestLoginUser_Success(t *testing.T) {
 	sessionID := "session123"
 	requestBody, _ := json.Marshal(loginRequest)
 
-	mockAuthUsecase.EXPECT().LoginUser(gomock.Any(), loginRequest.Email, loginRequest.Password).Return(sessionID, nil)
+	mockUserUC.EXPECT().LoginUser(gomock.Any(), loginRequest.Email, loginRequest.Password, gomock.Any(), gomock.Any()).Return(sessionID, "", nil)
 
 	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewReader(requestBody))
 	w := httptest.NewRecorder()
 
-	authDelivery.LoginUser(w, req)
+	userDelivery.LoginUser(w, req)
 
 	res := w.Result()
 	defer res.Body.Close()
@@ -52,12 +52,12 @@ func TestLoginUser_InvalidRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	authDelivery := AuthDelivery{}
+	userDelivery := UserDelivery{}
 
 	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewReader([]byte("{invalid json}")))
 	w := httptest.NewRecorder()
 
-	authDelivery.LoginUser(w, req)
+	userDelivery.LoginUser(w, req)
 
 	res := w.Result()
 	defer res.Body.Close()
@@ -70,9 +70,9 @@ func TestLoginUser_WrongCredentials(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
 
-	authDelivery := AuthDelivery{authUsecase: mockAuthUsecase}
+	userDelivery := UserDelivery{userUC: mockUserUC}
 
 	loginRequest := models.LoginRequest{
 		Email:    "user@example.com",
@@ -80,12 +80,12 @@ func TestLoginUser_WrongCredentials(t *testing.T) {
 	}
 	requestBody, _ := json.Marshal(loginRequest)
 
-	mockAuthUsecase.EXPECT().LoginUser(gomock.Any(), loginRequest.Email, loginRequest.Password).Return("", errs.ErrWrongCredentials)
+	mockUserUC.EXPECT().LoginUser(gomock.Any(), loginRequest.Email, loginRequest.Password, gomock.Any(), gomock.Any()).Return("", "", errs.ErrWrongCredentials)
 
 	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewReader(requestBody))
 	w := httptest.NewRecorder()
 
-	authDelivery.LoginUser(w, req)
+	userDelivery.LoginUser(w, req)
 
 	res := w.Result()
 	defer res.Body.Close()
@@ -98,9 +98,9 @@ func TestLoginUser_InternalServerError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
+	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
 
-	authDelivery := AuthDelivery{authUsecase: mockAuthUsecase}
+	userDelivery := UserDelivery{userUC: mockUserUC}
 
 	loginRequest := models.LoginRequest{
 		Email:    "user@example.com",
@@ -108,12 +108,12 @@ func TestLoginUser_InternalServerError(t *testing.T) {
 	}
 	requestBody, _ := json.Marshal(loginRequest)
 
-	mockAuthUsecase.EXPECT().LoginUser(gomock.Any(), loginRequest.Email, loginRequest.Password).Return("", errors.New("unexpected error"))
+	mockUserUC.EXPECT().LoginUser(gomock.Any(), loginRequest.Email, loginRequest.Password, gomock.Any(), gomock.Any()).Return("", "", errors.New("unexpected error"))
 
 	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewReader(requestBody))
 	w := httptest.NewRecorder()
 
-	authDelivery.LoginUser(w, req)
+	userDelivery.LoginUser(w, req)
 
 	res := w.Result()
 	defer res.Body.Close()
@@ -133,8 +133,8 @@ func TestRegisterUser_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
-	authDelivery := AuthDelivery{authUsecase: mockAuthUsecase}
+	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
+	userDelivery := UserDelivery{userUC: mockUserUC}
 
 	user := models.UserRegisterRequest{
 		Email:    "user@example.com",
@@ -144,12 +144,12 @@ func TestRegisterUser_Success(t *testing.T) {
 	sessionID := "session123"
 	requestBody, _ := json.Marshal(user)
 
-	mockAuthUsecase.EXPECT().RegisterUser(gomock.Any(), &user).Return(sessionID, nil)
+	mockUserUC.EXPECT().RegisterUser(gomock.Any(), &user, gomock.Any(), gomock.Any()).Return(sessionID, nil)
 
 	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewReader(requestBody))
 	w := httptest.NewRecorder()
 
-	authDelivery.RegisterUser(w, req)
+	userDelivery.RegisterUser(w, req)
 
 	res := w.Result()
 	defer res.Body.Close()
@@ -162,16 +162,44 @@ func TestRegisterUser_Success(t *testing.T) {
 	assert.Equal(t, sessionID, cookies[0].Value)
 }
 
+func TestRegisterUser_WithoutSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
+	userDelivery := UserDelivery{userUC: mockUserUC}
+
+	user := models.UserRegisterRequest{
+		Email:    "user@example.com",
+		Name:     "nickname",
+		Password: "password",
+	}
+	requestBody, _ := json.Marshal(user)
+
+	mockUserUC.EXPECT().RegisterUser(gomock.Any(), &user, gomock.Any(), gomock.Any()).Return("", nil)
+
+	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewReader(requestBody))
+	w := httptest.NewRecorder()
+
+	userDelivery.RegisterUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Empty(t, res.Cookies())
+}
+
 func TestRegisterUser_InvalidRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	authDelivery := AuthDelivery{}
+	userDelivery := UserDelivery{}
 
 	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewReader([]byte("{invalid json}")))
 	w := httptest.NewRecorder()
 
-	authDelivery.RegisterUser(w, req)
+	userDelivery.RegisterUser(w, req)
 
 	res := w.Result()
 	defer res.Body.Close()
@@ -184,8 +212,8 @@ func TestRegisterUser_EmailBusy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
-	authDelivery := AuthDelivery{authUsecase: mockAuthUsecase}
+	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
+	userDelivery := UserDelivery{userUC: mockUserUC}
 
 	user := models.UserRegisterRequest{
 		Email:    "user@example.com",
@@ -194,12 +222,12 @@ func TestRegisterUser_EmailBusy(t *testing.T) {
 	}
 	requestBody, _ := json.Marshal(user)
 
-	mockAuthUsecase.EXPECT().RegisterUser(gomock.Any(), &user).Return("", errs.ErrBusyEmail)
+	mockUserUC.EXPECT().RegisterUser(gomock.Any(), &user, gomock.Any(), gomock.Any()).Return("", errs.ErrBusyEmail)
 
 	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewReader(requestBody))
 	w := httptest.NewRecorder()
 
-	authDelivery.RegisterUser(w, req)
+	userDelivery.RegisterUser(w, req)
 
 	res := w.Result()
 	defer res.Body.Close()
@@ -212,8 +240,8 @@ func TestRegisterUser_NicknameBusy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
-	authDelivery := AuthDelivery{authUsecase: mockAuthUsecase}
+	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
+	userDelivery := UserDelivery{userUC: mockUserUC}
 
 	user := models.UserRegisterRequest{
 		Email:    "user@example.com",
@@ -222,12 +250,12 @@ func TestRegisterUser_NicknameBusy(t *testing.T) {
 	}
 	requestBody, _ := json.Marshal(user)
 
-	mockAuthUsecase.EXPECT().RegisterUser(gomock.Any(), &user).Return("", errs.ErrBusyNickname)
+	mockUserUC.EXPECT().RegisterUser(gomock.Any(), &user, gomock.Any(), gomock.Any()).Return("", errs.ErrBusyNickname)
 
 	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewReader(requestBody))
 	w := httptest.NewRecorder()
 
-	authDelivery.RegisterUser(w, req)
+	userDelivery.RegisterUser(w, req)
 
 	res := w.Result()
 	defer res.Body.Close()
@@ -240,8 +268,8 @@ func TestRegisterUser_InternalServerError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAuthUsecase := mocks.NewMockAuthUsecase(ctrl)
-	authDelivery := AuthDelivery{authUsecase: mockAuthUsecase}
+	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
+	userDelivery := UserDelivery{userUC: mockUserUC}
 
 	user := models.UserRegisterRequest{
 		Email:    "user@example.com",
@@ -250,12 +278,12 @@ func TestRegisterUser_InternalServerError(t *testing.T) {
 	}
 	requestBody, _ := json.Marshal(user)
 
-	mockAuthUsecase.EXPECT().RegisterUser(gomock.Any(), &user).Return("", errors.New("unexpected error"))
+	mockUserUC.EXPECT().RegisterUser(gomock.Any(), &user, gomock.Any(), gomock.Any()).Return("", errors.New("unexpected error"))
 
 	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewReader(requestBody))
 	w := httptest.NewRecorder()
 
-	authDelivery.RegisterUser(w, req)
+	userDelivery.RegisterUser(w, req)
 
 	res := w.Result()
 	defer res.Body.Close()
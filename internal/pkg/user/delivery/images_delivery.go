@@ -0,0 +1,31 @@
+package delivery
+
+import (
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// imageVariantCacheControl - копии картинок не меняются, так что перенаправление на них можно кешировать.
+// Только в браузере: копии отдаются лишь вошедшим пользователям
+const imageVariantCacheControl = "private, max-age=86400"
+
+// GetImageVariant перенаправляет на уменьшенную копию загруженной картинки, при необходимости построив её
+func (d *UserDelivery) GetImageVariant(w http.ResponseWriter, r *http.Request) {
+	funcName := "GetImageVariant"
+	if _, ok := requests.GetUserIDOrFail(w, r, funcName); !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	variantURL, err := d.userUC.GetImageVariant(r.Context(), vars["fileName"], vars["variant"])
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	w.Header().Set("Cache-Control", imageVariantCacheControl)
+	http.Redirect(w, r, variantURL, http.StatusFound)
+}
@@ -0,0 +1,132 @@
+package delivery
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/auth"
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	oidcStateCookieName = "oidc_state"
+	// Сервис стоит за прокси с префиксом (/api/v1/auth/oidc/callback снаружи), поэтому путь cookie
+	// не привязан к маршруту сервиса, иначе браузер не отправит cookie в callback
+	oidcCookiePath    = "/"
+	oidcStateLifeTime = 10 * time.Minute
+)
+
+// OIDCLogin начинает вход через внешний провайдер: запоминает state в cookie
+// и перенаправляет пользователя на страницу входа провайдера
+func (d *UserDelivery) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	funcName := "OIDCLogin"
+
+	authURL, state, err := d.userUC.BeginOIDCLogin(r.Context())
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	// SameSite=Lax: провайдер возвращает пользователя обычной навигацией, cookie при этом отправляется
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    state,
+		Path:     oidcCookiePath,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(oidcStateLifeTime.Seconds()),
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// OIDCCallback завершает вход через внешний провайдер и возвращает пользователя на фронтенд.
+// Ошибка передаётся фронтенду параметром error, необходимость ввести код TOTP - twoFactorToken
+func (d *UserDelivery) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	funcName := "OIDCCallback"
+	query := r.URL.Query()
+
+	// Cookie со state одноразовый
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookieName,
+		Path:     oidcCookiePath,
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
+
+	if providerErr := query.Get("error"); providerErr != "" {
+		log.Warn(funcName, ": provider returned error ", providerErr)
+		d.redirectAfterOIDC(w, r, "error", "access_denied")
+		return
+	}
+
+	// state из адреса должен совпасть с выданным этому браузеру - иначе это чужой вход
+	stateCookie, err := r.Cookie(oidcStateCookieName)
+	state := query.Get("state")
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(stateCookie.Value), []byte(state)) != 1 {
+		log.Warn(funcName, ": state mismatch")
+		d.redirectAfterOIDC(w, r, "error", "invalid_state")
+		return
+	}
+
+	sessionID, twoFactorToken, err := d.userUC.FinishOIDCLogin(r.Context(), state, query.Get("code"), requests.GetClientIP(r), r.UserAgent())
+	if err != nil {
+		d.redirectAfterOIDC(w, r, "error", oidcErrorCode(err, funcName))
+		return
+	}
+
+	if twoFactorToken != "" {
+		d.redirectAfterOIDC(w, r, "twoFactorToken", twoFactorToken)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookieName,
+		Value:    sessionID,
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   int(d.sessionMaxAge.Seconds()),
+	})
+	d.redirectAfterOIDC(w, r, "", "")
+}
+
+// redirectAfterOIDC перенаправляет на страницу фронтенда после входа, добавляя параметр key=value
+func (d *UserDelivery) redirectAfterOIDC(w http.ResponseWriter, r *http.Request, key string, value string) {
+	target, err := url.Parse(d.oidcPostLoginURL)
+	if err != nil {
+		target = &url.URL{Path: "/"}
+	}
+	if key != "" {
+		values := target.Query()
+		values.Set(key, value)
+		target.RawQuery = values.Encode()
+	}
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// oidcErrorCode переводит ошибку входа в код для фронтенда
+func oidcErrorCode(err error, funcName string) string {
+	var lockoutErr *errs.LockoutError
+	switch {
+	case errors.As(err, &lockoutErr):
+		log.Warn(funcName, ": ", err)
+		return "too_many_attempts"
+	case errors.Is(err, errs.ErrEmailNotVerified):
+		log.Warn(funcName, ": ", err)
+		return "email_not_verified"
+	case errors.Is(err, errs.ErrNotPermitted):
+		log.Warn(funcName, ": ", err)
+		return "signup_disabled"
+	case errors.Is(err, errs.ErrBadRequest):
+		log.Warn(funcName, ": ", err)
+		return "invalid_request"
+	default:
+		log.Error(funcName, ": ", err)
+		return "server_error"
+	}
+}
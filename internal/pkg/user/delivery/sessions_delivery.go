@@ -0,0 +1,59 @@
+package delivery
+
+import (
+	"RPO_back/internal/pkg/utils/responses"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// ListSessions возвращает активные сессии пользователя (устройства, с которых выполнен вход)
+func (d *UserDelivery) ListSessions(w http.ResponseWriter, r *http.Request) {
+	funcName := "ListSessions"
+	sessionID, ok := getSessionIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	sessions, err := d.userUC.ListSessions(r.Context(), sessionID)
+	if err != nil {
+		doAuthErrorResponse(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, sessions, http.StatusOK)
+}
+
+// RevokeSession завершает сессию пользователя по её публичному ID
+func (d *UserDelivery) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	funcName := "RevokeSession"
+	sessionID, ok := getSessionIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	err := d.userUC.RevokeSession(r.Context(), sessionID, mux.Vars(r)["sessionID"])
+	if err != nil {
+		doAuthErrorResponse(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
+
+// RevokeOtherSessions завершает все сессии пользователя, кроме текущей
+func (d *UserDelivery) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	funcName := "RevokeOtherSessions"
+	sessionID, ok := getSessionIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	err := d.userUC.RevokeOtherSessions(r.Context(), sessionID)
+	if err != nil {
+		doAuthErrorResponse(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
@@ -0,0 +1,133 @@
+package delivery
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/auth"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/requests"
+	"RPO_back/internal/pkg/utils/responses"
+	"errors"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// VerifyTwoFactorLogin - второй шаг входа: проверяет код из приложения или код восстановления
+// для токена из ответа /auth/login и ставит cookie сессии
+func (d *UserDelivery) VerifyTwoFactorLogin(w http.ResponseWriter, r *http.Request) {
+	funcName := "VerifyTwoFactorLogin"
+	data := models.TwoFactorLoginRequest{}
+	err := requests.GetRequestData(r, &data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		logging.Warn(r.Context(), funcName, " (getting data): ", err)
+		return
+	}
+
+	sessionID, err := d.userUC.VerifyTwoFactorLogin(r.Context(), data.Token, data.Code, requests.GetClientIP(r), r.UserAgent())
+	if err != nil {
+		doLoginErrorResponse(w, err, funcName)
+		return
+	}
+
+	cookie := http.Cookie{
+		Name:     auth.SessionCookieName,
+		Value:    sessionID,
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   int(d.sessionMaxAge.Seconds()),
+	}
+	http.SetCookie(w, &cookie)
+
+	responses.DoEmptyOkResponse(w)
+}
+
+// BeginTOTPEnrollment выдаёт секрет и otpauth:// URI для QR-кода. TOTP включится после подтверждения кодом
+func (d *UserDelivery) BeginTOTPEnrollment(w http.ResponseWriter, r *http.Request) {
+	funcName := "BeginTOTPEnrollment"
+	sessionID, ok := getSessionIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+
+	enrollment, err := d.userUC.BeginTOTPEnrollment(r.Context(), sessionID)
+	if err != nil {
+		doAuthErrorResponse(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, enrollment, http.StatusOK)
+}
+
+// ConfirmTOTPEnrollment включает TOTP по коду из приложения и отдаёт коды восстановления
+func (d *UserDelivery) ConfirmTOTPEnrollment(w http.ResponseWriter, r *http.Request) {
+	funcName := "ConfirmTOTPEnrollment"
+	sessionID, ok := getSessionIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+	data := models.TOTPCodeRequest{}
+	err := requests.GetRequestData(r, &data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		logging.Warn(r.Context(), funcName, " (getting data): ", err)
+		return
+	}
+
+	codes, err := d.userUC.ConfirmTOTPEnrollment(r.Context(), sessionID, data.Code)
+	if err != nil {
+		doAuthErrorResponse(w, err, funcName)
+		return
+	}
+
+	responses.DoJSONResponse(w, models.RecoveryCodesResponse{Codes: codes}, http.StatusOK)
+}
+
+// DisableTOTP отключает TOTP по коду из приложения или коду восстановления
+func (d *UserDelivery) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	funcName := "DisableTOTP"
+	sessionID, ok := getSessionIDOrFail(w, r, funcName)
+	if !ok {
+		return
+	}
+	data := models.TOTPCodeRequest{}
+	err := requests.GetRequestData(r, &data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		logging.Warn(r.Context(), funcName, " (getting data): ", err)
+		return
+	}
+
+	err = d.userUC.DisableTOTP(r.Context(), sessionID, data.Code)
+	if err != nil {
+		doAuthErrorResponse(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
+
+// getSessionIDOrFail достаёт ID сессии из cookie авторизованного пользователя, иначе отвечает 401
+func getSessionIDOrFail(w http.ResponseWriter, r *http.Request, funcName string) (sessionID string, ok bool) {
+	_, ok = requests.GetUserIDOrFail(w, r, funcName)
+	if !ok {
+		return "", false
+	}
+	sessionCookie, err := r.Cookie(auth.SessionCookieName)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusUnauthorized, "no session")
+		return "", false
+	}
+	return sessionCookie.Value, true
+}
+
+// doAuthErrorResponse отвечает на ошибку запроса к сервису авторизации от имени текущей сессии
+func doAuthErrorResponse(w http.ResponseWriter, err error, funcName string) {
+	if errors.Is(err, errs.ErrWrongCredentials) {
+		responses.DoBadResponse(w, http.StatusUnauthorized, "Wrong credentials")
+		log.Warn(funcName, ": ", err)
+		return
+	}
+	responses.ResponseErrorAndLog(w, err, funcName)
+}
@@ -8,21 +8,27 @@ import (
 	"RPO_back/internal/pkg/utils/logging"
 	"RPO_back/internal/pkg/utils/requests"
 	"RPO_back/internal/pkg/utils/responses"
+	"RPO_back/internal/pkg/utils/storage"
 	"RPO_back/internal/pkg/utils/uploads"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 )
 
 type UserDelivery struct {
-	userUC user.UserUsecase
+	userUC           user.UserUsecase
+	sessionMaxAge    time.Duration       // Срок cookie сессии - максимальное время жизни сессии
+	oidcPostLoginURL string              // Куда вернуть пользователя после входа через OIDC
+	fileStorage      storage.FileStorage // Откуда брать вложения для выгрузки данных
 }
 
-func CreateUserDelivery(userUC user.UserUsecase) *UserDelivery {
-	return &UserDelivery{userUC: userUC}
+func CreateUserDelivery(userUC user.UserUsecase, sessionMaxAge time.Duration, oidcPostLoginURL string, fileStorage storage.FileStorage) *UserDelivery {
+	return &UserDelivery{userUC: userUC, sessionMaxAge: sessionMaxAge, oidcPostLoginURL: oidcPostLoginURL, fileStorage: fileStorage}
 }
 
 // GetMyProfile возвращает пользователю его профиль
@@ -71,10 +77,9 @@ func (d *UserDelivery) SetMyAvatar(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	file, err := uploads.FormFile(r)
+	file, err := uploads.ReceiveFile(w, r, uploads.LimitsFor(uploads.PurposeAvatar))
 	if err != nil {
-		responses.DoBadResponse(w, 401, "Wrong credentials")
-		logging.Warn(r.Context(), "LoginUser (checking credentials): ", err)
+		responses.ResponseErrorAndLog(w, err, funcName)
 		return
 	}
 
@@ -98,15 +103,18 @@ func (d *UserDelivery) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sessionID, err := d.userUC.LoginUser(r.Context(), loginRequest.Email, loginRequest.Password)
+	sessionID, twoFactorToken, err := d.userUC.LoginUser(r.Context(), loginRequest.Email, loginRequest.Password, requests.GetClientIP(r), r.UserAgent())
 	if err != nil {
-		if errors.Is(err, errs.ErrWrongCredentials) {
-			responses.DoBadResponse(w, 401, "Wrong credentials")
-			log.Warn("LoginUser (checking credentials): ", err)
-			return
-		}
-		responses.DoBadResponse(w, 500, "Internal Server Error")
-		log.Error("LoginUser (checking credentials): ", err)
+		doLoginErrorResponse(w, err, "LoginUser")
+		return
+	}
+
+	// Пароль верный, но сессия появится только после ввода кода (см. VerifyTwoFactorLogin)
+	if twoFactorToken != "" {
+		responses.DoJSONResponse(w, models.TwoFactorRequiredResponse{
+			TwoFactorRequired: true,
+			TwoFactorToken:    twoFactorToken,
+		}, http.StatusOK)
 		return
 	}
 
@@ -115,13 +123,46 @@ func (d *UserDelivery) LoginUser(w http.ResponseWriter, r *http.Request) {
 		Value:    sessionID,
 		Path:     "/",
 		HttpOnly: true,
-		MaxAge:   int((7 * 24 * time.Hour).Seconds()),
+		MaxAge:   int(d.sessionMaxAge.Seconds()),
 	}
 	http.SetCookie(w, &cookie)
 
 	responses.DoEmptyOkResponse(w)
 }
 
+// doLoginErrorResponse отвечает на ошибку входа по паролю или по коду второго фактора
+func doLoginErrorResponse(w http.ResponseWriter, err error, funcName string) {
+	var lockoutErr *errs.LockoutError
+	if errors.As(err, &lockoutErr) {
+		doLoginLockoutResponse(w, lockoutErr.Until)
+		log.Warn(funcName, " (checking credentials): ", err)
+		return
+	}
+	if errors.Is(err, errs.ErrWrongCredentials) {
+		responses.DoBadResponse(w, 401, "Wrong credentials")
+		log.Warn(funcName, " (checking credentials): ", err)
+		return
+	}
+	if errors.Is(err, errs.ErrEmailNotVerified) {
+		responses.DoBadResponse(w, http.StatusForbidden, "Email is not verified")
+		log.Warn(funcName, " (checking credentials): ", err)
+		return
+	}
+	responses.DoBadResponse(w, 500, "Internal Server Error")
+	log.Error(funcName, " (checking credentials): ", err)
+}
+
+// doLoginLockoutResponse отвечает 429 со временем снятия блокировки входа
+func doLoginLockoutResponse(w http.ResponseWriter, lockedUntil time.Time) {
+	retryAfter := int(math.Ceil(time.Until(lockedUntil).Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+	responses.DoJSONResponse(w, models.LoginLockoutResponse{
+		Status:      http.StatusTooManyRequests,
+		Text:        "too many login attempts",
+		LockedUntil: lockedUntil,
+	}, http.StatusTooManyRequests)
+}
+
 // RegisterUser регистрирует пользователя
 func (d *UserDelivery) RegisterUser(w http.ResponseWriter, r *http.Request) {
 	var user models.UserRegisterRequest
@@ -132,7 +173,7 @@ func (d *UserDelivery) RegisterUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sessionID, err := d.userUC.RegisterUser(r.Context(), &user)
+	sessionID, err := d.userUC.RegisterUser(r.Context(), &user, requests.GetClientIP(r), r.UserAgent())
 	if err != nil {
 		log.Error("Auth: ", err)
 		if errors.Is(err, errs.ErrBusyEmail) && errors.Is(err, errs.ErrBusyNickname) {
@@ -147,13 +188,19 @@ func (d *UserDelivery) RegisterUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Пустой sessionID - вход до подтверждения email запрещён, пользователь войдёт после него
+	if sessionID == "" {
+		responses.DoEmptyOkResponse(w)
+		return
+	}
+
 	cookie := http.Cookie{
 		Name:     auth.SessionCookieName,
 		Value:    sessionID,
 		SameSite: http.SameSiteStrictMode,
 		Path:     "/",
 		HttpOnly: true,
-		MaxAge:   int((7 * 24 * time.Hour).Seconds()),
+		MaxAge:   int(d.sessionMaxAge.Seconds()),
 	}
 	http.SetCookie(w, &cookie)
 
@@ -189,7 +236,7 @@ func (d *UserDelivery) LogoutUser(w http.ResponseWriter, r *http.Request) {
 // ChangePassword отвечает за смену пароля
 func (d *UserDelivery) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	funcName := "ChangePassword"
-	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
+	_, ok := requests.GetUserIDOrFail(w, r, funcName)
 	if !ok {
 		return
 	}
@@ -199,51 +246,137 @@ func (d *UserDelivery) ChangePassword(w http.ResponseWriter, r *http.Request) {
 		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
 		return
 	}
-	err = d.userUC.ChangePassword(r.Context(), userID, data.OldPassword, data.NewPassword)
+	sessionCookie, err := r.Cookie(auth.SessionCookieName)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusUnauthorized, "no session")
+		return
+	}
+	err = d.userUC.ChangePassword(r.Context(), sessionCookie.Value, data.OldPassword, data.NewPassword)
 	if err != nil {
+		if errors.Is(err, errs.ErrWrongCredentials) {
+			responses.DoBadResponse(w, http.StatusUnauthorized, "Wrong credentials")
+			log.Warn(funcName, " (checking credentials): ", err)
+			return
+		}
 		responses.DoBadResponse(w, http.StatusInternalServerError, "internal error")
+		log.Error(funcName, ": ", err)
 		return
 	}
 	responses.DoEmptyOkResponse(w)
 }
 
-func (d *UserDelivery) SubmitPoll(w http.ResponseWriter, r *http.Request) {
-	funcName := "SubmitPoll"
-	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
-	if !ok {
+// RequestPasswordReset отправляет письмо со ссылкой на сброс пароля.
+// Ответ не зависит от того, есть ли пользователь с таким email
+func (d *UserDelivery) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	funcName := "RequestPasswordReset"
+	data := models.PasswordResetRequest{}
+	err := requests.GetRequestData(r, &data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
 		return
 	}
-	pollSubmit := models.PollSubmit{}
-	err := requests.GetRequestData(r, &pollSubmit)
+
+	err = d.userUC.RequestPasswordReset(r.Context(), data.Email, requests.GetClientIP(r))
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
+
+// ConfirmPasswordReset задаёт новый пароль по токену из письма
+func (d *UserDelivery) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	funcName := "ConfirmPasswordReset"
+	data := models.PasswordResetConfirmRequest{}
+	err := requests.GetRequestData(r, &data)
 	if err != nil {
-		logging.Warn(r.Context(), err)
 		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
 		return
 	}
 
-	err = d.userUC.SubmitPoll(r.Context(), userID, &pollSubmit)
+	err = d.userUC.ConfirmPasswordReset(r.Context(), data.Token, data.NewPassword)
 	if err != nil {
-		logging.Warn(r.Context(), err)
+		if errors.Is(err, errs.ErrWrongCredentials) {
+			responses.DoBadResponse(w, http.StatusBadRequest, "invalid or expired token")
+			log.Warn(funcName, ": ", err)
+			return
+		}
 		responses.DoBadResponse(w, http.StatusInternalServerError, "internal error")
+		log.Error(funcName, ": ", err)
 		return
 	}
 
 	responses.DoEmptyOkResponse(w)
 }
 
-func (d *UserDelivery) GetPollResults(w http.ResponseWriter, r *http.Request) {
-	funcName := "GetPollResults"
-	_, ok := requests.GetUserIDOrFail(w, r, funcName)
+// VerifyEmail подтверждает email по токену из письма
+func (d *UserDelivery) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	funcName := "VerifyEmail"
+	data := models.VerifyEmailRequest{}
+	err := requests.GetRequestData(r, &data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
+		return
+	}
+
+	err = d.userUC.VerifyEmail(r.Context(), data.Token)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			responses.DoBadResponse(w, http.StatusBadRequest, "invalid or expired token")
+			log.Warn(funcName, ": ", err)
+			return
+		}
+		if errors.Is(err, errs.ErrBusyEmail) {
+			responses.DoBadResponse(w, http.StatusConflict, "Email is busy")
+			log.Warn(funcName, ": ", err)
+			return
+		}
+		responses.DoBadResponse(w, http.StatusInternalServerError, "internal error")
+		log.Error(funcName, ": ", err)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
+}
+
+// ResendEmailVerification повторно отправляет письмо для подтверждения email
+func (d *UserDelivery) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	funcName := "ResendEmailVerification"
+	userID, ok := requests.GetUserIDOrFail(w, r, funcName)
 	if !ok {
 		return
 	}
 
-	pollResults, err := d.userUC.GetPollResults(r.Context())
+	err := d.userUC.ResendEmailVerification(r.Context(), userID)
 	if err != nil {
-		logging.Warn(r.Context(), err)
 		responses.ResponseErrorAndLog(w, err, funcName)
 		return
 	}
 
-	responses.DoJSONResponse(w, pollResults, http.StatusOK)
+	responses.DoEmptyOkResponse(w)
+}
+
+// ResendEmailVerificationByEmail повторно отправляет письмо для подтверждения email без входа в аккаунт.
+// Ответ не зависит от того, есть ли пользователь с таким email
+func (d *UserDelivery) ResendEmailVerificationByEmail(w http.ResponseWriter, r *http.Request) {
+	funcName := "ResendEmailVerificationByEmail"
+	data := models.ResendEmailVerificationRequest{}
+	err := requests.GetRequestData(r, &data)
+	if err != nil {
+		responses.DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(funcName, ": ", err)
+		return
+	}
+
+	err = d.userUC.ResendEmailVerificationByEmail(r.Context(), data.Email)
+	if err != nil {
+		responses.ResponseErrorAndLog(w, err, funcName)
+		return
+	}
+
+	responses.DoEmptyOkResponse(w)
 }
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"testing"
 
+	"RPO_back/internal/pkg/config"
 	"RPO_back/internal/pkg/middleware/session"
 	mock_user "RPO_back/internal/pkg/user/mocks"
 
@@ -22,12 +23,12 @@ import (
 func TestGetMyProfile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
-	handler := CreateUserDelivery(mockUserUC)
+	handler := CreateUserDelivery(mockUserUC, 0, "", nil)
 
 	profile := models.UserProfile{ID: 1, Name: "John Doe"}
-	mockUserUC.EXPECT().GetMyProfile(gomock.Any(), 1).Return(&profile, nil)
+	mockUserUC.EXPECT().GetMyProfile(gomock.Any(), int64(1)).Return(&profile, nil)
 
-	ctx := context.WithValue(context.Background(), session.UserIDContextKey, 1)
+	ctx := context.WithValue(context.Background(), session.UserIDContextKey, int64(1))
 	req, _ := http.NewRequestWithContext(ctx, "GET", "/users/me", nil)
 
 	rr := httptest.NewRecorder()
@@ -42,7 +43,7 @@ func TestGetMyProfile(t *testing.T) {
 	var responseProfile models.UserProfile
 	json.NewDecoder(rr.Body).Decode(&responseProfile)
 
-	if responseProfile != profile {
+	if responseProfile.ID != profile.ID || responseProfile.Name != profile.Name {
 		t.Errorf("expected response profile %v, got %v", profile, responseProfile)
 	}
 }
@@ -50,14 +51,14 @@ func TestGetMyProfile(t *testing.T) {
 func TestUpdateMyProfile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
-	handler := CreateUserDelivery(mockUserUC)
+	handler := CreateUserDelivery(mockUserUC, 0, "", nil)
 
 	oldProfile := models.UserProfile{ID: 1, Name: "John Smith"}
 	updateData := models.UserProfileUpdateRequest{NewName: "Romanov Vasily", Email: "rvasily@google.com"}
 
-	mockUserUC.EXPECT().UpdateMyProfile(gomock.Any(), 1, &updateData).Return(&oldProfile, nil)
+	mockUserUC.EXPECT().UpdateMyProfile(gomock.Any(), int64(1), &updateData).Return(&oldProfile, nil)
 
-	ctx := context.WithValue(context.Background(), session.UserIDContextKey, 1)
+	ctx := context.WithValue(context.Background(), session.UserIDContextKey, int64(1))
 	updateDataJSON, _ := json.Marshal(updateData)
 	req, _ := http.NewRequestWithContext(ctx, "PUT", "/users/me", bytes.NewBuffer(updateDataJSON))
 	req = mux.SetURLVars(req, map[string]string{"userID": "1"})
@@ -75,7 +76,7 @@ func TestUpdateMyProfile(t *testing.T) {
 	var responseProfile models.UserProfile
 	json.NewDecoder(rr.Body).Decode(&responseProfile)
 
-	if responseProfile != oldProfile {
+	if responseProfile.ID != oldProfile.ID || responseProfile.Name != oldProfile.Name {
 		t.Errorf("expected response profile %v, got %v", oldProfile, responseProfile)
 	}
 }
@@ -83,11 +84,15 @@ func TestUpdateMyProfile(t *testing.T) {
 func TestSetMyAvatar(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockUserUC := mock_user.NewMockUserUsecase(ctrl)
-	handler := CreateUserDelivery(mockUserUC)
+	handler := CreateUserDelivery(mockUserUC, 0, "", nil)
+
+	oldConfig := config.CurrentConfig
+	defer func() { config.CurrentConfig = oldConfig }()
+	config.CurrentConfig = &config.Config{Limits: &config.UploadLimitsConfig{MaxAvatarSize: 1 << 20}}
 
 	updatedProfile := models.UserProfile{ID: 1, Name: "John Doe", AvatarImageURL: "http://example.com/avatar.jpg"}
 
-	mockUserUC.EXPECT().SetMyAvatar(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(&updatedProfile, nil)
+	mockUserUC.EXPECT().SetMyAvatar(gomock.Any(), int64(1), gomock.Any()).Return(&updatedProfile, nil)
 
 	var buffer bytes.Buffer
 	writer := multipart.NewWriter(&buffer)
@@ -95,13 +100,13 @@ func TestSetMyAvatar(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fileContent := strings.NewReader("fake image content")
+	fileContent := strings.NewReader("\x89PNG\r\n\x1a\nfake image content")
 	if _, err := io.Copy(part, fileContent); err != nil {
 		t.Fatal(err)
 	}
 	writer.Close()
 
-	ctx := context.WithValue(context.Background(), session.UserIDContextKey, 1)
+	ctx := context.WithValue(context.Background(), session.UserIDContextKey, int64(1))
 	req, _ := http.NewRequestWithContext(ctx, "PUT", "/users/me/avatarImage", &buffer)
 	req = mux.SetURLVars(req, map[string]string{"userID": "1"})
 	req.Header.Set("Content-Type", writer.FormDataContentType())
@@ -118,7 +123,7 @@ func TestSetMyAvatar(t *testing.T) {
 	var responseProfile models.UserProfile
 	json.NewDecoder(rr.Body).Decode(&responseProfile)
 
-	if responseProfile != updatedProfile {
+	if responseProfile.ID != updatedProfile.ID || responseProfile.Name != updatedProfile.Name {
 		t.Errorf("expected response profile %v, got %v", updatedProfile, responseProfile)
 	}
 }
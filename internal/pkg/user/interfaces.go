@@ -2,7 +2,9 @@ package user
 
 import (
 	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/oidc"
 	"context"
+	"time"
 )
 
 //go:generate mockgen -source=interfaces.go -destination=mocks/mock.go
@@ -10,12 +12,30 @@ type UserUsecase interface {
 	GetMyProfile(ctx context.Context, userID int64) (profile *models.UserProfile, err error)
 	UpdateMyProfile(ctx context.Context, userID int64, data *models.UserProfileUpdateRequest) (updatedProfile *models.UserProfile, err error)
 	SetMyAvatar(ctx context.Context, userID int64, file *models.UploadedFile) (updated *models.UserProfile, err error)
-	LoginUser(ctx context.Context, email string, password string) (sessionID string, err error)
-	RegisterUser(ctx context.Context, user *models.UserRegisterRequest) (sessionID string, err error)
+	LoginUser(ctx context.Context, email string, password string, clientIP string, userAgent string) (sessionID string, twoFactorToken string, err error)
+	VerifyTwoFactorLogin(ctx context.Context, twoFactorToken string, code string, clientIP string, userAgent string) (sessionID string, err error)
+	RegisterUser(ctx context.Context, user *models.UserRegisterRequest, clientIP string, userAgent string) (sessionID string, err error)
 	LogoutUser(ctx context.Context, sessionID string) error
-	ChangePassword(ctx context.Context, userID int64, oldPassword string, newPassword string) error
-	SubmitPoll(ctx context.Context, userID int64, pollQuestion *models.PollSubmit) error
-	GetPollResults(ctx context.Context) (pollResults *models.PollResults, err error)
+	ChangePassword(ctx context.Context, sessionID string, oldPassword string, newPassword string) error
+	RequestPasswordReset(ctx context.Context, email string, clientIP string) error
+	ConfirmPasswordReset(ctx context.Context, token string, newPassword string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendEmailVerification(ctx context.Context, userID int64) error
+	ResendEmailVerificationByEmail(ctx context.Context, email string) error
+	BeginTOTPEnrollment(ctx context.Context, sessionID string) (enrollment *models.TOTPEnrollmentResponse, err error)
+	ConfirmTOTPEnrollment(ctx context.Context, sessionID string, code string) (recoveryCodes []string, err error)
+	DisableTOTP(ctx context.Context, sessionID string, code string) error
+	ListSessions(ctx context.Context, sessionID string) (sessions []models.SessionInfo, err error)
+	RevokeSession(ctx context.Context, sessionID string, targetID string) error
+	RevokeOtherSessions(ctx context.Context, sessionID string) error
+	CreateAccessToken(ctx context.Context, sessionID string, data *models.AccessTokenCreateRequest) (token *models.AccessToken, err error)
+	ListAccessTokens(ctx context.Context, sessionID string) (tokens []models.AccessToken, err error)
+	RevokeAccessToken(ctx context.Context, sessionID string, tokenID int64) error
+	BeginOIDCLogin(ctx context.Context) (authURL string, state string, err error)
+	FinishOIDCLogin(ctx context.Context, state string, code string, clientIP string, userAgent string) (sessionID string, twoFactorToken string, err error)
+	ExportMyData(ctx context.Context, userID int64) (export *models.UserDataExport, err error)
+	DeleteMyAccount(ctx context.Context, sessionID string, password string) (result *models.AccountDeletionResult, err error)
+	GetImageVariant(ctx context.Context, fileName string, variantName string) (variantURL string, err error)
 }
 
 type UserRepo interface {
@@ -23,13 +43,28 @@ type UserRepo interface {
 	UpdateUserProfile(ctx context.Context, userID int64, data models.UserProfileUpdateRequest) (newProfile *models.UserProfile, err error)
 	SetUserAvatar(ctx context.Context, userID int64, avatarFileID int64) (err error)
 	GetUserByEmail(ctx context.Context, email string) (user *models.UserProfile, err error)
-	CreateUser(ctx context.Context, user *models.UserRegisterRequest) (newUser *models.UserProfile, err error)
+	CreateUser(ctx context.Context, user *models.UserRegisterRequest, passwordHash string) (newUser *models.UserProfile, err error)
 	CheckUniqueCredentials(ctx context.Context, nickname string, email string) error
-	DeduplicateFile(ctx context.Context, file *models.UploadedFile) (fileNames []string, fileIDs []int64, err error)
-	RegisterFile(ctx context.Context, file *models.UploadedFile) error
-	SubmitPoll(ctx context.Context, userID int64, pollSubmit *models.PollSubmit) error
-	GetRatingResults(ctx context.Context) (results []models.RatingResults, err error)
-	GetTextResults(ctx context.Context) (results []models.AnswerResults, err error)
-	SetNextPollDT(ctx context.Context, userID int64) error
-	PickPollQuestions(ctx context.Context) (pollQuestions []models.PollQuestion, err error)
+	RegisterFile(ctx context.Context, file *models.UploadedFile) (isNew bool, err error)
+	IsDisplayedImage(ctx context.Context, fileUUID string, fileExtension string) (isDisplayed bool, err error)
+	CreateEmailVerificationToken(ctx context.Context, userID int64, email string, tokenHash string, expiresAt time.Time) error
+	UseEmailVerificationToken(ctx context.Context, tokenHash string) (userID int64, err error)
+	GetEmailVerificationStats(ctx context.Context, userID int64, since time.Time) (lastSentAt *time.Time, sentCount int, err error)
+	SaveOIDCLoginState(ctx context.Context, stateHash string, nonce string, codeVerifier string, expiresAt time.Time) error
+	UseOIDCLoginState(ctx context.Context, stateHash string) (nonce string, codeVerifier string, err error)
+	GetUserIDByIdentity(ctx context.Context, issuer string, subject string) (userID int64, err error)
+	LinkIdentity(ctx context.Context, userID int64, issuer string, subject string, email string) error
+	CreateSSOUser(ctx context.Context, nickname string, email string, issuer string, subject string) (newUser *models.UserProfile, err error)
+	GetUserBoardMemberships(ctx context.Context, userID int64) (memberships []models.BoardMembership, err error)
+	GetUserComments(ctx context.Context, userID int64) (comments []models.CommentExport, err error)
+	GetUserAttachments(ctx context.Context, userID int64) (attachments []models.AttachmentExport, err error)
+	GetUserTimeEntries(ctx context.Context, userID int64) (entries []models.TimeEntryExport, err error)
+	DeleteUser(ctx context.Context, userID int64) (result *models.AccountDeletionResult, err error)
+}
+
+// OIDCProvider - внешний провайдер входа (реализуется oidc.Provider)
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code string, codeVerifier string) (rawIDToken string, err error)
+	VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*oidc.Claims, error)
 }
@@ -6,8 +6,10 @@ package mock_user
 
 import (
 	models "RPO_back/internal/models"
+	oidc "RPO_back/internal/pkg/utils/oidc"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,18 +37,168 @@ func (m *MockUserUsecase) EXPECT() *MockUserUsecaseMockRecorder {
 	return m.recorder
 }
 
+// BeginOIDCLogin mocks base method.
+func (m *MockUserUsecase) BeginOIDCLogin(ctx context.Context) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginOIDCLogin", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BeginOIDCLogin indicates an expected call of BeginOIDCLogin.
+func (mr *MockUserUsecaseMockRecorder) BeginOIDCLogin(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginOIDCLogin", reflect.TypeOf((*MockUserUsecase)(nil).BeginOIDCLogin), ctx)
+}
+
+// BeginTOTPEnrollment mocks base method.
+func (m *MockUserUsecase) BeginTOTPEnrollment(ctx context.Context, sessionID string) (*models.TOTPEnrollmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTOTPEnrollment", ctx, sessionID)
+	ret0, _ := ret[0].(*models.TOTPEnrollmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTOTPEnrollment indicates an expected call of BeginTOTPEnrollment.
+func (mr *MockUserUsecaseMockRecorder) BeginTOTPEnrollment(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTOTPEnrollment", reflect.TypeOf((*MockUserUsecase)(nil).BeginTOTPEnrollment), ctx, sessionID)
+}
+
 // ChangePassword mocks base method.
-func (m *MockUserUsecase) ChangePassword(ctx context.Context, userID int64, oldPassword, newPassword string) error {
+func (m *MockUserUsecase) ChangePassword(ctx context.Context, sessionID, oldPassword, newPassword string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, oldPassword, newPassword)
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, sessionID, oldPassword, newPassword)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ChangePassword indicates an expected call of ChangePassword.
-func (mr *MockUserUsecaseMockRecorder) ChangePassword(ctx, userID, oldPassword, newPassword interface{}) *gomock.Call {
+func (mr *MockUserUsecaseMockRecorder) ChangePassword(ctx, sessionID, oldPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserUsecase)(nil).ChangePassword), ctx, sessionID, oldPassword, newPassword)
+}
+
+// ConfirmPasswordReset mocks base method.
+func (m *MockUserUsecase) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPasswordReset", ctx, token, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmPasswordReset indicates an expected call of ConfirmPasswordReset.
+func (mr *MockUserUsecaseMockRecorder) ConfirmPasswordReset(ctx, token, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPasswordReset", reflect.TypeOf((*MockUserUsecase)(nil).ConfirmPasswordReset), ctx, token, newPassword)
+}
+
+// ConfirmTOTPEnrollment mocks base method.
+func (m *MockUserUsecase) ConfirmTOTPEnrollment(ctx context.Context, sessionID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPEnrollment", ctx, sessionID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTPEnrollment indicates an expected call of ConfirmTOTPEnrollment.
+func (mr *MockUserUsecaseMockRecorder) ConfirmTOTPEnrollment(ctx, sessionID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPEnrollment", reflect.TypeOf((*MockUserUsecase)(nil).ConfirmTOTPEnrollment), ctx, sessionID, code)
+}
+
+// CreateAccessToken mocks base method.
+func (m *MockUserUsecase) CreateAccessToken(ctx context.Context, sessionID string, data *models.AccessTokenCreateRequest) (*models.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessToken", ctx, sessionID, data)
+	ret0, _ := ret[0].(*models.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccessToken indicates an expected call of CreateAccessToken.
+func (mr *MockUserUsecaseMockRecorder) CreateAccessToken(ctx, sessionID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessToken", reflect.TypeOf((*MockUserUsecase)(nil).CreateAccessToken), ctx, sessionID, data)
+}
+
+// DeleteMyAccount mocks base method.
+func (m *MockUserUsecase) DeleteMyAccount(ctx context.Context, sessionID, password string) (*models.AccountDeletionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMyAccount", ctx, sessionID, password)
+	ret0, _ := ret[0].(*models.AccountDeletionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMyAccount indicates an expected call of DeleteMyAccount.
+func (mr *MockUserUsecaseMockRecorder) DeleteMyAccount(ctx, sessionID, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMyAccount", reflect.TypeOf((*MockUserUsecase)(nil).DeleteMyAccount), ctx, sessionID, password)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockUserUsecase) DisableTOTP(ctx context.Context, sessionID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, sessionID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockUserUsecaseMockRecorder) DisableTOTP(ctx, sessionID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockUserUsecase)(nil).DisableTOTP), ctx, sessionID, code)
+}
+
+// ExportMyData mocks base method.
+func (m *MockUserUsecase) ExportMyData(ctx context.Context, userID int64) (*models.UserDataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMyData", ctx, userID)
+	ret0, _ := ret[0].(*models.UserDataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportMyData indicates an expected call of ExportMyData.
+func (mr *MockUserUsecaseMockRecorder) ExportMyData(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMyData", reflect.TypeOf((*MockUserUsecase)(nil).ExportMyData), ctx, userID)
+}
+
+// FinishOIDCLogin mocks base method.
+func (m *MockUserUsecase) FinishOIDCLogin(ctx context.Context, state, code, clientIP, userAgent string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishOIDCLogin", ctx, state, code, clientIP, userAgent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FinishOIDCLogin indicates an expected call of FinishOIDCLogin.
+func (mr *MockUserUsecaseMockRecorder) FinishOIDCLogin(ctx, state, code, clientIP, userAgent interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserUsecase)(nil).ChangePassword), ctx, userID, oldPassword, newPassword)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishOIDCLogin", reflect.TypeOf((*MockUserUsecase)(nil).FinishOIDCLogin), ctx, state, code, clientIP, userAgent)
+}
+
+// GetImageVariant mocks base method.
+func (m *MockUserUsecase) GetImageVariant(ctx context.Context, fileName, variantName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageVariant", ctx, fileName, variantName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImageVariant indicates an expected call of GetImageVariant.
+func (mr *MockUserUsecaseMockRecorder) GetImageVariant(ctx, fileName, variantName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageVariant", reflect.TypeOf((*MockUserUsecase)(nil).GetImageVariant), ctx, fileName, variantName)
 }
 
 // GetMyProfile mocks base method.
@@ -64,19 +216,50 @@ func (mr *MockUserUsecaseMockRecorder) GetMyProfile(ctx, userID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyProfile", reflect.TypeOf((*MockUserUsecase)(nil).GetMyProfile), ctx, userID)
 }
 
-// LoginUser mocks base method.
-func (m *MockUserUsecase) LoginUser(ctx context.Context, email, password string) (string, error) {
+// ListAccessTokens mocks base method.
+func (m *MockUserUsecase) ListAccessTokens(ctx context.Context, sessionID string) ([]models.AccessToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoginUser", ctx, email, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ListAccessTokens", ctx, sessionID)
+	ret0, _ := ret[0].([]models.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccessTokens indicates an expected call of ListAccessTokens.
+func (mr *MockUserUsecaseMockRecorder) ListAccessTokens(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccessTokens", reflect.TypeOf((*MockUserUsecase)(nil).ListAccessTokens), ctx, sessionID)
+}
+
+// ListSessions mocks base method.
+func (m *MockUserUsecase) ListSessions(ctx context.Context, sessionID string) ([]models.SessionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, sessionID)
+	ret0, _ := ret[0].([]models.SessionInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockUserUsecaseMockRecorder) ListSessions(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockUserUsecase)(nil).ListSessions), ctx, sessionID)
+}
+
+// LoginUser mocks base method.
+func (m *MockUserUsecase) LoginUser(ctx context.Context, email, password, clientIP, userAgent string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUser", ctx, email, password, clientIP, userAgent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // LoginUser indicates an expected call of LoginUser.
-func (mr *MockUserUsecaseMockRecorder) LoginUser(ctx, email, password interface{}) *gomock.Call {
+func (mr *MockUserUsecaseMockRecorder) LoginUser(ctx, email, password, clientIP, userAgent interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUserUsecase)(nil).LoginUser), ctx, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUserUsecase)(nil).LoginUser), ctx, email, password, clientIP, userAgent)
 }
 
 // LogoutUser mocks base method.
@@ -94,18 +277,102 @@ func (mr *MockUserUsecaseMockRecorder) LogoutUser(ctx, sessionID interface{}) *g
 }
 
 // RegisterUser mocks base method.
-func (m *MockUserUsecase) RegisterUser(ctx context.Context, user *models.UserRegisterRequest) (string, error) {
+func (m *MockUserUsecase) RegisterUser(ctx context.Context, user *models.UserRegisterRequest, clientIP, userAgent string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterUser", ctx, user)
+	ret := m.ctrl.Call(m, "RegisterUser", ctx, user, clientIP, userAgent)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RegisterUser indicates an expected call of RegisterUser.
-func (mr *MockUserUsecaseMockRecorder) RegisterUser(ctx, user interface{}) *gomock.Call {
+func (mr *MockUserUsecaseMockRecorder) RegisterUser(ctx, user, clientIP, userAgent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserUsecase)(nil).RegisterUser), ctx, user, clientIP, userAgent)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockUserUsecase) RequestPasswordReset(ctx context.Context, email, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, email, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockUserUsecaseMockRecorder) RequestPasswordReset(ctx, email, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockUserUsecase)(nil).RequestPasswordReset), ctx, email, clientIP)
+}
+
+// ResendEmailVerification mocks base method.
+func (m *MockUserUsecase) ResendEmailVerification(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendEmailVerification", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendEmailVerification indicates an expected call of ResendEmailVerification.
+func (mr *MockUserUsecaseMockRecorder) ResendEmailVerification(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserUsecase)(nil).RegisterUser), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendEmailVerification", reflect.TypeOf((*MockUserUsecase)(nil).ResendEmailVerification), ctx, userID)
+}
+
+// ResendEmailVerificationByEmail mocks base method.
+func (m *MockUserUsecase) ResendEmailVerificationByEmail(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendEmailVerificationByEmail", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendEmailVerificationByEmail indicates an expected call of ResendEmailVerificationByEmail.
+func (mr *MockUserUsecaseMockRecorder) ResendEmailVerificationByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendEmailVerificationByEmail", reflect.TypeOf((*MockUserUsecase)(nil).ResendEmailVerificationByEmail), ctx, email)
+}
+
+// RevokeAccessToken mocks base method.
+func (m *MockUserUsecase) RevokeAccessToken(ctx context.Context, sessionID string, tokenID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccessToken", ctx, sessionID, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAccessToken indicates an expected call of RevokeAccessToken.
+func (mr *MockUserUsecaseMockRecorder) RevokeAccessToken(ctx, sessionID, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessToken", reflect.TypeOf((*MockUserUsecase)(nil).RevokeAccessToken), ctx, sessionID, tokenID)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockUserUsecase) RevokeOtherSessions(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockUserUsecaseMockRecorder) RevokeOtherSessions(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockUserUsecase)(nil).RevokeOtherSessions), ctx, sessionID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockUserUsecase) RevokeSession(ctx context.Context, sessionID, targetID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUserUsecaseMockRecorder) RevokeSession(ctx, sessionID, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUserUsecase)(nil).RevokeSession), ctx, sessionID, targetID)
 }
 
 // SetMyAvatar mocks base method.
@@ -138,6 +405,35 @@ func (mr *MockUserUsecaseMockRecorder) UpdateMyProfile(ctx, userID, data interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMyProfile", reflect.TypeOf((*MockUserUsecase)(nil).UpdateMyProfile), ctx, userID, data)
 }
 
+// VerifyEmail mocks base method.
+func (m *MockUserUsecase) VerifyEmail(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserUsecaseMockRecorder) VerifyEmail(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserUsecase)(nil).VerifyEmail), ctx, token)
+}
+
+// VerifyTwoFactorLogin mocks base method.
+func (m *MockUserUsecase) VerifyTwoFactorLogin(ctx context.Context, twoFactorToken, code, clientIP, userAgent string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTwoFactorLogin", ctx, twoFactorToken, code, clientIP, userAgent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyTwoFactorLogin indicates an expected call of VerifyTwoFactorLogin.
+func (mr *MockUserUsecaseMockRecorder) VerifyTwoFactorLogin(ctx, twoFactorToken, code, clientIP, userAgent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTwoFactorLogin", reflect.TypeOf((*MockUserUsecase)(nil).VerifyTwoFactorLogin), ctx, twoFactorToken, code, clientIP, userAgent)
+}
+
 // MockUserRepo is a mock of UserRepo interface.
 type MockUserRepo struct {
 	ctrl     *gomock.Controller
@@ -175,35 +471,109 @@ func (mr *MockUserRepoMockRecorder) CheckUniqueCredentials(ctx, nickname, email
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUniqueCredentials", reflect.TypeOf((*MockUserRepo)(nil).CheckUniqueCredentials), ctx, nickname, email)
 }
 
+// CreateEmailVerificationToken mocks base method.
+func (m *MockUserRepo) CreateEmailVerificationToken(ctx context.Context, userID int64, email, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailVerificationToken", ctx, userID, email, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEmailVerificationToken indicates an expected call of CreateEmailVerificationToken.
+func (mr *MockUserRepoMockRecorder) CreateEmailVerificationToken(ctx, userID, email, tokenHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerificationToken", reflect.TypeOf((*MockUserRepo)(nil).CreateEmailVerificationToken), ctx, userID, email, tokenHash, expiresAt)
+}
+
+// CreateSSOUser mocks base method.
+func (m *MockUserRepo) CreateSSOUser(ctx context.Context, nickname, email, issuer, subject string) (*models.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSSOUser", ctx, nickname, email, issuer, subject)
+	ret0, _ := ret[0].(*models.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSSOUser indicates an expected call of CreateSSOUser.
+func (mr *MockUserRepoMockRecorder) CreateSSOUser(ctx, nickname, email, issuer, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSSOUser", reflect.TypeOf((*MockUserRepo)(nil).CreateSSOUser), ctx, nickname, email, issuer, subject)
+}
+
 // CreateUser mocks base method.
-func (m *MockUserRepo) CreateUser(ctx context.Context, user *models.UserRegisterRequest) (*models.UserProfile, error) {
+func (m *MockUserRepo) CreateUser(ctx context.Context, user *models.UserRegisterRequest, passwordHash string) (*models.UserProfile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
+	ret := m.ctrl.Call(m, "CreateUser", ctx, user, passwordHash)
 	ret0, _ := ret[0].(*models.UserProfile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockUserRepoMockRecorder) CreateUser(ctx, user interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) CreateUser(ctx, user, passwordHash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepo)(nil).CreateUser), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepo)(nil).CreateUser), ctx, user, passwordHash)
 }
 
-// DeduplicateFile mocks base method.
-func (m *MockUserRepo) DeduplicateFile(ctx context.Context, file *models.UploadedFile) ([]string, []int64, error) {
+// DeleteUser mocks base method.
+func (m *MockUserRepo) DeleteUser(ctx context.Context, userID int64) (*models.AccountDeletionResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeduplicateFile", ctx, file)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].([]int64)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID)
+	ret0, _ := ret[0].(*models.AccountDeletionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserRepoMockRecorder) DeleteUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserRepo)(nil).DeleteUser), ctx, userID)
+}
+
+// GetEmailVerificationStats mocks base method.
+func (m *MockUserRepo) GetEmailVerificationStats(ctx context.Context, userID int64, since time.Time) (*time.Time, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailVerificationStats", ctx, userID, since)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// DeduplicateFile indicates an expected call of DeduplicateFile.
-func (mr *MockUserRepoMockRecorder) DeduplicateFile(ctx, file interface{}) *gomock.Call {
+// GetEmailVerificationStats indicates an expected call of GetEmailVerificationStats.
+func (mr *MockUserRepoMockRecorder) GetEmailVerificationStats(ctx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationStats", reflect.TypeOf((*MockUserRepo)(nil).GetEmailVerificationStats), ctx, userID, since)
+}
+
+// GetUserAttachments mocks base method.
+func (m *MockUserRepo) GetUserAttachments(ctx context.Context, userID int64) ([]models.AttachmentExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAttachments", ctx, userID)
+	ret0, _ := ret[0].([]models.AttachmentExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAttachments indicates an expected call of GetUserAttachments.
+func (mr *MockUserRepoMockRecorder) GetUserAttachments(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAttachments", reflect.TypeOf((*MockUserRepo)(nil).GetUserAttachments), ctx, userID)
+}
+
+// GetUserBoardMemberships mocks base method.
+func (m *MockUserRepo) GetUserBoardMemberships(ctx context.Context, userID int64) ([]models.BoardMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBoardMemberships", ctx, userID)
+	ret0, _ := ret[0].([]models.BoardMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBoardMemberships indicates an expected call of GetUserBoardMemberships.
+func (mr *MockUserRepoMockRecorder) GetUserBoardMemberships(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeduplicateFile", reflect.TypeOf((*MockUserRepo)(nil).DeduplicateFile), ctx, file)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBoardMemberships", reflect.TypeOf((*MockUserRepo)(nil).GetUserBoardMemberships), ctx, userID)
 }
 
 // GetUserByEmail mocks base method.
@@ -221,6 +591,36 @@ func (mr *MockUserRepoMockRecorder) GetUserByEmail(ctx, email interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserRepo)(nil).GetUserByEmail), ctx, email)
 }
 
+// GetUserComments mocks base method.
+func (m *MockUserRepo) GetUserComments(ctx context.Context, userID int64) ([]models.CommentExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserComments", ctx, userID)
+	ret0, _ := ret[0].([]models.CommentExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserComments indicates an expected call of GetUserComments.
+func (mr *MockUserRepoMockRecorder) GetUserComments(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserComments", reflect.TypeOf((*MockUserRepo)(nil).GetUserComments), ctx, userID)
+}
+
+// GetUserIDByIdentity mocks base method.
+func (m *MockUserRepo) GetUserIDByIdentity(ctx context.Context, issuer, subject string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDByIdentity", ctx, issuer, subject)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDByIdentity indicates an expected call of GetUserIDByIdentity.
+func (mr *MockUserRepoMockRecorder) GetUserIDByIdentity(ctx, issuer, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDByIdentity", reflect.TypeOf((*MockUserRepo)(nil).GetUserIDByIdentity), ctx, issuer, subject)
+}
+
 // GetUserProfile mocks base method.
 func (m *MockUserRepo) GetUserProfile(ctx context.Context, userID int64) (*models.UserProfile, error) {
 	m.ctrl.T.Helper()
@@ -236,20 +636,79 @@ func (mr *MockUserRepoMockRecorder) GetUserProfile(ctx, userID interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockUserRepo)(nil).GetUserProfile), ctx, userID)
 }
 
-// RegisterFile mocks base method.
-func (m *MockUserRepo) RegisterFile(ctx context.Context, file *models.UploadedFile) error {
+// GetUserTimeEntries mocks base method.
+func (m *MockUserRepo) GetUserTimeEntries(ctx context.Context, userID int64) ([]models.TimeEntryExport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterFile", ctx, file)
+	ret := m.ctrl.Call(m, "GetUserTimeEntries", ctx, userID)
+	ret0, _ := ret[0].([]models.TimeEntryExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTimeEntries indicates an expected call of GetUserTimeEntries.
+func (mr *MockUserRepoMockRecorder) GetUserTimeEntries(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTimeEntries", reflect.TypeOf((*MockUserRepo)(nil).GetUserTimeEntries), ctx, userID)
+}
+
+// IsDisplayedImage mocks base method.
+func (m *MockUserRepo) IsDisplayedImage(ctx context.Context, fileUUID, fileExtension string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDisplayedImage", ctx, fileUUID, fileExtension)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDisplayedImage indicates an expected call of IsDisplayedImage.
+func (mr *MockUserRepoMockRecorder) IsDisplayedImage(ctx, fileUUID, fileExtension interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDisplayedImage", reflect.TypeOf((*MockUserRepo)(nil).IsDisplayedImage), ctx, fileUUID, fileExtension)
+}
+
+// LinkIdentity mocks base method.
+func (m *MockUserRepo) LinkIdentity(ctx context.Context, userID int64, issuer, subject, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIdentity", ctx, userID, issuer, subject, email)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// LinkIdentity indicates an expected call of LinkIdentity.
+func (mr *MockUserRepoMockRecorder) LinkIdentity(ctx, userID, issuer, subject, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIdentity", reflect.TypeOf((*MockUserRepo)(nil).LinkIdentity), ctx, userID, issuer, subject, email)
+}
+
+// RegisterFile mocks base method.
+func (m *MockUserRepo) RegisterFile(ctx context.Context, file *models.UploadedFile) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFile", ctx, file)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // RegisterFile indicates an expected call of RegisterFile.
 func (mr *MockUserRepoMockRecorder) RegisterFile(ctx, file interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFile", reflect.TypeOf((*MockUserRepo)(nil).RegisterFile), ctx, file)
 }
 
+// SaveOIDCLoginState mocks base method.
+func (m *MockUserRepo) SaveOIDCLoginState(ctx context.Context, stateHash, nonce, codeVerifier string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOIDCLoginState", ctx, stateHash, nonce, codeVerifier, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOIDCLoginState indicates an expected call of SaveOIDCLoginState.
+func (mr *MockUserRepoMockRecorder) SaveOIDCLoginState(ctx, stateHash, nonce, codeVerifier, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOIDCLoginState", reflect.TypeOf((*MockUserRepo)(nil).SaveOIDCLoginState), ctx, stateHash, nonce, codeVerifier, expiresAt)
+}
+
 // SetUserAvatar mocks base method.
 func (m *MockUserRepo) SetUserAvatar(ctx context.Context, userID, avatarFileID int64) error {
 	m.ctrl.T.Helper()
@@ -278,3 +737,102 @@ func (mr *MockUserRepoMockRecorder) UpdateUserProfile(ctx, userID, data interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserRepo)(nil).UpdateUserProfile), ctx, userID, data)
 }
+
+// UseEmailVerificationToken mocks base method.
+func (m *MockUserRepo) UseEmailVerificationToken(ctx context.Context, tokenHash string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseEmailVerificationToken", ctx, tokenHash)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseEmailVerificationToken indicates an expected call of UseEmailVerificationToken.
+func (mr *MockUserRepoMockRecorder) UseEmailVerificationToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseEmailVerificationToken", reflect.TypeOf((*MockUserRepo)(nil).UseEmailVerificationToken), ctx, tokenHash)
+}
+
+// UseOIDCLoginState mocks base method.
+func (m *MockUserRepo) UseOIDCLoginState(ctx context.Context, stateHash string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseOIDCLoginState", ctx, stateHash)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UseOIDCLoginState indicates an expected call of UseOIDCLoginState.
+func (mr *MockUserRepoMockRecorder) UseOIDCLoginState(ctx, stateHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseOIDCLoginState", reflect.TypeOf((*MockUserRepo)(nil).UseOIDCLoginState), ctx, stateHash)
+}
+
+// MockOIDCProvider is a mock of OIDCProvider interface.
+type MockOIDCProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCProviderMockRecorder
+}
+
+// MockOIDCProviderMockRecorder is the mock recorder for MockOIDCProvider.
+type MockOIDCProviderMockRecorder struct {
+	mock *MockOIDCProvider
+}
+
+// NewMockOIDCProvider creates a new mock instance.
+func NewMockOIDCProvider(ctrl *gomock.Controller) *MockOIDCProvider {
+	mock := &MockOIDCProvider{ctrl: ctrl}
+	mock.recorder = &MockOIDCProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCProvider) EXPECT() *MockOIDCProviderMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockOIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", ctx, state, nonce, codeChallenge)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockOIDCProviderMockRecorder) AuthCodeURL(ctx, state, nonce, codeChallenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockOIDCProvider)(nil).AuthCodeURL), ctx, state, nonce, codeChallenge)
+}
+
+// Exchange mocks base method.
+func (m *MockOIDCProvider) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, code, codeVerifier)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockOIDCProviderMockRecorder) Exchange(ctx, code, codeVerifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockOIDCProvider)(nil).Exchange), ctx, code, codeVerifier)
+}
+
+// VerifyIDToken mocks base method.
+func (m *MockOIDCProvider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*oidc.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyIDToken", ctx, rawIDToken, nonce)
+	ret0, _ := ret[0].(*oidc.Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyIDToken indicates an expected call of VerifyIDToken.
+func (mr *MockOIDCProviderMockRecorder) VerifyIDToken(ctx, rawIDToken, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyIDToken", reflect.TypeOf((*MockOIDCProvider)(nil).VerifyIDToken), ctx, rawIDToken, nonce)
+}
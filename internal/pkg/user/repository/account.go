@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/uploads"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetUserBoardMemberships возвращает доски, в которых состоит пользователь, и его роли в них
+func (r *UserRepository) GetUserBoardMemberships(ctx context.Context, userID int64) (memberships []models.BoardMembership, err error) {
+	funcName := "GetUserBoardMemberships"
+	query := `
+	SELECT b.board_id, b.name, utb.role, utb.added_at, utb.last_visit_at
+	FROM user_to_board AS utb
+	JOIN board AS b ON b.board_id=utb.board_id
+	WHERE utb.u_id=$1
+	ORDER BY utb.added_at;
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	memberships = make([]models.BoardMembership, 0)
+	for rows.Next() {
+		var m models.BoardMembership
+		if err := rows.Scan(&m.BoardID, &m.BoardName, &m.Role, &m.AddedAt, &m.LastVisitAt); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		memberships = append(memberships, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return memberships, nil
+}
+
+// GetUserComments возвращает все комментарии пользователя, в том числе на досках, из которых он вышел
+func (r *UserRepository) GetUserComments(ctx context.Context, userID int64) (comments []models.CommentExport, err error) {
+	funcName := "GetUserComments"
+	query := `
+	SELECT cc.comment_id, b.board_id, b.name, c.card_id, c.title,
+		cc.title, cc.is_edited, cc.created_at, cc.updated_at
+	FROM card_comment AS cc
+	JOIN card AS c ON c.card_id=cc.card_id
+	JOIN kanban_column AS kc ON kc.col_id=c.col_id
+	JOIN board AS b ON b.board_id=kc.board_id
+	WHERE cc.created_by=$1
+	ORDER BY cc.created_at;
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	comments = make([]models.CommentExport, 0)
+	for rows.Next() {
+		var c models.CommentExport
+		if err := rows.Scan(&c.ID, &c.BoardID, &c.BoardName, &c.CardID, &c.CardTitle,
+			&c.Text, &c.IsEdited, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return comments, nil
+}
+
+// GetUserAttachments возвращает вложения, которые прикрепил пользователь
+func (r *UserRepository) GetUserAttachments(ctx context.Context, userID int64) (attachments []models.AttachmentExport, err error) {
+	funcName := "GetUserAttachments"
+	query := `
+	SELECT ca.attachment_id, b.board_id, b.name, c.card_id, c.title,
+		ca.original_name, ca.attached_at,
+		f.file_uuid::text, COALESCE(f.file_extension, '')
+	FROM card_attachment AS ca
+	JOIN user_uploaded_file AS f ON f.file_id=ca.file_id
+	JOIN card AS c ON c.card_id=ca.card_id
+	JOIN kanban_column AS kc ON kc.col_id=c.col_id
+	JOIN board AS b ON b.board_id=kc.board_id
+	WHERE ca.attached_by=$1
+	ORDER BY ca.attached_at;
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	attachments = make([]models.AttachmentExport, 0)
+	for rows.Next() {
+		var a models.AttachmentExport
+		var fileUUID, fileExtension string
+		if err := rows.Scan(&a.ID, &a.BoardID, &a.BoardName, &a.CardID, &a.CardTitle,
+			&a.OriginalName, &a.AttachedAt, &fileUUID, &fileExtension); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		a.StoredName = uploads.JoinFilePath(fileUUID, fileExtension)
+		attachments = append(attachments, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return attachments, nil
+}
+
+// GetUserTimeEntries возвращает записи учёта времени пользователя вместе с карточками, на которых они сделаны
+func (r *UserRepository) GetUserTimeEntries(ctx context.Context, userID int64) (entries []models.TimeEntryExport, err error) {
+	funcName := "GetUserTimeEntries"
+	query := `
+	SELECT te.time_entry_id, b.board_id, b.name, c.card_id, c.title,
+		te.started_at, te.ended_at, te.comment
+	FROM card_time_entry AS te
+	JOIN card AS c ON c.card_id=te.card_id
+	JOIN kanban_column AS kc ON kc.col_id=c.col_id
+	JOIN board AS b ON b.board_id=kc.board_id
+	WHERE te.u_id=$1
+	ORDER BY te.started_at;
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	entries = make([]models.TimeEntryExport, 0)
+	for rows.Next() {
+		var e models.TimeEntryExport
+		if err := rows.Scan(&e.ID, &e.BoardID, &e.BoardName, &e.CardID, &e.CardTitle,
+			&e.StartedAt, &e.EndedAt, &e.Comment); err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+
+	return entries, nil
+}
+
+// DeleteUser удаляет пользователя в одной транзакции. На досках, где он был единственным
+// админом, админом становится участник с наибольшей ролью (при равенстве - самый давний),
+// доски без других участников удаляются. Его правила автоматизации переходят к участнику доски
+// (тоже в первую очередь к админу), чтобы продолжали работать. Комментарии, вложения и учтённое время
+// пользователя остаются на досках без автора (внешние ключи ON DELETE SET NULL), запущенный таймер останавливается
+func (r *UserRepository) DeleteUser(ctx context.Context, userID int64) (result *models.AccountDeletionResult, err error) {
+	funcName := "DeleteUser"
+	transferQuery := `
+	WITH sole_admin_boards AS (
+		SELECT utb.board_id
+		FROM user_to_board AS utb
+		WHERE utb.u_id=$1 AND utb.role='admin' AND NOT EXISTS (
+			SELECT 1 FROM user_to_board AS other
+			WHERE other.board_id=utb.board_id AND other.u_id<>$1 AND other.role='admin'
+		)
+	),
+	successors AS (
+		SELECT DISTINCT ON (utb.board_id) utb.board_id, utb.u_id
+		FROM user_to_board AS utb
+		JOIN sole_admin_boards AS sab ON sab.board_id=utb.board_id
+		WHERE utb.u_id<>$1
+		ORDER BY utb.board_id, utb.role DESC, utb.added_at
+	)
+	UPDATE user_to_board AS utb
+	SET role='admin', updated_at=CURRENT_TIMESTAMP, updated_by=NULL
+	FROM successors AS s
+	WHERE utb.board_id=s.board_id AND utb.u_id=s.u_id
+	RETURNING utb.board_id;
+	`
+	deleteBoardsQuery := `
+	DELETE FROM board
+	WHERE board_id IN (
+		SELECT utb.board_id
+		FROM user_to_board AS utb
+		WHERE utb.u_id=$1 AND NOT EXISTS (
+			SELECT 1 FROM user_to_board AS other
+			WHERE other.board_id=utb.board_id AND other.u_id<>$1
+		)
+	)
+	RETURNING board_id;
+	`
+	reassignRulesQuery := `
+	UPDATE automation_rule AS ar
+	SET created_by=(
+		SELECT utb.u_id
+		FROM user_to_board AS utb
+		WHERE utb.board_id=ar.board_id AND utb.u_id<>$1
+		ORDER BY utb.role DESC, utb.added_at
+		LIMIT 1
+	), updated_at=CURRENT_TIMESTAMP
+	WHERE ar.created_by=$1;
+	`
+	stopTimerQuery := `
+	UPDATE card_time_entry
+	SET ended_at=GREATEST(CURRENT_TIMESTAMP, started_at + INTERVAL '1 second')
+	WHERE u_id=$1 AND ended_at IS NULL;
+	`
+	deleteUserQuery := `
+	DELETE FROM "user"
+	WHERE u_id=$1;
+	`
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s (begin): %w", funcName, err)
+	}
+	defer tx.Rollback(ctx)
+
+	result = &models.AccountDeletionResult{}
+	result.TransferredBoards, err = collectIDs(tx.Query(ctx, transferQuery, userID))
+	logging.Debug(ctx, funcName, " transfer query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (transfer boards): %w", funcName, err)
+	}
+
+	result.DeletedBoards, err = collectIDs(tx.Query(ctx, deleteBoardsQuery, userID))
+	logging.Debug(ctx, funcName, " delete boards query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (delete boards): %w", funcName, err)
+	}
+
+	_, err = tx.Exec(ctx, reassignRulesQuery, userID)
+	logging.Debug(ctx, funcName, " reassign rules query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (reassign rules): %w", funcName, err)
+	}
+
+	_, err = tx.Exec(ctx, stopTimerQuery, userID)
+	logging.Debug(ctx, funcName, " stop timer query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (stop timer): %w", funcName, err)
+	}
+
+	tag, err := tx.Exec(ctx, deleteUserQuery, userID)
+	logging.Debug(ctx, funcName, " delete user query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (delete user): %w", funcName, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, fmt.Errorf("%s (delete user): %w", funcName, errs.ErrNotFound)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s (commit): %w", funcName, err)
+	}
+
+	return result, nil
+}
+
+// collectIDs вычитывает из результата запроса список ID
+func collectIDs(rows pgx.Rows, err error) ([]int64, error) {
+	if err != nil {
+		return nil, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		ids = make([]int64, 0)
+	}
+	return ids, nil
+}
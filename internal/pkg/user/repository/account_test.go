@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteUser(t *testing.T) {
+	userID := int64(42)
+
+	t.Run("transfers boards, reassigns rules, stops the timer and deletes the user", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		repo := CreateUserRepository(mock)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`UPDATE user_to_board AS utb`).WithArgs(userID).
+			WillReturnRows(pgxmock.NewRows([]string{"board_id"}).AddRow(int64(3)).AddRow(int64(5)))
+		mock.ExpectQuery(`DELETE FROM board`).WithArgs(userID).
+			WillReturnRows(pgxmock.NewRows([]string{"board_id"}).AddRow(int64(7)))
+		mock.ExpectExec(`UPDATE automation_rule AS ar`).WithArgs(userID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+		mock.ExpectExec(`UPDATE card_time_entry\s+SET ended_at=.*WHERE u_id=\$1 AND ended_at IS NULL`).WithArgs(userID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec(`DELETE FROM "user"`).WithArgs(userID).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectCommit()
+		mock.ExpectRollback()
+
+		result, err := repo.DeleteUser(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 5}, result.TransferredBoards)
+		assert.Equal(t, []int64{7}, result.DeletedBoards)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no boards", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		repo := CreateUserRepository(mock)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`UPDATE user_to_board AS utb`).WithArgs(userID).
+			WillReturnRows(pgxmock.NewRows([]string{"board_id"}))
+		mock.ExpectQuery(`DELETE FROM board`).WithArgs(userID).
+			WillReturnRows(pgxmock.NewRows([]string{"board_id"}))
+		mock.ExpectExec(`UPDATE automation_rule AS ar`).WithArgs(userID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mock.ExpectExec(`UPDATE card_time_entry`).WithArgs(userID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mock.ExpectExec(`DELETE FROM "user"`).WithArgs(userID).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectCommit()
+		mock.ExpectRollback()
+
+		result, err := repo.DeleteUser(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Empty(t, result.TransferredBoards)
+		assert.NotNil(t, result.DeletedBoards)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		repo := CreateUserRepository(mock)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`UPDATE user_to_board AS utb`).WithArgs(userID).
+			WillReturnRows(pgxmock.NewRows([]string{"board_id"}))
+		mock.ExpectQuery(`DELETE FROM board`).WithArgs(userID).
+			WillReturnRows(pgxmock.NewRows([]string{"board_id"}))
+		mock.ExpectExec(`UPDATE automation_rule AS ar`).WithArgs(userID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mock.ExpectExec(`UPDATE card_time_entry`).WithArgs(userID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mock.ExpectExec(`DELETE FROM "user"`).WithArgs(userID).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectRollback()
+
+		_, err = repo.DeleteUser(context.Background(), userID)
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetUserTimeEntries(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreateUserRepository(mock)
+
+	startedAt := time.Date(2024, 12, 2, 9, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(time.Hour)
+	mock.ExpectQuery(`FROM card_time_entry AS te`).WithArgs(int64(42)).
+		WillReturnRows(pgxmock.NewRows([]string{"time_entry_id", "board_id", "name", "card_id", "title", "started_at", "ended_at", "comment"}).
+			AddRow(int64(1), int64(3), "Clients", int64(10), "Landing", startedAt, &endedAt, "design").
+			AddRow(int64(2), int64(3), "Clients", int64(11), "Invoice", endedAt, (*time.Time)(nil), ""))
+
+	entries, err := repo.GetUserTimeEntries(context.Background(), 42)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Landing", entries[0].CardTitle)
+	assert.Equal(t, endedAt, *entries[0].EndedAt)
+	assert.Nil(t, entries[1].EndedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const pgUniqueViolation = "23505"
+
+// CreateEmailVerificationToken сохраняет хеш токена подтверждения email.
+// Прежние неиспользованные токены пользователя перестают действовать
+func (r *UserRepository) CreateEmailVerificationToken(ctx context.Context, userID int64, email string, tokenHash string, expiresAt time.Time) error {
+	funcName := "CreateEmailVerificationToken"
+	query := `
+	WITH revoke_old_tokens AS (
+		UPDATE email_verification_token
+		SET used_at=CURRENT_TIMESTAMP
+		WHERE u_id=$2 AND used_at IS NULL
+	)
+	INSERT INTO email_verification_token (token_hash, u_id, email, expires_at)
+	VALUES ($1, $2, $3, $4);
+	`
+
+	_, err := r.db.Exec(ctx, query, tokenHash, userID, email, expiresAt)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return nil
+}
+
+// UseEmailVerificationToken погашает токен подтверждения и делает email из него подтверждённым
+// email пользователя. Если токен не найден, уже использован или истёк - errs.ErrNotFound,
+// если email успел занять другой пользователь - errs.ErrBusyEmail
+func (r *UserRepository) UseEmailVerificationToken(ctx context.Context, tokenHash string) (userID int64, err error) {
+	funcName := "UseEmailVerificationToken"
+	query := `
+	WITH used_token AS (
+		UPDATE email_verification_token
+		SET used_at=CURRENT_TIMESTAMP
+		WHERE token_hash=$1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING u_id, email
+	)
+	UPDATE "user" AS u
+	SET email=t.email, email_verified_at=CURRENT_TIMESTAMP, email_verified_legacy=FALSE, updated_at=CURRENT_TIMESTAMP
+	FROM used_token AS t
+	WHERE u.u_id=t.u_id
+	RETURNING u.u_id;
+	`
+
+	err = r.db.QueryRow(ctx, query, tokenHash).Scan(&userID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", funcName, errs.ErrNotFound)
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return 0, fmt.Errorf("%s: %w", funcName, errs.ErrBusyEmail)
+		}
+		return 0, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return userID, nil
+}
+
+// GetEmailVerificationStats возвращает, когда пользователю последний раз отправлялось письмо
+// подтверждения и сколько писем отправлено начиная с since
+func (r *UserRepository) GetEmailVerificationStats(ctx context.Context, userID int64, since time.Time) (lastSentAt *time.Time, sentCount int, err error) {
+	funcName := "GetEmailVerificationStats"
+	query := `
+	SELECT MAX(created_at), COUNT(*) FILTER (WHERE created_at >= $2)
+	FROM email_verification_token
+	WHERE u_id=$1;
+	`
+
+	err = r.db.QueryRow(ctx, query, userID, since).Scan(&lastSentAt, &sentCount)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return lastSentAt, sentCount, nil
+}
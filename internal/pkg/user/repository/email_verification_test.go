@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEmailVerificationToken(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := CreateUserRepository(mock)
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	// Новый токен гасит прежние неиспользованные токены пользователя
+	mock.ExpectExec(`UPDATE email_verification_token\s+SET used_at=CURRENT_TIMESTAMP\s+WHERE u_id=\$2 AND used_at IS NULL.*INSERT INTO email_verification_token`).
+		WithArgs("hash", int64(7), "new@mail.ru", expiresAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.CreateEmailVerificationToken(context.Background(), 7, "new@mail.ru", "hash", expiresAt))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseEmailVerificationToken(t *testing.T) {
+	// Email из токена становится основным: так подтверждается и адрес при регистрации, и новый адрес при смене
+	query := `WHERE token_hash=\$1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP.*` +
+		`UPDATE "user" AS u\s+SET email=t.email, email_verified_at=CURRENT_TIMESTAMP`
+
+	tests := []struct {
+		name        string
+		queryErr    error
+		expectedErr error
+	}{
+		{name: "email is swapped"},
+		{name: "unknown, used or expired token", queryErr: pgx.ErrNoRows, expectedErr: errs.ErrNotFound},
+		{name: "email was taken meanwhile", queryErr: &pgconn.PgError{Code: pgUniqueViolation}, expectedErr: errs.ErrBusyEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+			repo := CreateUserRepository(mock)
+
+			expectation := mock.ExpectQuery(query).WithArgs("hash")
+			if tt.queryErr != nil {
+				expectation.WillReturnError(tt.queryErr)
+			} else {
+				expectation.WillReturnRows(pgxmock.NewRows([]string{"u_id"}).AddRow(int64(7)))
+			}
+
+			userID, err := repo.UseEmailVerificationToken(context.Background(), "hash")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(7), userID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
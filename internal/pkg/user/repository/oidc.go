@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SaveOIDCLoginState запоминает начатый вход через OIDC (по хешу state),
+// заодно удаляя истёкшие незавершённые входы
+func (r *UserRepository) SaveOIDCLoginState(ctx context.Context, stateHash string, nonce string, codeVerifier string, expiresAt time.Time) error {
+	funcName := "SaveOIDCLoginState"
+	query := `
+	WITH delete_expired AS (
+		DELETE FROM oidc_login_state
+		WHERE expires_at <= CURRENT_TIMESTAMP
+	)
+	INSERT INTO oidc_login_state (state_hash, nonce, code_verifier, expires_at)
+	VALUES ($1, $2, $3, $4);
+	`
+
+	_, err := r.db.Exec(ctx, query, stateHash, nonce, codeVerifier, expiresAt)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return nil
+}
+
+// UseOIDCLoginState погашает начатый вход и возвращает его nonce и PKCE verifier.
+// Если вход не найден или истёк - errs.ErrNotFound
+func (r *UserRepository) UseOIDCLoginState(ctx context.Context, stateHash string) (nonce string, codeVerifier string, err error) {
+	funcName := "UseOIDCLoginState"
+	query := `
+	DELETE FROM oidc_login_state
+	WHERE state_hash=$1
+	RETURNING nonce, code_verifier, expires_at > CURRENT_TIMESTAMP;
+	`
+
+	var active bool
+	err = r.db.QueryRow(ctx, query, stateHash).Scan(&nonce, &codeVerifier, &active)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", fmt.Errorf("%s: %w", funcName, errs.ErrNotFound)
+		}
+		return "", "", fmt.Errorf("%s: %w", funcName, err)
+	}
+	if !active {
+		return "", "", fmt.Errorf("%s: %w", funcName, errs.ErrNotFound)
+	}
+
+	return nonce, codeVerifier, nil
+}
+
+// GetUserIDByIdentity возвращает пользователя, к которому привязан аккаунт внешнего провайдера
+func (r *UserRepository) GetUserIDByIdentity(ctx context.Context, issuer string, subject string) (userID int64, err error) {
+	funcName := "GetUserIDByIdentity"
+	query := `
+	SELECT u_id
+	FROM user_identity
+	WHERE issuer=$1 AND subject=$2;
+	`
+
+	err = r.db.QueryRow(ctx, query, issuer, subject).Scan(&userID)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", funcName, errs.ErrNotFound)
+		}
+		return 0, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return userID, nil
+}
+
+// LinkIdentity привязывает аккаунт внешнего провайдера к существующему пользователю
+func (r *UserRepository) LinkIdentity(ctx context.Context, userID int64, issuer string, subject string, email string) error {
+	funcName := "LinkIdentity"
+	query := `
+	INSERT INTO user_identity (issuer, subject, u_id, email)
+	VALUES ($1, $2, $3, $4);
+	`
+
+	_, err := r.db.Exec(ctx, query, issuer, subject, userID, email)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return fmt.Errorf("%s: %w", funcName, errs.ErrAlreadyExists)
+		}
+		return fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return nil
+}
+
+// CreateSSOUser создаёт пользователя без пароля, с уже подтверждённым провайдером email,
+// и сразу привязывает к нему аккаунт провайдера. Занятые nickname и email -
+// errs.ErrBusyNickname и errs.ErrBusyEmail
+func (r *UserRepository) CreateSSOUser(ctx context.Context, nickname string, email string, issuer string, subject string) (newUser *models.UserProfile, err error) {
+	funcName := "CreateSSOUser"
+	query := `
+	WITH new_user AS (
+		INSERT INTO "user" (nickname, email, email_verified_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		RETURNING u_id, nickname, email, joined_at, updated_at
+	), new_identity AS (
+		INSERT INTO user_identity (issuer, subject, u_id, email)
+		SELECT $3, $4, u_id, email FROM new_user
+	)
+	SELECT u_id, nickname, email, joined_at, updated_at
+	FROM new_user;
+	`
+
+	newUser = &models.UserProfile{EmailVerified: true}
+	err = r.db.QueryRow(ctx, query, nickname, email, issuer, subject).Scan(
+		&newUser.ID,
+		&newUser.Name,
+		&newUser.Email,
+		&newUser.JoinedAt,
+		&newUser.UpdatedAt,
+	)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			switch pgErr.ConstraintName {
+			case "user_nickname_key":
+				return nil, fmt.Errorf("%s: %w", funcName, errs.ErrBusyNickname)
+			case "user_email_key":
+				return nil, fmt.Errorf("%s: %w", funcName, errs.ErrBusyEmail)
+			default:
+				return nil, fmt.Errorf("%s: %w", funcName, errs.ErrAlreadyExists)
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	return newUser, nil
+}
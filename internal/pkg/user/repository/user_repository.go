@@ -9,7 +9,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/jackc/pgx/v5"
 )
@@ -31,6 +30,13 @@ func (r *UserRepository) GetUserProfile(ctx context.Context, userID int64) (prof
 		u.u_id,
 		u.nickname,
 		u.email,
+		u.email_verified_at IS NOT NULL,
+		(
+			SELECT evt.email FROM email_verification_token AS evt
+			WHERE evt.u_id=u.u_id AND evt.email<>u.email
+				AND evt.used_at IS NULL AND evt.expires_at > CURRENT_TIMESTAMP
+			ORDER BY evt.created_at DESC LIMIT 1
+		),
 		u.joined_at,
 		u.updated_at,
 		COALESCE(f.file_uuid::text, ''),
@@ -47,6 +53,8 @@ func (r *UserRepository) GetUserProfile(ctx context.Context, userID int64) (prof
 		&user.ID,
 		&user.Name,
 		&user.Email,
+		&user.EmailVerified,
+		&user.PendingEmail,
 		&user.JoinedAt,
 		&user.UpdatedAt,
 		&fileUUID,
@@ -59,7 +67,8 @@ func (r *UserRepository) GetUserProfile(ctx context.Context, userID int64) (prof
 		}
 		return nil, fmt.Errorf("GetUserProfile: %w", err)
 	}
-	user.AvatarImageURL = uploads.JoinFileURL(fileUUID, fileExt, uploads.DefaultAvatarURL)
+	user.AvatarImageURL = uploads.JoinImageURL(fileUUID, fileExt, uploads.DefaultAvatarURL)
+	user.AvatarImageVariants = uploads.JoinVariantURLs(fileUUID, fileExt, uploads.DefaultAvatarURL)
 
 	return &user, nil
 }
@@ -68,10 +77,11 @@ func (r *UserRepository) GetUserProfile(ctx context.Context, userID int64) (prof
 func (r *UserRepository) UpdateUserProfile(ctx context.Context, userID int64, data models.UserProfileUpdateRequest) (newProfile *models.UserProfile, err error) {
 	query1 := `SELECT COUNT(*) FROM "user" WHERE email=$1 AND u_id!=$2;`
 	query2 := `SELECT COUNT(*) FROM "user" WHERE nickname=$1 AND u_id!=$2;`
+	// Email меняется только после подтверждения, здесь он лишь проверяется на уникальность
 	query3 := `
 	UPDATE "user"
-	SET email=$1, nickname=$2
-	WHERE u_id=$3;`
+	SET nickname=$1
+	WHERE u_id=$2;`
 	var nicknameCount, emailCount int
 	row := r.db.QueryRow(ctx, query1, data.Email, userID)
 	err = row.Scan(&emailCount)
@@ -94,7 +104,7 @@ func (r *UserRepository) UpdateUserProfile(ctx context.Context, userID int64, da
 	if emailCount != 0 {
 		return nil, fmt.Errorf("UpdateUserProfile (check unique): %w", errs.ErrBusyEmail)
 	}
-	tag, err := r.db.Exec(ctx, query3, data.Email, data.NewName, userID)
+	tag, err := r.db.Exec(ctx, query3, data.NewName, userID)
 	logging.Debug(ctx, "UpdateUserProfile query 3 has err: ", err)
 	if err != nil {
 		return nil, fmt.Errorf("UpdateUserProfile (action): %w", err)
@@ -125,8 +135,8 @@ func (r *UserRepository) SetUserAvatar(ctx context.Context, userID int64, avatar
 // GetUserByEmail получает данные пользователя из базы по email
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (user *models.UserProfile, err error) {
 	query := `
-	SELECT u_id, nickname, email,
-	joined_at, updated_at
+	SELECT u_id, nickname, email, email_verified_at IS NOT NULL,
+	email_verified_legacy, joined_at, updated_at
 	FROM "user"
 	WHERE email=$1;`
 	user = &models.UserProfile{}
@@ -134,6 +144,8 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (user
 		&user.ID,
 		&user.Name,
 		&user.Email,
+		&user.EmailVerified,
+		&user.EmailVerifiedLegacy,
 		&user.JoinedAt,
 		&user.UpdatedAt,
 	)
@@ -148,12 +160,12 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (user
 }
 
 // CreateUser создаёт пользователя (или не создаёт, если повторяются креды)
-func (r *UserRepository) CreateUser(ctx context.Context, user *models.UserRegisterRequest) (newUser *models.UserProfile, err error) {
+func (r *UserRepository) CreateUser(ctx context.Context, user *models.UserRegisterRequest, passwordHash string) (newUser *models.UserProfile, err error) {
 	newUser = &models.UserProfile{}
-	query := `INSERT INTO "user" (nickname, email, password_hash, csat_poll_dt)
-              VALUES ($1, $2, NULL, (CURRENT_TIMESTAMP+$3)) RETURNING u_id, nickname, email, joined_at, updated_at`
+	query := `INSERT INTO "user" (nickname, email, password_hash)
+              VALUES ($1, $2, $3) RETURNING u_id, nickname, email, joined_at, updated_at`
 
-	err = r.db.QueryRow(ctx, query, user.Name, user.Email, 24*7*time.Hour).Scan(
+	err = r.db.QueryRow(ctx, query, user.Name, user.Email, passwordHash).Scan(
 		&newUser.ID,
 		&newUser.Name,
 		&newUser.Email,
@@ -169,7 +181,7 @@ func (r *UserRepository) CheckUniqueCredentials(ctx context.Context, nickname st
 	funcName := `UserRepository.CheckUniqueCredentials`
 	query := `SELECT nickname, email FROM "user" WHERE nickname = $1 OR email=$2;`
 	var emailCount, nicknameCount int
-	rows, err := r.db.Query(ctx, query, nickname)
+	rows, err := r.db.Query(ctx, query, nickname, email)
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -200,129 +212,30 @@ func (r *UserRepository) CheckUniqueCredentials(ctx context.Context, nickname st
 	return nil
 }
 
-func (r *UserRepository) DeduplicateFile(ctx context.Context, file *models.UploadedFile) (fileNames []string, fileIDs []int64, err error) {
-	return uploads.DeduplicateFile(ctx, r.db, file)
-}
-func (r *UserRepository) RegisterFile(ctx context.Context, file *models.UploadedFile) error {
+func (r *UserRepository) RegisterFile(ctx context.Context, file *models.UploadedFile) (isNew bool, err error) {
 	return uploads.RegisterFile(ctx, r.db, file)
 }
 
-func (r *UserRepository) SubmitPoll(ctx context.Context, userID int64, PollSubmit *models.PollSubmit) error {
-	funcName := "SubmitPoll"
-	query := `
-	INSERT INTO csat_results (question_id, rating, comment, u_id, created_at) VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP);
-	`
-
-	_, err := r.db.Exec(ctx, query, PollSubmit.QuestionID, PollSubmit.Rating, PollSubmit.Text, userID)
-	logging.Debug(ctx, funcName, " query has err: ", err)
-	if err != nil {
-		return fmt.Errorf("SubmitPoll (query): %w", err)
-	}
-
-	return nil
-}
-
-func (r *UserRepository) GetRatingResults(ctx context.Context) (results []models.RatingResults, err error) {
-	funcName := "GetRatingResults"
+// IsDisplayedImage проверяет, что файл стоит аватаркой пользователя, фоном доски или обложкой карточки
+func (r *UserRepository) IsDisplayedImage(ctx context.Context, fileUUID string, fileExtension string) (isDisplayed bool, err error) {
+	funcName := "IsDisplayedImage"
 	query := `
-	SELECT cq.question_text, AVG(cr.rating) AS rating FROM csat_results AS cr
-	JOIN csat_question AS cq ON cr.question_id = cq.question_id
-	WHERE cr.created_at >= CURRENT_TIMESTAMP - INTERVAL '7 days' AND cq.type='answer_rating'
-	GROUP BY cq.question_id, cr.rating;	
+	SELECT EXISTS (
+		SELECT 1
+		FROM user_uploaded_file AS f
+		WHERE f.file_uuid=$1 AND COALESCE(f.file_extension, '')=$2 AND (
+			EXISTS (SELECT 1 FROM "user" AS u WHERE u.avatar_file_id=f.file_id)
+			OR EXISTS (SELECT 1 FROM board AS b WHERE b.background_image_id=f.file_id)
+			OR EXISTS (SELECT 1 FROM card AS c WHERE c.cover_file_id=f.file_id)
+		)
+	);
 	`
 
-	rows, err := r.db.Query(ctx, query)
+	err = r.db.QueryRow(ctx, query, fileUUID, fileExtension).Scan(&isDisplayed)
 	logging.Debug(ctx, funcName, " query has err: ", err)
 	if err != nil {
-		return nil, fmt.Errorf("GetRatingResults (query): %w", err)
-	}
-
-	for rows.Next() {
-		result := models.RatingResults{}
-		if err := rows.Scan(&result.Question, &result.Rating); err != nil {
-			return nil, fmt.Errorf("GetRatingResults (scan): %w", err)
-		}
-		results = append(results, result)
-	}
-
-	return results, nil
-}
-
-func (r *UserRepository) GetTextResults(ctx context.Context) (results []models.AnswerResults, err error) {
-	funcName := "GetTextResults"
-	query := `
-	SELECT cr.comment, cq.question_text FROM csat_results AS cr
-	JOIN csat_question AS cq ON cr.question_id = cq.question_id
-	WHERE cr.created_at >= CURRENT_TIMESTAMP - INTERVAL '7 days' AND cq.type='answer_text'
-	ORDER BY cq.question_id;
-	`
-
-	rows, err := r.db.Query(ctx, query)
-	logging.Debug(ctx, funcName, " query has err: ", err)
-	if err != nil {
-		return nil, fmt.Errorf("GetTextResults (query): %w", err)
-	}
-
-	for rows.Next() {
-		var q, a string
-		if err := rows.Scan(&a, &q); err != nil {
-			return nil, fmt.Errorf("GetTextResults (scan): %w", err)
-		}
-		if len(results) == 0 {
-			results = append(results, models.AnswerResults{
-				Question: q,
-				Text:     []string{a},
-			})
-		} else {
-			if results[len(results)-1].Question == q {
-				results[len(results)-1].Text = append(results[len(results)-1].Text, a)
-			} else {
-				results = append(results, models.AnswerResults{
-					Question: q,
-					Text:     []string{a},
-				})
-			}
-		}
-	}
-
-	return results, nil
-}
-
-func (r *UserRepository) SetNextPollDT(ctx context.Context, userID int64) error {
-	funcName := "SetNextPollDate"
-	query := `
-	UPDATE "user" SET csat_poll_dt=(CURRENT_TIMESTAMP+$2) WHERE u_id=$1;
-	`
-
-	_, err := r.db.Exec(ctx, query, userID, 24*7*time.Hour)
-	logging.Debug(ctx, funcName, " query has err: ", err)
-	if err != nil {
-		return fmt.Errorf("SetNextPollDate (query): %w", err)
-	}
-
-	return nil
-}
-
-func (r *UserRepository) PickPollQuestions(ctx context.Context) (pollQuestions []models.PollQuestion, err error) {
-	funcName := "PickPollQuestions"
-	query := `
-	SELECT cq.question_id, cq.question_text, cq.type
-	FROM csat_question AS cq;
-	`
-
-	rows, err := r.db.Query(ctx, query)
-	logging.Debug(ctx, funcName, " query has err: ", err)
-	if err != nil {
-		return nil, fmt.Errorf("PickPollQuestions (query): %w", err)
-	}
-
-	for rows.Next() {
-		pollQuestion := models.PollQuestion{}
-		if err := rows.Scan(&pollQuestion.QuestionID, &pollQuestion.QuestionText, &pollQuestion.QuestionType); err != nil {
-			return nil, fmt.Errorf("PickPollQuestions (scan): %w", err)
-		}
-		pollQuestions = append(pollQuestions, pollQuestion)
+		return false, fmt.Errorf("%s (query): %w", funcName, err)
 	}
 
-	return pollQuestions, nil
+	return isDisplayed, nil
 }
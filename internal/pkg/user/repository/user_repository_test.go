@@ -1,11 +1,14 @@
 package repository
 
 import (
+	"RPO_back/internal/errs"
 	"RPO_back/internal/models"
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v4"
 )
 
@@ -20,42 +23,39 @@ func TestGetUserByEmail_Success(t *testing.T) {
 
 	repo := &UserRepository{db: mock}
 
-	query := `SELECT u_id, nickname, email, description, joined_at, updated_at, password_hash FROM "user" WHERE email=\$1;`
-	rows := pgxmock.NewRows([]string{"u_id", "nickname", "email", "description", "joined_at", "updated_at", "password_hash"}).
-		AddRow(1, "testnickname", email, "test description", time.Now(), time.Now(), "hashedpassword")
+	rows := pgxmock.NewRows([]string{"u_id", "nickname", "email", "email_verified", "email_verified_legacy", "joined_at", "updated_at"}).
+		AddRow(int64(1), "testnickname", email, true, true, time.Now(), time.Now())
 
-	mock.ExpectQuery(query).WithArgs(email).WillReturnRows(rows)
+	mock.ExpectQuery(`FROM "user"\s+WHERE email=\$1;`).WithArgs(email).WillReturnRows(rows)
 
-	_, err = repo.GetUserByEmail(ctx, email)
+	user, err := repo.GetUserByEmail(ctx, email)
 	if err != nil {
 		t.Errorf("expected no error, but got %v", err)
 	}
+	if user.ID != 1 || !user.EmailVerified || !user.EmailVerifiedLegacy {
+		t.Errorf("unexpected user %+v", user)
+	}
 }
 
-func TestGetUserByID_Success(t *testing.T) {
+func TestGetUserByEmail_NotFound(t *testing.T) {
 	ctx := context.Background()
 	mock, err := pgxmock.NewConn()
 	if err != nil {
 		t.Fatalf("unable to create mock connection: %v", err)
 	}
 	defer mock.Close(ctx)
-	email := "kaymekaydex@mail.ru"
 
 	repo := &UserRepository{db: mock}
 
-	query := `SELECT u_id, nickname, email, description, joined_at, updated_at, password_hash FROM "user" WHERE u_id=\$1;`
-	rows := pgxmock.NewRows([]string{"u_id", "nickname", "email", "description", "joined_at", "updated_at", "password_hash"}).
-		AddRow(1337, "testnickname", email, "test description", time.Now(), time.Now(), "hashedpassword")
-
-	mock.ExpectQuery(query).WithArgs(1337).WillReturnRows(rows)
+	mock.ExpectQuery(`FROM "user"\s+WHERE email=\$1;`).WithArgs("nobody@mail.ru").WillReturnError(pgx.ErrNoRows)
 
-	_, err = repo.GetUserByID(ctx, 1337)
-	if err != nil {
-		t.Errorf("expected no error, but got %v", err)
+	_, err = repo.GetUserByEmail(ctx, "nobody@mail.ru")
+	if !errors.Is(err, errs.ErrWrongCredentials) {
+		t.Errorf("expected ErrWrongCredentials, but got %v", err)
 	}
 }
 
-func TestCreateUser_Success(t *testing.T) {
+func TestGetUserProfile_Success(t *testing.T) {
 	ctx := context.Background()
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -65,19 +65,22 @@ func TestCreateUser_Success(t *testing.T) {
 
 	repo := &UserRepository{db: mock}
 
-	query := `INSERT INTO "user" \(nickname, email, password_hash, description, joined_at, updated_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING u_id, nickname, email, password_hash, description, joined_at, updated_at`
-	rows := pgxmock.NewRows([]string{"u_id", "nickname", "email", "password_hash", "description", "joined_at", "updated_at"}).
-		AddRow(1, "testnickname", "testemail", "hashedpassword", "", time.Now(), time.Now())
+	pendingEmail := "new@mail.ru"
+	rows := pgxmock.NewRows([]string{"u_id", "nickname", "email", "email_verified", "pending_email", "joined_at", "updated_at", "file_uuid", "file_extension"}).
+		AddRow(int64(1337), "testnickname", "kaymekaydex@mail.ru", false, &pendingEmail, time.Now(), time.Now(), "", "")
 
-	mock.ExpectQuery(query).WithArgs("testnickname", "testemail", "hashedpassword", "", pgxmock.AnyArg(), pgxmock.AnyArg()).WillReturnRows(rows)
+	mock.ExpectQuery(`FROM "user" AS u`).WithArgs(int64(1337)).WillReturnRows(rows)
 
-	_, err = repo.CreateUser(ctx, &models.UserRegisterRequest{Name: "testnickname", Email: "testemail"}, "hashedpassword")
+	profile, err := repo.GetUserProfile(ctx, 1337)
 	if err != nil {
-		t.Errorf("expected no error, but got %v", err)
+		t.Fatalf("expected no error, but got %v", err)
+	}
+	if profile.PendingEmail == nil || *profile.PendingEmail != pendingEmail {
+		t.Errorf("expected pending email %s, got %v", pendingEmail, profile.PendingEmail)
 	}
 }
 
-func TestCheckUniqueCredentials_Success(t *testing.T) {
+func TestCreateUser_Success(t *testing.T) {
 	ctx := context.Background()
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -87,19 +90,19 @@ func TestCheckUniqueCredentials_Success(t *testing.T) {
 
 	repo := &UserRepository{db: mock}
 
-	query1 := `SELECT COUNT\(\*\) FROM "user" WHERE nickname = \$1;`
-	query2 := `SELECT COUNT\(\*\) FROM "user" WHERE email = \$1;`
+	query := `INSERT INTO "user" \(nickname, email, password_hash\)`
+	rows := pgxmock.NewRows([]string{"u_id", "nickname", "email", "joined_at", "updated_at"}).
+		AddRow(int64(1), "testnickname", "testemail", time.Now(), time.Now())
 
-	mock.ExpectQuery(query1).WithArgs("testnickname").WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
-	mock.ExpectQuery(query2).WithArgs("testemail").WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(query).WithArgs("testnickname", "testemail", "hashedpassword").WillReturnRows(rows)
 
-	err = repo.CheckUniqueCredentials(ctx, "testnickname", "testemail")
+	_, err = repo.CreateUser(ctx, &models.UserRegisterRequest{Name: "testnickname", Email: "testemail"}, "hashedpassword")
 	if err != nil {
 		t.Errorf("expected no error, but got %v", err)
 	}
 }
 
-func TestSetNewPasswordHash_Success(t *testing.T) {
+func TestCheckUniqueCredentials(t *testing.T) {
 	ctx := context.Background()
 	mock, err := pgxmock.NewConn()
 	if err != nil {
@@ -109,12 +112,21 @@ func TestSetNewPasswordHash_Success(t *testing.T) {
 
 	repo := &UserRepository{db: mock}
 
-	query := `UPDATE "user" SET password_hash=\$1 WHERE u_id=\$2;`
+	query := `SELECT nickname, email FROM "user" WHERE nickname = \$1 OR email=\$2;`
 
-	mock.ExpectExec(query).WithArgs("newhashedpassword", 228).WillReturnResult(pgxmock.NewResult("1", 1))
+	mock.ExpectQuery(query).WithArgs("testnickname", "testemail").
+		WillReturnRows(pgxmock.NewRows([]string{"nickname", "email"}))
 
-	err = repo.SetNewPasswordHash(ctx, 228, "newhashedpassword")
+	err = repo.CheckUniqueCredentials(ctx, "testnickname", "testemail")
 	if err != nil {
 		t.Errorf("expected no error, but got %v", err)
 	}
+
+	mock.ExpectQuery(query).WithArgs("testnickname", "testemail").
+		WillReturnRows(pgxmock.NewRows([]string{"nickname", "email"}).AddRow("othernickname", "testemail"))
+
+	err = repo.CheckUniqueCredentials(ctx, "testnickname", "testemail")
+	if !errors.Is(err, errs.ErrBusyEmail) || errors.Is(err, errs.ErrBusyNickname) {
+		t.Errorf("expected only ErrBusyEmail, but got %v", err)
+	}
 }
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"RPO_back/internal/models"
+	authGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreateAccessToken создаёт персональный токен доступа для пользователя текущей сессии
+func (uc *UserUsecase) CreateAccessToken(ctx context.Context, sessionID string, data *models.AccessTokenCreateRequest) (token *models.AccessToken, err error) {
+	request := &authGRPC.CreateAccessTokenRequest{
+		SessionID: sessionID,
+		Name:      data.Name,
+		Scopes:    data.Scopes,
+	}
+	if data.ExpiresInDays != nil {
+		request.ExpiresAt = time.Now().AddDate(0, 0, *data.ExpiresInDays).Unix()
+	}
+
+	responce, err := uc.authClient.CreateAccessToken(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("CreateAccessToken (GRPC request): %w", err)
+	}
+
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return nil, fmt.Errorf("CreateAccessToken (GRPC response): %w", err)
+	}
+
+	return accessTokenFromGRPC(responce), nil
+}
+
+// ListAccessTokens возвращает персональные токены пользователя текущей сессии
+func (uc *UserUsecase) ListAccessTokens(ctx context.Context, sessionID string) (tokens []models.AccessToken, err error) {
+	responce, err := uc.authClient.ListAccessTokens(ctx, &authGRPC.CheckSessionRequest{SessionID: sessionID})
+	if err != nil {
+		return nil, fmt.Errorf("ListAccessTokens (GRPC request): %w", err)
+	}
+
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return nil, fmt.Errorf("ListAccessTokens (GRPC response): %w", err)
+	}
+
+	tokens = make([]models.AccessToken, 0, len(responce.GetTokens()))
+	for _, token := range responce.GetTokens() {
+		tokens = append(tokens, *accessTokenFromGRPC(token))
+	}
+
+	return tokens, nil
+}
+
+// RevokeAccessToken отзывает персональный токен пользователя текущей сессии
+func (uc *UserUsecase) RevokeAccessToken(ctx context.Context, sessionID string, tokenID int64) error {
+	responce, err := uc.authClient.RevokeAccessToken(ctx, &authGRPC.RevokeAccessTokenRequest{
+		SessionID: sessionID,
+		TokenID:   tokenID,
+	})
+	if err != nil {
+		return fmt.Errorf("RevokeAccessToken (GRPC request): %w", err)
+	}
+
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return fmt.Errorf("RevokeAccessToken (GRPC response): %w", err)
+	}
+
+	return nil
+}
+
+// accessTokenFromGRPC переводит персональный токен из gRPC-сообщения; нулевые даты означают их отсутствие
+func accessTokenFromGRPC(message *authGRPC.AccessToken) *models.AccessToken {
+	token := &models.AccessToken{
+		ID:        message.GetId(),
+		Token:     message.GetToken(),
+		Name:      message.GetName(),
+		Scopes:    message.GetScopes(),
+		CreatedAt: time.Unix(message.GetCreatedAt(), 0),
+	}
+	if message.GetExpiresAt() != 0 {
+		expiresAt := time.Unix(message.GetExpiresAt(), 0)
+		token.ExpiresAt = &expiresAt
+	}
+	if message.GetLastUsedAt() != 0 {
+		lastUsedAt := time.Unix(message.GetLastUsedAt(), 0)
+		token.LastUsedAt = &lastUsedAt
+	}
+	return token
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"RPO_back/internal/models"
+	authGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	"RPO_back/internal/pkg/utils/logging"
+	"context"
+	"fmt"
+	"time"
+)
+
+// ExportMyData собирает персональные данные пользователя: профиль, участие в досках,
+// комментарии и прикреплённые им вложения
+func (uc *UserUsecase) ExportMyData(ctx context.Context, userID int64) (export *models.UserDataExport, err error) {
+	export = &models.UserDataExport{ExportedAt: time.Now()}
+
+	export.Profile, err = uc.userRepo.GetUserProfile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ExportMyData (GetUserProfile): %w", err)
+	}
+
+	export.Boards, err = uc.userRepo.GetUserBoardMemberships(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ExportMyData (GetUserBoardMemberships): %w", err)
+	}
+
+	export.Comments, err = uc.userRepo.GetUserComments(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ExportMyData (GetUserComments): %w", err)
+	}
+
+	export.Attachments, err = uc.userRepo.GetUserAttachments(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ExportMyData (GetUserAttachments): %w", err)
+	}
+
+	export.TimeEntries, err = uc.userRepo.GetUserTimeEntries(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ExportMyData (GetUserTimeEntries): %w", err)
+	}
+
+	return export, nil
+}
+
+// DeleteMyAccount удаляет аккаунт владельца сессии после проверки пароля (у пользователя без пароля -
+// недавнего входа) и завершает все его сессии.
+// Написанное пользователем на общих досках остаётся, но без автора
+func (uc *UserUsecase) DeleteMyAccount(ctx context.Context, sessionID string, password string) (result *models.AccountDeletionResult, err error) {
+	responce, err := uc.authClient.CheckPassword(ctx, &authGRPC.CheckPasswordRequest{
+		SessionID: sessionID,
+		Password:  password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DeleteMyAccount (GRPC request): %w", err)
+	}
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return nil, fmt.Errorf("DeleteMyAccount (GRPC response): %w", err)
+	}
+
+	result, err = uc.userRepo.DeleteUser(ctx, responce.GetUserID())
+	if err != nil {
+		return nil, fmt.Errorf("DeleteMyAccount (DeleteUser): %w", err)
+	}
+
+	// Пользователя уже нет, поэтому ошибки здесь не возвращаются: его сессии всё равно не пройдут проверку
+	if err := uc.RevokeOtherSessions(ctx, sessionID); err != nil {
+		logging.Warn(ctx, "DeleteMyAccount (RevokeOtherSessions): ", err)
+	}
+	if err := uc.LogoutUser(ctx, sessionID); err != nil {
+		logging.Warn(ctx, "DeleteMyAccount (LogoutUser): ", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/mailer"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	emailVerificationTokenLifeTime = 24 * time.Hour
+	emailVerificationMinInterval   = time.Minute // Письма подтверждения можно запрашивать не чаще раза в минуту
+	emailVerificationHourlyLimit   = 5           // и не больше 5 писем в час
+)
+
+// VerifyEmail подтверждает email по токену из письма. Если это был новый email, он становится основным
+func (uc *UserUsecase) VerifyEmail(ctx context.Context, token string) error {
+	_, err := uc.userRepo.UseEmailVerificationToken(ctx, encrypt.HashToken(token))
+	if err != nil {
+		return fmt.Errorf("VerifyEmail: %w", err)
+	}
+
+	return nil
+}
+
+// ResendEmailVerification повторно отправляет письмо подтверждения на новый email,
+// а если его нет - на текущий неподтверждённый
+func (uc *UserUsecase) ResendEmailVerification(ctx context.Context, userID int64) error {
+	profile, err := uc.userRepo.GetUserProfile(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("ResendEmailVerification (GetUserProfile): %w", err)
+	}
+
+	email := profile.Email
+	if profile.PendingEmail != nil {
+		email = *profile.PendingEmail
+	} else if profile.EmailVerified {
+		return fmt.Errorf("ResendEmailVerification: email is already verified: %w", errs.ErrBadRequest)
+	}
+
+	if err = uc.checkEmailVerificationThrottle(ctx, userID); err != nil {
+		return fmt.Errorf("ResendEmailVerification: %w", err)
+	}
+
+	err = uc.sendEmailVerification(ctx, userID, email)
+	if err != nil {
+		return fmt.Errorf("ResendEmailVerification (sendEmailVerification): %w", err)
+	}
+
+	return nil
+}
+
+// ResendEmailVerificationByEmail повторно отправляет письмо подтверждения по email без входа в аккаунт
+// (когда вход с неподтверждённым email запрещён). Чтобы по ответу нельзя было узнать, есть ли такой
+// пользователь, неизвестный или уже подтверждённый email и превышение лимита писем ошибкой не считаются.
+// Email, подтверждённый только миграцией, можно подтвердить письмом, чтобы к нему привязался вход через OIDC
+func (uc *UserUsecase) ResendEmailVerificationByEmail(ctx context.Context, email string) error {
+	user, err := uc.userRepo.GetUserByEmail(ctx, email)
+	if errors.Is(err, errs.ErrWrongCredentials) {
+		logging.Info(ctx, "ResendEmailVerificationByEmail: no user with this email")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("ResendEmailVerificationByEmail (GetUserByEmail): %w", err)
+	}
+	if user.EmailVerified && !user.EmailVerifiedLegacy {
+		logging.Info(ctx, "ResendEmailVerificationByEmail: email of user ", user.ID, " is already verified")
+		return nil
+	}
+
+	err = uc.checkEmailVerificationThrottle(ctx, user.ID)
+	if errors.Is(err, errs.ErrTooManyRequests) {
+		logging.Warn(ctx, "ResendEmailVerificationByEmail: ", err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("ResendEmailVerificationByEmail: %w", err)
+	}
+
+	err = uc.sendEmailVerification(ctx, user.ID, user.Email)
+	if err != nil {
+		return fmt.Errorf("ResendEmailVerificationByEmail (sendEmailVerification): %w", err)
+	}
+
+	return nil
+}
+
+// checkEmailVerificationThrottle не даёт слать письма подтверждения слишком часто
+func (uc *UserUsecase) checkEmailVerificationThrottle(ctx context.Context, userID int64) error {
+	now := time.Now()
+	lastSentAt, sentCount, err := uc.userRepo.GetEmailVerificationStats(ctx, userID, now.Add(-time.Hour))
+	if err != nil {
+		return fmt.Errorf("checkEmailVerificationThrottle (GetEmailVerificationStats): %w", err)
+	}
+
+	if lastSentAt != nil && now.Sub(*lastSentAt) < emailVerificationMinInterval {
+		return fmt.Errorf("checkEmailVerificationThrottle: last mail was sent at %s: %w", lastSentAt.Format(time.RFC3339), errs.ErrTooManyRequests)
+	}
+	if sentCount >= emailVerificationHourlyLimit {
+		return fmt.Errorf("checkEmailVerificationThrottle: %d mails sent in the last hour: %w", sentCount, errs.ErrTooManyRequests)
+	}
+
+	return nil
+}
+
+// sendEmailVerification создаёт токен подтверждения email и отправляет письмо со ссылкой
+func (uc *UserUsecase) sendEmailVerification(ctx context.Context, userID int64, email string) error {
+	token := encrypt.GenerateSessionID()
+	err := uc.userRepo.CreateEmailVerificationToken(ctx, userID, email, encrypt.HashToken(token), time.Now().Add(emailVerificationTokenLifeTime))
+	if err != nil {
+		return fmt.Errorf("sendEmailVerification (CreateEmailVerificationToken): %w", err)
+	}
+
+	link := token
+	if uc.emailVerificationURL != "" {
+		link = uc.emailVerificationURL + "?token=" + url.QueryEscape(token)
+	}
+
+	err = uc.mailer.Send(ctx, mailer.Message{
+		To:      email,
+		Subject: "Подтверждение email",
+		Body: fmt.Sprintf("Здравствуйте!\n\n"+
+			"Чтобы подтвердить этот адрес для вашего аккаунта, перейдите по ссылке:\n%s\n\n"+
+			"Ссылка действует %d часа. Если вы не регистрировались и не меняли email, просто проигнорируйте это письмо.\n",
+			link, int(emailVerificationTokenLifeTime.Hours())),
+	})
+	if err != nil {
+		return fmt.Errorf("sendEmailVerification (Send): %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	authGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	mock_auth "RPO_back/internal/pkg/auth/mocks"
+	mocks "RPO_back/internal/pkg/user/mocks"
+	"RPO_back/internal/pkg/user/usecase"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"RPO_back/internal/pkg/utils/mailer"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sentMails запоминает письма вместо отправки
+type sentMails struct {
+	messages []mailer.Message
+}
+
+func (m *sentMails) Send(ctx context.Context, msg mailer.Message) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func TestUserUsecase_RegisterUserWithoutUnverifiedLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepo(ctrl)
+	mockAuthClient := mock_auth.NewMockAuthClient(ctrl)
+	mails := &sentMails{}
+	userUsecase := usecase.CreateUserUsecase(mockUserRepo, mockAuthClient, mails, "", false, nil, false, nil, nil)
+
+	request := &models.UserRegisterRequest{Name: "newuser", Email: "new@mail.ru", Password: "password123"}
+	mockAuthClient.EXPECT().CheckLoginLockout(gomock.Any(), gomock.Any()).Return(&authGRPC.Session{}, nil)
+	mockUserRepo.EXPECT().CreateUser(gomock.Any(), request, gomock.Any()).Return(&models.UserProfile{ID: 7, Email: request.Email}, nil)
+	mockUserRepo.EXPECT().CreateEmailVerificationToken(gomock.Any(), int64(7), request.Email, gomock.Any(), gomock.Any()).Return(nil)
+	// CreateSession не ожидается: до подтверждения email сессия не нужна
+
+	sessionID, err := userUsecase.RegisterUser(context.Background(), request, "10.0.0.1", "test")
+	require.NoError(t, err)
+	assert.Empty(t, sessionID)
+	require.Len(t, mails.messages, 1)
+	assert.Equal(t, request.Email, mails.messages[0].To)
+}
+
+func TestUserUsecase_ResendEmailVerificationByEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepo(ctrl)
+	mails := &sentMails{}
+	userUsecase := usecase.CreateUserUsecase(mockUserRepo, nil, mails, "", false, nil, false, nil, nil)
+	ctx := context.Background()
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "nobody@mail.ru").Return(nil, errs.ErrWrongCredentials)
+
+		assert.NoError(t, userUsecase.ResendEmailVerificationByEmail(ctx, "nobody@mail.ru"))
+		assert.Empty(t, mails.messages)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "done@mail.ru").Return(&models.UserProfile{ID: 1, Email: "done@mail.ru", EmailVerified: true}, nil)
+
+		assert.NoError(t, userUsecase.ResendEmailVerificationByEmail(ctx, "done@mail.ru"))
+		assert.Empty(t, mails.messages)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		lastSentAt := time.Now().Add(-10 * time.Second)
+		mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "new@mail.ru").Return(&models.UserProfile{ID: 7, Email: "new@mail.ru"}, nil)
+		mockUserRepo.EXPECT().GetEmailVerificationStats(gomock.Any(), int64(7), gomock.Any()).Return(&lastSentAt, 1, nil)
+
+		assert.NoError(t, userUsecase.ResendEmailVerificationByEmail(ctx, "new@mail.ru"))
+		assert.Empty(t, mails.messages)
+	})
+
+	t.Run("sent", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "new@mail.ru").Return(&models.UserProfile{ID: 7, Email: "new@mail.ru"}, nil)
+		mockUserRepo.EXPECT().GetEmailVerificationStats(gomock.Any(), int64(7), gomock.Any()).Return(nil, 0, nil)
+		mockUserRepo.EXPECT().CreateEmailVerificationToken(gomock.Any(), int64(7), "new@mail.ru", gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, userUsecase.ResendEmailVerificationByEmail(ctx, "new@mail.ru"))
+		require.Len(t, mails.messages, 1)
+		assert.Equal(t, "new@mail.ru", mails.messages[0].To)
+	})
+
+	t.Run("verified by migration only", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "old@mail.ru").Return(&models.UserProfile{ID: 3, Email: "old@mail.ru", EmailVerified: true, EmailVerifiedLegacy: true}, nil)
+		mockUserRepo.EXPECT().GetEmailVerificationStats(gomock.Any(), int64(3), gomock.Any()).Return(nil, 0, nil)
+		mockUserRepo.EXPECT().CreateEmailVerificationToken(gomock.Any(), int64(3), "old@mail.ru", gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, userUsecase.ResendEmailVerificationByEmail(ctx, "old@mail.ru"))
+		require.Len(t, mails.messages, 2)
+		assert.Equal(t, "old@mail.ru", mails.messages[1].To)
+	})
+}
+
+func TestUserUsecase_ChangeEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepo(ctrl)
+	mails := &sentMails{}
+	userUsecase := usecase.CreateUserUsecase(mockUserRepo, nil, mails, "https://example.com/verify", false, nil, false, nil, nil)
+	ctx := context.Background()
+
+	updateData := &models.UserProfileUpdateRequest{NewName: "alice", Email: "new@mail.ru"}
+	mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), int64(7)).Return(&models.UserProfile{ID: 7, Email: "old@mail.ru", EmailVerified: true}, nil)
+	mockUserRepo.EXPECT().GetEmailVerificationStats(gomock.Any(), int64(7), gomock.Any()).Return(nil, 0, nil)
+	mockUserRepo.EXPECT().UpdateUserProfile(gomock.Any(), int64(7), *updateData).Return(&models.UserProfile{ID: 7, Name: "alice", Email: "old@mail.ru", EmailVerified: true}, nil)
+	var tokenHash string
+	mockUserRepo.EXPECT().CreateEmailVerificationToken(gomock.Any(), int64(7), "new@mail.ru", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID int64, email string, hash string, expiresAt time.Time) error {
+			tokenHash = hash
+			return nil
+		})
+
+	// До подтверждения основным остаётся старый адрес, новый ждёт в PendingEmail
+	profile, err := userUsecase.UpdateMyProfile(ctx, 7, updateData)
+	require.NoError(t, err)
+	assert.Equal(t, "old@mail.ru", profile.Email)
+	require.NotNil(t, profile.PendingEmail)
+	assert.Equal(t, "new@mail.ru", *profile.PendingEmail)
+
+	require.Len(t, mails.messages, 1)
+	assert.Equal(t, "new@mail.ru", mails.messages[0].To)
+	link := mails.messages[0].Body[strings.Index(mails.messages[0].Body, "https://example.com/verify?token="):]
+	token := strings.TrimPrefix(strings.Fields(link)[0], "https://example.com/verify?token=")
+
+	// В базе хранится только хеш токена, а по ссылке из письма адреса меняются местами
+	assert.Equal(t, encrypt.HashToken(token), tokenHash)
+	mockUserRepo.EXPECT().UseEmailVerificationToken(gomock.Any(), tokenHash).Return(int64(7), nil)
+	assert.NoError(t, userUsecase.VerifyEmail(ctx, token))
+
+	mockUserRepo.EXPECT().UseEmailVerificationToken(gomock.Any(), tokenHash).Return(int64(0), errs.ErrNotFound)
+	assert.ErrorIs(t, userUsecase.VerifyEmail(ctx, token), errs.ErrNotFound)
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/utils/images"
+	"RPO_back/internal/pkg/utils/uploads"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/satori/uuid"
+)
+
+// GetImageVariant возвращает адрес уменьшенной копии загруженной картинки. Если копий ещё нет
+// (файл загружен до их появления или построить их при загрузке не удалось), они строятся сейчас.
+// Копии есть только у картинок, которые где-то показываются (аватарки, фоны досок, обложки карточек):
+// остальные файлы - вложения, и их незачем декодировать
+func (uc *UserUsecase) GetImageVariant(ctx context.Context, fileName string, variantName string) (variantURL string, err error) {
+	variant, err := images.ParseVariant(variantName)
+	if err != nil {
+		return "", fmt.Errorf("GetImageVariant: %w", err)
+	}
+
+	fileUUID, fileExtension, _ := strings.Cut(fileName, ".")
+	if _, err := uuid.FromString(fileUUID); err != nil {
+		return "", fmt.Errorf("GetImageVariant: bad file name %q: %w", fileName, errs.ErrNotFound)
+	}
+	isDisplayed, err := uc.userRepo.IsDisplayedImage(ctx, fileUUID, fileExtension)
+	if err != nil {
+		return "", fmt.Errorf("GetImageVariant (IsDisplayedImage): %w", err)
+	}
+	if !isDisplayed {
+		return "", fmt.Errorf("GetImageVariant: %s is not a displayed image: %w", fileName, errs.ErrNotFound)
+	}
+
+	name, err := images.EnsureVariant(ctx, uc.fileStorage, fileName, variant)
+	if err != nil {
+		return "", fmt.Errorf("GetImageVariant: %w", err)
+	}
+	return uploads.JoinFileURL(name, "", ""), nil
+}
@@ -0,0 +1,52 @@
+package usecase_test
+
+import (
+	"RPO_back/internal/errs"
+	mocks "RPO_back/internal/pkg/user/mocks"
+	"RPO_back/internal/pkg/user/usecase"
+	"RPO_back/internal/pkg/utils/storage"
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUsecase_GetImageVariant(t *testing.T) {
+	ctx := context.Background()
+	fileUUID := "123e4567-e89b-12d3-a456-426614174000"
+	fileStorage := storage.CreateLocalStorage(t.TempDir())
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 10, 10))))
+	require.NoError(t, fileStorage.Put(ctx, fileUUID+".png", &encoded, int64(encoded.Len()), "image/png"))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepo(ctrl)
+	userUsecase := usecase.CreateUserUsecase(mockUserRepo, nil, nil, "", false, nil, false, nil, fileStorage)
+
+	t.Run("displayed image", func(t *testing.T) {
+		t.Setenv("USER_UPLOADS_URL", "/uploads/")
+		mockUserRepo.EXPECT().IsDisplayedImage(gomock.Any(), fileUUID, "png").Return(true, nil)
+
+		variantURL, err := userUsecase.GetImageVariant(ctx, fileUUID+".png", "thumbnail")
+		require.NoError(t, err)
+		assert.Equal(t, "/uploads/"+fileUUID+"_thumbnail.png", variantURL)
+	})
+
+	t.Run("attachment is not decoded", func(t *testing.T) {
+		mockUserRepo.EXPECT().IsDisplayedImage(gomock.Any(), fileUUID, "png").Return(false, nil)
+
+		_, err := userUsecase.GetImageVariant(ctx, fileUUID+".png", "medium")
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("not an uploaded file name", func(t *testing.T) {
+		_, err := userUsecase.GetImageVariant(ctx, "..%2Fsecret.png", "medium")
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	authGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"RPO_back/internal/pkg/utils/oidc"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	oidcLoginLifeTime     = 10 * time.Minute // Сколько ждём возврата пользователя от провайдера
+	ssoNicknameMinLength  = 3
+	ssoNicknameMaxLength  = 30
+	ssoNicknameSuffixLen  = 4 // Цифры, добавляемые к занятому nickname
+	ssoNicknameMaxRetries = 5
+)
+
+// BeginOIDCLogin начинает вход через внешний провайдер: возвращает адрес, на который
+// нужно перенаправить пользователя, и state, который нужно сохранить в его браузере
+func (uc *UserUsecase) BeginOIDCLogin(ctx context.Context) (authURL string, state string, err error) {
+	if uc.oidcProvider == nil {
+		return "", "", fmt.Errorf("BeginOIDCLogin: oidc is not configured: %w", errs.ErrNotFound)
+	}
+
+	state, err = oidc.GenerateState()
+	if err != nil {
+		return "", "", fmt.Errorf("BeginOIDCLogin (GenerateState): %w", err)
+	}
+	nonce, err := oidc.GenerateState()
+	if err != nil {
+		return "", "", fmt.Errorf("BeginOIDCLogin (GenerateState): %w", err)
+	}
+	verifier, challenge, err := oidc.GeneratePKCE()
+	if err != nil {
+		return "", "", fmt.Errorf("BeginOIDCLogin (GeneratePKCE): %w", err)
+	}
+
+	authURL, err = uc.oidcProvider.AuthCodeURL(ctx, state, nonce, challenge)
+	if err != nil {
+		return "", "", fmt.Errorf("BeginOIDCLogin (AuthCodeURL): %w", err)
+	}
+
+	err = uc.userRepo.SaveOIDCLoginState(ctx, encrypt.HashToken(state), nonce, verifier, time.Now().Add(oidcLoginLifeTime))
+	if err != nil {
+		return "", "", fmt.Errorf("BeginOIDCLogin (SaveOIDCLoginState): %w", err)
+	}
+
+	return authURL, state, nil
+}
+
+// FinishOIDCLogin завершает вход через внешний провайдер: обменивает код на ID-токен,
+// находит (привязывает или создаёт) пользователя и создаёт сессию. Если у пользователя
+// включён TOTP, вместо сессии возвращается токен для VerifyTwoFactorLogin
+func (uc *UserUsecase) FinishOIDCLogin(ctx context.Context, state string, code string, clientIP string, userAgent string) (sessionID string, twoFactorToken string, err error) {
+	if uc.oidcProvider == nil {
+		return "", "", fmt.Errorf("FinishOIDCLogin: oidc is not configured: %w", errs.ErrNotFound)
+	}
+
+	nonce, verifier, err := uc.userRepo.UseOIDCLoginState(ctx, encrypt.HashToken(state))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return "", "", fmt.Errorf("FinishOIDCLogin (UseOIDCLoginState): unknown state: %w", errs.ErrBadRequest)
+		}
+		return "", "", fmt.Errorf("FinishOIDCLogin (UseOIDCLoginState): %w", err)
+	}
+
+	rawIDToken, err := uc.oidcProvider.Exchange(ctx, code, verifier)
+	if err != nil {
+		return "", "", fmt.Errorf("FinishOIDCLogin (Exchange): %w", err)
+	}
+	claims, err := uc.oidcProvider.VerifyIDToken(ctx, rawIDToken, nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			return "", "", fmt.Errorf("FinishOIDCLogin (VerifyIDToken): %w: %w", errs.ErrBadRequest, err)
+		}
+		return "", "", fmt.Errorf("FinishOIDCLogin (VerifyIDToken): %w", err)
+	}
+
+	userID, err := uc.resolveOIDCUser(ctx, claims)
+	if err != nil {
+		return "", "", fmt.Errorf("FinishOIDCLogin (resolveOIDCUser): %w", err)
+	}
+
+	responce, err := uc.authClient.CreateTrustedSession(ctx, &authGRPC.TrustedSessionRequest{
+		UserID:    userID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("FinishOIDCLogin (GRPC request): %w", err)
+	}
+
+	if responce.GetError() == authGRPC.Error_TOO_MANY_ATTEMPTS {
+		return "", "", fmt.Errorf("FinishOIDCLogin (GRPC response): %w", &errs.LockoutError{Until: time.Unix(responce.GetLockedUntil(), 0)})
+	}
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return "", "", fmt.Errorf("FinishOIDCLogin (GRPC response): %w", err)
+	}
+
+	if responce.GetTwoFactorRequired() {
+		return "", responce.GetPartialSessionID(), nil
+	}
+
+	return responce.GetSessionID(), "", nil
+}
+
+// resolveOIDCUser находит пользователя для аккаунта провайдера. Непривязанный аккаунт
+// привязывается к пользователю с тем же email, только если email подтверждён и провайдером,
+// и у нас - иначе можно было бы заранее завести аккаунт на чужой адрес и получить доступ.
+// Email, подтверждённый только миграцией для старых пользователей, для привязки не подходит:
+// такой адрес никто не проверял, сначала его нужно подтвердить письмом
+func (uc *UserUsecase) resolveOIDCUser(ctx context.Context, claims *oidc.Claims) (userID int64, err error) {
+	userID, err = uc.userRepo.GetUserIDByIdentity(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		return userID, nil
+	}
+	if !errors.Is(err, errs.ErrNotFound) {
+		return 0, fmt.Errorf("resolveOIDCUser (GetUserIDByIdentity): %w", err)
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return 0, fmt.Errorf("resolveOIDCUser: provider email is not verified: %w", errs.ErrEmailNotVerified)
+	}
+
+	existing, err := uc.userRepo.GetUserByEmail(ctx, claims.Email)
+	if err != nil && !errors.Is(err, errs.ErrWrongCredentials) {
+		return 0, fmt.Errorf("resolveOIDCUser (GetUserByEmail): %w", err)
+	}
+	if existing != nil {
+		if !existing.EmailVerified {
+			return 0, fmt.Errorf("resolveOIDCUser: local email is not verified: %w", errs.ErrEmailNotVerified)
+		}
+		if existing.EmailVerifiedLegacy {
+			return 0, fmt.Errorf("resolveOIDCUser: local email was verified by migration only: %w", errs.ErrEmailNotVerified)
+		}
+		err = uc.userRepo.LinkIdentity(ctx, int64(existing.ID), claims.Issuer, claims.Subject, claims.Email)
+		if err != nil {
+			return 0, fmt.Errorf("resolveOIDCUser (LinkIdentity): %w", err)
+		}
+		return int64(existing.ID), nil
+	}
+
+	if !uc.oidcAllowSignup {
+		return 0, fmt.Errorf("resolveOIDCUser: signup via oidc is disabled: %w", errs.ErrNotPermitted)
+	}
+
+	nickname := ssoNickname(claims)
+	for attempt := 0; ; attempt++ {
+		newUser, err := uc.userRepo.CreateSSOUser(ctx, nickname, claims.Email, claims.Issuer, claims.Subject)
+		if err == nil {
+			return int64(newUser.ID), nil
+		}
+		if !errors.Is(err, errs.ErrBusyNickname) || attempt >= ssoNicknameMaxRetries {
+			return 0, fmt.Errorf("resolveOIDCUser (CreateSSOUser): %w", err)
+		}
+		nickname, err = withRandomSuffix(ssoNickname(claims))
+		if err != nil {
+			return 0, fmt.Errorf("resolveOIDCUser (withRandomSuffix): %w", err)
+		}
+	}
+}
+
+// ssoNickname подбирает nickname для нового пользователя из данных провайдера
+func ssoNickname(claims *oidc.Claims) string {
+	candidates := []string{claims.PreferredUsername, claims.Name, strings.Split(claims.Email, "@")[0]}
+	for _, candidate := range candidates {
+		nickname := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' {
+				return r
+			}
+			if unicode.IsSpace(r) {
+				return '_'
+			}
+			return -1
+		}, candidate)
+
+		runes := []rune(nickname)
+		if len(runes) > ssoNicknameMaxLength {
+			runes = runes[:ssoNicknameMaxLength]
+		}
+		if len(runes) >= ssoNicknameMinLength {
+			return string(runes)
+		}
+	}
+	return "user"
+}
+
+// withRandomSuffix дописывает к nickname случайные цифры, не выходя за максимальную длину
+func withRandomSuffix(nickname string) (string, error) {
+	suffixMax := big.NewInt(1)
+	for i := 0; i < ssoNicknameSuffixLen; i++ {
+		suffixMax.Mul(suffixMax, big.NewInt(10))
+	}
+	suffix, err := rand.Int(rand.Reader, suffixMax)
+	if err != nil {
+		return "", err
+	}
+
+	runes := []rune(nickname)
+	if maxBase := ssoNicknameMaxLength - ssoNicknameSuffixLen - 1; len(runes) > maxBase {
+		runes = runes[:maxBase]
+	}
+	return fmt.Sprintf("%s_%0*d", string(runes), ssoNicknameSuffixLen, suffix), nil
+}
@@ -0,0 +1,74 @@
+package usecase_test
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	authGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	mock_auth "RPO_back/internal/pkg/auth/mocks"
+	mocks "RPO_back/internal/pkg/user/mocks"
+	"RPO_back/internal/pkg/user/usecase"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"RPO_back/internal/pkg/utils/oidc"
+	"context"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUsecase_FinishOIDCLoginLinksByEmail(t *testing.T) {
+	claims := &oidc.Claims{Issuer: "https://idp.example.com", Subject: "sub-1", Email: "alice@mail.ru", EmailVerified: true}
+
+	tests := []struct {
+		name        string
+		existing    *models.UserProfile
+		expectedErr error
+	}{
+		{
+			name:     "email verified by letter",
+			existing: &models.UserProfile{ID: 7, Email: "alice@mail.ru", EmailVerified: true},
+		},
+		{
+			name:        "email not verified",
+			existing:    &models.UserProfile{ID: 7, Email: "alice@mail.ru"},
+			expectedErr: errs.ErrEmailNotVerified,
+		},
+		{
+			// Старым пользователям email подтвердила миграция, сам адрес никто не проверял
+			name:        "email verified by migration only",
+			existing:    &models.UserProfile{ID: 7, Email: "alice@mail.ru", EmailVerified: true, EmailVerifiedLegacy: true},
+			expectedErr: errs.ErrEmailNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockAuthClient := mock_auth.NewMockAuthClient(ctrl)
+			mockProvider := mocks.NewMockOIDCProvider(ctrl)
+			userUsecase := usecase.CreateUserUsecase(mockUserRepo, mockAuthClient, nil, "", true, mockProvider, false, nil, nil)
+
+			mockUserRepo.EXPECT().UseOIDCLoginState(gomock.Any(), encrypt.HashToken("state")).Return("nonce", "verifier", nil)
+			mockProvider.EXPECT().Exchange(gomock.Any(), "code", "verifier").Return("id-token", nil)
+			mockProvider.EXPECT().VerifyIDToken(gomock.Any(), "id-token", "nonce").Return(claims, nil)
+			mockUserRepo.EXPECT().GetUserIDByIdentity(gomock.Any(), claims.Issuer, claims.Subject).Return(int64(0), errs.ErrNotFound)
+			mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), claims.Email).Return(tt.existing, nil)
+			if tt.expectedErr == nil {
+				mockUserRepo.EXPECT().LinkIdentity(gomock.Any(), int64(7), claims.Issuer, claims.Subject, claims.Email).Return(nil)
+				mockAuthClient.EXPECT().CreateTrustedSession(gomock.Any(), gomock.Any()).Return(&authGRPC.Session{SessionID: "session"}, nil)
+			}
+
+			sessionID, _, err := userUsecase.FinishOIDCLogin(context.Background(), "state", "code", "203.0.113.7", "test-agent")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "session", sessionID)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"RPO_back/internal/models"
+	authGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	"context"
+	"fmt"
+	"time"
+)
+
+// ListSessions возвращает активные сессии пользователя текущей сессии
+func (uc *UserUsecase) ListSessions(ctx context.Context, sessionID string) (sessions []models.SessionInfo, err error) {
+	responce, err := uc.authClient.ListSessions(ctx, &authGRPC.CheckSessionRequest{SessionID: sessionID})
+	if err != nil {
+		return nil, fmt.Errorf("ListSessions (GRPC request): %w", err)
+	}
+
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return nil, fmt.Errorf("ListSessions (GRPC response): %w", err)
+	}
+
+	sessions = make([]models.SessionInfo, 0, len(responce.GetSessions()))
+	for _, session := range responce.GetSessions() {
+		info := models.SessionInfo{
+			ID:        session.GetId(),
+			UserAgent: session.GetUserAgent(),
+			ClientIP:  session.GetClientIP(),
+			Current:   session.GetCurrent(),
+		}
+		if session.GetCreatedAt() != 0 {
+			info.CreatedAt = time.Unix(session.GetCreatedAt(), 0)
+		}
+		if session.GetLastSeenAt() != 0 {
+			info.LastSeenAt = time.Unix(session.GetLastSeenAt(), 0)
+		}
+		sessions = append(sessions, info)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession завершает одну из сессий пользователя по её публичному ID
+func (uc *UserUsecase) RevokeSession(ctx context.Context, sessionID string, targetID string) error {
+	responce, err := uc.authClient.RevokeSession(ctx, &authGRPC.RevokeSessionRequest{
+		SessionID: sessionID,
+		TargetID:  targetID,
+	})
+	if err != nil {
+		return fmt.Errorf("RevokeSession (GRPC request): %w", err)
+	}
+
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return fmt.Errorf("RevokeSession (GRPC response): %w", err)
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions завершает все сессии пользователя, кроме текущей
+func (uc *UserUsecase) RevokeOtherSessions(ctx context.Context, sessionID string) error {
+	responce, err := uc.authClient.RevokeOtherSessions(ctx, &authGRPC.CheckSessionRequest{SessionID: sessionID})
+	if err != nil {
+		return fmt.Errorf("RevokeOtherSessions (GRPC request): %w", err)
+	}
+
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return fmt.Errorf("RevokeOtherSessions (GRPC response): %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	authGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	"context"
+	"fmt"
+	"time"
+)
+
+// VerifyTwoFactorLogin завершает вход с двухфакторной аутентификацией: проверяет код
+// для токена, полученного от LoginUser, и создаёт сессию
+func (uc *UserUsecase) VerifyTwoFactorLogin(ctx context.Context, twoFactorToken string, code string, clientIP string, userAgent string) (sessionID string, err error) {
+	responce, err := uc.authClient.VerifyTwoFactor(ctx, &authGRPC.TwoFactorRequest{
+		PartialSessionID: twoFactorToken,
+		Code:             code,
+		ClientIP:         clientIP,
+		UserAgent:        userAgent,
+	})
+	if err != nil {
+		return "", fmt.Errorf("VerifyTwoFactorLogin (GRPC request): %w", err)
+	}
+
+	if responce.GetError() == authGRPC.Error_TOO_MANY_ATTEMPTS {
+		return "", fmt.Errorf("VerifyTwoFactorLogin (GRPC response): %w", &errs.LockoutError{Until: time.Unix(responce.GetLockedUntil(), 0)})
+	}
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return "", fmt.Errorf("VerifyTwoFactorLogin (GRPC response): %w", err)
+	}
+
+	return responce.GetSessionID(), nil
+}
+
+// BeginTOTPEnrollment начинает подключение TOTP для пользователя текущей сессии
+func (uc *UserUsecase) BeginTOTPEnrollment(ctx context.Context, sessionID string) (enrollment *models.TOTPEnrollmentResponse, err error) {
+	responce, err := uc.authClient.BeginTOTPEnrollment(ctx, &authGRPC.CheckSessionRequest{SessionID: sessionID})
+	if err != nil {
+		return nil, fmt.Errorf("BeginTOTPEnrollment (GRPC request): %w", err)
+	}
+
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return nil, fmt.Errorf("BeginTOTPEnrollment (GRPC response): %w", err)
+	}
+
+	return &models.TOTPEnrollmentResponse{
+		Secret:          responce.GetSecret(),
+		ProvisioningURI: responce.GetProvisioningURI(),
+	}, nil
+}
+
+// ConfirmTOTPEnrollment включает TOTP по первому коду из приложения и возвращает коды восстановления
+func (uc *UserUsecase) ConfirmTOTPEnrollment(ctx context.Context, sessionID string, code string) (recoveryCodes []string, err error) {
+	responce, err := uc.authClient.ConfirmTOTPEnrollment(ctx, &authGRPC.TOTPCodeRequest{
+		SessionID: sessionID,
+		Code:      code,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ConfirmTOTPEnrollment (GRPC request): %w", err)
+	}
+
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return nil, fmt.Errorf("ConfirmTOTPEnrollment (GRPC response): %w", err)
+	}
+
+	return responce.GetCodes(), nil
+}
+
+// DisableTOTP отключает TOTP по коду из приложения или коду восстановления
+func (uc *UserUsecase) DisableTOTP(ctx context.Context, sessionID string, code string) error {
+	responce, err := uc.authClient.DisableTOTP(ctx, &authGRPC.TOTPCodeRequest{
+		SessionID: sessionID,
+		Code:      code,
+	})
+	if err != nil {
+		return fmt.Errorf("DisableTOTP (GRPC request): %w", err)
+	}
+
+	err = authErrorFromGRPC(responce.GetError())
+	if err != nil {
+		return fmt.Errorf("DisableTOTP (GRPC response): %w", err)
+	}
+
+	return nil
+}
@@ -4,22 +4,46 @@ import (
 	"RPO_back/internal/errs"
 	"RPO_back/internal/models"
 	authGRPC "RPO_back/internal/pkg/auth/delivery/grpc/gen"
+	pollGRPC "RPO_back/internal/pkg/poll/delivery/grpc/gen"
 	"RPO_back/internal/pkg/user"
+	"RPO_back/internal/pkg/utils/encrypt"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/mailer"
+	"RPO_back/internal/pkg/utils/storage"
 	"RPO_back/internal/pkg/utils/uploads"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 )
 
+// Сколько ждать сервис опросов: профиль не должен зависать из-за опроса
+const pollRequestTimeout = 2 * time.Second
+
 type UserUsecase struct {
-	authClient authGRPC.AuthClient
-	userRepo   user.UserRepo
+	authClient           authGRPC.AuthClient
+	userRepo             user.UserRepo
+	mailer               mailer.Mailer
+	emailVerificationURL string
+	allowUnverifiedLogin bool
+	oidcProvider         user.OIDCProvider // nil, если вход через OIDC не настроен
+	oidcAllowSignup      bool
+	pollClient           pollGRPC.PollClient
+	fileStorage          storage.FileStorage
 }
 
-func CreateUserUsecase(userRepo user.UserRepo, authClient authGRPC.AuthClient) *UserUsecase {
+func CreateUserUsecase(userRepo user.UserRepo, authClient authGRPC.AuthClient, mailer mailer.Mailer, emailVerificationURL string, allowUnverifiedLogin bool,
+	oidcProvider user.OIDCProvider, oidcAllowSignup bool, pollClient pollGRPC.PollClient, fileStorage storage.FileStorage) *UserUsecase {
 	return &UserUsecase{
-		authClient: authClient,
-		userRepo:   userRepo,
+		authClient:           authClient,
+		userRepo:             userRepo,
+		mailer:               mailer,
+		emailVerificationURL: emailVerificationURL,
+		allowUnverifiedLogin: allowUnverifiedLogin,
+		oidcProvider:         oidcProvider,
+		oidcAllowSignup:      oidcAllowSignup,
+		pollClient:           pollClient,
+		fileStorage:          fileStorage,
 	}
 }
 
@@ -30,56 +54,112 @@ func (uc *UserUsecase) GetMyProfile(ctx context.Context, userID int64) (profile
 		return nil, fmt.Errorf("GetMyProfile: %w", err)
 	}
 
-	if profile.CsatPollDT.Second() < time.Now().Second() {
-		uc.userRepo.SetNextPollDT(ctx, userID)
-		poll, err := uc.userRepo.PickPollQuestions(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("GetMyProfile: %w", err)
-		}
-		profile.PollQuestions = poll
+	// Без опроса профиль всё равно нужен, поэтому ошибки опроса только логируем
+	profile.PollQuestions, err = uc.pickPoll(ctx, userID)
+	if err != nil {
+		logging.Warn(ctx, "GetMyProfile (pickPoll): ", err)
+	}
+
+	return profile, nil
+}
+
+// pickPoll спрашивает у сервиса опросов, какие вопросы пора показать пользователю
+func (uc *UserUsecase) pickPoll(ctx context.Context, userID int64) (pollQuestions []models.PollQuestion, err error) {
+	ctx, cancel := context.WithTimeout(ctx, pollRequestTimeout)
+	defer cancel()
+
+	response, err := uc.pollClient.PickPollQuestions(ctx, &pollGRPC.PickPollRequest{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("pickPoll (grpc): %w", err)
+	}
+	if response.Error != pollGRPC.Error_NONE {
+		return nil, fmt.Errorf("pickPoll (grpc): poll service error %s", response.Error)
 	}
 
-	return
+	for _, question := range response.Questions {
+		pollQuestions = append(pollQuestions, models.PollQuestion{
+			QuestionID:   question.QuestionID,
+			QuestionText: question.QuestionText,
+			QuestionType: question.QuestionType,
+			RatingMin:    int(question.RatingMin),
+			RatingMax:    int(question.RatingMax),
+		})
+	}
+
+	return pollQuestions, nil
 }
 
 // UpdateMyProfile обновляет профиль пользователя и возвращает обновлённый профиль
+// Новый email начинает действовать только после подтверждения по ссылке из письма
 func (uc *UserUsecase) UpdateMyProfile(ctx context.Context, userID int64, data *models.UserProfileUpdateRequest) (updatedProfile *models.UserProfile, err error) {
+	profile, err := uc.userRepo.GetUserProfile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateMyProfile (GetUserProfile): %w", err)
+	}
+
+	emailChanged := data.Email != profile.Email
+	if emailChanged {
+		if err = uc.checkEmailVerificationThrottle(ctx, userID); err != nil {
+			return nil, fmt.Errorf("UpdateMyProfile: %w", err)
+		}
+	}
+
 	updatedProfile, err = uc.userRepo.UpdateUserProfile(ctx, userID, *data)
 	if err != nil {
-		return nil, fmt.Errorf("GetMyProfile: %w", err)
+		return nil, fmt.Errorf("UpdateMyProfile: %w", err)
+	}
+
+	if emailChanged {
+		err = uc.sendEmailVerification(ctx, userID, data.Email)
+		if err != nil {
+			return nil, fmt.Errorf("UpdateMyProfile (sendEmailVerification): %w", err)
+		}
+		updatedProfile.PendingEmail = &data.Email
 	}
 
-	return
+	return updatedProfile, nil
 }
 
-// SetMyAvatar устанавливает пользователю аватарку
+// SetMyAvatar устанавливает пользователю аватарку. Если файл с таким же хешем уже загружали, используется он
 func (uc *UserUsecase) SetMyAvatar(ctx context.Context, userID int64, file *models.UploadedFile) (updated *models.UserProfile, err error) {
-	fileNames, fileIDs, err := uc.userRepo.DeduplicateFile(ctx, file)
+	err = uploads.StripImageMetadata(file)
 	if err != nil {
-		return nil, fmt.Errorf("SetMyAvatar: %w", err)
+		return nil, fmt.Errorf("SetMyAvatar (StripImageMetadata): %w", err)
 	}
-
-	existingID, err := uploads.CompareFiles(fileNames, fileIDs, file)
+	err = uploads.StoreFile(ctx, uc.fileStorage, file)
 	if err != nil {
-		return nil, fmt.Errorf("SetMyAvatar: %w", err)
+		return nil, fmt.Errorf("SetMyAvatar (StoreFile): %w", err)
 	}
 
-	if existingID != nil {
-		file.FileID = existingID
-	} else {
-		uc.userRepo.RegisterFile(ctx, file)
-		uploads.SaveFile(file)
+	storedName := uploads.JoinFilePath(*file.UUID, file.FileExtension)
+
+	isNew, err := uc.userRepo.RegisterFile(ctx, file)
+	if err != nil || !isNew {
+		if deleteErr := uc.fileStorage.Delete(ctx, storedName); deleteErr != nil {
+			logging.Warn(ctx, "SetMyAvatar (delete duplicate): ", deleteErr)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SetMyAvatar (RegisterFile): %w", err)
+	}
+	if isNew {
+		uploads.GenerateImageVariants(ctx, uc.fileStorage, file)
 	}
 
-	uploads.SaveFile(file)
+	err = uc.userRepo.SetUserAvatar(ctx, userID, *file.FileID)
+	if err != nil {
+		return nil, fmt.Errorf("SetMyAvatar (SetUserAvatar): %w", err)
+	}
 
 	return uc.userRepo.GetUserProfile(ctx, userID)
 }
 
-func (uc *UserUsecase) ChangePassword(ctx context.Context, userID int64, oldPassword string, newPassword string) error {
+// ChangePassword меняет пароль пользователя текущей сессии и завершает остальные его сессии
+func (uc *UserUsecase) ChangePassword(ctx context.Context, sessionID string, oldPassword string, newPassword string) error {
 	responce, err := uc.authClient.ChangePassword(ctx, &authGRPC.ChangePasswordRequest{
 		PasswordOld: oldPassword,
 		PasswordNew: newPassword,
+		SessionID:   sessionID,
 	})
 	if err != nil {
 		return fmt.Errorf("ChangePassword: %w", err)
@@ -95,32 +175,89 @@ func (uc *UserUsecase) ChangePassword(ctx context.Context, userID int64, oldPass
 	return nil
 }
 
-func (uc *UserUsecase) LoginUser(ctx context.Context, email string, password string) (sessionID string, err error) {
-	user, err := uc.userRepo.GetUserByEmail(ctx, email)
+// RequestPasswordReset просит сервис авторизации отправить письмо для сброса пароля
+func (uc *UserUsecase) RequestPasswordReset(ctx context.Context, email string, clientIP string) error {
+	responce, err := uc.authClient.RequestPasswordReset(ctx, &authGRPC.PasswordResetRequest{Email: email, ClientIP: clientIP})
 	if err != nil {
-		return "", fmt.Errorf("LoginUser (GetUserByEmail): %w", err)
+		return fmt.Errorf("RequestPasswordReset (GRPC request): %w", err)
 	}
 
-	userID := user.ID
+	if err = authErrorFromGRPC(responce.GetError()); err != nil {
+		return fmt.Errorf("RequestPasswordReset (GRPC response): %w", err)
+	}
 
-	responce, err := uc.authClient.CreateSession(ctx, &authGRPC.UserDataRequest{
-		UserID:   int64(userID),
-		Password: password,
+	return nil
+}
+
+// ConfirmPasswordReset задаёт новый пароль по токену из письма
+func (uc *UserUsecase) ConfirmPasswordReset(ctx context.Context, token string, newPassword string) error {
+	responce, err := uc.authClient.ConfirmPasswordReset(ctx, &authGRPC.ConfirmPasswordResetRequest{
+		Token:       token,
+		PasswordNew: newPassword,
 	})
 	if err != nil {
-		return "", fmt.Errorf("LoginUser (GRPC request): %w", err)
+		return fmt.Errorf("ConfirmPasswordReset (GRPC request): %w", err)
 	}
 
 	errGRPC := responce.GetError()
 	if errGRPC == authGRPC.Error_INVALID_CREDENTIALS {
-		return "", fmt.Errorf("CreateSession (GRPC response): %w", errs.ErrWrongCredentials)
+		return fmt.Errorf("ConfirmPasswordReset (GRPC response): %w", errs.ErrWrongCredentials)
 	} else if errGRPC == authGRPC.Error_INTERNAL_SERVER_ERROR {
-		return "", fmt.Errorf("CreateSession (GRPC response): internal error at auth service")
+		return fmt.Errorf("ConfirmPasswordReset (GRPC response): internal error at auth service")
+	}
+
+	return nil
+}
+
+// LoginUser проверяет email и пароль через сервис авторизации и создаёт сессию.
+// Если у пользователя включена двухфакторная аутентификация, сессия не создаётся, а возвращается
+// twoFactorToken для второго шага входа (VerifyTwoFactorLogin)
+func (uc *UserUsecase) LoginUser(ctx context.Context, email string, password string, clientIP string, userAgent string) (sessionID string, twoFactorToken string, err error) {
+	var userID int64
+	user, err := uc.userRepo.GetUserByEmail(ctx, email)
+	if err != nil && !errors.Is(err, errs.ErrWrongCredentials) {
+		return "", "", fmt.Errorf("LoginUser (GetUserByEmail): %w", err)
+	}
+	if user != nil {
+		userID = int64(user.ID)
+	}
+
+	// Вход с неизвестным email тоже идёт в сервис авторизации (с userID = 0):
+	// там он учитывается в попытках с этого IP и проверяется столько же времени
+	responce, err := uc.authClient.CreateSession(ctx, &authGRPC.UserDataRequest{
+		UserID:    userID,
+		Password:  password,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("LoginUser (GRPC request): %w", err)
+	}
+
+	errGRPC := responce.GetError()
+	if errGRPC == authGRPC.Error_TOO_MANY_ATTEMPTS {
+		return "", "", fmt.Errorf("CreateSession (GRPC response): %w", &errs.LockoutError{Until: time.Unix(responce.GetLockedUntil(), 0)})
+	} else if errGRPC == authGRPC.Error_INVALID_CREDENTIALS {
+		return "", "", fmt.Errorf("CreateSession (GRPC response): %w", errs.ErrWrongCredentials)
+	} else if errGRPC == authGRPC.Error_INTERNAL_SERVER_ERROR {
+		return "", "", fmt.Errorf("CreateSession (GRPC response): internal error at auth service")
+	}
+
+	if !uc.allowUnverifiedLogin && !user.EmailVerified {
+		// Пароль верный, но вход с неподтверждённым email запрещён - созданная сессия не нужна
+		if sessionID := responce.GetSessionID(); sessionID != "" {
+			if err := uc.LogoutUser(ctx, sessionID); err != nil {
+				logging.Warn(ctx, "LoginUser (LogoutUser): ", err)
+			}
+		}
+		return "", "", fmt.Errorf("LoginUser: %w", errs.ErrEmailNotVerified)
 	}
 
-	sessionID = responce.GetSessionID()
+	if responce.GetTwoFactorRequired() {
+		return "", responce.GetPartialSessionID(), nil
+	}
 
-	return sessionID, nil
+	return responce.GetSessionID(), "", nil
 }
 
 func (uc *UserUsecase) LogoutUser(ctx context.Context, sessionID string) error {
@@ -139,15 +276,44 @@ func (uc *UserUsecase) LogoutUser(ctx context.Context, sessionID string) error {
 	return nil
 }
 
-func (uc *UserUsecase) RegisterUser(ctx context.Context, user *models.UserRegisterRequest) (sessionID string, err error) {
-	newUser, err := uc.userRepo.CreateUser(ctx, user)
+// RegisterUser создаёт пользователя и отправляет письмо для подтверждения email.
+// Если вход с неподтверждённым email запрещён, сессия не создаётся и sessionID пуст
+func (uc *UserUsecase) RegisterUser(ctx context.Context, user *models.UserRegisterRequest, clientIP string, userAgent string) (sessionID string, err error) {
+	lockout, err := uc.authClient.CheckLoginLockout(ctx, &authGRPC.LoginLockoutRequest{ClientIP: clientIP})
+	if err != nil {
+		return "", fmt.Errorf("RegisterUser (CheckLoginLockout): %w", err)
+	}
+	if lockout.GetError() == authGRPC.Error_TOO_MANY_ATTEMPTS {
+		return "", fmt.Errorf("RegisterUser (CheckLoginLockout): %w", &errs.LockoutError{Until: time.Unix(lockout.GetLockedUntil(), 0)})
+	}
+	if err = authErrorFromGRPC(lockout.GetError()); err != nil {
+		return "", fmt.Errorf("RegisterUser (CheckLoginLockout): %w", err)
+	}
+
+	passwordHash, err := encrypt.SaltAndHashPassword(user.Password)
+	if err != nil {
+		return "", fmt.Errorf("RegisterUser (SaltAndHashPassword): %w", err)
+	}
+
+	newUser, err := uc.userRepo.CreateUser(ctx, user, passwordHash)
 	if err != nil {
 		return "", fmt.Errorf("RegisterUser (CreateUser): %w", err)
 	}
 
+	err = uc.sendEmailVerification(ctx, newUser.ID, newUser.Email)
+	if err != nil {
+		logging.Error(ctx, "RegisterUser (sendEmailVerification): ", err)
+	}
+
+	if !uc.allowUnverifiedLogin {
+		return "", nil
+	}
+
 	responce, err := uc.authClient.CreateSession(ctx, &authGRPC.UserDataRequest{
-		UserID:   int64(newUser.ID),
-		Password: user.Password,
+		UserID:    int64(newUser.ID),
+		Password:  user.Password,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
 	})
 	if err != nil {
 		return "", fmt.Errorf("RegisterUser (GRPC request): %w", err)
@@ -160,35 +326,23 @@ func (uc *UserUsecase) RegisterUser(ctx context.Context, user *models.UserRegist
 		return "", fmt.Errorf("CreateSession (GRPC response): internal error at auth service")
 	}
 
-	sessionID = responce.GetSessionID()
-
-	return sessionID, nil
-}
-
-func (uc *UserUsecase) SubmitPoll(ctx context.Context, userID int64, pollSubmit *models.PollSubmit) error {
-	err := uc.userRepo.SubmitPoll(ctx, userID, pollSubmit)
-	if err != nil {
-		return fmt.Errorf("SubmitPoll: %w", err)
-	}
-
-	return nil
+	return responce.GetSessionID(), nil
 }
 
-func (uc *UserUsecase) GetPollResults(ctx context.Context) (pollResults *models.PollResults, err error) {
-	pollRating, err := uc.userRepo.GetRatingResults(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("GetPollResults (GetRatingResults): %w", err)
+// authErrorFromGRPC переводит код ошибки из ответа сервиса авторизации в ошибку errs
+func authErrorFromGRPC(errGRPC authGRPC.Error) error {
+	switch errGRPC {
+	case authGRPC.Error_NONE:
+		return nil
+	case authGRPC.Error_INVALID_CREDENTIALS:
+		return errs.ErrWrongCredentials
+	case authGRPC.Error_TOO_MANY_ATTEMPTS:
+		return errs.ErrTooManyRequests
+	case authGRPC.Error_BAD_REQUEST:
+		return errs.ErrBadRequest
+	case authGRPC.Error_NOT_FOUND:
+		return errs.ErrNotFound
+	default:
+		return fmt.Errorf("internal error at auth service")
 	}
-
-	pollText, err := uc.userRepo.GetTextResults(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("GetPollResults (GetTextResults): %w", err)
-	}
-
-	pollResults = &models.PollResults{
-		RatingResults: pollRating,
-		TextResults:   pollText,
-	}
-
-	return pollResults, nil
 }
@@ -2,6 +2,8 @@ package usecase_test
 
 import (
 	"RPO_back/internal/models"
+	pollGRPC "RPO_back/internal/pkg/poll/delivery/grpc/gen"
+	mock_poll "RPO_back/internal/pkg/poll/mocks"
 	mocks "RPO_back/internal/pkg/user/mocks"
 	"RPO_back/internal/pkg/user/usecase"
 	"context"
@@ -18,14 +20,17 @@ func TestUserUsecase_GetMyProfile(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepo(ctrl)
-	userUsecase := usecase.CreateUserUsecase(mockUserRepo)
+	mockPollClient := mock_poll.NewMockPollClient(ctrl)
+	userUsecase := usecase.CreateUserUsecase(mockUserRepo, nil, nil, "", false, nil, false, mockPollClient, nil)
 
 	t.Run("successful profile retrieval", func(t *testing.T) {
-		mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), 1).Return(&models.UserProfile{
+		mockPollClient.EXPECT().PickPollQuestions(gomock.Any(), &pollGRPC.PickPollRequest{UserID: 1}).Return(&pollGRPC.PollQuestions{
+			Questions: []*pollGRPC.PollQuestion{{QuestionID: 5, QuestionText: "How is it going?", QuestionType: "rating", RatingMin: 1, RatingMax: 5}},
+		}, nil)
+		mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), int64(1)).Return(&models.UserProfile{
 			ID:             1,
 			Name:           "Test User",
 			Email:          "testuser@example.com",
-			Description:    "User Description",
 			JoinedAt:       time.Now(),
 			UpdatedAt:      time.Now(),
 			AvatarImageURL: "",
@@ -35,10 +40,20 @@ func TestUserUsecase_GetMyProfile(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "Test User", profile.Name)
 		assert.Equal(t, "testuser@example.com", profile.Email)
+		assert.Len(t, profile.PollQuestions, 1)
+	})
+
+	t.Run("poll service is down", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), int64(1)).Return(&models.UserProfile{ID: 1, Name: "Test User"}, nil)
+		mockPollClient.EXPECT().PickPollQuestions(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+
+		profile, err := userUsecase.GetMyProfile(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Empty(t, profile.PollQuestions)
 	})
 
 	t.Run("failed profile retrieval", func(t *testing.T) {
-		mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), 1).Return(nil, errors.New("user not found"))
+		mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), int64(1)).Return(nil, errors.New("user not found"))
 
 		profile, err := userUsecase.GetMyProfile(context.Background(), 1)
 		assert.Error(t, err)
@@ -51,18 +66,18 @@ func TestUserUsecase_UpdateMyProfile(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepo(ctrl)
-	userUsecase := usecase.CreateUserUsecase(mockUserRepo)
+	userUsecase := usecase.CreateUserUsecase(mockUserRepo, nil, nil, "", false, nil, false, nil, nil)
 
 	t.Run("successful profile update", func(t *testing.T) {
 		updateData := &models.UserProfileUpdateRequest{
 			NewName: "Updated User",
 			Email:   "updateduser@example.com",
 		}
-		mockUserRepo.EXPECT().UpdateUserProfile(gomock.Any(), 1, *updateData).Return(&models.UserProfile{
+		mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), int64(1)).Return(&models.UserProfile{ID: 1, Email: "updateduser@example.com"}, nil)
+		mockUserRepo.EXPECT().UpdateUserProfile(gomock.Any(), int64(1), *updateData).Return(&models.UserProfile{
 			ID:             1,
 			Name:           "Updated User",
 			Email:          "updateduser@example.com",
-			Description:    "Updated Description",
 			JoinedAt:       time.Now(),
 			UpdatedAt:      time.Now(),
 			AvatarImageURL: "",
@@ -79,7 +94,8 @@ func TestUserUsecase_UpdateMyProfile(t *testing.T) {
 			NewName: "Updated User",
 			Email:   "updateduser@example.com",
 		}
-		mockUserRepo.EXPECT().UpdateUserProfile(gomock.Any(), 1, *updateData).Return(nil, errors.New("update failed"))
+		mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), int64(1)).Return(&models.UserProfile{ID: 1, Email: "updateduser@example.com"}, nil)
+		mockUserRepo.EXPECT().UpdateUserProfile(gomock.Any(), int64(1), *updateData).Return(nil, errors.New("update failed"))
 
 		profile, err := userUsecase.UpdateMyProfile(context.Background(), 1, updateData)
 		assert.Error(t, err)
@@ -92,7 +108,7 @@ func TestUserUsecase_UpdateMyProfile(t *testing.T) {
 // 	defer ctrl.Finish()
 
 // 	mockUserRepo := mocks.NewMockUserRepo(ctrl)
-// 	userUsecase := usecase.CreateUserUsecase(mockUserRepo)
+// 	userUsecase := usecase.CreateUserUsecase(mockUserRepo, nil, nil, "", false, nil, false, nil, nil)
 
 // 	// Мокаем загрузку аватара
 // 	t.Run("successful avatar upload", func(t *testing.T) {
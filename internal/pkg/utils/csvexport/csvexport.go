@@ -0,0 +1,50 @@
+package csvexport
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// Writer - csv.Writer, экранирующий ячейки, которые табличный редактор принял бы за формулу
+// (CSV injection): в выгрузки попадают названия и ответы, которые пишут сами пользователи
+type Writer struct {
+	*csv.Writer
+}
+
+// NewWriter создаёт Writer, пишущий в w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{Writer: csv.NewWriter(w)}
+}
+
+// Write записывает строку, экранируя каждую ячейку через EscapeFormula
+func (w *Writer) Write(record []string) error {
+	escaped := make([]string, len(record))
+	for i, value := range record {
+		escaped[i] = EscapeFormula(value)
+	}
+	return w.Writer.Write(escaped)
+}
+
+// WriteAll записывает все строки и сбрасывает буфер, как csv.Writer.WriteAll
+func (w *Writer) WriteAll(records [][]string) error {
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// EscapeFormula добавляет апостроф перед значением, которое начинается с =, +, -, @, табуляции
+// или возврата каретки: так Excel и LibreOffice покажут его как текст, а не выполнят
+func EscapeFormula(value string) string {
+	if value == "" {
+		return value
+	}
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,38 @@
+package csvexport
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"", ""},
+		{"Обычная карточка", "Обычная карточка"},
+		{"=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"+1+2", "'+1+2"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+		{"a=1", "a=1"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, EscapeFormula(test.value), test.value)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewWriter(&buf)
+	require.NoError(t, writer.Write([]string{"title", "value"}))
+	require.NoError(t, writer.WriteAll([][]string{{"=1+1", "42"}}))
+
+	assert.Equal(t, "title,value\n'=1+1,42\n", buf.String())
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"io"
+	"sync"
 
 	"github.com/satori/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -44,6 +45,31 @@ func CheckPassword(password string, hash string) bool {
 	return err == nil
 }
 
+// dummyPasswordHash - хеш, с которым сравнивается пароль, когда у пользователя нет хеша
+// (или самого пользователя нет), чтобы проверка занимала столько же времени
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := SaltAndHashPassword(GenerateSessionID())
+	return hash
+})
+
+// CheckPasswordOrDummy проверяет пароль по хешу. Если хеша нет, пароль всё равно
+// сравнивается с фиктивным хешем, и возвращается false: по времени ответа нельзя
+// понять, существует ли пользователь
+func CheckPasswordOrDummy(password string, hash *string) bool {
+	if hash == nil {
+		CheckPassword(password, dummyPasswordHash())
+		return false
+	}
+	return CheckPassword(password, *hash)
+}
+
+// HashToken возвращает SHA-256 одноразового токена (в hex) для хранения в базе:
+// сам токен есть только у пользователя
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
 // GenerateCSRFToken генерирует безопасный CSRF-токен
 func GenerateCSRFToken() string {
 	return uuid.NewV4().String()
@@ -41,6 +41,41 @@ func TestSaltAndHashPasswordAndCheckPassword(t *testing.T) {
 	}
 }
 
+// Тест для CheckPasswordOrDummy
+func TestCheckPasswordOrDummy(t *testing.T) {
+	password := "Test@123"
+	hashedPassword, err := SaltAndHashPassword(password)
+	if err != nil {
+		t.Fatalf("ошибка хеширования пароля: %v", err)
+	}
+
+	if !CheckPasswordOrDummy(password, &hashedPassword) {
+		t.Errorf("проверка пароля не удалась")
+	}
+	if CheckPasswordOrDummy("WrongPassword", &hashedPassword) {
+		t.Errorf("неправильный пароль прошел проверку")
+	}
+	if CheckPasswordOrDummy(password, nil) {
+		t.Errorf("пароль прошел проверку без хеша")
+	}
+}
+
+// Тест для HashToken
+func TestHashToken(t *testing.T) {
+	token := GenerateSessionID()
+
+	hash := HashToken(token)
+	if len(hash) != 64 {
+		t.Errorf("ожидалась длина 64, но получена %d", len(hash))
+	}
+	if hash == token {
+		t.Errorf("хеш токена не должен совпадать с токеном")
+	}
+	if HashToken(token) != hash {
+		t.Errorf("хеш одного и того же токена должен совпадать")
+	}
+}
+
 // Тест для GenerateCSRFToken
 func TestGenerateCSRFToken(t *testing.T) {
 	token := GenerateCSRFToken()
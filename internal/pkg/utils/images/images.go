@@ -0,0 +1,188 @@
+package images
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/utils/storage"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // Регистрация декодера GIF для image.Decode
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Variant - уменьшенная копия загруженной картинки
+type Variant string
+
+const (
+	Thumbnail Variant = "thumbnail"
+	Medium    Variant = "medium"
+	Full      Variant = "full"
+)
+
+// Variants - все копии в порядке генерации: каждая следующая уменьшается из предыдущей
+var Variants = []Variant{Full, Medium, Thumbnail}
+
+// maxSides - наибольшая сторона каждой копии в пикселях; меньшие картинки не увеличиваются
+var maxSides = map[Variant]int{
+	Full:      2048,
+	Medium:    800,
+	Thumbnail: 200,
+}
+
+const (
+	// maxSourceSize - больше этого оригинал не читается (лимиты загрузки картинок заметно меньше)
+	maxSourceSize = 32 << 20
+
+	// maxSourcePixels - защита от картинок, которые при декодировании занимают гигабайты
+	maxSourcePixels = 50_000_000
+
+	jpegQuality = 85
+)
+
+// outputExtensions - в каком формате сохраняются копии для каждого расширения оригинала.
+// Копии GIF сохраняются в PNG (первый кадр); WebP без внешних зависимостей не декодируется
+var outputExtensions = map[string]string{
+	"jpg":  "jpg",
+	"jpeg": "jpg",
+	"png":  "png",
+	"gif":  "png",
+}
+
+// ParseVariant разбирает название копии из запроса
+func ParseVariant(name string) (Variant, error) {
+	variant := Variant(name)
+	if _, ok := maxSides[variant]; !ok {
+		return "", fmt.Errorf("ParseVariant: unknown variant %q: %w", name, errs.ErrBadRequest)
+	}
+	return variant, nil
+}
+
+// VariantName возвращает имя копии в хранилище для оригинала fileName ("<uuid>.png" -> "<uuid>_thumbnail.png").
+// ok = false, если для такого формата копии не делаются
+func VariantName(fileName string, variant Variant) (name string, ok bool) {
+	base, extension, found := strings.Cut(fileName, ".")
+	if !found {
+		return "", false
+	}
+	outputExtension, ok := outputExtensions[strings.ToLower(extension)]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s_%s.%s", base, variant, outputExtension), true
+}
+
+// Generate строит все копии картинки fileName и кладёт их в хранилище рядом с оригиналом.
+// Картинка поворачивается по EXIF; при перекодировании метаданные оригинала не сохраняются
+func Generate(ctx context.Context, fileStorage storage.FileStorage, fileName string) error {
+	funcName := "Generate"
+	img, err := decodeStored(ctx, fileStorage, fileName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	for _, variant := range Variants {
+		name, _ := VariantName(fileName, variant)
+		img = resize(img, maxSides[variant])
+
+		var encoded bytes.Buffer
+		contentType := storage.ContentTypeByName(name)
+		if strings.HasSuffix(name, ".jpg") {
+			err = jpeg.Encode(&encoded, img, &jpeg.Options{Quality: jpegQuality})
+		} else {
+			err = png.Encode(&encoded, img)
+		}
+		if err != nil {
+			return fmt.Errorf("%s (encode %s): %w", funcName, variant, err)
+		}
+
+		err = fileStorage.Put(ctx, name, &encoded, int64(encoded.Len()), contentType)
+		if err != nil {
+			return fmt.Errorf("%s (put %s): %w", funcName, variant, err)
+		}
+	}
+	return nil
+}
+
+// generating - копии, которые строятся прямо сейчас: одновременные запросы одной картинки
+// ждут одно построение, а не декодируют оригинал каждый сам
+var generating singleflight.Group
+
+// EnsureVariant возвращает имя копии картинки, если нужно - сначала создав все её копии
+// (так копии появляются у файлов, загруженных раньше). Ошибки: errs.ErrNotFound - нет оригинала,
+// errs.ErrUnsupportedMediaType - файл не картинка или формат не поддерживается
+func EnsureVariant(ctx context.Context, fileStorage storage.FileStorage, fileName string, variant Variant) (name string, err error) {
+	funcName := "EnsureVariant"
+	name, ok := VariantName(fileName, variant)
+	if !ok {
+		return "", fmt.Errorf("%s: %s has no variants: %w", funcName, fileName, errs.ErrUnsupportedMediaType)
+	}
+
+	_, err = fileStorage.Stat(ctx, name)
+	if err == nil {
+		return name, nil
+	}
+	if !errors.Is(err, errs.ErrNotFound) {
+		return "", fmt.Errorf("%s (stat): %w", funcName, err)
+	}
+
+	// Построение не прерывается, если ушёл запрос, который его начал: результата ждут и другие
+	_, err, _ = generating.Do(fileName, func() (interface{}, error) {
+		return nil, Generate(context.WithoutCancel(ctx), fileStorage, fileName)
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", funcName, err)
+	}
+	return name, nil
+}
+
+// decodeStored читает и декодирует оригинал из хранилища, поворачивая JPEG по тегу EXIF Orientation
+func decodeStored(ctx context.Context, fileStorage storage.FileStorage, fileName string) (*image.RGBA, error) {
+	content, err := fileStorage.Get(ctx, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("decodeStored (get): %w", err)
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(io.LimitReader(content, maxSourceSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("decodeStored (read): %w", err)
+	}
+	if len(data) > maxSourceSize {
+		return nil, fmt.Errorf("decodeStored: %s is larger than %d bytes: %w", fileName, maxSourceSize, errs.ErrTooLarge)
+	}
+
+	img, err := decodeImage(data)
+	if err != nil {
+		return nil, fmt.Errorf("decodeStored %s: %w", fileName, err)
+	}
+	return img, nil
+}
+
+// decodeImage декодирует картинку, поворачивая JPEG по тегу EXIF Orientation
+func decodeImage(data []byte) (*image.RGBA, error) {
+	imgConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decodeImage (config): %v: %w", err, errs.ErrUnsupportedMediaType)
+	}
+	if imgConfig.Width*imgConfig.Height > maxSourcePixels {
+		return nil, fmt.Errorf("decodeImage: image is %dx%d: %w", imgConfig.Width, imgConfig.Height, errs.ErrTooLarge)
+	}
+
+	decoded, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decodeImage (decode): %v: %w", err, errs.ErrUnsupportedMediaType)
+	}
+
+	img := toRGBA(decoded)
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	return img, nil
+}
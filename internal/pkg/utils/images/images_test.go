@@ -0,0 +1,290 @@
+package images
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/utils/storage"
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withOrientation вставляет в JPEG сразу после SOI сегмент APP1 с EXIF, где записан только тег Orientation
+func withOrientation(t *testing.T, jpegData []byte, orientation byte) []byte {
+	tiff := []byte{
+		'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08, // Заголовок TIFF, первый IFD по смещению 8
+		0x00, 0x01, // Одна запись
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, orientation, 0x00, 0x00, // Orientation, SHORT
+		0x00, 0x00, 0x00, 0x00, // Следующего IFD нет
+	}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := append([]byte{0xFF, 0xE1, 0x00, byte(len(payload) + 2)}, payload...)
+	require.True(t, bytes.HasPrefix(jpegData, []byte{0xFF, 0xD8}))
+	return append(append([]byte{0xFF, 0xD8}, segment...), jpegData[2:]...)
+}
+
+func solidImage(width int, height int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func storedImage(t *testing.T, fileStorage storage.FileStorage, name string) (image.Image, []byte) {
+	content, err := fileStorage.Get(context.Background(), name)
+	require.NoError(t, err)
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	img, _, err := image.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return img, data
+}
+
+func TestVariantName(t *testing.T) {
+	tests := []struct {
+		fileName string
+		expected string
+		ok       bool
+	}{
+		{"123e4567.jpg", "123e4567_thumbnail.jpg", true},
+		{"123e4567.JPEG", "123e4567_thumbnail.jpg", true},
+		{"123e4567.png", "123e4567_thumbnail.png", true},
+		{"123e4567.gif", "123e4567_thumbnail.png", true},
+		{"123e4567.webp", "", false},
+		{"123e4567.txt", "", false},
+		{"123e4567", "", false},
+	}
+
+	for _, test := range tests {
+		name, ok := VariantName(test.fileName, Thumbnail)
+		assert.Equal(t, test.ok, ok, test.fileName)
+		assert.Equal(t, test.expected, name, test.fileName)
+	}
+}
+
+func TestParseVariant(t *testing.T) {
+	variant, err := ParseVariant("medium")
+	require.NoError(t, err)
+	assert.Equal(t, Medium, variant)
+
+	_, err = ParseVariant("huge")
+	assert.True(t, errors.Is(err, errs.ErrBadRequest))
+}
+
+func TestResize(t *testing.T) {
+	// Большая сторона ужимается до maxSide, пропорции сохраняются
+	resized := resize(solidImage(400, 100, color.White), 200)
+	assert.Equal(t, image.Rect(0, 0, 200, 50), resized.Rect)
+
+	resized = resize(solidImage(100, 300, color.White), 200)
+	assert.Equal(t, image.Rect(0, 0, 67, 200), resized.Rect)
+
+	// Маленькие картинки не увеличиваются
+	small := solidImage(10, 10, color.White)
+	assert.Same(t, small, resize(small, 200))
+
+	// Пиксели усредняются
+	checker := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	checker.Set(0, 0, color.White)
+	checker.Set(1, 1, color.White)
+	checker.Set(1, 0, color.Black)
+	checker.Set(0, 1, color.Black)
+	resized = resize(checker, 1)
+	assert.Equal(t, color.RGBA{128, 128, 128, 255}, resized.RGBAAt(0, 0))
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, solidImage(4, 4, color.White), nil))
+
+	assert.Equal(t, 1, jpegOrientation(encoded.Bytes()))
+	assert.Equal(t, 6, jpegOrientation(withOrientation(t, encoded.Bytes(), 6)))
+	assert.Equal(t, 1, jpegOrientation(withOrientation(t, encoded.Bytes(), 42)))
+	assert.Equal(t, 1, jpegOrientation([]byte("not a jpeg")))
+	assert.Equal(t, 1, jpegOrientation(withOrientation(t, encoded.Bytes(), 6)[:20]))
+}
+
+func TestApplyOrientation(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	blue := color.RGBA{0, 0, 255, 255}
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, red)
+	src.SetRGBA(1, 0, blue)
+
+	// Поворот на 90 по часовой: левый пиксель оказывается сверху
+	rotated := applyOrientation(src, 6)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), rotated.Rect)
+	assert.Equal(t, red, rotated.RGBAAt(0, 0))
+	assert.Equal(t, blue, rotated.RGBAAt(0, 1))
+
+	// Поворот на 90 против часовой: левый пиксель оказывается снизу
+	rotated = applyOrientation(src, 8)
+	assert.Equal(t, blue, rotated.RGBAAt(0, 0))
+	assert.Equal(t, red, rotated.RGBAAt(0, 1))
+
+	flipped := applyOrientation(src, 3)
+	assert.Equal(t, blue, flipped.RGBAAt(0, 0))
+	assert.Equal(t, red, flipped.RGBAAt(1, 0))
+
+	assert.Same(t, src, applyOrientation(src, 1))
+}
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	fileStorage := storage.CreateLocalStorage(t.TempDir())
+
+	// Снимок 3000x1000, который надо повернуть на 90 градусов
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, solidImage(3000, 1000, color.White), nil))
+	original := withOrientation(t, encoded.Bytes(), 6)
+	require.NoError(t, fileStorage.Put(ctx, "photo.jpg", bytes.NewReader(original), int64(len(original)), "image/jpeg"))
+
+	require.NoError(t, Generate(ctx, fileStorage, "photo.jpg"))
+
+	expected := map[Variant]image.Rectangle{
+		Full:      image.Rect(0, 0, 683, 2048),
+		Medium:    image.Rect(0, 0, 267, 800),
+		Thumbnail: image.Rect(0, 0, 67, 200),
+	}
+	for variant, bounds := range expected {
+		name, _ := VariantName("photo.jpg", variant)
+		img, data := storedImage(t, fileStorage, name)
+		assert.Equal(t, bounds, img.Bounds(), variant)
+		assert.False(t, bytes.Contains(data, []byte("Exif")), "%s keeps EXIF", variant)
+	}
+}
+
+func TestEnsureVariant(t *testing.T) {
+	ctx := context.Background()
+	fileStorage := storage.CreateLocalStorage(t.TempDir())
+
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, solidImage(50, 40, color.Black)))
+	require.NoError(t, fileStorage.Put(ctx, "small.png", &encoded, int64(encoded.Len()), "image/png"))
+	notImage := strings.NewReader("definitely not a picture")
+	require.NoError(t, fileStorage.Put(ctx, "fake.png", notImage, notImage.Size(), "image/png"))
+
+	// Копий ещё нет: строятся при первом обращении
+	name, err := EnsureVariant(ctx, fileStorage, "small.png", Medium)
+	require.NoError(t, err)
+	assert.Equal(t, "small_medium.png", name)
+	img, _ := storedImage(t, fileStorage, name)
+	assert.Equal(t, image.Rect(0, 0, 50, 40), img.Bounds())
+	_, err = fileStorage.Stat(ctx, "small_thumbnail.png")
+	assert.NoError(t, err)
+
+	_, err = EnsureVariant(ctx, fileStorage, "missing.png", Medium)
+	assert.True(t, errors.Is(err, errs.ErrNotFound))
+
+	_, err = EnsureVariant(ctx, fileStorage, "fake.png", Medium)
+	assert.True(t, errors.Is(err, errs.ErrUnsupportedMediaType))
+
+	_, err = EnsureVariant(ctx, fileStorage, "photo.webp", Medium)
+	assert.True(t, errors.Is(err, errs.ErrUnsupportedMediaType))
+}
+
+// gatedStorage считает чтения и не отдаёт файл, пока не открыт gate
+type gatedStorage struct {
+	storage.FileStorage
+	gate  chan struct{}
+	reads atomic.Int32
+}
+
+func (s *gatedStorage) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	s.reads.Add(1)
+	<-s.gate
+	return s.FileStorage.Get(ctx, name)
+}
+
+func TestEnsureVariantConcurrent(t *testing.T) {
+	ctx := context.Background()
+	local := storage.CreateLocalStorage(t.TempDir())
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, solidImage(50, 40, color.Black)))
+	require.NoError(t, local.Put(ctx, "shared.png", &encoded, int64(encoded.Len()), "image/png"))
+	fileStorage := &gatedStorage{FileStorage: local, gate: make(chan struct{})}
+
+	// Одновременные запросы одной картинки декодируют оригинал один раз
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name, err := EnsureVariant(ctx, fileStorage, "shared.png", Thumbnail)
+			assert.NoError(t, err)
+			assert.Equal(t, "shared_thumbnail.png", name)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(fileStorage.gate)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), fileStorage.reads.Load())
+}
+
+func TestStripMetadataJPEG(t *testing.T) {
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, solidImage(4, 2, color.White), nil))
+	withEXIF := withOrientation(t, encoded.Bytes(), 6)
+
+	stripped, err := StripMetadata(withEXIF, "jpg")
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(stripped, []byte("Exif\x00\x00")))
+
+	// Тег Orientation пропал вместе с EXIF, поэтому поворот уже применён к пикселям
+	img, format, err := image.Decode(bytes.NewReader(stripped))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, image.Rect(0, 0, 2, 4), img.Bounds())
+}
+
+func TestStripMetadataWebP(t *testing.T) {
+	chunk := func(fourCC string, payload []byte) []byte {
+		header := append([]byte(fourCC), byte(len(payload)), 0, 0, 0)
+		if len(payload)%2 == 1 {
+			payload = append(payload, 0)
+		}
+		return append(header, payload...)
+	}
+	vp8x := chunk("VP8X", []byte{webpFlagEXIF | webpFlagXMP, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	bitstream := chunk("VP8L", []byte{0x2F, 1, 2})
+	body := append(append(append(append([]byte("WEBP"), vp8x...), chunk("EXIF", []byte("gps"))...), bitstream...), chunk("XMP ", []byte("<x/>"))...)
+	data := append(append([]byte("RIFF"), byte(len(body)), 0, 0, 0), body...)
+
+	stripped, err := StripMetadata(data, "webp")
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(stripped, []byte("EXIF")))
+	assert.False(t, bytes.Contains(stripped, []byte("XMP ")))
+	assert.True(t, bytes.Contains(stripped, bitstream))
+	assert.Equal(t, byte(0), stripped[20]&(webpFlagEXIF|webpFlagXMP))
+	assert.Equal(t, len(stripped)-8, int(stripped[4]))
+
+	_, err = StripMetadata(data[:len(data)-2], "webp")
+	assert.ErrorIs(t, err, errs.ErrUnsupportedMediaType)
+}
+
+func TestStripMetadataUnsupported(t *testing.T) {
+	gif := []byte("GIF89a")
+	stripped, err := StripMetadata(gif, "gif")
+	require.NoError(t, err)
+	assert.Equal(t, gif, stripped)
+
+	_, err = StripMetadata([]byte("%PDF"), "pdf")
+	assert.ErrorIs(t, err, errs.ErrUnsupportedMediaType)
+}
@@ -0,0 +1,89 @@
+package images
+
+import (
+	"RPO_back/internal/errs"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"strings"
+)
+
+// Флаги чанка VP8X о наличии метаданных в WebP
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// StripMetadata возвращает картинку без метаданных (EXIF с геолокацией и моделью камеры, XMP, текстовые чанки),
+// чтобы они не попали в публично доступный оригинал. JPEG и PNG перекодируются (JPEG сначала поворачивается
+// по EXIF Orientation, ведь сам тег пропадёт); из WebP вырезаются чанки EXIF и XMP. В GIF EXIF не бывает,
+// он возвращается как есть
+func StripMetadata(data []byte, extension string) (stripped []byte, err error) {
+	funcName := "StripMetadata"
+	switch strings.ToLower(extension) {
+	case "gif":
+		return data, nil
+	case "webp":
+		stripped, err = stripWebPMetadata(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", funcName, err)
+		}
+		return stripped, nil
+	case "jpg", "jpeg", "png":
+	default:
+		return nil, fmt.Errorf("%s: unsupported extension %q: %w", funcName, extension, errs.ErrUnsupportedMediaType)
+	}
+
+	img, err := decodeImage(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", funcName, err)
+	}
+
+	var encoded bytes.Buffer
+	if strings.ToLower(extension) == "png" {
+		err = png.Encode(&encoded, img)
+	} else {
+		err = jpeg.Encode(&encoded, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s (encode): %w", funcName, err)
+	}
+	return encoded.Bytes(), nil
+}
+
+// stripWebPMetadata вырезает из контейнера RIFF чанки EXIF и XMP и снимает их флаги в VP8X
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("stripWebPMetadata: not a WebP file: %w", errs.ErrUnsupportedMediaType)
+	}
+
+	stripped := make([]byte, 12, len(data))
+	copy(stripped, data[:12])
+	pos := 12
+	for pos+8 <= len(data) {
+		fourCC := string(data[pos : pos+4])
+		chunkLen := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		end := pos + 8 + chunkLen + chunkLen%2 // Чанки выравниваются до чётной длины
+		if end > len(data) {
+			return nil, fmt.Errorf("stripWebPMetadata: chunk %q is truncated: %w", fourCC, errs.ErrUnsupportedMediaType)
+		}
+
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunkStart := len(stripped)
+			stripped = append(stripped, data[pos:end]...)
+			if chunkLen > 0 {
+				stripped[chunkStart+8] &^= webpFlagEXIF | webpFlagXMP
+			}
+		default:
+			stripped = append(stripped, data[pos:end]...)
+		}
+		pos = end
+	}
+
+	binary.LittleEndian.PutUint32(stripped[4:], uint32(len(stripped)-8))
+	return stripped, nil
+}
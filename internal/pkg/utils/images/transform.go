@@ -0,0 +1,176 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// exifOrientationTag - номер тега Orientation в EXIF
+const exifOrientationTag = 0x0112
+
+// jpegOrientation ищет в JPEG сегмент APP1 с EXIF и возвращает значение тега Orientation
+// (1 - поворачивать не нужно; так же, если тега нет или EXIF битый)
+func jpegOrientation(data []byte) int {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		switch {
+		case marker == 0xFF: // Заполняющий байт
+			pos++
+			continue
+		case marker == 0xDA || marker == 0xD9: // Начались сжатые данные: EXIF уже не встретится
+			return 1
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7): // Маркеры без длины
+			pos += 2
+			continue
+		}
+
+		segmentLen := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if segmentLen < 2 || pos+2+segmentLen > len(data) {
+			return 1
+		}
+		segment := data[pos+4 : pos+2+segmentLen]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		pos += 2 + segmentLen
+	}
+	return 1
+}
+
+// exifOrientation читает тег Orientation из первого IFD заголовка TIFF внутри EXIF
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifdOffset := int64(order.Uint32(tiff[4:]))
+	if ifdOffset+2 > int64(len(tiff)) {
+		return 1
+	}
+	ifd := int(ifdOffset)
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 1
+		}
+		return orientation
+	}
+	return 1
+}
+
+// toRGBA переводит картинку в RGBA с началом координат в (0, 0)
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Rect, img, bounds.Min, draw.Src)
+	return rgba
+}
+
+// applyOrientation поворачивает и отражает картинку так, чтобы она выглядела как задумано
+// без тега EXIF Orientation (значения 2-8; 1 и неизвестные - без изменений)
+func applyOrientation(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		// Повороты на 90 градусов и транспонирования меняют стороны местами
+		dstW, dstH = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // Отражение по горизонтали
+				dx, dy = w-1-x, y
+			case 3: // Поворот на 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // Отражение по вертикали
+				dx, dy = x, h-1-y
+			case 5: // Транспонирование
+				dx, dy = y, x
+			case 6: // Поворот на 90 по часовой
+				dx, dy = h-1-y, x
+			case 7: // Транспонирование относительно побочной диагонали
+				dx, dy = h-1-y, w-1-x
+			case 8: // Поворот на 90 против часовой
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], src.Pix[src.PixOffset(x, y):src.PixOffset(x, y)+4])
+		}
+	}
+	return dst
+}
+
+// resize уменьшает картинку так, чтобы большая сторона была не больше maxSide, усредняя
+// исходные пиксели, попавшие в каждый новый (box-фильтр). Маленькие картинки не увеличиваются
+func resize(src *image.RGBA, maxSide int) *image.RGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	if w <= maxSide && h <= maxSide {
+		return src
+	}
+	dstW, dstH := maxSide, maxSide
+	if w > h {
+		dstH = max(1, (h*maxSide+w/2)/w)
+	} else {
+		dstW = max(1, (w*maxSide+h/2)/h)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for dy := 0; dy < dstH; dy++ {
+		y0, y1 := dy*h/dstH, (dy+1)*h/dstH
+		for dx := 0; dx < dstW; dx++ {
+			x0, x1 := dx*w/dstW, (dx+1)*w/dstW
+
+			// RGBA хранит цвета, умноженные на альфу, поэтому их можно усреднять напрямую
+			var sum [4]uint64
+			for y := y0; y < y1; y++ {
+				row := src.Pix[src.PixOffset(x0, y):src.PixOffset(x1, y)]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += uint64(row[i])
+					sum[1] += uint64(row[i+1])
+					sum[2] += uint64(row[i+2])
+					sum[3] += uint64(row[i+3])
+				}
+			}
+			count := uint64((x1 - x0) * (y1 - y0))
+			pixel := dst.Pix[dst.PixOffset(dx, dy):]
+			for c := 0; c < 4; c++ {
+				pixel[c] = uint8((sum[c] + count/2) / count)
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,114 @@
+package mailer
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Message - письмо одному получателю
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer отправляет письма пользователям
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPMailer отправляет письма через SMTP-сервер
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// CreateSMTPMailer создаёт SMTPMailer. Если username пустой, сервер используется без авторизации
+// (например, локальная заглушка вроде MailHog)
+func CreateSMTPMailer(addr string, username string, password string, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		host, _, _ := strings.Cut(addr, ":")
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailer{
+		addr: addr,
+		from: from,
+		auth: auth,
+	}
+}
+
+// Send отправляет письмо через SMTP
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	err := smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, buildMessage(m.from, msg))
+	if err != nil {
+		return fmt.Errorf("SMTPMailer.Send: %w", err)
+	}
+	return nil
+}
+
+// FileMailer вместо отправки дописывает письма в файл, а если путь не задан - в лог.
+// Нужен для разработки и тестов, когда SMTP-сервера нет
+type FileMailer struct {
+	mu   sync.Mutex
+	path string
+}
+
+// CreateFileMailer создаёт FileMailer, который пишет письма в path
+func CreateFileMailer(path string) *FileMailer {
+	return &FileMailer{path: path}
+}
+
+// Send записывает письмо в файл
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if m.path == "" {
+		log.Info("FileMailer: mail to ", msg.To, ": ", msg.Subject, "\n", msg.Body)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, err := os.OpenFile(m.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("FileMailer.Send (open): %w", err)
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC1123Z), msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("FileMailer.Send (write): %w", err)
+	}
+	return nil
+}
+
+// buildMessage собирает письмо в формате RFC 5322 с телом в UTF-8
+func buildMessage(from string, msg Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n")
+	b.WriteString("\r\n")
+
+	body := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(body) > 76 {
+		b.WriteString(body[:76] + "\r\n")
+		body = body[76:]
+	}
+	b.WriteString(body + "\r\n")
+	return []byte(b.String())
+}
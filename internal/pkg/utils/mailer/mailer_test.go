@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMailer_Send(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	m := CreateFileMailer(path)
+
+	err := m.Send(context.Background(), Message{To: "user@example.com", Subject: "Тема", Body: "Текст письма"})
+	assert.NoError(t, err)
+	err = m.Send(context.Background(), Message{To: "other@example.com", Subject: "Вторая", Body: "Ещё письмо"})
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "To: user@example.com")
+	assert.Contains(t, string(content), "Текст письма")
+	assert.Contains(t, string(content), "To: other@example.com")
+}
+
+func TestBuildMessage(t *testing.T) {
+	body := strings.Repeat("Очень длинное письмо. ", 20)
+	raw := string(buildMessage("noreply@example.com", Message{To: "user@example.com", Subject: "Восстановление пароля", Body: body}))
+
+	headers, encodedBody, found := strings.Cut(raw, "\r\n\r\n")
+	assert.True(t, found)
+	assert.Contains(t, headers, "From: noreply@example.com")
+	assert.Contains(t, headers, "To: user@example.com")
+	assert.Contains(t, headers, "Subject: =?utf-8?q?")
+
+	for _, line := range strings.Split(strings.TrimSpace(encodedBody), "\r\n") {
+		assert.LessOrEqual(t, len(line), 76)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encodedBody, "\r\n", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(decoded))
+}
@@ -0,0 +1,19 @@
+package misc
+
+import (
+	"RPO_back/internal/pkg/config"
+	"RPO_back/internal/pkg/utils/mailer"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateMailer создаёт отправителя писем по конфигу: SMTP, если он задан, иначе запись писем в файл
+func CreateMailer() mailer.Mailer {
+	mailConfig := config.CurrentConfig.Mail
+	if mailConfig.SMTPAddr != "" {
+		log.Info("Mail is sent via SMTP server ", mailConfig.SMTPAddr)
+		return mailer.CreateSMTPMailer(mailConfig.SMTPAddr, mailConfig.SMTPUsername, mailConfig.SMTPPassword, mailConfig.From)
+	}
+	log.Info("SMTP is not configured, mail is written to ", mailConfig.SinkFile)
+	return mailer.CreateFileMailer(mailConfig.SinkFile)
+}
@@ -0,0 +1,25 @@
+package misc
+
+import (
+	"RPO_back/internal/pkg/config"
+	"RPO_back/internal/pkg/utils/storage"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateFileStorage создаёт хранилище загруженных файлов по конфигу: бакет S3 или каталог на диске
+func CreateFileStorage() (storage.FileStorage, error) {
+	filesConfig := config.CurrentConfig.Files
+	if filesConfig.Backend == config.FileStorageS3 {
+		log.Info("Uploaded files are stored in S3 bucket ", filesConfig.S3Bucket, " at ", filesConfig.S3Endpoint)
+		s3Storage, err := storage.CreateS3Storage(filesConfig.S3Endpoint, filesConfig.S3Region, filesConfig.S3Bucket,
+			filesConfig.S3AccessKey, filesConfig.S3SecretKey)
+		if err != nil {
+			return nil, fmt.Errorf("CreateFileStorage: %w", err)
+		}
+		return s3Storage, nil
+	}
+	log.Info("Uploaded files are stored in ", config.CurrentConfig.UploadsDir)
+	return storage.CreateLocalStorage(config.CurrentConfig.UploadsDir), nil
+}
@@ -0,0 +1,109 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"math/big"
+)
+
+// Поддерживаемые алгоритмы подписи ID-токена. none и HS* не принимаются никогда
+const (
+	algRS256 = "RS256"
+	algES256 = "ES256"
+)
+
+// publicKey - ключ подписи IdP из JWKS
+type publicKey interface {
+	supports(algorithm string) bool
+	verify(algorithm string, signingInput []byte, signature []byte) error
+}
+
+type rsaKey struct{ key *rsa.PublicKey }
+
+func (k rsaKey) supports(algorithm string) bool { return algorithm == algRS256 }
+
+func (k rsaKey) verify(algorithm string, signingInput []byte, signature []byte) error {
+	if !k.supports(algorithm) {
+		return errors.New("unsupported algorithm " + algorithm)
+	}
+	digest := sha256.Sum256(signingInput)
+	return rsa.VerifyPKCS1v15(k.key, crypto.SHA256, digest[:], signature)
+}
+
+type ecKey struct{ key *ecdsa.PublicKey }
+
+func (k ecKey) supports(algorithm string) bool { return algorithm == algES256 }
+
+func (k ecKey) verify(algorithm string, signingInput []byte, signature []byte) error {
+	if !k.supports(algorithm) {
+		return errors.New("unsupported algorithm " + algorithm)
+	}
+	// В JWS подпись ES256 - это r и s по 32 байта подряд, а не ASN.1
+	if len(signature) != 64 {
+		return errors.New("bad es256 signature length")
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	digest := sha256.Sum256(signingInput)
+	if !ecdsa.Verify(k.key, digest[:], r, s) {
+		return errors.New("ecdsa verification failed")
+	}
+	return nil
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// publicKeys разбирает ключи подписи из JWKS; ключи шифрования и неподдерживаемых типов пропускаются
+func (s jsonWebKeySet) publicKeys() map[string]publicKey {
+	keys := make(map[string]publicKey, len(s.Keys))
+	for _, jwk := range s.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		switch jwk.KeyType {
+		case "RSA":
+			n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+			e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+			if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+				continue
+			}
+			keys[jwk.KeyID] = rsaKey{key: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}}
+		case "EC":
+			if jwk.Curve != "P-256" {
+				continue
+			}
+			x, errX := base64.RawURLEncoding.DecodeString(jwk.X)
+			y, errY := base64.RawURLEncoding.DecodeString(jwk.Y)
+			if errX != nil || errY != nil {
+				continue
+			}
+			key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+			if !key.Curve.IsOnCurve(key.X, key.Y) {
+				continue
+			}
+			keys[jwk.KeyID] = ecKey{key: key}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,129 @@
+package oidc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type parsedJWT struct {
+	header       jwtHeader
+	payload      []byte
+	signingInput []byte
+	signature    []byte
+}
+
+// parseJWT разбирает JWS в компактной форме, не проверяя подпись
+func parseJWT(raw string) (*parsedJWT, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed jwt")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("payload: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+
+	token := &parsedJWT{
+		payload:      payload,
+		signingInput: []byte(parts[0] + "." + parts[1]),
+		signature:    signature,
+	}
+	if err = json.Unmarshal(headerJSON, &token.header); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+
+	return token, nil
+}
+
+// audience - claim aud, который по спецификации бывает строкой или массивом строк
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// flexibleBool - claim email_verified; некоторые IdP присылают его строкой "true"
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = flexibleBool(value)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	*b = flexibleBool(text == "true")
+	return nil
+}
+
+type idTokenClaims struct {
+	Issuer            string       `json:"iss"`
+	Subject           string       `json:"sub"`
+	Audience          audience     `json:"aud"`
+	AuthorizedParty   string       `json:"azp"`
+	ExpiresAt         int64        `json:"exp"`
+	IssuedAt          int64        `json:"iat"`
+	Nonce             string       `json:"nonce"`
+	Email             string       `json:"email"`
+	EmailVerified     flexibleBool `json:"email_verified"`
+	Name              string       `json:"name"`
+	PreferredUsername string       `json:"preferred_username"`
+}
+
+// validate проверяет claims ID-токена по OIDC Core, 3.1.3.7
+func (c *idTokenClaims) validate(issuer string, clientID string, nonce string, now time.Time) error {
+	if c.Issuer != issuer {
+		return fmt.Errorf("issuer mismatch: %q", c.Issuer)
+	}
+	if c.Subject == "" {
+		return errors.New("no subject")
+	}
+	if !slices.Contains(c.Audience, clientID) {
+		return errors.New("token is not issued for this client")
+	}
+	if len(c.Audience) > 1 && c.AuthorizedParty != clientID {
+		return errors.New("authorized party mismatch")
+	}
+	if c.ExpiresAt == 0 || now.After(time.Unix(c.ExpiresAt, 0).Add(clockLeeway)) {
+		return errors.New("token expired")
+	}
+	if c.IssuedAt != 0 && time.Unix(c.IssuedAt, 0).After(now.Add(clockLeeway)) {
+		return errors.New("token issued in the future")
+	}
+	if c.Nonce != nonce {
+		return errors.New("nonce mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidToken - ID-токен не прошёл проверку (подпись, издатель, получатель, срок или nonce)
+var ErrInvalidToken = errors.New("invalid id token")
+
+const (
+	discoveryTTL       = time.Hour        // Как долго доверять документу discovery
+	jwksMinRefresh     = time.Minute      // Не чаще этого JWKS перезапрашивается из-за неизвестного kid
+	clockLeeway        = time.Minute      // Допуск на расхождение часов с IdP
+	maxResponseSize    = 1 << 20          // Ответы IdP больше этого не читаются
+	defaultHTTPTimeout = 10 * time.Second // Таймаут запросов к IdP
+)
+
+// Config - настройки клиента OIDC
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string // Пустой для публичного клиента (тогда защищает только PKCE)
+	RedirectURL  string
+	Scopes       []string // Если пусто - openid email profile
+}
+
+// Claims - утверждения ID-токена, которые нужны для входа
+type Claims struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// Provider ходит к IdP: discovery, обмен кода на токены и проверка ID-токена по JWKS.
+// Документ discovery и ключи кешируются
+type Provider struct {
+	config     Config
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu            sync.Mutex
+	discovery     *discoveryDocument
+	discoveredAt  time.Time
+	keys          map[string]publicKey
+	keysFetchedAt time.Time
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+func CreateProvider(config Config) *Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &Provider{
+		config:     config,
+		httpClient: &http.Client{Timeout: defaultHTTPTimeout},
+		now:        time.Now,
+	}
+}
+
+// GenerateState создаёт случайную строку для state и nonce
+func GenerateState() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("GenerateState: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// GeneratePKCE создаёт code_verifier и соответствующий ему code_challenge (метод S256)
+func GeneratePKCE() (verifier string, challenge string, err error) {
+	verifier, err = GenerateState()
+	if err != nil {
+		return "", "", fmt.Errorf("GeneratePKCE: %w", err)
+	}
+	return verifier, PKCEChallenge(verifier), nil
+}
+
+// PKCEChallenge вычисляет code_challenge для code_verifier по методу S256
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL возвращает адрес страницы входа IdP для authorization code flow с PKCE
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", fmt.Errorf("AuthCodeURL (getDiscovery): %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange меняет код авторизации на токены и возвращает ID-токен (без проверки, см. VerifyIDToken)
+func (p *Provider) Exchange(ctx context.Context, code string, codeVerifier string) (rawIDToken string, err error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", fmt.Errorf("Exchange (getDiscovery): %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("code_verifier", codeVerifier)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("Exchange (NewRequest): %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		// client_secret_basic: id и секрет кодируются как form-значения (RFC 6749, 2.3.1)
+		request.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var tokenResponse struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.doJSON(request, &tokenResponse)
+	if err != nil {
+		return "", fmt.Errorf("Exchange (token request): %w", err)
+	}
+	if status != http.StatusOK || tokenResponse.Error != "" {
+		return "", fmt.Errorf("Exchange: token endpoint returned %d %s: %s", status, tokenResponse.Error, tokenResponse.ErrorDescription)
+	}
+	if tokenResponse.IDToken == "" {
+		return "", fmt.Errorf("Exchange: no id_token in response")
+	}
+
+	return tokenResponse.IDToken, nil
+}
+
+// VerifyIDToken проверяет подпись ID-токена по ключам IdP, издателя, получателя, срок действия и nonce
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*Claims, error) {
+	token, err := parseJWT(rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyIDToken (parseJWT): %w: %w", ErrInvalidToken, err)
+	}
+
+	key, err := p.getKey(ctx, token.header.KeyID, token.header.Algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyIDToken (getKey): %w", err)
+	}
+	if err = key.verify(token.header.Algorithm, token.signingInput, token.signature); err != nil {
+		return nil, fmt.Errorf("VerifyIDToken (signature): %w: %w", ErrInvalidToken, err)
+	}
+
+	var claims idTokenClaims
+	if err = json.Unmarshal(token.payload, &claims); err != nil {
+		return nil, fmt.Errorf("VerifyIDToken (claims): %w: %w", ErrInvalidToken, err)
+	}
+	if err = claims.validate(p.config.Issuer, p.config.ClientID, nonce, p.now()); err != nil {
+		return nil, fmt.Errorf("VerifyIDToken (claims): %w: %w", ErrInvalidToken, err)
+	}
+
+	return &Claims{
+		Issuer:            claims.Issuer,
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     bool(claims.EmailVerified),
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
+
+// getDiscovery получает документ discovery (из кеша или от IdP)
+func (p *Provider) getDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	if p.discovery != nil && p.now().Sub(p.discoveredAt) < discoveryTTL {
+		discovery := p.discovery
+		p.mu.Unlock()
+		return discovery, nil
+	}
+	p.mu.Unlock()
+
+	discoveryURL := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getDiscovery (NewRequest): %w", err)
+	}
+
+	discovery := &discoveryDocument{}
+	status, err := p.doJSON(request, discovery)
+	if err != nil {
+		return nil, fmt.Errorf("getDiscovery (request): %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("getDiscovery: discovery returned %d", status)
+	}
+	// Издатель в документе должен совпадать с настроенным, иначе это чужой IdP (OIDC Discovery, 4.3)
+	if discovery.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("getDiscovery: issuer mismatch: %q", discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("getDiscovery: incomplete discovery document")
+	}
+
+	p.mu.Lock()
+	p.discovery = discovery
+	p.discoveredAt = p.now()
+	p.mu.Unlock()
+
+	return discovery, nil
+}
+
+// getKey находит ключ подписи по kid. При неизвестном kid JWKS перезапрашивается (IdP мог сменить ключи)
+func (p *Provider) getKey(ctx context.Context, keyID string, algorithm string) (publicKey, error) {
+	p.mu.Lock()
+	key, ok := p.findKey(keyID, algorithm)
+	canRefresh := p.now().Sub(p.keysFetchedAt) >= jwksMinRefresh
+	p.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	if !canRefresh {
+		return nil, fmt.Errorf("getKey: unknown key %q: %w", keyID, ErrInvalidToken)
+	}
+
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getKey (getDiscovery): %w", err)
+	}
+	keys, err := p.fetchJWKS(ctx, discovery.JWKSURI)
+	if err != nil {
+		return nil, fmt.Errorf("getKey (fetchJWKS): %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = keys
+	p.keysFetchedAt = p.now()
+	key, ok = p.findKey(keyID, algorithm)
+	if !ok {
+		return nil, fmt.Errorf("getKey: unknown key %q: %w", keyID, ErrInvalidToken)
+	}
+	return key, nil
+}
+
+// findKey ищет ключ в кеше; без kid подходит единственный ключ нужного типа. Вызывается под p.mu
+func (p *Provider) findKey(keyID string, algorithm string) (publicKey, bool) {
+	if keyID != "" {
+		key, ok := p.keys[keyID]
+		return key, ok && key.supports(algorithm)
+	}
+
+	var found publicKey
+	for _, key := range p.keys {
+		if key.supports(algorithm) {
+			if found != nil {
+				return nil, false
+			}
+			found = key
+		}
+	}
+	return found, found != nil
+}
+
+// fetchJWKS загружает ключи подписи IdP
+func (p *Provider) fetchJWKS(ctx context.Context, jwksURI string) (map[string]publicKey, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetchJWKS (NewRequest): %w", err)
+	}
+
+	var set jsonWebKeySet
+	status, err := p.doJSON(request, &set)
+	if err != nil {
+		return nil, fmt.Errorf("fetchJWKS (request): %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("fetchJWKS: jwks returned %d", status)
+	}
+
+	return set.publicKeys(), nil
+}
+
+// doJSON выполняет запрос и разбирает JSON-ответ, возвращая HTTP-статус
+func (p *Provider) doJSON(request *http.Request, target any) (status int, err error) {
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize))
+	if err != nil {
+		return response.StatusCode, err
+	}
+	if err = json.Unmarshal(body, target); err != nil && response.StatusCode == http.StatusOK {
+		return response.StatusCode, fmt.Errorf("decode json: %w", err)
+	}
+	return response.StatusCode, nil
+}
@@ -0,0 +1,145 @@
+package oidc
+
+import (
+	"RPO_back/internal/pkg/utils/oidc/oidctest"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRedirectURL = "http://localhost/auth/oidc/callback"
+
+// login проходит authorization code flow с PKCE на mock IdP и возвращает ID-токен
+func login(t *testing.T, provider *Provider, nonce string, tamperVerifier bool) (string, error) {
+	verifier, challenge, err := GeneratePKCE()
+	require.NoError(t, err)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", nonce, challenge)
+	require.NoError(t, err)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	response, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusFound, response.StatusCode)
+
+	callback, err := url.Parse(response.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "state-1", callback.Query().Get("state"))
+
+	if tamperVerifier {
+		verifier += "x"
+	}
+	return provider.Exchange(context.Background(), callback.Query().Get("code"), verifier)
+}
+
+func newTestProvider(idp *oidctest.Server) *Provider {
+	return CreateProvider(Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  testRedirectURL,
+	})
+}
+
+func TestLoginFlow(t *testing.T) {
+	idp := oidctest.NewServer("pumpkin", "secret")
+	defer idp.Close()
+	provider := newTestProvider(idp)
+
+	rawIDToken, err := login(t, provider, "nonce-1", false)
+	require.NoError(t, err)
+
+	claims, err := provider.VerifyIDToken(context.Background(), rawIDToken, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, idp.Issuer(), claims.Issuer)
+	assert.Equal(t, "mock-subject", claims.Subject)
+	assert.Equal(t, "user@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, "mockuser", claims.PreferredUsername)
+}
+
+func TestExchange_PKCEMismatch(t *testing.T) {
+	idp := oidctest.NewServer("pumpkin", "secret")
+	defer idp.Close()
+
+	_, err := login(t, newTestProvider(idp), "nonce-1", true)
+	assert.Error(t, err)
+}
+
+func TestVerifyIDToken_Rejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		nonce  string
+		modify func(claims map[string]any)
+	}{
+		{name: "wrong nonce", nonce: "other-nonce"},
+		{name: "wrong audience", nonce: "nonce-1", modify: func(c map[string]any) { c["aud"] = "someone-else" }},
+		{name: "wrong issuer", nonce: "nonce-1", modify: func(c map[string]any) { c["iss"] = "https://evil.example.com" }},
+		{name: "expired", nonce: "nonce-1", modify: func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "foreign azp", nonce: "nonce-1", modify: func(c map[string]any) {
+			c["aud"] = []string{"pumpkin", "other"}
+			c["azp"] = "other"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := oidctest.NewServer("pumpkin", "secret")
+			defer idp.Close()
+			idp.ModifyClaims = tt.modify
+			provider := newTestProvider(idp)
+
+			rawIDToken, err := login(t, provider, "nonce-1", false)
+			require.NoError(t, err)
+
+			_, err = provider.VerifyIDToken(context.Background(), rawIDToken, tt.nonce)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestVerifyIDToken_BadSignature(t *testing.T) {
+	idp := oidctest.NewServer("pumpkin", "secret")
+	defer idp.Close()
+	provider := newTestProvider(idp)
+
+	rawIDToken, err := login(t, provider, "nonce-1", false)
+	require.NoError(t, err)
+
+	// Подменяем payload, оставляя старую подпись
+	parts := strings.Split(rawIDToken, ".")
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"` + idp.Issuer() + `","sub":"admin","aud":"pumpkin","exp":9999999999,"nonce":"nonce-1"}`))
+	_, err = provider.VerifyIDToken(context.Background(), strings.Join(parts, "."), "nonce-1")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// Неподписанный токен (alg none) не принимается
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	_, err = provider.VerifyIDToken(context.Background(), none+"."+parts[1]+".", "nonce-1")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	idp := oidctest.NewServer("pumpkin", "secret")
+	defer idp.Close()
+
+	authURL, err := newTestProvider(idp).AuthCodeURL(context.Background(), "state-1", "nonce-1", PKCEChallenge("verifier"))
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Equal(t, testRedirectURL, query.Get("redirect_uri"))
+}
@@ -1,6 +1,7 @@
 package requests
 
 import (
+	"RPO_back/internal/pkg/config"
 	"RPO_back/internal/pkg/middleware/session"
 	"RPO_back/internal/pkg/utils/responses"
 	"RPO_back/internal/pkg/utils/validate"
@@ -8,8 +9,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/netip"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -86,3 +90,43 @@ func GetUserIDOrFail(w http.ResponseWriter, r *http.Request, prefix string) (use
 	}
 	return userID, true
 }
+
+// GetClientIP достаёт IP-адрес клиента из RemoteAddr. Заголовкам X-Real-IP и X-Forwarded-For
+// верим, только если запрос пришёл от доверенного прокси (TRUSTED_PROXIES): тогда берём X-Real-IP,
+// а без него - последний адрес в X-Forwarded-For, который не принадлежит доверенным прокси
+func GetClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	var trustedProxies []netip.Prefix
+	if config.CurrentConfig != nil {
+		trustedProxies = config.CurrentConfig.TrustedProxies
+	}
+	if !isTrustedProxy(host, trustedProxies) {
+		return host
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+		return realIP
+	}
+	forwardedFor := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		clientIP := strings.TrimSpace(forwardedFor[i])
+		if clientIP != "" && !isTrustedProxy(clientIP, trustedProxies) {
+			return clientIP
+		}
+	}
+	return host
+}
+
+// isTrustedProxy проверяет, входит ли адрес в одну из подсетей доверенных прокси
+func isTrustedProxy(ip string, trustedProxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	return slices.ContainsFunc(trustedProxies, func(prefix netip.Prefix) bool { return prefix.Contains(addr) })
+}
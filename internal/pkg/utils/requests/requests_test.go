@@ -2,8 +2,10 @@ package requests
 
 import (
 	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/config"
 	"bytes"
 	"net/http"
+	"net/netip"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -44,3 +46,50 @@ func TestGetIDFromRequestWithPrefixError(t *testing.T) {
 	_, err := GetIDFromRequest(req, "boardID", "board_")
 	assert.Error(t, err)
 }
+
+func TestGetClientIP(t *testing.T) {
+	oldConfig := config.CurrentConfig
+	defer func() { config.CurrentConfig = oldConfig }()
+	config.CurrentConfig = &config.Config{
+		TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.7:5555", expected: "203.0.113.7"},
+		{
+			name:       "headers from untrusted client are ignored",
+			remoteAddr: "203.0.113.7:5555",
+			headers:    map[string]string{"X-Real-IP": "1.1.1.1", "X-Forwarded-For": "2.2.2.2"},
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "real ip from trusted proxy",
+			remoteAddr: "10.0.0.5:5555",
+			headers:    map[string]string{"X-Real-IP": "198.51.100.1"},
+			expected:   "198.51.100.1",
+		},
+		{
+			name:       "spoofed forwarded-for prefix is skipped",
+			remoteAddr: "10.0.0.5:5555",
+			headers:    map[string]string{"X-Forwarded-For": "1.1.1.1, 198.51.100.1, 10.0.0.9"},
+			expected:   "198.51.100.1",
+		},
+		{name: "trusted proxy without headers", remoteAddr: "10.0.0.5:5555", expected: "10.0.0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/auth/login", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			assert.Equal(t, tt.expected, GetClientIP(req))
+		})
+	}
+}
@@ -58,8 +58,13 @@ func DoJSONResponse(w http.ResponseWriter, responseData interface{}, successStat
 // Типичная запись в логе: `UserToBoard: Not found`.
 // В данном случае префикс - `UserToBoard`, двоеточие мы поставим сами.
 //
-// Поддерживаемые типы ошибок: 404, 403, 500
+// Поддерживаемые типы ошибок: 400, 404, 403, 409, 429, 500
 func ResponseErrorAndLog(w http.ResponseWriter, err error, prefix string) {
+	if errors.Is(err, errs.ErrBadRequest) {
+		DoBadResponse(w, http.StatusBadRequest, "bad request")
+		log.Warn(prefix, ": ", err)
+		return
+	}
 	if errors.Is(err, errs.ErrNotFound) {
 		DoBadResponse(w, http.StatusNotFound, "not found")
 		log.Warn(prefix, ": ", err)
@@ -70,6 +75,26 @@ func ResponseErrorAndLog(w http.ResponseWriter, err error, prefix string) {
 		log.Warn(prefix, ": ", err)
 		return
 	}
+	if errors.Is(err, errs.ErrAlreadyExists) {
+		DoBadResponse(w, http.StatusConflict, "already exists")
+		log.Warn(prefix, ": ", err)
+		return
+	}
+	if errors.Is(err, errs.ErrTooManyRequests) {
+		DoBadResponse(w, http.StatusTooManyRequests, "too many requests")
+		log.Warn(prefix, ": ", err)
+		return
+	}
+	if errors.Is(err, errs.ErrTooLarge) {
+		DoBadResponse(w, http.StatusRequestEntityTooLarge, "file is too large")
+		log.Warn(prefix, ": ", err)
+		return
+	}
+	if errors.Is(err, errs.ErrUnsupportedMediaType) {
+		DoBadResponse(w, http.StatusUnsupportedMediaType, "unsupported file type")
+		log.Warn(prefix, ": ", err)
+		return
+	}
 	log.Error(prefix, ": ", err)
 	DoBadResponse(w, http.StatusInternalServerError, "internal error")
 }
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"RPO_back/internal/errs"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalStorage хранит файлы в каталоге на диске, а отдаются они статикой по адресу USER_UPLOADS_URL
+type LocalStorage struct {
+	dir string
+}
+
+// CreateLocalStorage создаёт LocalStorage в каталоге dir
+func CreateLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{dir: dir}
+}
+
+// Put записывает файл через временный файл, чтобы читатели не увидели его недописанным
+func (s *LocalStorage) Put(ctx context.Context, name string, content io.Reader, size int64, contentType string) error {
+	if err := checkName(name); err != nil {
+		return fmt.Errorf("LocalStorage.Put: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("LocalStorage.Put (create): %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = io.Copy(tmpFile, content)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("LocalStorage.Put (write): %w", err)
+	}
+
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return fmt.Errorf("LocalStorage.Put (chmod): %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("LocalStorage.Put (rename): %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	if err := checkName(name); err != nil {
+		return nil, fmt.Errorf("LocalStorage.Get: %w", err)
+	}
+
+	file, err := os.Open(filepath.Join(s.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("LocalStorage.Get %s: %w", name, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("LocalStorage.Get: %w", err)
+	}
+	return file, nil
+}
+
+// Delete удаляет файл; удаление отсутствующего файла не считается ошибкой
+func (s *LocalStorage) Delete(ctx context.Context, name string) error {
+	if err := checkName(name); err != nil {
+		return fmt.Errorf("LocalStorage.Delete: %w", err)
+	}
+
+	err := os.Remove(filepath.Join(s.dir, name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("LocalStorage.Delete: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) Stat(ctx context.Context, name string) (*FileInfo, error) {
+	if err := checkName(name); err != nil {
+		return nil, fmt.Errorf("LocalStorage.Stat: %w", err)
+	}
+
+	info, err := os.Stat(filepath.Join(s.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("LocalStorage.Stat %s: %w", name, errs.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("LocalStorage.Stat: %w", err)
+	}
+	return &FileInfo{
+		Size:        info.Size(),
+		ContentType: ContentTypeByName(name),
+		ModTime:     info.ModTime(),
+	}, nil
+}
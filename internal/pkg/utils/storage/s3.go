@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"RPO_back/internal/errs"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3DateFormat      = "20060102T150405Z"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// S3Storage хранит файлы в бакете S3-совместимого хранилища (AWS S3, MinIO и т.п.).
+// Запросы подписываются AWS Signature Version 4, бакет адресуется в пути (path-style),
+// поэтому endpoint может быть любым адресом вроде http://minio:9000.
+// Подписываются только запросы бэкенда: читают файлы клиенты из публичного бакета
+type S3Storage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+// CreateS3Storage создаёт S3Storage для бакета bucket на сервере endpoint
+func CreateS3Storage(endpoint string, region string, bucket string, accessKey string, secretKey string) (*S3Storage, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("CreateS3Storage (endpoint): %w", err)
+	}
+	if endpointURL.Scheme != "http" && endpointURL.Scheme != "https" || endpointURL.Host == "" {
+		return nil, fmt.Errorf("CreateS3Storage: endpoint %q must be an http(s) URL", endpoint)
+	}
+	if bucket == "" {
+		return nil, fmt.Errorf("CreateS3Storage: bucket is empty")
+	}
+	return &S3Storage{
+		endpoint:  endpointURL,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{},
+	}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, name string, content io.Reader, size int64, contentType string) error {
+	if err := checkName(name); err != nil {
+		return fmt.Errorf("S3Storage.Put: %w", err)
+	}
+
+	// S3 принимает PUT только с Content-Length, поэтому поток неизвестной длины
+	// сначала пишется во временный файл
+	if size < 0 {
+		spool, err := os.CreateTemp("", "s3-upload-*")
+		if err != nil {
+			return fmt.Errorf("S3Storage.Put (spool): %w", err)
+		}
+		defer os.Remove(spool.Name())
+		defer spool.Close()
+
+		size, err = io.Copy(spool, content)
+		if err != nil {
+			return fmt.Errorf("S3Storage.Put (spool): %w", err)
+		}
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("S3Storage.Put (spool): %w", err)
+		}
+		content = spool
+	}
+
+	request, err := s.newRequest(ctx, http.MethodPut, name, content)
+	if err != nil {
+		return fmt.Errorf("S3Storage.Put (request): %w", err)
+	}
+	request.ContentLength = size
+	if size == 0 {
+		request.Body = http.NoBody
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return fmt.Errorf("S3Storage.Put: %w", err)
+	}
+	response.Body.Close()
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	if err := checkName(name); err != nil {
+		return nil, fmt.Errorf("S3Storage.Get: %w", err)
+	}
+
+	request, err := s.newRequest(ctx, http.MethodGet, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("S3Storage.Get (request): %w", err)
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("S3Storage.Get %s: %w", name, err)
+	}
+	return response.Body, nil
+}
+
+// Delete удаляет объект; S3 не считает ошибкой удаление отсутствующего объекта
+func (s *S3Storage) Delete(ctx context.Context, name string) error {
+	if err := checkName(name); err != nil {
+		return fmt.Errorf("S3Storage.Delete: %w", err)
+	}
+
+	request, err := s.newRequest(ctx, http.MethodDelete, name, nil)
+	if err != nil {
+		return fmt.Errorf("S3Storage.Delete (request): %w", err)
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return fmt.Errorf("S3Storage.Delete: %w", err)
+	}
+	response.Body.Close()
+	return nil
+}
+
+func (s *S3Storage) Stat(ctx context.Context, name string) (*FileInfo, error) {
+	if err := checkName(name); err != nil {
+		return nil, fmt.Errorf("S3Storage.Stat: %w", err)
+	}
+
+	request, err := s.newRequest(ctx, http.MethodHead, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("S3Storage.Stat (request): %w", err)
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("S3Storage.Stat %s: %w", name, err)
+	}
+	response.Body.Close()
+
+	info := &FileInfo{
+		Size:        response.ContentLength,
+		ContentType: response.Header.Get("Content-Type"),
+	}
+	if lastModified := response.Header.Get("Last-Modified"); lastModified != "" {
+		info.ModTime, _ = http.ParseTime(lastModified)
+	}
+	return info, nil
+}
+
+// objectURL - адрес объекта в path-style: endpoint/bucket/name
+func (s *S3Storage) objectURL(name string) *url.URL {
+	objectURL := *s.endpoint
+	objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + s.bucket + "/" + name
+	objectURL.RawPath = strings.TrimSuffix(s.endpoint.EscapedPath(), "/") + "/" + uriEncode(s.bucket, false) + "/" + uriEncode(name, false)
+	return &objectURL
+}
+
+// newRequest создаёт подписанный запрос к объекту. Тело не хешируется (UNSIGNED-PAYLOAD),
+// чтобы его можно было передавать потоком
+func (s *S3Storage) newRequest(ctx context.Context, method string, name string, body io.Reader) (*http.Request, error) {
+	objectURL := s.objectURL(name)
+	request, err := http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	request.Header.Set("X-Amz-Date", now.Format(s3DateFormat))
+	request.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	signedHeaders := map[string]string{
+		"host":                 objectURL.Host,
+		"x-amz-content-sha256": s3UnsignedPayload,
+		"x-amz-date":           now.Format(s3DateFormat),
+	}
+	signature := s.sign(method, objectURL.EscapedPath(), url.Values{}, signedHeaders, s3UnsignedPayload, now)
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKey, credentialScope(now, s.region), signedHeaderNames(signedHeaders), signature))
+	return request, nil
+}
+
+// do выполняет запрос и переводит неуспешный ответ в ошибку (404 - errs.ErrNotFound)
+func (s *S3Storage) do(request *http.Request) (*http.Response, error) {
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return response, nil
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, errs.ErrNotFound
+	}
+	message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return nil, fmt.Errorf("unexpected status %d: %s", response.StatusCode, strings.TrimSpace(string(message)))
+}
+
+// sign считает подпись Signature Version 4 для запроса с данными заголовками (имена в нижнем регистре)
+func (s *S3Storage) sign(method string, escapedPath string, query url.Values,
+	headers map[string]string, payloadHash string, t time.Time) string {
+	names := strings.Split(signedHeaderNames(headers), ";")
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		escapedPath,
+		canonicalQuery(query),
+		canonicalHeaders.String(),
+		signedHeaderNames(headers),
+		payloadHash,
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		t.UTC().Format(s3DateFormat),
+		credentialScope(t, s.region),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), t.UTC().Format("20060102"))
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+func credentialScope(t time.Time, region string) string {
+	return t.UTC().Format("20060102") + "/" + region + "/s3/aws4_request"
+}
+
+func signedHeaderNames(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ";")
+}
+
+// canonicalQuery кодирует параметры по правилам SigV4: отсортированы, пробел - %20
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode кодирует всё, кроме незарезервированных символов RFC 3986 (и '/', если encodeSlash=false)
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"RPO_back/internal/errs"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+// FileInfo - метаданные сохранённого файла
+type FileInfo struct {
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// FileStorage хранит загруженные пользователями файлы. Имя файла - плоское
+// (без каталогов), обычно результат uploads.JoinFilePath.
+// Если файла нет, Get и Stat возвращают ошибку, для которой errors.Is(err, errs.ErrNotFound).
+// Ссылки на файлы не подписываются: клиенты скачивают их напрямую по USER_UPLOADS_URL
+// (см. uploads.JoinFileURL), поэтому бакет S3 должен быть открыт на чтение без авторизации
+type FileStorage interface {
+	// Put сохраняет файл, читая content потоком. size = -1, если размер заранее неизвестен
+	Put(ctx context.Context, name string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	Delete(ctx context.Context, name string) error
+	Stat(ctx context.Context, name string) (*FileInfo, error)
+}
+
+// checkName не даёт выйти за пределы хранилища через имя файла
+func checkName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+		return fmt.Errorf("invalid file name %q: %w", name, errs.ErrBadRequest)
+	}
+	return nil
+}
+
+// ContentTypeByName определяет MIME-тип по расширению файла
+func ContentTypeByName(name string) string {
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		return "application/octet-stream"
+	}
+	return contentType
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"RPO_back/internal/errs"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := CreateLocalStorage(dir)
+
+	err := store.Put(ctx, "avatar.png", strings.NewReader("image"), 5, "image/png")
+	require.NoError(t, err)
+
+	file, err := store.Get(ctx, "avatar.png")
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	file.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "image", string(content))
+
+	info, err := store.Stat(ctx, "avatar.png")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), info.Size)
+	assert.Equal(t, "image/png", info.ContentType)
+
+	// Временные файлы не остаются в каталоге
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, store.Delete(ctx, "avatar.png"))
+	require.NoError(t, store.Delete(ctx, "avatar.png"))
+
+	_, err = store.Get(ctx, "avatar.png")
+	assert.True(t, errors.Is(err, errs.ErrNotFound))
+	_, err = store.Stat(ctx, "avatar.png")
+	assert.True(t, errors.Is(err, errs.ErrNotFound))
+}
+
+func TestLocalStorage_InvalidName(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := CreateLocalStorage(filepath.Join(dir, "uploads"))
+
+	for _, name := range []string{"", "..", "../secret", "a/b", `a\b`} {
+		err := store.Put(ctx, name, strings.NewReader("x"), 1, "")
+		assert.True(t, errors.Is(err, errs.ErrBadRequest), name)
+		_, err = store.Get(ctx, name)
+		assert.True(t, errors.Is(err, errs.ErrBadRequest), name)
+	}
+}
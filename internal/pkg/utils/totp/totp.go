@@ -0,0 +1,128 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Параметры кодов, которые понимают все популярные приложения-аутентификаторы
+const (
+	Digits     = 6
+	Period     = 30 * time.Second
+	secretSize = 20 // 160 бит, как рекомендует RFC 4226
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret создаёт случайный секрет в base32 без паддинга
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("GenerateSecret: %w", err)
+	}
+	return secretEncoding.EncodeToString(secret), nil
+}
+
+// Step возвращает номер 30-секундного интервала для момента времени t
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// Code вычисляет код для интервала step (RFC 6238 поверх HOTP из RFC 4226, HMAC-SHA1)
+func Code(secret string, step int64) (string, error) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("Code (decode secret): %w", err)
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// Validate проверяет код в момент t с допуском skew интервалов в обе стороны
+// (на случай расхождения часов) и возвращает интервал, которому код соответствует
+func Validate(secret string, code string, t time.Time, skew int64) (step int64, ok bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(t)
+	for s := current - skew; s <= current+skew; s++ {
+		expected, err := Code(secret, s)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// ProvisioningURI возвращает otpauth:// URI, который кодируется в QR-код для приложения-аутентификатора
+func ProvisioningURI(issuer string, account string, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period.Seconds())))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// recoveryCodeAlphabet - символы кодов восстановления (base32 Крокфорда: без легко путаемых i, l, o, u).
+// Ровно 32 символа, поэтому выбор по младшим 5 битам случайного байта равномерный
+const recoveryCodeAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// GenerateRecoveryCodes создаёт count одноразовых кодов восстановления вида xxxx-xxxx
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	codes := make([]string, 0, count)
+	raw := make([]byte, 8)
+	for i := 0; i < count; i++ {
+		if _, err := rand.Read(raw); err != nil {
+			return nil, fmt.Errorf("GenerateRecoveryCodes: %w", err)
+		}
+		code := make([]byte, 0, 9)
+		for j, b := range raw {
+			if j == 4 {
+				code = append(code, '-')
+			}
+			code = append(code, recoveryCodeAlphabet[b&31])
+		}
+		codes = append(codes, string(code))
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode приводит введённый пользователем код восстановления к виду, в котором он хранится
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.NewReplacer(" ", "", "-", "").Replace(code)
+	if len(code) == 8 {
+		code = code[:4] + "-" + code[4:]
+	}
+	return code
+}
@@ -0,0 +1,185 @@
+package uploads
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/pkg/utils/logging"
+	"RPO_back/internal/pkg/utils/pgxiface"
+	"RPO_back/internal/pkg/utils/storage"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// BackfillStats - итоги BackfillHashes
+type BackfillStats struct {
+	Hashed  int // Файлы, которым записан хеш
+	Merged  int // Дубликаты, слитые с уже зарегистрированным файлом
+	Missing int // Записи, для которых в хранилище нет объекта
+}
+
+// unhashedFile - запись user_uploaded_file без хеша
+type unhashedFile struct {
+	fileID        int64
+	fileExtension string
+	fileName      string
+}
+
+// BackfillHashes досчитывает SHA-256 для файлов, загруженных до дедупликации по хешу (file_hash IS NULL).
+// Содержимое читается из хранилища потоком, заодно исправляется размер. Если файл с таким хешем уже есть,
+// ссылки переводятся на него, а дубликат удаляется из таблицы и из хранилища. Дубликатом считается
+// только файл с тем же расширением, как и при загрузке (см. RegisterFile).
+// Записи без объекта в хранилище пропускаются с предупреждением в логе
+func BackfillHashes(ctx context.Context, db pgxiface.PgxIface, fileStorage storage.FileStorage, batchSize int) (stats BackfillStats, err error) {
+	funcName := "BackfillHashes"
+	var lastID int64
+	for {
+		batch, err := selectUnhashedFiles(ctx, db, lastID, batchSize)
+		if err != nil {
+			return stats, fmt.Errorf("%s: %w", funcName, err)
+		}
+		if len(batch) == 0 {
+			return stats, nil
+		}
+
+		for _, file := range batch {
+			lastID = file.fileID
+			hash, size, err := hashStoredFile(ctx, fileStorage, file.fileName)
+			if errors.Is(err, errs.ErrNotFound) {
+				logging.Warn(ctx, funcName, " file ", file.fileID, " (", file.fileName, ") is missing in storage, skipping")
+				stats.Missing++
+				continue
+			}
+			if err != nil {
+				return stats, fmt.Errorf("%s (hash %s): %w", funcName, file.fileName, err)
+			}
+
+			merged, err := setFileHash(ctx, db, file.fileID, file.fileExtension, hash, size)
+			if err != nil {
+				return stats, fmt.Errorf("%s (file %d): %w", funcName, file.fileID, err)
+			}
+			if !merged {
+				stats.Hashed++
+				continue
+			}
+			stats.Merged++
+			err = fileStorage.Delete(ctx, file.fileName)
+			if err != nil {
+				logging.Warn(ctx, funcName, " (delete duplicate ", file.fileName, "): ", err)
+			}
+		}
+	}
+}
+
+// selectUnhashedFiles возвращает очередную порцию файлов без хеша с file_id больше lastID
+func selectUnhashedFiles(ctx context.Context, db pgxiface.PgxIface, lastID int64, limit int) (files []unhashedFile, err error) {
+	funcName := "selectUnhashedFiles"
+	query := `
+	SELECT file_id, file_uuid::text, COALESCE(file_extension, '')
+	FROM user_uploaded_file
+	WHERE file_hash IS NULL AND file_id > $1
+	ORDER BY file_id
+	LIMIT $2;
+	`
+	rows, err := db.Query(ctx, query, lastID, limit)
+	logging.Debug(ctx, funcName, " query has err: ", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s (query): %w", funcName, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fileID int64
+		var fileUUID, fileExtension string
+		err = rows.Scan(&fileID, &fileUUID, &fileExtension)
+		if err != nil {
+			return nil, fmt.Errorf("%s (scan): %w", funcName, err)
+		}
+		files = append(files, unhashedFile{
+			fileID:        fileID,
+			fileExtension: fileExtension,
+			fileName:      JoinFilePath(fileUUID, fileExtension),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s (rows): %w", funcName, err)
+	}
+	return files, nil
+}
+
+// hashStoredFile потоком считает SHA-256 и размер объекта из хранилища
+func hashStoredFile(ctx context.Context, fileStorage storage.FileStorage, fileName string) (hash string, size int64, err error) {
+	content, err := fileStorage.Get(ctx, fileName)
+	if err != nil {
+		return "", 0, fmt.Errorf("hashStoredFile (get): %w", err)
+	}
+	defer content.Close()
+
+	hasher := sha256.New()
+	size, err = io.Copy(hasher, content)
+	if err != nil {
+		return "", 0, fmt.Errorf("hashStoredFile (read): %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), size, nil
+}
+
+// setFileHash записывает файлу хеш и размер. Если файл с таким хешем и расширением уже есть, переводит ссылки
+// на него и удаляет запись fileID (merged = true)
+func setFileHash(ctx context.Context, db pgxiface.PgxIface, fileID int64, fileExtension string, hash string, size int64) (merged bool, err error) {
+	funcName := "setFileHash"
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%s (begin): %w", funcName, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var existingID int64
+	query := `
+	SELECT file_id FROM user_uploaded_file
+	WHERE file_hash=$1 AND COALESCE(file_extension, '')=$2
+	FOR UPDATE;
+	`
+	err = tx.QueryRow(ctx, query, hash, fileExtension).Scan(&existingID)
+	logging.Debug(ctx, funcName, " select query has err: ", err)
+	if errors.Is(err, pgx.ErrNoRows) {
+		_, err = tx.Exec(ctx, `UPDATE user_uploaded_file SET file_hash=$1, "size"=$2 WHERE file_id=$3;`, hash, size, fileID)
+		if err != nil {
+			return false, fmt.Errorf("%s (update): %w", funcName, err)
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			return false, fmt.Errorf("%s (commit): %w", funcName, err)
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s (select): %w", funcName, err)
+	}
+
+	queries := []string{
+		`UPDATE "user" SET avatar_file_id=$1 WHERE avatar_file_id=$2;`,
+		`UPDATE board SET background_image_id=$1 WHERE background_image_id=$2;`,
+		`UPDATE card SET cover_file_id=$1 WHERE cover_file_id=$2;`,
+		`UPDATE card_attachment SET file_id=$1 WHERE file_id=$2;`,
+	}
+	for _, query := range queries {
+		_, err = tx.Exec(ctx, query, existingID, fileID)
+		if err != nil {
+			return false, fmt.Errorf("%s (relink): %w", funcName, err)
+		}
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM user_uploaded_file WHERE file_id=$1;`, fileID)
+	if err != nil {
+		return false, fmt.Errorf("%s (delete): %w", funcName, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%s (commit): %w", funcName, err)
+	}
+	return true, nil
+}
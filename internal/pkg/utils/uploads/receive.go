@@ -0,0 +1,153 @@
+package uploads
+
+import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/config"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+)
+
+// Purpose - для чего загружается файл; от этого зависят ограничения на него
+type Purpose string
+
+const (
+	PurposeAvatar     Purpose = "avatar"
+	PurposeBackground Purpose = "background"
+	PurposeCover      Purpose = "cover"
+	PurposeAttachment Purpose = "attachment"
+)
+
+// Limits - ограничения на загружаемый файл
+type Limits struct {
+	MaxSize      int64
+	AllowedTypes []string // nil - любой тип
+}
+
+// imageExtensions - разрешённые типы изображений и расширения, с которыми они сохраняются
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+var imageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+const (
+	formFileField = "file"
+
+	// multipartOverhead - запас на заголовки multipart и прочие поля формы сверх размера файла
+	multipartOverhead = 64 << 10
+
+	// sniffLen - сколько байт смотрит http.DetectContentType
+	sniffLen = 512
+)
+
+// LimitsFor возвращает ограничения для загрузки по конфигу
+func LimitsFor(purpose Purpose) Limits {
+	limits := config.CurrentConfig.Limits
+	switch purpose {
+	case PurposeAvatar:
+		return Limits{MaxSize: limits.MaxAvatarSize, AllowedTypes: imageTypes}
+	case PurposeBackground:
+		return Limits{MaxSize: limits.MaxBackgroundSize, AllowedTypes: imageTypes}
+	case PurposeCover:
+		return Limits{MaxSize: limits.MaxCoverSize, AllowedTypes: imageTypes}
+	default:
+		return Limits{MaxSize: config.CurrentConfig.MaxUploadSize}
+	}
+}
+
+// ReceiveFile находит в multipart-форме поле "file" и возвращает файл с потоком содержимого,
+// не читая его в память. Тип определяется по первым байтам содержимого, а не по имени файла.
+// Ошибки: errs.ErrUnsupportedMediaType - тип не разрешён, errs.ErrTooLarge - файл больше лимита
+// (может вернуться и позже, при чтении file.Content), errs.ErrBadRequest - нет файла или форма битая
+func ReceiveFile(w http.ResponseWriter, r *http.Request, limits Limits) (*models.UploadedFile, error) {
+	maxBodySize := limits.MaxSize + multipartOverhead
+	if r.ContentLength > maxBodySize {
+		return nil, fmt.Errorf("ReceiveFile: request body is %d bytes, limit is %d: %w", r.ContentLength, limits.MaxSize, errs.ErrTooLarge)
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("ReceiveFile (multipart): %v: %w", err, errs.ErrBadRequest)
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("ReceiveFile: no %q field in form: %w", formFileField, errs.ErrBadRequest)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ReceiveFile (next part): %w", readError(err))
+		}
+		if part.FormName() != formFileField || part.FileName() == "" {
+			continue
+		}
+
+		content := bufio.NewReaderSize(part, sniffLen)
+		head, err := content.Peek(sniffLen)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("ReceiveFile (peek): %w", readError(err))
+		}
+		if len(head) == 0 {
+			return nil, fmt.Errorf("ReceiveFile: file is empty: %w", errs.ErrBadRequest)
+		}
+
+		contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+		if limits.AllowedTypes != nil && !slices.Contains(limits.AllowedTypes, contentType) {
+			return nil, fmt.Errorf("ReceiveFile: content type %s is not allowed: %w", contentType, errs.ErrUnsupportedMediaType)
+		}
+
+		file := &models.UploadedFile{
+			Content:       &sizeLimitedReader{r: content, remaining: limits.MaxSize},
+			ContentType:   contentType,
+			OriginalName:  part.FileName(),
+			FileExtension: ExtractFileExtension(part.FileName()),
+		}
+		// Изображение сохраняется с расширением по настоящему типу, а не по имени файла
+		if extension, ok := imageExtensions[contentType]; ok && limits.AllowedTypes != nil {
+			file.FileExtension = extension
+		}
+		return file, nil
+	}
+}
+
+// sizeLimitedReader отдаёт не больше remaining байт, а если данных больше - возвращает errs.ErrTooLarge
+type sizeLimitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	// Читаем на байт больше остатка, чтобы заметить превышение
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		l.remaining = 0
+		return 0, fmt.Errorf("file is larger than the limit: %w", errs.ErrTooLarge)
+	}
+	l.remaining -= int64(n)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, readError(err)
+	}
+	return n, err
+}
+
+// readError переводит превышение http.MaxBytesReader в errs.ErrTooLarge, остальное - в errs.ErrBadRequest
+func readError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return fmt.Errorf("request body is larger than %d bytes: %w", maxBytesErr.Limit, errs.ErrTooLarge)
+	}
+	return fmt.Errorf("%v: %w", err, errs.ErrBadRequest)
+}
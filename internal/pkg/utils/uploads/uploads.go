@@ -2,19 +2,22 @@ package uploads
 
 import (
 	"RPO_back/internal/models"
-	"RPO_back/internal/pkg/config"
+	"RPO_back/internal/pkg/utils/images"
 	"RPO_back/internal/pkg/utils/logging"
 	"RPO_back/internal/pkg/utils/pgxiface"
+	"RPO_back/internal/pkg/utils/storage"
+	"bytes"
 	"context"
-	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
-	"path/filepath"
-	"slices"
 	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/satori/uuid"
 )
 
 const (
@@ -35,6 +38,34 @@ func JoinFileURL(fileUUID string, fileExtension string, defaultValue string) str
 	return urlPrefix + fileUUID
 }
 
+// JoinImageURL восстанавливает адрес, по которому картинка показывается в профиле, на доске или карточке:
+// это копия full, потому что в оригинале остаются метаданные (EXIF, в том числе геопозиция).
+// На оригинал адрес ведёт, только если копии не делаются (см. JoinVariantURLs)
+func JoinImageURL(fileUUID string, fileExtension string, defaultValue string) string {
+	return JoinVariantURLs(fileUUID, fileExtension, defaultValue).Full
+}
+
+// JoinVariantURLs восстанавливает адреса уменьшенных копий картинки. Копии отдаёт (и при первом
+// обращении создаёт) обработчик /images сервиса пользователей, адрес которого задаёт IMAGE_VARIANTS_URL.
+// Если он не задан или формат не поддерживается, все адреса ведут на оригинал; без файла - на defaultValue
+func JoinVariantURLs(fileUUID string, fileExtension string, defaultValue string) models.ImageVariants {
+	if fileUUID == "" {
+		return models.ImageVariants{Thumbnail: defaultValue, Medium: defaultValue, Full: defaultValue}
+	}
+
+	fileName := JoinFilePath(fileUUID, fileExtension)
+	urlPrefix := os.Getenv("IMAGE_VARIANTS_URL")
+	if _, ok := images.VariantName(fileName, images.Full); !ok || urlPrefix == "" {
+		originalURL := JoinFileURL(fileUUID, fileExtension, defaultValue)
+		return models.ImageVariants{Thumbnail: originalURL, Medium: originalURL, Full: originalURL}
+	}
+	return models.ImageVariants{
+		Thumbnail: fmt.Sprintf("%s%s/%s", urlPrefix, fileName, images.Thumbnail),
+		Medium:    fmt.Sprintf("%s%s/%s", urlPrefix, fileName, images.Medium),
+		Full:      fmt.Sprintf("%s%s/%s", urlPrefix, fileName, images.Full),
+	}
+}
+
 // ExtractFileExtension извлекает из имени файла его расширение (возвращает "", если файл без расширения)
 func ExtractFileExtension(fileName string) string {
 	lastDotIndex := strings.LastIndex(fileName, ".")
@@ -52,31 +83,6 @@ func JoinFilePath(fileUUID string, fileExtension string) string {
 	return fileUUID
 }
 
-// CompareFiles смотрит, равен ли данный файл какому-нибудь из существующих
-// загруженных файлов. Если да, возвращает fileUUID эквивалентного файла
-func CompareFiles(fileNames []string, fileIDs []int64, newFile *models.UploadedFile) (fileID *int64, err error) {
-	for idx, filePath := range fileNames {
-		// Читаем существующий файл
-		file, err := os.Open(filepath.Join(config.CurrentConfig.UploadsDir, filePath))
-		if err != nil {
-			return nil, fmt.Errorf("CompareFiles (open) %s: %w", filePath, err)
-		}
-		existingFileContent, err := io.ReadAll(file)
-		file.Close()
-		if err != nil {
-			return nil, fmt.Errorf("CompareFiles (read) %s: %w", filePath, err)
-		}
-
-		// Сравнение по содержимому
-		if slices.Equal(newFile.Content, existingFileContent) {
-			return &fileIDs[idx], err
-		}
-	}
-
-	// Не найдено совпадений
-	return nil, nil
-}
-
 // extractUUID предполагает, что UUID находится в начале имени файла, разделённого символом '_'
 // Например: "123e4567-e89b-12d3-a456-426614174000_filename.ext"
 func extractUUID(filePath string) (string, error) {
@@ -88,104 +94,102 @@ func extractUUID(filePath string) (string, error) {
 	return string(runes[:36]), nil
 }
 
-func FormFile(r *http.Request) (file *models.UploadedFile, err error) {
-	r.ParseMultipartForm(10 << 20)
+// StoreFile потоком кладёт содержимое файла в хранилище под новым UUID, попутно считая
+// размер и SHA-256. Заполняет file.UUID, file.Size и file.Hash
+func StoreFile(ctx context.Context, fileStorage storage.FileStorage, file *models.UploadedFile) error {
+	fileUUID := uuid.NewV4().String()
+	fileName := JoinFilePath(fileUUID, file.FileExtension)
 
-	fileContent, fileHeader, err := r.FormFile("file")
-	if err != nil {
-		return nil, fmt.Errorf("FormFile: %w", err)
-	}
+	hash := sha256.New()
+	counter := &countingWriter{}
+	content := io.TeeReader(file.Content, io.MultiWriter(hash, counter))
 
-	currentPos, err := fileContent.Seek(0, io.SeekCurrent)
+	err := fileStorage.Put(ctx, fileName, content, -1, file.ContentType)
 	if err != nil {
-		return nil, fmt.Errorf("FormFile (seek 1): %w", err)
+		return fmt.Errorf("StoreFile: %w", err)
 	}
 
-	file = &models.UploadedFile{}
-
-	// Переходим в конец файла, чтобы определить его размер
-	size, err := fileContent.Seek(0, io.SeekEnd)
-	if err != nil {
-		return nil, fmt.Errorf("FormFile (seek 2): %w", err)
-	}
+	file.UUID = &fileUUID
+	file.Size = counter.n
+	file.Hash = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
 
-	// Возвращаемся в исходную позицию чтения
-	_, err = fileContent.Seek(currentPos, io.SeekStart)
+// StripImageMetadata заменяет содержимое загруженной картинки на копию без метаданных (images.StripMetadata).
+// Вызывается до StoreFile, так что хеш и размер считаются уже по очищенному содержимому
+func StripImageMetadata(file *models.UploadedFile) error {
+	data, err := io.ReadAll(file.Content)
 	if err != nil {
-		return nil, fmt.Errorf("FormFile (seek 3): %w", err)
+		return fmt.Errorf("StripImageMetadata (read): %w", err)
 	}
-
-	// Предварительно аллоцируем слайс байтов нужного размера
-	file.Content = make([]byte, size)
-
-	// Читаем содержимое файла в слайс
-	_, err = io.ReadFull(fileContent, file.Content)
+	stripped, err := images.StripMetadata(data, file.FileExtension)
 	if err != nil {
-		return nil, fmt.Errorf("FormFile (read): %w", err)
+		return fmt.Errorf("StripImageMetadata: %w", err)
 	}
-
-	file.OriginalName = fileHeader.Filename
-	file.FileExtension = ExtractFileExtension(file.OriginalName)
-
-	return file, nil
+	file.Content = bytes.NewReader(stripped)
+	return nil
 }
 
-func SaveFile(file *models.UploadedFile) (err error) {
-	if file.UUID == nil {
-		return fmt.Errorf("SaveFile: file uuid is nil")
-	}
+// GenerateImageVariants строит уменьшенные копии только что зарегистрированной (RegisterFile) картинки.
+// Ошибка только логируется: недостающие копии создаются при первом обращении к ним
+func GenerateImageVariants(ctx context.Context, fileStorage storage.FileStorage, file *models.UploadedFile) {
 	fileName := JoinFilePath(*file.UUID, file.FileExtension)
-	err = os.WriteFile(filepath.Join(config.CurrentConfig.UploadsDir, fileName), file.Content, 0644)
+	if _, ok := images.VariantName(fileName, images.Full); !ok {
+		return
+	}
+	err := images.Generate(ctx, fileStorage, fileName)
 	if err != nil {
-		return fmt.Errorf("SaveFile: cant write to file %s: %w", fileName, err)
+		logging.Warn(ctx, "GenerateImageVariants ", fileName, ": ", err)
 	}
-	return nil
 }
 
-// DeduplicateFile возвращает список файлов с таким же расширением, ID и размером
-func DeduplicateFile(ctx context.Context, db pgxiface.PgxIface, file *models.UploadedFile) (fileNames []string, fileIDs []int64, err error) {
-	funcName := "DeduplicateFile"
-	query := `
-	SELECT file_id, file_uuid, file_extension
-	FROM user_uploaded_file
-	WHERE file_hash=$1 AND "size"=$2 AND file_extension=$3;
-	`
-
-	h := sha1.New()
-	h.Write(file.Content)
-	fileHash := hex.EncodeToString(h.Sum(nil))
+// countingWriter считает записанные в него байты
+type countingWriter struct {
+	n int64
+}
 
-	rows, err := db.Query(ctx, query, fileHash, len(file.Content), file.FileExtension)
-	if err != nil {
-		return nil, nil, fmt.Errorf("%s (query): %w", funcName, err)
-	}
-	for rows.Next() {
-		var fileUUID, fileExtension string
-		var fileID int64
-		err = rows.Scan(&fileID, &fileUUID, &fileExtension)
-		if err != nil {
-			return nil, nil, fmt.Errorf("%s (scan): %w", funcName, err)
-		}
-		fileIDs = append(fileIDs, fileID)
-		fileNames = append(fileNames, JoinFilePath(fileUUID, fileExtension))
-	}
-	return fileNames, fileIDs, nil
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
 }
 
-// RegisterFile заносит информацию о файле в таблицу и по указателю меняет поля FileID и UUID в структуре file
-func RegisterFile(ctx context.Context, db pgxiface.PgxIface, file *models.UploadedFile) error {
+// RegisterFile заносит сохранённый (StoreFile) файл в таблицу. Файлы адресуются по SHA-256 содержимого
+// и расширению: если такой файл уже зарегистрирован, новая запись не создаётся, а в file подставляются
+// FileID и UUID существующего файла (isNew = false). Тогда сохранённую копию можно удалять.
+// Копия с другим расширением регистрируется отдельно: расширение определяет, как файл будет отдаваться,
+// и у новой копии оно проверено при загрузке
+func RegisterFile(ctx context.Context, db pgxiface.PgxIface, file *models.UploadedFile) (isNew bool, err error) {
 	funcName := "RegisterFile"
 	query := `
-	INSERT INTO user_uploaded_file
-	(file_extension, created_at, "size")
-	VALUES ($1, CURRENT_TIMESTAMP, $2)
-	RETURNING file_uuid::text, file_id;
+	WITH inserted AS (
+		INSERT INTO user_uploaded_file
+		(file_uuid, file_hash, file_extension, created_at, "size")
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, $4)
+		ON CONFLICT (file_hash, file_extension) DO NOTHING
+		RETURNING file_id, file_uuid
+	)
+	SELECT file_id, file_uuid::text, true FROM inserted
+	UNION ALL
+	SELECT file_id, file_uuid::text, false FROM user_uploaded_file WHERE file_hash=$2 AND file_extension=$3
+	LIMIT 1;
 	`
-	row := db.QueryRow(ctx, query, file.FileExtension, len(file.Content))
-	err := row.Scan(&file.UUID, file.FileID)
-	logging.Debug(ctx, funcName, " query has err: ", err)
-	if err != nil {
-		return fmt.Errorf("%s: %w", funcName, err)
+	// Если такой же файл параллельно регистрирует другой запрос, его строка ещё не видна:
+	// повторная попытка её уже найдёт
+	for attempt := 0; attempt < 2; attempt++ {
+		var fileID int64
+		var fileUUID string
+		row := db.QueryRow(ctx, query, file.UUID, file.Hash, file.FileExtension, file.Size)
+		err = row.Scan(&fileID, &fileUUID, &isNew)
+		logging.Debug(ctx, funcName, " query has err: ", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", funcName, err)
+		}
+		file.FileID = &fileID
+		file.UUID = &fileUUID
+		return isNew, nil
 	}
-	return nil
+	return false, fmt.Errorf("%s: %w", funcName, err)
 }
@@ -1,8 +1,25 @@
 package uploads
 
 import (
+	"RPO_back/internal/errs"
+	"RPO_back/internal/models"
+	"RPO_back/internal/pkg/utils/storage"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestJoinFileURL(t *testing.T) {
@@ -27,6 +44,36 @@ func TestJoinFileURL(t *testing.T) {
 	}
 }
 
+func TestJoinVariantURLs(t *testing.T) {
+	os.Setenv("USER_UPLOADS_URL", "http://example.com/uploads/")
+	os.Setenv("IMAGE_VARIANTS_URL", "http://example.com/api/images/")
+	defer os.Unsetenv("IMAGE_VARIANTS_URL")
+
+	assert.Equal(t, models.ImageVariants{
+		Thumbnail: "http://example.com/api/images/12345.jpg/thumbnail",
+		Medium:    "http://example.com/api/images/12345.jpg/medium",
+		Full:      "http://example.com/api/images/12345.jpg/full",
+	}, JoinVariantURLs("12345", "jpg", DefaultAvatarURL))
+
+	// Без файла - значение по умолчанию, для неподдерживаемых форматов - оригинал
+	assert.Equal(t, DefaultAvatarURL, JoinVariantURLs("", "", DefaultAvatarURL).Thumbnail)
+	assert.Equal(t, "http://example.com/uploads/12345.webp", JoinVariantURLs("12345", "webp", DefaultAvatarURL).Medium)
+
+	os.Unsetenv("IMAGE_VARIANTS_URL")
+	assert.Equal(t, "http://example.com/uploads/12345.jpg", JoinVariantURLs("12345", "jpg", DefaultAvatarURL).Full)
+}
+
+func TestJoinImageURL(t *testing.T) {
+	os.Setenv("USER_UPLOADS_URL", "http://example.com/uploads/")
+	os.Setenv("IMAGE_VARIANTS_URL", "http://example.com/api/images/")
+	defer os.Unsetenv("IMAGE_VARIANTS_URL")
+
+	// Показывается перекодированная копия без метаданных оригинала
+	assert.Equal(t, "http://example.com/api/images/12345.jpg/full", JoinImageURL("12345", "jpg", DefaultAvatarURL))
+	assert.Equal(t, DefaultAvatarURL, JoinImageURL("", "", DefaultAvatarURL))
+	assert.Equal(t, "http://example.com/uploads/12345.webp", JoinImageURL("12345", "webp", DefaultAvatarURL))
+}
+
 func TestExtractFileExtension(t *testing.T) {
 	tests := []struct {
 		fileName string
@@ -63,3 +110,233 @@ func TestJoinFilePath(t *testing.T) {
 		}
 	}
 }
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// multipartRequest собирает запрос с формой: поле note и файл в поле field
+func multipartRequest(t *testing.T, field string, fileName string, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("note", "hello"))
+	if field != "" {
+		part, err := writer.CreateFormFile(field, fileName)
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	r := httptest.NewRequest(http.MethodPut, "/upload", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+func TestReceiveFile(t *testing.T) {
+	imageLimits := Limits{MaxSize: 1024, AllowedTypes: imageTypes}
+
+	t.Run("image", func(t *testing.T) {
+		content := append(append([]byte{}, pngHeader...), make([]byte, 600)...)
+		file, err := ReceiveFile(httptest.NewRecorder(), multipartRequest(t, "file", "photo.JPG", content), imageLimits)
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", file.ContentType)
+		assert.Equal(t, "png", file.FileExtension)
+		assert.Equal(t, "photo.JPG", file.OriginalName)
+
+		received, err := io.ReadAll(file.Content)
+		require.NoError(t, err)
+		assert.Equal(t, content, received)
+	})
+
+	t.Run("not an image", func(t *testing.T) {
+		_, err := ReceiveFile(httptest.NewRecorder(), multipartRequest(t, "file", "photo.png", []byte("<html></html>")), imageLimits)
+		assert.True(t, errors.Is(err, errs.ErrUnsupportedMediaType))
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		content := append(append([]byte{}, pngHeader...), make([]byte, 2048)...)
+		file, err := ReceiveFile(httptest.NewRecorder(), multipartRequest(t, "file", "photo.png", content), imageLimits)
+		require.NoError(t, err)
+		_, err = io.ReadAll(file.Content)
+		assert.True(t, errors.Is(err, errs.ErrTooLarge))
+	})
+
+	t.Run("request too large", func(t *testing.T) {
+		r := multipartRequest(t, "file", "photo.png", make([]byte, 100<<10))
+		_, err := ReceiveFile(httptest.NewRecorder(), r, imageLimits)
+		assert.True(t, errors.Is(err, errs.ErrTooLarge))
+	})
+
+	t.Run("any type for attachments", func(t *testing.T) {
+		file, err := ReceiveFile(httptest.NewRecorder(), multipartRequest(t, "file", "notes.txt", []byte("some notes")), Limits{MaxSize: 1024})
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", file.ContentType)
+		assert.Equal(t, "txt", file.FileExtension)
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		_, err := ReceiveFile(httptest.NewRecorder(), multipartRequest(t, "", "", nil), imageLimits)
+		assert.True(t, errors.Is(err, errs.ErrBadRequest))
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		_, err := ReceiveFile(httptest.NewRecorder(), multipartRequest(t, "file", "photo.png", nil), imageLimits)
+		assert.True(t, errors.Is(err, errs.ErrBadRequest))
+	})
+}
+
+func TestStoreFile(t *testing.T) {
+	ctx := context.Background()
+	fileStorage := storage.CreateLocalStorage(t.TempDir())
+	content := []byte("attachment content")
+
+	file, err := ReceiveFile(httptest.NewRecorder(), multipartRequest(t, "file", "notes.txt", content), Limits{MaxSize: 1024})
+	require.NoError(t, err)
+	require.NoError(t, StoreFile(ctx, fileStorage, file))
+
+	hash := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(hash[:]), file.Hash)
+	assert.Equal(t, int64(len(content)), file.Size)
+	require.NotNil(t, file.UUID)
+
+	stored, err := fileStorage.Get(ctx, JoinFilePath(*file.UUID, file.FileExtension))
+	require.NoError(t, err)
+	defer stored.Close()
+	storedContent, err := io.ReadAll(stored)
+	require.NoError(t, err)
+	assert.Equal(t, content, storedContent)
+}
+
+func TestStoreFile_TooLarge(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	fileStorage := storage.CreateLocalStorage(dir)
+
+	file, err := ReceiveFile(httptest.NewRecorder(), multipartRequest(t, "file", "big.bin", make([]byte, 2048)), Limits{MaxSize: 1024})
+	require.NoError(t, err)
+	err = StoreFile(ctx, fileStorage, file)
+	assert.True(t, errors.Is(err, errs.ErrTooLarge))
+
+	// Недописанный файл не остаётся в хранилище
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRegisterFile(t *testing.T) {
+	ctx := context.Background()
+	hash := strings.Repeat("ab", 32)
+
+	t.Run("new file", func(t *testing.T) {
+		mock, err := pgxmock.NewConn()
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+
+		fileUUID := "123e4567-e89b-12d3-a456-426614174000"
+		file := &models.UploadedFile{UUID: &fileUUID, Hash: hash, Size: 5, FileExtension: "png"}
+		mock.ExpectQuery(`ON CONFLICT \(file_hash, file_extension\) DO NOTHING`).WithArgs(file.UUID, hash, "png", int64(5)).
+			WillReturnRows(pgxmock.NewRows([]string{"file_id", "file_uuid", "is_new"}).
+				AddRow(int64(1), fileUUID, true))
+
+		isNew, err := RegisterFile(ctx, mock, file)
+		require.NoError(t, err)
+		assert.True(t, isNew)
+		assert.Equal(t, int64(1), *file.FileID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("same content and extension already registered", func(t *testing.T) {
+		mock, err := pgxmock.NewConn()
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+
+		fileUUID := "123e4567-e89b-12d3-a456-426614174000"
+		file := &models.UploadedFile{UUID: &fileUUID, Hash: hash, Size: 5, FileExtension: "png"}
+		// Первая попытка не видит строку, которую параллельно вставил другой запрос
+		mock.ExpectQuery(`ON CONFLICT \(file_hash, file_extension\) DO NOTHING`).WithArgs(file.UUID, hash, "png", int64(5)).
+			WillReturnRows(pgxmock.NewRows([]string{"file_id", "file_uuid", "is_new"}))
+		mock.ExpectQuery(`ON CONFLICT \(file_hash, file_extension\) DO NOTHING`).WithArgs(file.UUID, hash, "png", int64(5)).
+			WillReturnRows(pgxmock.NewRows([]string{"file_id", "file_uuid", "is_new"}).
+				AddRow(int64(7), "existing-uuid", false))
+
+		isNew, err := RegisterFile(ctx, mock, file)
+		require.NoError(t, err)
+		assert.False(t, isNew)
+		assert.Equal(t, int64(7), *file.FileID)
+		assert.Equal(t, "existing-uuid", *file.UUID)
+		assert.Equal(t, "png", file.FileExtension)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("same content with another extension", func(t *testing.T) {
+		mock, err := pgxmock.NewConn()
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+
+		// Тот же файл, загруженный как картинка, не подменяется ранее загруженной копией с расширением exe
+		fileUUID := "123e4567-e89b-12d3-a456-426614174000"
+		file := &models.UploadedFile{UUID: &fileUUID, Hash: hash, Size: 5, FileExtension: "png"}
+		mock.ExpectQuery(`WHERE file_hash=\$2 AND file_extension=\$3`).WithArgs(file.UUID, hash, "png", int64(5)).
+			WillReturnRows(pgxmock.NewRows([]string{"file_id", "file_uuid", "is_new"}).
+				AddRow(int64(8), fileUUID, true))
+
+		isNew, err := RegisterFile(ctx, mock, file)
+		require.NoError(t, err)
+		assert.True(t, isNew)
+		assert.Equal(t, fileUUID, *file.UUID)
+		assert.Equal(t, "png", file.FileExtension)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBackfillHashes(t *testing.T) {
+	ctx := context.Background()
+	fileStorage := storage.CreateLocalStorage(t.TempDir())
+	content := []byte("same content")
+	require.NoError(t, fileStorage.Put(ctx, "first.txt", bytes.NewReader(content), int64(len(content)), "text/plain"))
+	require.NoError(t, fileStorage.Put(ctx, "second.txt", bytes.NewReader(content), int64(len(content)), "text/plain"))
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	mock, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	defer mock.Close(ctx)
+
+	mock.ExpectQuery(`WHERE file_hash IS NULL AND file_id > \$1`).WithArgs(int64(0), 10).
+		WillReturnRows(pgxmock.NewRows([]string{"file_id", "file_uuid", "file_extension"}).
+			AddRow(int64(1), "first", "txt").
+			AddRow(int64(2), "second", "txt").
+			AddRow(int64(3), "missing", "txt"))
+
+	// Первый файл: хеша ещё нет ни у кого, просто записываем его
+	mock.ExpectBegin()
+	mock.ExpectQuery(`WHERE file_hash=\$1 AND COALESCE\(file_extension, ''\)=\$2`).WithArgs(hash, "txt").
+		WillReturnRows(pgxmock.NewRows([]string{"file_id"}))
+	mock.ExpectExec(`UPDATE user_uploaded_file SET file_hash`).WithArgs(hash, int64(len(content)), int64(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	// Второй файл - дубликат первого: ссылки переводятся, запись удаляется
+	mock.ExpectBegin()
+	mock.ExpectQuery(`WHERE file_hash=\$1 AND COALESCE\(file_extension, ''\)=\$2`).WithArgs(hash, "txt").
+		WillReturnRows(pgxmock.NewRows([]string{"file_id"}).AddRow(int64(1)))
+	for _, table := range []string{`"user"`, "board", "card", "card_attachment"} {
+		mock.ExpectExec(`UPDATE `+table+` SET`).WithArgs(int64(1), int64(2)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	}
+	mock.ExpectExec(`DELETE FROM user_uploaded_file`).WithArgs(int64(2)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectCommit()
+
+	mock.ExpectQuery(`WHERE file_hash IS NULL AND file_id > \$1`).WithArgs(int64(3), 10).
+		WillReturnRows(pgxmock.NewRows([]string{"file_id", "file_uuid", "file_extension"}))
+
+	stats, err := BackfillHashes(ctx, mock, fileStorage, 10)
+	require.NoError(t, err)
+	assert.Equal(t, BackfillStats{Hashed: 1, Merged: 1, Missing: 1}, stats)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = fileStorage.Stat(ctx, "second.txt")
+	assert.True(t, errors.Is(err, errs.ErrNotFound))
+	_, err = fileStorage.Stat(ctx, "first.txt")
+	assert.NoError(t, err)
+}
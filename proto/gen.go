@@ -1,3 +1,4 @@
 package proto
 
 //go:generate protoc auth.proto --go_out=./ --go-grpc_out=./
+//go:generate protoc poll.proto --go_out=./ --go-grpc_out=./